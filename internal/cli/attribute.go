@@ -4,9 +4,7 @@ import (
 	"errors"
 	"os"
 
-	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -48,7 +46,7 @@ var addAttributeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -73,7 +71,7 @@ var addAttributeCmd = &cobra.Command{
 		attribute := core.CreateAttribute(ProcessID, ColonyID, "", core.OUT, Key, Value)
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		addedAttribute, err := client.AddAttribute(attribute, RuntimePrvKey)
 		CheckError(err)
@@ -89,7 +87,7 @@ var getAttributeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -112,7 +110,7 @@ var getAttributeCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		attribute, err := client.GetAttribute(AttributeID, RuntimePrvKey)
 		CheckError(err)
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/server"
+	"github.com/kataras/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	auditCmd.AddCommand(lsAuditCmd)
+	auditCmd.AddCommand(verifyAuditCmd)
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", DefaultServerHost, "Server host")
+	auditCmd.PersistentFlags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
+	auditCmd.PersistentFlags().StringVarP(&ServerID, "serverid", "", "", "Colonies server Id")
+	auditCmd.PersistentFlags().StringVarP(&ServerPrvKey, "serverprvkey", "", "", "Colonies server private key")
+
+	lsAuditCmd.Flags().StringVarP(&TargetID, "targetid", "", "", "Only list entries targeting this Id, e.g. a colony, runtime or process Id")
+	lsAuditCmd.Flags().Int64VarP(&FromSeq, "fromseq", "", 0, "List entries starting at this sequence number, the latest entries are listed if not specified")
+	lsAuditCmd.Flags().IntVarP(&Count, "count", "", server.MAX_COUNT, "Number of entries to list")
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the audit log",
+	Long:  "Inspect the audit log of mutating RPC calls, entries are hash-chained so that modifications can be detected",
+}
+
+func createAuditClient() *client.ColoniesClient {
+	parseServerEnv()
+
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	if ServerID == "" {
+		ServerID = os.Getenv("COLONIES_SERVERID")
+	}
+	if ServerID == "" {
+		CheckError(errors.New("Unknown Server Id"))
+	}
+
+	if ServerPrvKey == "" {
+		ServerPrvKey, err = keychain.GetPrvKey(ServerID)
+		CheckError(err)
+	}
+
+	log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+	return createColoniesClient()
+}
+
+var lsAuditCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List audit log entries",
+	Long:  "List audit log entries",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createAuditClient()
+
+		auditEntries, err := client.GetAuditLog(TargetID, FromSeq, Count, ServerPrvKey)
+		CheckError(err)
+
+		if len(auditEntries) == 0 {
+			log.Info("No audit entries found")
+			os.Exit(0)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Seq", "Time", "CallerID", "SignerID", "PayloadType", "TargetIDs", "Result"})
+		for _, auditEntry := range auditEntries {
+			table.Append([]string{
+				strconv.FormatInt(auditEntry.Seq, 10),
+				auditEntry.Time.Format(TimeLayout),
+				auditEntry.CallerID,
+				auditEntry.SignerID,
+				auditEntry.PayloadType,
+				strings.Join(auditEntry.TargetIDs, "\n"),
+				auditEntry.Result + " (" + strconv.Itoa(auditEntry.Status) + ")"})
+		}
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	},
+}
+
+var verifyAuditCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify the hash chain of the audit log",
+	Long:  "Verify the hash chain of the audit log, all entries are downloaded starting at the first entry",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createAuditClient()
+
+		var prev *core.AuditEntry
+		verified := 0
+		fromSeq := int64(1)
+		for {
+			auditEntries, err := client.GetAuditLog("", fromSeq, server.MAX_COUNT, ServerPrvKey)
+			CheckError(err)
+
+			if len(auditEntries) == 0 {
+				break
+			}
+
+			// Include the last entry of the previous batch so that the batches are linked too
+			if prev != nil {
+				auditEntries = append([]*core.AuditEntry{prev}, auditEntries...)
+			}
+
+			err = core.VerifyAuditChain(auditEntries)
+			CheckError(err)
+
+			if auditEntries[0].Seq != fromSeq && prev == nil {
+				CheckError(errors.New("Audit log does not start at sequence number 1"))
+			}
+
+			for i := 1; i < len(auditEntries); i++ {
+				if auditEntries[i].Seq != auditEntries[i-1].Seq+1 {
+					CheckError(errors.New("Audit entry <" + strconv.FormatInt(auditEntries[i-1].Seq+1, 10) + "> is missing"))
+				}
+			}
+
+			if prev != nil {
+				verified += len(auditEntries) - 1
+			} else {
+				verified += len(auditEntries)
+			}
+			prev = auditEntries[len(auditEntries)-1]
+			fromSeq = prev.Seq + 1
+		}
+
+		fields := log.Fields{"Entries": verified}
+		if prev != nil {
+			fields["LastSeq"] = prev.Seq
+			fields["LastHash"] = prev.Hash
+		}
+		log.WithFields(fields).Info("Audit log verified")
+	},
+}
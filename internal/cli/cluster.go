@@ -4,9 +4,10 @@ import (
 	"errors"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/colonyos/colonies/pkg/client"
-	"github.com/colonyos/colonies/pkg/security"
+	"github.com/colonyos/colonies/pkg/cluster"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -14,12 +15,26 @@ import (
 
 func init() {
 	clusterCmd.AddCommand(clusterInfoCmd)
+	clusterCmd.AddCommand(addClusterNodeCmd)
+	clusterCmd.AddCommand(removeClusterNodeCmd)
 	rootCmd.AddCommand(clusterCmd)
 
-	clusterInfoCmd.Flags().StringVarP(&ServerHost, "host", "", "localhost", "Server host")
-	clusterInfoCmd.Flags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
-	clusterInfoCmd.Flags().StringVarP(&ServerID, "serverid", "", "", "Colonies server Id")
-	clusterInfoCmd.Flags().StringVarP(&ServerPrvKey, "serverprvkey", "", "", "Colonies server private key")
+	clusterCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", "localhost", "Server host")
+	clusterCmd.PersistentFlags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
+	clusterCmd.PersistentFlags().StringVarP(&ServerID, "serverid", "", "", "Colonies server Id")
+	clusterCmd.PersistentFlags().StringVarP(&ServerPrvKey, "serverprvkey", "", "", "Colonies server private key")
+
+	addClusterNodeCmd.Flags().StringVarP(&EtcdName, "name", "", "", "Name of the node")
+	addClusterNodeCmd.MarkFlagRequired("name")
+	addClusterNodeCmd.Flags().StringVarP(&EtcdHost, "nodehost", "", "", "Host name of the node")
+	addClusterNodeCmd.MarkFlagRequired("nodehost")
+	addClusterNodeCmd.Flags().IntVarP(&EtcdClientPort, "etcdclientport", "", 2379, "Etcd client port of the node")
+	addClusterNodeCmd.Flags().IntVarP(&EtcdPeerPort, "etcdpeerport", "", 2380, "Etcd peer port of the node")
+	addClusterNodeCmd.Flags().IntVarP(&RelayPort, "relayport", "", 2381, "Relay port of the node")
+	addClusterNodeCmd.Flags().IntVarP(&NodeAPIPort, "apiport", "", 50080, "Colonies server HTTP port of the node")
+
+	removeClusterNodeCmd.Flags().StringVarP(&EtcdName, "name", "", "", "Name of the node")
+	removeClusterNodeCmd.MarkFlagRequired("name")
 }
 
 var clusterCmd = &cobra.Command{
@@ -28,30 +43,34 @@ var clusterCmd = &cobra.Command{
 	Long:  "Manage Colonies clusters",
 }
 
+func createClusterClient() *client.ColoniesClient {
+	parseServerEnv()
+
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	if ServerID == "" {
+		ServerID = os.Getenv("COLONIES_SERVERID")
+	}
+	if ServerID == "" {
+		CheckError(errors.New("Unknown Server Id"))
+	}
+
+	if ServerPrvKey == "" {
+		ServerPrvKey, err = keychain.GetPrvKey(ServerID)
+		CheckError(err)
+	}
+
+	log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+	return createColoniesClient()
+}
+
 var clusterInfoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show info about a colonies cluster",
 	Long:  "Show info about a colonies cluster",
 	Run: func(cmd *cobra.Command, args []string) {
-		parseServerEnv()
-
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
-		CheckError(err)
-
-		if ServerID == "" {
-			ServerID = os.Getenv("COLONIES_SERVERID")
-		}
-		if ServerID == "" {
-			CheckError(errors.New("Unknown Server Id"))
-		}
-
-		if ServerPrvKey == "" {
-			ServerPrvKey, err = keychain.GetPrvKey(ServerID)
-			CheckError(err)
-		}
-
-		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createClusterClient()
 
 		cluster, err := client.GetClusterInfo(ServerPrvKey)
 		CheckError(err)
@@ -73,6 +92,59 @@ var clusterInfoCmd = &cobra.Command{
 		}
 		table.SetAlignment(tablewriter.ALIGN_LEFT)
 		table.Render()
+
+		if len(cluster.RelayMetrics) > 0 {
+			log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort}).Info("Relay metrics of the server")
+			relayTable := tablewriter.NewWriter(os.Stdout)
+			relayTable.SetHeader([]string{"Name", "Queued", "Delivered", "Retried", "Dropped", "Last error"})
+			for _, metrics := range cluster.RelayMetrics {
+				relayTable.Append([]string{metrics.Name,
+					strconv.Itoa(metrics.Queued),
+					strconv.FormatInt(metrics.Delivered, 10),
+					strconv.FormatInt(metrics.Retried, 10),
+					strconv.FormatInt(metrics.Dropped, 10),
+					metrics.LastError})
+			}
+			relayTable.SetAlignment(tablewriter.ALIGN_LEFT)
+			relayTable.Render()
+		}
+	},
+}
+
+var addClusterNodeCmd = &cobra.Command{
+	Use:   "add-node",
+	Short: "Add a node to a colonies cluster",
+	Long:  "Add a node to a colonies cluster, the node must then be started with --join and the printed --initial-cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClusterClient()
+
+		node := cluster.Node{Name: EtcdName, Host: EtcdHost, EtcdClientPort: EtcdClientPort, EtcdPeerPort: EtcdPeerPort, RelayPort: RelayPort, APIPort: NodeAPIPort}
+		err := node.Validate()
+		CheckError(err)
+
+		clusterConfig, err := client.AddClusterNode(node, ServerPrvKey)
+		CheckError(err)
+
+		var initialCluster []string
+		for _, node := range clusterConfig.Nodes {
+			initialCluster = append(initialCluster, node.Name+"="+node.Host+":"+strconv.Itoa(node.EtcdPeerPort)+":"+strconv.Itoa(node.RelayPort)+":"+strconv.Itoa(node.APIPort))
+		}
+
+		log.WithFields(log.Fields{"Name": node.Name, "Host": node.Host}).Info("Node added to cluster, start it with --join --initial-cluster " + strings.Join(initialCluster, ","))
+	},
+}
+
+var removeClusterNodeCmd = &cobra.Command{
+	Use:   "remove-node",
+	Short: "Remove a node from a colonies cluster",
+	Long:  "Remove a node from a colonies cluster, the request must be sent to another node than the removed node",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClusterClient()
+
+		err := client.RemoveClusterNode(EtcdName, ServerPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"Name": EtcdName}).Info("Node removed from cluster")
 	},
 }
 
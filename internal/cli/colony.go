@@ -3,13 +3,10 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strconv"
 
-	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -21,6 +18,7 @@ func init() {
 	colonyCmd.AddCommand(unregisterColonyCmd)
 	colonyCmd.AddCommand(lsColoniesCmd)
 	colonyCmd.AddCommand(colonyStatCmd)
+	colonyCmd.AddCommand(rotateColonyKeyCmd)
 	rootCmd.AddCommand(colonyCmd)
 
 	colonyCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", DefaultServerHost, "Server host")
@@ -29,7 +27,7 @@ func init() {
 	registerColonyCmd.Flags().StringVarP(&ServerID, "serverid", "", "", "Colonies server Id")
 	registerColonyCmd.Flags().StringVarP(&ServerPrvKey, "serverprvkey", "", "", "Colonies server private key")
 	registerColonyCmd.Flags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
-	registerColonyCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON specification of a Colony")
+	registerColonyCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony")
 	registerColonyCmd.MarkFlagRequired("spec")
 
 	unregisterColonyCmd.Flags().StringVarP(&ServerID, "serverid", "", "", "Colonies server Id")
@@ -44,6 +42,10 @@ func init() {
 	colonyStatCmd.Flags().StringVarP(&ServerID, "serverid", "", "", "Colonies server Id")
 	colonyStatCmd.Flags().StringVarP(&ServerPrvKey, "serverprvkey", "", "", "Colonies server private key")
 	colonyStatCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
+
+	rotateColonyKeyCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
+	rotateColonyKeyCmd.Flags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Current colony private key")
+	rotateColonyKeyCmd.Flags().StringVarP(&NewColonyPrvKey, "newcolonyprvkey", "", "", "New colony private key, a new key is generated if not specified")
 }
 
 var colonyCmd = &cobra.Command{
@@ -59,15 +61,15 @@ var registerColonyCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		jsonSpecBytes, err := ioutil.ReadFile(SpecFile)
+		jsonSpec, err := ReadSpecFile(SpecFile)
 		CheckError(err)
 
-		colony, err := core.ConvertJSONToColony(string(jsonSpecBytes))
+		colony, err := core.ConvertJSONToColony(jsonSpec)
 		CheckError(err)
 
 		crypto := crypto.CreateCrypto()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		var prvKey string
@@ -98,7 +100,7 @@ var registerColonyCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		addedColony, err := client.AddColony(colony, ServerPrvKey)
 		CheckError(err)
@@ -117,7 +119,7 @@ var unregisterColonyCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ServerID == "" {
@@ -133,7 +135,7 @@ var unregisterColonyCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		err = client.DeleteColony(ColonyID, ServerPrvKey)
 		CheckError(err)
@@ -150,9 +152,9 @@ var lsColoniesCmd = &cobra.Command{
 		parseServerEnv()
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ServerID == "" {
@@ -205,7 +207,7 @@ var colonyStatCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -230,7 +232,7 @@ var colonyStatCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		stat, err := client.ColonyStatistics(ColonyID, RuntimePrvKey)
 		CheckError(err)
@@ -255,3 +257,76 @@ var colonyStatCmd = &cobra.Command{
 		specTable.Render()
 	},
 }
+
+var rotateColonyKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Hand over a colony to a new colony private key",
+	Long:  "Hand over a colony to a new colony private key, the colony Id and all runtimes and processes in the colony are kept",
+	Run: func(cmd *cobra.Command, args []string) {
+		parseServerEnv()
+
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		if ColonyID == "" {
+			ColonyID = os.Getenv("COLONIES_COLONYID")
+		}
+		if ColonyID == "" {
+			CheckError(errors.New("Unknown Colony Id"))
+		}
+
+		if ColonyPrvKey == "" {
+			ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
+			CheckError(err)
+		}
+
+		crypto := crypto.CreateCrypto()
+		if NewColonyPrvKey != "" {
+			if len(NewColonyPrvKey) != 64 {
+				CheckError(errors.New("Invalid private key length"))
+			}
+		} else {
+			NewColonyPrvKey, err = crypto.GeneratePrivateKey()
+			CheckError(err)
+		}
+
+		newOwnerID, err := crypto.GenerateID(NewColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+		client := createColoniesClient()
+
+		// The new key is stored under its own Id before the colony is handed over, so that it is not lost if the
+		// reply from the server or updating the keychain fails, the old key is kept until the server has confirmed
+		err = keychain.AddPrvKey(newOwnerID, NewColonyPrvKey)
+		CheckError(err)
+
+		err = client.RotateColonyKey(ColonyID, NewColonyPrvKey, ColonyPrvKey)
+		if err != nil {
+			log.WithFields(log.Fields{"ColonyID": ColonyID, "Error": err}).Error("Failed to rotate colony key, the colony may already have been handed over to the new key")
+			log.WithFields(log.Fields{"ID": newOwnerID}).Error("The new colony private key is stored in the keychain, use colonies keychain get --id " + newOwnerID)
+			os.Exit(-1)
+		}
+
+		// The colony Id is unchanged, so the new key replaces the old key in the keychain. The stored entry is copied,
+		// so an encrypted key is not decrypted again.
+		entry, err := keychain.GetEntry(newOwnerID)
+		if err == nil {
+			err = keychain.AddEntry(ColonyID, entry)
+		}
+		if err != nil {
+			log.WithFields(log.Fields{"ColonyID": ColonyID, "Error": err}).Error("Colony key rotated, but failed to replace the colony private key in the keychain")
+			log.WithFields(log.Fields{"ID": newOwnerID}).Error("The new colony private key is stored in the keychain, use colonies keychain get --id " + newOwnerID)
+			os.Exit(-1)
+		}
+
+		if newOwnerID != ColonyID {
+			err = keychain.RemovePrvKey(newOwnerID)
+			if err != nil {
+				log.WithFields(log.Fields{"ID": newOwnerID, "Error": err}).Warning("Failed to remove temporary keychain entry")
+			}
+		}
+
+		log.WithFields(log.Fields{"ColonyID": ColonyID, "OwnerID": newOwnerID}).Info("Colony key rotated")
+	},
+}
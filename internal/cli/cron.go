@@ -3,13 +3,10 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strconv"
 
-	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/server"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -29,8 +26,10 @@ func init() {
 
 	addCronCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
 	addCronCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
-	addCronCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON specification of a Colony workflow")
-	addCronCmd.MarkFlagRequired("spec")
+	addCronCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony workflow")
+	addCronCmd.Flags().StringVarP(&TemplateName, "template", "", "", "Name of a workflow template to use instead of a JSON specification")
+	addCronCmd.Flags().IntVarP(&TemplateVersion, "templateversion", "", 0, "Workflow template version, 0 is the latest version")
+	addCronCmd.Flags().StringSliceVarP(&TemplateParams, "param", "", make([]string, 0), "Workflow template parameter value, name=value")
 	addCronCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
 	addCronCmd.Flags().StringVarP(&CronName, "name", "", "", "Cron name")
 	addCronCmd.MarkFlagRequired("name")
@@ -72,39 +71,58 @@ var addCronCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		jsonSpecBytes, err := ioutil.ReadFile(SpecFile)
-		CheckError(err)
-
-		jsonStr := "{\"processspecs\":" + string(jsonSpecBytes) + "}"
-		workflowSpec, err := core.ConvertJSONToWorkflowSpec(jsonStr)
-		CheckError(err)
-
-		if workflowSpec.ColonyID == "" {
+		var workflowSpecJSON string
+		var workflowTemplateRef *core.WorkflowTemplateRef
+		if TemplateName != "" {
 			if ColonyID == "" {
 				ColonyID = os.Getenv("COLONIES_COLONYID")
 			}
 			if ColonyID == "" {
-				CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+				CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID"))
 			}
 
-			workflowSpec.ColonyID = ColonyID
-		}
+			var err error
+			workflowTemplateRef, err = parseTemplateRef()
+			CheckError(err)
+		} else {
+			if SpecFile == "" {
+				CheckError(errors.New("Either a specification or a workflow template must be specified"))
+			}
 
-		workflowSpecJSON, err := workflowSpec.ToJSON()
-		CheckError(err)
+			jsonSpec, err := ReadSpecFile(SpecFile)
+			CheckError(err)
 
-		if workflowSpec.ColonyID == "" {
-			if ColonyID == "" {
-				ColonyID = os.Getenv("COLONIES_COLONYID")
-			}
-			if ColonyID == "" {
-				CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+			jsonStr := "{\"processspecs\":" + jsonSpec + "}"
+			workflowSpec, err := core.ConvertJSONToWorkflowSpec(jsonStr)
+			CheckError(err)
+
+			if workflowSpec.ColonyID == "" {
+				if ColonyID == "" {
+					ColonyID = os.Getenv("COLONIES_COLONYID")
+				}
+				if ColonyID == "" {
+					CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+				}
+
+				workflowSpec.ColonyID = ColonyID
 			}
 
-			workflowSpec.ColonyID = ColonyID
+			workflowSpecJSON, err = workflowSpec.ToJSON()
+			CheckError(err)
+
+			if workflowSpec.ColonyID == "" {
+				if ColonyID == "" {
+					ColonyID = os.Getenv("COLONIES_COLONYID")
+				}
+				if ColonyID == "" {
+					CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+				}
+
+				workflowSpec.ColonyID = ColonyID
+			}
 		}
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -120,7 +138,7 @@ var addCronCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if CronName == "" {
 			CheckError(errors.New("Cron name not specified"))
@@ -131,6 +149,7 @@ var addCronCmd = &cobra.Command{
 		}
 
 		cron := core.CreateCron(ColonyID, CronName, CronExpr, CronIntervall, CronRandom, workflowSpecJSON)
+		cron.WorkflowTemplate = workflowTemplateRef
 		addedCron, err := client.AddCron(cron, RuntimePrvKey)
 		CheckError(err)
 
@@ -145,7 +164,7 @@ var delCronCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -161,7 +180,7 @@ var delCronCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if CronID == "" {
 			CheckError(errors.New("Cron Id not specified"))
@@ -181,7 +200,7 @@ var getCronCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -197,7 +216,7 @@ var getCronCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if CronID == "" {
 			CheckError(errors.New("Cron Id not specified"))
@@ -230,6 +249,20 @@ var getCronCmd = &cobra.Command{
 		generatorTable.Render()
 
 		fmt.Println()
+		if cron.WorkflowTemplate != nil {
+			fmt.Println("WorkflowTemplate:")
+			templateTable := tablewriter.NewWriter(os.Stdout)
+			templateTable.Append([]string{"Name", cron.WorkflowTemplate.Name})
+			templateTable.Append([]string{"Version", strconv.Itoa(cron.WorkflowTemplate.Version)})
+			for name, value := range cron.WorkflowTemplate.Parameters {
+				templateTable.Append([]string{"Parameter " + name, value})
+			}
+			templateTable.SetAlignment(tablewriter.ALIGN_LEFT)
+			templateTable.SetAutoWrapText(false)
+			templateTable.Render()
+			os.Exit(0)
+		}
+
 		fmt.Println("WorkflowSpec:")
 		workflowSpec, err := core.ConvertJSONToWorkflowSpec(cron.WorkflowSpec)
 		CheckError(err)
@@ -248,7 +281,7 @@ var getCronsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -271,7 +304,7 @@ var getCronsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		crons, err := client.GetCrons(ColonyID, Count, RuntimePrvKey)
 		if crons == nil {
@@ -300,7 +333,7 @@ var runCronCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -316,7 +349,7 @@ var runCronCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if CronID == "" {
 			CheckError(errors.New("Cron Id not specified"))
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/kataras/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	delegationCmd.AddCommand(issueDelegationCmd)
+	delegationCmd.AddCommand(showDelegationCmd)
+	rootCmd.AddCommand(delegationCmd)
+
+	issueDelegationCmd.Flags().StringVarP(&DelegateID, "delegateid", "", "", "Id of the key the delegation is issued to")
+	issueDelegationCmd.MarkFlagRequired("delegateid")
+	issueDelegationCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id the delegation is restricted to")
+	issueDelegationCmd.Flags().StringVarP(&IssuerID, "issuerid", "", "", "Id of the issuer, the private key is obtained from the keychain, the colony owner by default")
+	issueDelegationCmd.Flags().StringVarP(&PrvKey, "prvkey", "", "", "Private key of the issuer")
+	issueDelegationCmd.Flags().StringSliceVarP(&PayloadTypes, "payloadtypes", "", []string{}, "Payload types the delegate may send, e.g. submitprocessspecmsg")
+	issueDelegationCmd.MarkFlagRequired("payloadtypes")
+	issueDelegationCmd.Flags().StringSliceVarP(&Funcs, "funcs", "", []string{}, "Functions the delegate may submit processes for, all functions if not specified")
+	issueDelegationCmd.Flags().StringSliceVarP(&RuntimeTypes, "runtimetypes", "", []string{}, "Runtime types the delegate may submit processes to, all runtime types if not specified")
+	issueDelegationCmd.Flags().StringVarP(&TTL, "ttl", "", "1h", "Time until the delegation expires, e.g. 30m or 24h")
+	issueDelegationCmd.Flags().StringVarP(&SpecFile, "chain", "", "", "Delegation chain to extend, the issuer must be the last delegate in the chain")
+	issueDelegationCmd.Flags().StringVarP(&KeychainFile, "file", "", "", "File to write the delegation chain to, printed to stdout if not specified")
+
+	showDelegationCmd.Flags().StringVarP(&KeychainFile, "file", "", "", "Delegation chain file")
+	showDelegationCmd.MarkFlagRequired("file")
+}
+
+var delegationCmd = &cobra.Command{
+	Use:   "delegation",
+	Short: "Manage delegated credentials",
+	Long:  "Manage delegated credentials, a delegation allows another key to send a restricted set of RPC messages on behalf of a colony owner or a runtime until it expires",
+}
+
+func readDelegationChain(filename string) ([]*core.Delegation, error) {
+	jsonBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToDelegationArray(string(jsonBytes))
+}
+
+// createColoniesClient creates a client, which attaches the delegation chain specified by --delegation or the
+// COLONIES_DELEGATION environment variable to all messages
+func createColoniesClient() *client.ColoniesClient {
+	coloniesClient := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+
+	if DelegationFile == "" {
+		DelegationFile = os.Getenv("COLONIES_DELEGATION")
+	}
+	if DelegationFile != "" {
+		delegations, err := readDelegationChain(DelegationFile)
+		CheckError(err)
+		coloniesClient.SetDelegations(delegations)
+	}
+
+	return coloniesClient
+}
+
+var issueDelegationCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "Issue a delegation",
+	Long:  "Issue a delegation, or extend an existing delegation chain with a delegation of a subset of its scope",
+	Run: func(cmd *cobra.Command, args []string) {
+		ttl, err := time.ParseDuration(TTL)
+		CheckError(err)
+
+		var chain []*core.Delegation
+		if SpecFile != "" {
+			chain, err = readDelegationChain(SpecFile)
+			CheckError(err)
+			if len(chain) == 0 {
+				CheckError(errors.New("Delegation chain is empty"))
+			}
+			if ColonyID == "" {
+				ColonyID = chain[0].ColonyID
+			}
+			if IssuerID == "" {
+				IssuerID = chain[len(chain)-1].DelegateID
+			}
+		}
+
+		if ColonyID == "" {
+			ColonyID = os.Getenv("COLONIES_COLONYID")
+		}
+		if ColonyID == "" {
+			CheckError(errors.New("Unknown Colony Id"))
+		}
+
+		if PrvKey == "" {
+			if IssuerID == "" {
+				IssuerID = ColonyID
+			}
+			keychain, err := createKeychain()
+			CheckError(err)
+			PrvKey, err = keychain.GetPrvKey(IssuerID)
+			CheckError(err)
+		}
+
+		issuerID, err := crypto.CreateCrypto().GenerateID(PrvKey)
+		CheckError(err)
+
+		delegation := core.CreateDelegation(DelegateID, ColonyID, PayloadTypes, Funcs, RuntimeTypes, time.Now().Add(ttl))
+		err = delegation.Sign(PrvKey)
+		CheckError(err)
+
+		chain = append(chain, delegation)
+		err = core.VerifyDelegationChain(chain, DelegateID, time.Now())
+		CheckError(err)
+
+		jsonString, err := core.ConvertDelegationArrayToJSON(chain)
+		CheckError(err)
+
+		if KeychainFile == "" {
+			fmt.Println(jsonString)
+			return
+		}
+
+		err = os.WriteFile(KeychainFile, []byte(jsonString), 0600)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"IssuerID": issuerID, "DelegateID": DelegateID, "ColonyID": ColonyID, "Expires": delegation.Expires, "File": KeychainFile}).Info("Delegation issued")
+	},
+}
+
+var showDelegationCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a delegation chain",
+	Long:  "Show a delegation chain",
+	Run: func(cmd *cobra.Command, args []string) {
+		chain, err := readDelegationChain(KeychainFile)
+		CheckError(err)
+
+		for _, delegation := range chain {
+			err := delegation.Verify()
+			valid := "true"
+			if err != nil {
+				valid = "false"
+			}
+
+			delegationData := [][]string{
+				[]string{"IssuerID", delegation.IssuerID},
+				[]string{"DelegateID", delegation.DelegateID},
+				[]string{"ColonyID", delegation.ColonyID},
+				[]string{"PayloadTypes", strings.Join(delegation.PayloadTypes, ", ")},
+				[]string{"Funcs", strings.Join(delegation.Funcs, ", ")},
+				[]string{"RuntimeTypes", strings.Join(delegation.RuntimeTypes, ", ")},
+				[]string{"Expires", delegation.Expires.Local().Format(TimeLayout)},
+				[]string{"Valid signature", valid},
+			}
+			table := tablewriter.NewWriter(os.Stdout)
+			for _, v := range delegationData {
+				table.Append(v)
+			}
+			table.SetAlignment(tablewriter.ALIGN_LEFT)
+			table.Render()
+		}
+	},
+}
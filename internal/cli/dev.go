@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/colonyos/colonies/internal/crypto"
 	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/cluster"
 	"github.com/colonyos/colonies/pkg/core"
@@ -246,6 +247,10 @@ var devCmd = &cobra.Command{
 		}
 
 		coloniesServer := server.CreateColoniesServer(coloniesDB, coloniesServerPort, serverID, false, "", "", node, clusterConfig, "/tmp/coloniesdev/dev/etcd")
+		secretsKey, err := crypto.GenerateAESKey()
+		CheckError(err)
+		err = coloniesServer.SetSecretsKey(secretsKey)
+		CheckError(err)
 		go coloniesServer.ServeForever()
 
 		coloniesServerHost := os.Getenv("COLONIES_SERVERHOST")
@@ -3,13 +3,10 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strconv"
 
-	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/server"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -29,13 +26,16 @@ func init() {
 
 	addGeneratorCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
 	addGeneratorCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
-	addGeneratorCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON specification of a Colony workflow")
-	addGeneratorCmd.MarkFlagRequired("spec")
+	addGeneratorCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony workflow")
+	addGeneratorCmd.Flags().StringVarP(&TemplateName, "template", "", "", "Name of a workflow template to use instead of a JSON specification")
+	addGeneratorCmd.Flags().IntVarP(&TemplateVersion, "templateversion", "", 0, "Workflow template version, 0 is the latest version")
+	addGeneratorCmd.Flags().StringSliceVarP(&TemplateParams, "param", "", make([]string, 0), "Workflow template parameter value, name=value")
 	addGeneratorCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
 	addGeneratorCmd.Flags().StringVarP(&GeneratorName, "name", "", "", "Generator name")
 	addGeneratorCmd.MarkFlagRequired("name")
 	addGeneratorCmd.Flags().IntVarP(&GeneratorTrigger, "trigger", "", -1, "Trigger")
 	addGeneratorCmd.MarkFlagRequired("trigger")
+	addGeneratorCmd.Flags().StringVarP(&GeneratorWebhookSecret, "webhooksecret", "", "", "Shared secret to enable HMAC signed webhooks")
 
 	packGeneratorCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
 	packGeneratorCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
@@ -73,39 +73,58 @@ var addGeneratorCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		jsonSpecBytes, err := ioutil.ReadFile(SpecFile)
-		CheckError(err)
-
-		jsonStr := "{\"processspecs\":" + string(jsonSpecBytes) + "}"
-		workflowSpec, err := core.ConvertJSONToWorkflowSpec(jsonStr)
-		CheckError(err)
-
-		if workflowSpec.ColonyID == "" {
+		var workflowSpecJSON string
+		var workflowTemplateRef *core.WorkflowTemplateRef
+		if TemplateName != "" {
 			if ColonyID == "" {
 				ColonyID = os.Getenv("COLONIES_COLONYID")
 			}
 			if ColonyID == "" {
-				CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+				CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID"))
 			}
 
-			workflowSpec.ColonyID = ColonyID
-		}
+			var err error
+			workflowTemplateRef, err = parseTemplateRef()
+			CheckError(err)
+		} else {
+			if SpecFile == "" {
+				CheckError(errors.New("Either a specification or a workflow template must be specified"))
+			}
 
-		workflowSpecJSON, err := workflowSpec.ToJSON()
-		CheckError(err)
+			jsonSpec, err := ReadSpecFile(SpecFile)
+			CheckError(err)
 
-		if workflowSpec.ColonyID == "" {
-			if ColonyID == "" {
-				ColonyID = os.Getenv("COLONIES_COLONYID")
-			}
-			if ColonyID == "" {
-				CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+			jsonStr := "{\"processspecs\":" + jsonSpec + "}"
+			workflowSpec, err := core.ConvertJSONToWorkflowSpec(jsonStr)
+			CheckError(err)
+
+			if workflowSpec.ColonyID == "" {
+				if ColonyID == "" {
+					ColonyID = os.Getenv("COLONIES_COLONYID")
+				}
+				if ColonyID == "" {
+					CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+				}
+
+				workflowSpec.ColonyID = ColonyID
 			}
 
-			workflowSpec.ColonyID = ColonyID
+			workflowSpecJSON, err = workflowSpec.ToJSON()
+			CheckError(err)
+
+			if workflowSpec.ColonyID == "" {
+				if ColonyID == "" {
+					ColonyID = os.Getenv("COLONIES_COLONYID")
+				}
+				if ColonyID == "" {
+					CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
+				}
+
+				workflowSpec.ColonyID = ColonyID
+			}
 		}
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -121,7 +140,7 @@ var addGeneratorCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if GeneratorName == "" {
 			CheckError(errors.New("Generator name not specified"))
@@ -136,6 +155,8 @@ var addGeneratorCmd = &cobra.Command{
 		}
 
 		generator := core.CreateGenerator(ColonyID, GeneratorName, workflowSpecJSON, GeneratorTrigger)
+		generator.WorkflowTemplate = workflowTemplateRef
+		generator.WebhookSecret = GeneratorWebhookSecret
 		addedGenerator, err := client.AddGenerator(generator, RuntimePrvKey)
 
 		log.WithFields(log.Fields{"GeneratorID": addedGenerator.ID}).Info("Generator added")
@@ -149,7 +170,7 @@ var packGeneratorCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -165,7 +186,7 @@ var packGeneratorCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if GeneratorID == "" {
 			CheckError(errors.New("Generator Id not specified"))
@@ -185,7 +206,7 @@ var delGeneratorCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -201,7 +222,7 @@ var delGeneratorCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if GeneratorID == "" {
 			CheckError(errors.New("Generator Id not specified"))
@@ -221,7 +242,7 @@ var getGeneratorCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -237,7 +258,7 @@ var getGeneratorCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if GeneratorID == "" {
 			CheckError(errors.New("Generator Id not specified"))
@@ -256,6 +277,9 @@ var getGeneratorCmd = &cobra.Command{
 			[]string{"Trigger", strconv.Itoa(generator.Trigger)},
 			[]string{"Lastrun", generator.LastRun.Format(TimeLayout)},
 		}
+		if generator.WebhookEnabled {
+			generatorData = append(generatorData, []string{"Webhook", "/generators/" + generator.ID + "/webhook"})
+		}
 		generatorTable := tablewriter.NewWriter(os.Stdout)
 		for _, v := range generatorData {
 			generatorTable.Append(v)
@@ -265,6 +289,20 @@ var getGeneratorCmd = &cobra.Command{
 		generatorTable.Render()
 
 		fmt.Println()
+		if generator.WorkflowTemplate != nil {
+			fmt.Println("WorkflowTemplate:")
+			templateTable := tablewriter.NewWriter(os.Stdout)
+			templateTable.Append([]string{"Name", generator.WorkflowTemplate.Name})
+			templateTable.Append([]string{"Version", strconv.Itoa(generator.WorkflowTemplate.Version)})
+			for name, value := range generator.WorkflowTemplate.Parameters {
+				templateTable.Append([]string{"Parameter " + name, value})
+			}
+			templateTable.SetAlignment(tablewriter.ALIGN_LEFT)
+			templateTable.SetAutoWrapText(false)
+			templateTable.Render()
+			os.Exit(0)
+		}
+
 		fmt.Println("WorkflowSpec:")
 		workflowSpec, err := core.ConvertJSONToWorkflowSpec(generator.WorkflowSpec)
 		CheckError(err)
@@ -283,7 +321,7 @@ var getGeneratorsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -306,7 +344,7 @@ var getGeneratorsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		generators, err := client.GetGenerators(ColonyID, Count, RuntimePrvKey)
 		if generators == nil {
@@ -1,18 +1,30 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+const WORKER_LABEL = "worker"
+
 func init() {
 	keychainCmd.AddCommand(addPrivateKeyCmd)
 	keychainCmd.AddCommand(getPrivateKeyCmd)
 	keychainCmd.AddCommand(genPrivateKeyCmd)
+	keychainCmd.AddCommand(lsPrivateKeysCmd)
+	keychainCmd.AddCommand(removePrivateKeyCmd)
+	keychainCmd.AddCommand(exportPrivateKeysCmd)
+	keychainCmd.AddCommand(importPrivateKeysCmd)
 	rootCmd.AddCommand(keychainCmd)
 
 	getPrivateKeyCmd.Flags().StringVarP(&ID, "id", "", "", "Identity")
@@ -22,12 +34,87 @@ func init() {
 	addPrivateKeyCmd.MarkFlagRequired("id")
 	addPrivateKeyCmd.Flags().StringVarP(&PrvKey, "prvkey", "", "", "Private key")
 	addPrivateKeyCmd.MarkFlagRequired("prvkey")
+	addPrivateKeyCmd.Flags().BoolVarP(&Encrypt, "encrypt", "", false, "Encrypt the private key with a passphrase")
+
+	genPrivateKeyCmd.Flags().BoolVarP(&Encrypt, "encrypt", "", false, "Encrypt the private key with a passphrase")
+
+	removePrivateKeyCmd.Flags().StringVarP(&ID, "id", "", "", "Identity")
+	removePrivateKeyCmd.MarkFlagRequired("id")
+
+	exportPrivateKeysCmd.Flags().StringVarP(&ID, "id", "", "", "Only export the private key of this identity")
+	exportPrivateKeysCmd.Flags().StringVarP(&KeychainFile, "file", "", "", "File to export to, printed to stdout if not specified")
+
+	importPrivateKeysCmd.Flags().StringVarP(&KeychainFile, "file", "", "", "File to import from")
+	importPrivateKeysCmd.MarkFlagRequired("file")
+	importPrivateKeysCmd.Flags().BoolVarP(&Encrypt, "encrypt", "", false, "Encrypt unencrypted private keys with a passphrase")
+}
+
+// An exportedKey is a keychain entry as stored, i.e. encrypted entries stay encrypted when exported
+type exportedKey struct {
+	ID    string `json:"id"`
+	Entry string `json:"entry"`
+}
+
+func readPassphrase(prompt string) (string, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New("Keychain passphrase required, set the COLONIES_KEYCHAIN_PASSPHRASE environment variable")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(passphrase), nil
+}
+
+// createKeychain opens the keychain, the passphrase of encrypted entries is read from the COLONIES_KEYCHAIN_PASSPHRASE
+// environment variable or prompted for when needed. New entries are encrypted if the environment variable is set.
+func createKeychain() (*security.Keychain, error) {
+	keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := os.Getenv("COLONIES_KEYCHAIN_PASSPHRASE")
+	if passphrase != "" {
+		keychain.SetPassphrase(passphrase)
+	} else {
+		keychain.SetPassphraseFunc(func() (string, error) {
+			return readPassphrase("Keychain passphrase: ")
+		})
+	}
+
+	return keychain, nil
+}
+
+// requireEncryption makes sure new entries are encrypted, a passphrase is prompted for unless already set
+func requireEncryption(keychain *security.Keychain) {
+	if os.Getenv("COLONIES_KEYCHAIN_PASSPHRASE") != "" {
+		return
+	}
+
+	passphrase, err := readPassphrase("Keychain passphrase: ")
+	CheckError(err)
+	if passphrase == "" {
+		CheckError(errors.New("Empty passphrase"))
+	}
+
+	confirmation, err := readPassphrase("Repeat passphrase: ")
+	CheckError(err)
+	if passphrase != confirmation {
+		CheckError(errors.New("Passphrases do not match"))
+	}
+
+	keychain.SetPassphrase(passphrase)
 }
 
 var keychainCmd = &cobra.Command{
 	Use:   "keychain",
 	Short: "Manage private keys",
-	Long:  "Manage private keys",
+	Long:  "Manage private keys, private keys are encrypted with a passphrase if --encrypt is specified or if the COLONIES_KEYCHAIN_PASSPHRASE environment variable is set",
 }
 
 var addPrivateKeyCmd = &cobra.Command{
@@ -35,9 +122,13 @@ var addPrivateKeyCmd = &cobra.Command{
 	Short: "Add a private key",
 	Long:  "Add a private key",
 	Run: func(cmd *cobra.Command, args []string) {
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
+		if Encrypt {
+			requireEncryption(keychain)
+		}
+
 		err = keychain.AddPrvKey(ID, PrvKey)
 		CheckError(err)
 	},
@@ -48,7 +139,7 @@ var getPrivateKeyCmd = &cobra.Command{
 	Short: "Get a private key for an identity",
 	Long:  "Get a private key for an identity",
 	Run: func(cmd *cobra.Command, args []string) {
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		privateKey, err := keychain.GetPrvKey(ID)
@@ -64,9 +155,13 @@ var genPrivateKeyCmd = &cobra.Command{
 	Short: "Generate a private key",
 	Long:  "Generate a private key",
 	Run: func(cmd *cobra.Command, args []string) {
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
+		if Encrypt {
+			requireEncryption(keychain)
+		}
+
 		crypto := crypto.CreateCrypto()
 		prvKey, err := crypto.GeneratePrivateKey()
 		CheckError(err)
@@ -77,6 +172,129 @@ var genPrivateKeyCmd = &cobra.Command{
 		err = keychain.AddPrvKey(id, prvKey)
 		CheckError(err)
 
-		log.WithFields(log.Fields{"ID": id, "privateKey": prvKey}).Info("Generated new private key and stored in keychain")
+		log.WithFields(log.Fields{"ID": id}).Info("Generated new private key and stored in keychain")
+	},
+}
+
+var lsPrivateKeysCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List identities in the keychain",
+	Long:  "List identities in the keychain",
+	Run: func(cmd *cobra.Command, args []string) {
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		entries, err := keychain.List()
+		CheckError(err)
+
+		if len(entries) == 0 {
+			log.Info("No private keys found")
+			os.Exit(0)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"ID", "Encrypted"})
+		for _, entry := range entries {
+			table.Append([]string{entry.ID, strconv.FormatBool(entry.Encrypted)})
+		}
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	},
+}
+
+var removePrivateKeyCmd = &cobra.Command{
+	Use:   "rm",
+	Short: "Remove a private key",
+	Long:  "Remove a private key",
+	Run: func(cmd *cobra.Command, args []string) {
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		err = keychain.RemovePrvKey(ID)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"ID": ID}).Info("Private key removed from keychain")
+	},
+}
+
+var exportPrivateKeysCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export private keys",
+	Long:  "Export private keys as JSON, encrypted private keys stay encrypted with the passphrase they were encrypted with",
+	Run: func(cmd *cobra.Command, args []string) {
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		var ids []string
+		if ID != "" {
+			ids = []string{ID}
+		} else {
+			entries, err := keychain.List()
+			CheckError(err)
+			for _, entry := range entries {
+				ids = append(ids, entry.ID)
+			}
+		}
+
+		exportedKeys := []*exportedKey{}
+		for _, id := range ids {
+			entry, err := keychain.GetEntry(id)
+			CheckError(err)
+			exportedKeys = append(exportedKeys, &exportedKey{ID: id, Entry: entry})
+		}
+
+		jsonBytes, err := json.MarshalIndent(exportedKeys, "", "    ")
+		CheckError(err)
+
+		if KeychainFile == "" {
+			fmt.Println(string(jsonBytes))
+			return
+		}
+
+		err = os.WriteFile(KeychainFile, jsonBytes, 0600)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"File": KeychainFile, "Keys": len(exportedKeys)}).Info("Private keys exported")
+	},
+}
+
+var importPrivateKeysCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import private keys",
+	Long:  "Import private keys exported with colonies keychain export",
+	Run: func(cmd *cobra.Command, args []string) {
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		if Encrypt {
+			requireEncryption(keychain)
+		}
+
+		jsonBytes, err := os.ReadFile(KeychainFile)
+		CheckError(err)
+
+		var exportedKeys []*exportedKey
+		err = json.Unmarshal(jsonBytes, &exportedKeys)
+		CheckError(err)
+
+		crypto := crypto.CreateCrypto()
+		for _, exportedKey := range exportedKeys {
+			if keychain.IsEncryptedEntry(exportedKey.Entry) {
+				err = keychain.AddEntry(exportedKey.ID, exportedKey.Entry)
+				CheckError(err)
+				continue
+			}
+
+			id, err := crypto.GenerateID(exportedKey.Entry)
+			CheckError(err)
+			if id != exportedKey.ID {
+				CheckError(errors.New("Private key does not belong to identity <" + exportedKey.ID + ">"))
+			}
+
+			err = keychain.AddPrvKey(exportedKey.ID, exportedKey.Entry)
+			CheckError(err)
+		}
+
+		log.WithFields(log.Fields{"File": KeychainFile, "Keys": len(exportedKeys)}).Info("Private keys imported")
 	},
 }
@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/colonyos/colonies/pkg/monitoring"
-	"github.com/colonyos/colonies/pkg/security"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -38,7 +37,7 @@ var monitoringStartCmd = &cobra.Command{
 
 		ServerPrvKey = os.Getenv("COLONIES_SERVERPRVKEY")
 		if ServerPrvKey == "" {
-			keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+			keychain, err := createKeychain()
 			CheckError(err)
 			ServerPrvKey, err = keychain.GetPrvKey(ServerID)
 			CheckError(err)
@@ -4,14 +4,12 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/server"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -38,7 +36,7 @@ func init() {
 
 	submitProcessCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
 	submitProcessCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
-	submitProcessCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON specification of a Colony process")
+	submitProcessCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony process")
 	submitProcessCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
 	submitProcessCmd.Flags().BoolVarP(&Wait, "wait", "", false, "Colony Id")
 	submitProcessCmd.MarkFlagRequired("spec")
@@ -170,7 +168,7 @@ var runProcessCmd = &cobra.Command{
 			CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
 		}
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -208,7 +206,7 @@ var runProcessCmd = &cobra.Command{
 			Env:         env}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		addedProcess, err := client.SubmitProcessSpec(&processSpec, RuntimePrvKey)
 		CheckError(err)
@@ -228,10 +226,10 @@ var submitProcessCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		jsonSpecBytes, err := ioutil.ReadFile(SpecFile)
+		jsonSpec, err := ReadSpecFile(SpecFile)
 		CheckError(err)
 
-		processSpec, err := core.ConvertJSONToProcessSpec(string(jsonSpecBytes))
+		processSpec, err := core.ConvertJSONToProcessSpec(jsonSpec)
 		CheckError(err)
 
 		if processSpec.Conditions.ColonyID == "" {
@@ -245,7 +243,7 @@ var submitProcessCmd = &cobra.Command{
 			processSpec.Conditions.ColonyID = ColonyID
 		}
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -261,7 +259,7 @@ var submitProcessCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		addedProcess, err := client.SubmitProcessSpec(processSpec, RuntimePrvKey)
 		CheckError(err)
@@ -281,7 +279,7 @@ var assignProcessCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -304,7 +302,7 @@ var assignProcessCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		if Latest {
 			process, err := client.AssignLatestProcess(ColonyID, Timeout, RuntimePrvKey)
@@ -332,7 +330,7 @@ var listWaitingProcessesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -354,7 +352,7 @@ var listWaitingProcessesCmd = &cobra.Command{
 			CheckError(err)
 		}
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		processes, err := client.GetWaitingProcesses(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -392,7 +390,7 @@ var listRunningProcessesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -415,7 +413,7 @@ var listRunningProcessesCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		processes, err := client.GetRunningProcesses(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -452,7 +450,7 @@ var listSuccessfulProcessesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -475,7 +473,7 @@ var listSuccessfulProcessesCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		processes, err := client.GetSuccessfulProcesses(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -512,7 +510,7 @@ var listFailedProcessesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -535,7 +533,7 @@ var listFailedProcessesCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		processes, err := client.GetFailedProcesses(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -635,7 +633,7 @@ var getProcessCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -650,7 +648,7 @@ var getProcessCmd = &cobra.Command{
 			CheckError(err)
 		}
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		process, err := client.GetProcess(ProcessID, RuntimePrvKey)
 		if err != nil {
@@ -762,7 +760,7 @@ var deleteProcessCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -777,7 +775,7 @@ var deleteProcessCmd = &cobra.Command{
 			CheckError(err)
 		}
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		err = client.DeleteProcess(ProcessID, RuntimePrvKey)
 		CheckError(err)
@@ -793,7 +791,7 @@ var deleteAllProcessesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -814,7 +812,7 @@ var deleteAllProcessesCmd = &cobra.Command{
 		reply, _ := reader.ReadString('\n')
 		if reply == "YES\n" {
 			log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-			client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+			client := createColoniesClient()
 
 			err = client.DeleteAllProcesses(ColonyID, ColonyPrvKey)
 			CheckError(err)
@@ -833,7 +831,7 @@ var closeSuccessful = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -849,7 +847,7 @@ var closeSuccessful = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		process, err := client.GetProcess(ProcessID, RuntimePrvKey)
 		CheckError(err)
@@ -868,7 +866,7 @@ var closeFailed = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -884,7 +882,7 @@ var closeFailed = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		process, err := client.GetProcess(ProcessID, RuntimePrvKey)
 		CheckError(err)
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/kataras/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	roleCmd.AddCommand(addRoleCmd)
+	roleCmd.AddCommand(removeRoleCmd)
+	roleCmd.AddCommand(lsRolesCmd)
+	runtimeCmd.AddCommand(roleCmd)
+
+	roleCmd.PersistentFlags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
+	roleCmd.PersistentFlags().StringVarP(&RuntimeID, "runtimeid", "", "", "Colony Runtime Id")
+	roleCmd.MarkPersistentFlagRequired("runtimeid")
+
+	addRoleCmd.Flags().StringVarP(&Role, "role", "", "", "Role, one of "+strings.Join(core.Roles, ", "))
+	addRoleCmd.MarkFlagRequired("role")
+
+	removeRoleCmd.Flags().StringVarP(&Role, "role", "", "", "Role, one of "+strings.Join(core.Roles, ", "))
+	removeRoleCmd.MarkFlagRequired("role")
+}
+
+var roleCmd = &cobra.Command{
+	Use:   "role",
+	Short: "Manage the roles of a colony runtime",
+	Long:  "Manage the roles of a colony runtime, a runtime without any roles is allowed to do everything a colony member can do",
+}
+
+func createRoleClient() *client.ColoniesClient {
+	parseServerEnv()
+
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	if ColonyID == "" {
+		ColonyID = os.Getenv("COLONIES_COLONYID")
+	}
+	if ColonyID == "" {
+		CheckError(errors.New("Unknown Colony Id"))
+	}
+
+	if ColonyPrvKey == "" {
+		ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
+		CheckError(err)
+	}
+
+	log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+	return createColoniesClient()
+}
+
+var addRoleCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a role to a colony runtime",
+	Long:  "Add a role to a colony runtime",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createRoleClient()
+
+		if !core.IsValidRole(Role) {
+			CheckError(errors.New("Invalid role <" + Role + ">, must be one of " + strings.Join(core.Roles, ", ")))
+		}
+
+		err := client.AddRuntimeRole(RuntimeID, Role, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"runtimeID": RuntimeID, "role": Role}).Info("Runtime role added")
+	},
+}
+
+var removeRoleCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a role from a colony runtime",
+	Long:  "Remove a role from a colony runtime",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createRoleClient()
+
+		err := client.RemoveRuntimeRole(RuntimeID, Role, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"runtimeID": RuntimeID, "role": Role}).Info("Runtime role removed")
+	},
+}
+
+var lsRolesCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List the roles of a colony runtime",
+	Long:  "List the roles of a colony runtime",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createRoleClient()
+
+		roles, err := client.GetRuntimeRoles(RuntimeID, ColonyPrvKey)
+		CheckError(err)
+
+		if len(roles) == 0 {
+			log.WithFields(log.Fields{"runtimeID": RuntimeID}).Info("Runtime has no roles and is not restricted")
+			os.Exit(0)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Role"})
+		for _, role := range roles {
+			table.Append([]string{role})
+		}
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	},
+}
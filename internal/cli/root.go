@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/colonyos/colonies/pkg/build"
 	"github.com/colonyos/colonies/pkg/core"
@@ -68,6 +71,7 @@ var GeneratorID string
 var GeneratorName string
 var GeneratorTrigger int
 var GeneratorTimeout int
+var GeneratorWebhookSecret string
 var Func string
 var Arg string
 var Args []string
@@ -82,6 +86,11 @@ var EtcdPeerPort int
 var EtcdCluster []string
 var EtcdDataDir string
 var RelayPort int
+var EtcdJoin bool
+var NodeAPIPort int
+var ClusterCACert string
+var ClusterCert string
+var ClusterKey string
 var Latest bool
 var Timeout int
 var CronID string
@@ -89,11 +98,51 @@ var CronName string
 var CronExpr string
 var CronIntervall int
 var CronRandom bool
+var TemplateName string
+var TemplateVersion int
+var TemplateParams []string
+var GraphFormat string
+var Role string
+var MinRPCVersion int
+var MaxClockSkew int
+var WSAllowedOrigins []string
+var GRPCPort int
+var WSMaxSubscriptions int
+var WSMaxSubscriptionsPerRuntime int
+var WSIdleTimeout int
+var WSPingInterval int
+var Tracing string
+var NewColonyPrvKey string
+var SecretName string
+var SecretValue string
+var SecretsKey string
+var TargetID string
+var FromSeq int64
+var Encrypt bool
+var KeychainFile string
+var DelegationFile string
+var DelegateID string
+var IssuerID string
+var PayloadTypes []string
+var Funcs []string
+var RuntimeTypes []string
+var TTL string
+var Reason string
+var WebhookID string
+var WebhookURL string
+var WebhookEvents []string
+var WebhookStates []string
+var WebhookSecret string
+var WebhookMaxAttempts int
+var WebhookBackoff int
+var WebhookAllowPrivate bool
+var Pending bool
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&Verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVarP(&Insecure, "insecure", "", false, "Disable TLS and use HTTP")
 	rootCmd.PersistentFlags().BoolVarP(&SkipTLSVerify, "skip-tls-verify", "", false, "Skip TLS certificate verification")
+	rootCmd.PersistentFlags().StringVarP(&DelegationFile, "delegation", "", "", "Delegation chain file used to act on behalf of the issuer of the chain")
 }
 
 var rootCmd = &cobra.Command{
@@ -116,6 +165,21 @@ func CheckError(err error) {
 	}
 }
 
+// ReadSpecFile reads a JSON or YAML specification file and returns it as JSON, the format is detected by the file extension
+func ReadSpecFile(filename string) (string, error) {
+	specBytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		return core.ConvertYAMLToJSON(string(specBytes))
+	default:
+		return string(specBytes), nil
+	}
+}
+
 func Args2String(args []string) string {
 	if len(args) == 0 {
 		return ""
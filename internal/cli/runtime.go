@@ -3,13 +3,10 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strconv"
 
-	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -22,6 +19,8 @@ func init() {
 	runtimeCmd.AddCommand(approveRuntimeCmd)
 	runtimeCmd.AddCommand(rejectRuntimeCmd)
 	runtimeCmd.AddCommand(deleteRuntimeCmd)
+	runtimeCmd.AddCommand(revokeRuntimeCmd)
+	runtimeCmd.AddCommand(lsRevocationsCmd)
 	runtimeCmd.AddCommand(resolveRuntimeCmd)
 	rootCmd.AddCommand(runtimeCmd)
 
@@ -31,7 +30,7 @@ func init() {
 
 	registerRuntimeCmd.Flags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
 	registerRuntimeCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
-	registerRuntimeCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON specification of a Colony Runtime")
+	registerRuntimeCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony Runtime")
 	registerRuntimeCmd.MarkFlagRequired("spec")
 
 	lsRuntimesCmd.Flags().BoolVarP(&JSON, "json", "", false, "Print JSON instead of tables")
@@ -51,6 +50,14 @@ func init() {
 	deleteRuntimeCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
 	deleteRuntimeCmd.MarkFlagRequired("runtimeid")
 
+	revokeRuntimeCmd.Flags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
+	revokeRuntimeCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
+	revokeRuntimeCmd.Flags().StringVarP(&Reason, "reason", "", "", "Reason for revoking the runtime")
+	revokeRuntimeCmd.MarkFlagRequired("runtimeid")
+
+	lsRevocationsCmd.Flags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
+	lsRevocationsCmd.Flags().BoolVarP(&JSON, "json", "", false, "Print JSON instead of tables")
+
 	resolveRuntimeCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
 	resolveRuntimeCmd.Flags().StringVarP(&TargetRuntimeName, "targetname", "", "", "Target runtime Id")
 	resolveRuntimeCmd.MarkFlagRequired("runtimeid")
@@ -69,7 +76,7 @@ var registerRuntimeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		jsonSpecBytes, err := ioutil.ReadFile(SpecFile)
+		jsonSpec, err := ReadSpecFile(SpecFile)
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -79,10 +86,10 @@ var registerRuntimeCmd = &cobra.Command{
 			CheckError(errors.New("Unknown Colony Id"))
 		}
 
-		runtime, err := core.ConvertJSONToRuntime(string(jsonSpecBytes))
+		runtime, err := core.ConvertJSONToRuntime(jsonSpec)
 		CheckError(err)
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		crypto := crypto.CreateCrypto()
@@ -109,7 +116,7 @@ var registerRuntimeCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		addedRuntime, err := client.AddRuntime(runtime, ColonyPrvKey)
 		CheckError(err)
@@ -128,7 +135,7 @@ var lsRuntimesCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -153,7 +160,7 @@ var lsRuntimesCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		runtimesFromServer, err := client.GetRuntimes(ColonyID, RuntimePrvKey)
 		if err != nil {
@@ -242,7 +249,7 @@ var approveRuntimeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -258,7 +265,7 @@ var approveRuntimeCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		err = client.ApproveRuntime(RuntimeID, ColonyPrvKey)
 		CheckError(err)
@@ -274,7 +281,7 @@ var rejectRuntimeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -290,7 +297,7 @@ var rejectRuntimeCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		err = client.RejectRuntime(RuntimeID, ColonyPrvKey)
 		CheckError(err)
@@ -306,7 +313,7 @@ var deleteRuntimeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -321,7 +328,7 @@ var deleteRuntimeCmd = &cobra.Command{
 			CheckError(err)
 		}
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		err = client.DeleteRuntime(RuntimeID, ColonyPrvKey)
 		CheckError(err)
@@ -330,6 +337,90 @@ var deleteRuntimeCmd = &cobra.Command{
 	},
 }
 
+var revokeRuntimeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "Revoke a colony runtime",
+	Long:  "Revoke a colony runtime, its processes are moved back to the queue and the runtime Id can never be registered again",
+	Run: func(cmd *cobra.Command, args []string) {
+		parseServerEnv()
+
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		if ColonyID == "" {
+			ColonyID = os.Getenv("COLONIES_COLONYID")
+		}
+		if ColonyID == "" {
+			CheckError(errors.New("Unknown Colony Id"))
+		}
+
+		if ColonyPrvKey == "" {
+			ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
+			CheckError(err)
+		}
+		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+		client := createColoniesClient()
+
+		_, err = client.RevokeRuntime(RuntimeID, Reason, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"runtimeID": RuntimeID, "colonyID": ColonyID, "reason": Reason}).Info("Runtime revoked")
+	},
+}
+
+var lsRevocationsCmd = &cobra.Command{
+	Use:   "revocations",
+	Short: "List revoked colony runtimes",
+	Long:  "List revoked colony runtimes",
+	Run: func(cmd *cobra.Command, args []string) {
+		parseServerEnv()
+
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		if ColonyID == "" {
+			ColonyID = os.Getenv("COLONIES_COLONYID")
+		}
+		if ColonyID == "" {
+			CheckError(errors.New("Unknown Colony Id"))
+		}
+
+		if ColonyPrvKey == "" {
+			ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
+			CheckError(err)
+		}
+		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+		client := createColoniesClient()
+
+		revocations, err := client.GetRevocations(ColonyID, ColonyPrvKey)
+		CheckError(err)
+
+		if JSON {
+			jsonString, err := core.ConvertRevocationArrayToJSON(revocations)
+			CheckError(err)
+			fmt.Println(jsonString)
+			os.Exit(0)
+		}
+
+		if len(revocations) == 0 {
+			log.Info("No revoked runtimes found")
+			os.Exit(0)
+		}
+
+		var data [][]string
+		for _, revocation := range revocations {
+			data = append(data, []string{revocation.RuntimeID, revocation.Reason, revocation.RevokedTime.Format(TimeLayout)})
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"RuntimeID", "Reason", "Revoked"})
+		for _, v := range data {
+			table.Append(v)
+		}
+		table.Render()
+	},
+}
+
 var resolveRuntimeCmd = &cobra.Command{
 	Use:   "resolve",
 	Short: "Resolve runtime Id",
@@ -344,7 +435,7 @@ var resolveRuntimeCmd = &cobra.Command{
 			CheckError(errors.New("Unknown Colony Id, please set COLONYID env variable or specify ColonyID in JSON file"))
 		}
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -363,7 +454,7 @@ var resolveRuntimeCmd = &cobra.Command{
 			CheckError(errors.New("Target Runtime Name must be specified"))
 		}
 
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		runtimes, err := client.GetRuntimes(ColonyID, RuntimePrvKey)
 		CheckError(err)
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"errors"
+	"os"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/kataras/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	secretCmd.AddCommand(setSecretCmd)
+	secretCmd.AddCommand(lsSecretsCmd)
+	secretCmd.AddCommand(deleteSecretCmd)
+	rootCmd.AddCommand(secretCmd)
+
+	secretCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", DefaultServerHost, "Server host")
+	secretCmd.PersistentFlags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
+	secretCmd.PersistentFlags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
+	secretCmd.PersistentFlags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
+
+	setSecretCmd.Flags().StringVarP(&SecretName, "name", "", "", "Secret name, also used as environment variable name when a process is executed")
+	setSecretCmd.MarkFlagRequired("name")
+	setSecretCmd.Flags().StringVarP(&SecretValue, "value", "", "", "Secret value, read from the COLONIES_SECRETVALUE environment variable if not specified")
+
+	deleteSecretCmd.Flags().StringVarP(&SecretName, "name", "", "", "Secret name")
+	deleteSecretCmd.MarkFlagRequired("name")
+}
+
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage colony secrets",
+	Long:  "Manage colony secrets, secret values are encrypted by the server and only given to runtimes assigned to processes referencing the secret",
+}
+
+func createSecretClient() *client.ColoniesClient {
+	parseServerEnv()
+
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	if ColonyID == "" {
+		ColonyID = os.Getenv("COLONIES_COLONYID")
+	}
+	if ColonyID == "" {
+		CheckError(errors.New("Unknown Colony Id"))
+	}
+
+	if ColonyPrvKey == "" {
+		ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
+		CheckError(err)
+	}
+
+	log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+	return createColoniesClient()
+}
+
+var setSecretCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Add or update a secret",
+	Long:  "Add or update a secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createSecretClient()
+
+		err := core.ValidateSecretName(SecretName)
+		CheckError(err)
+
+		// Reading the value from the environment avoids leaking it into the shell history
+		if SecretValue == "" {
+			SecretValue = os.Getenv("COLONIES_SECRETVALUE")
+		}
+		if SecretValue == "" {
+			CheckError(errors.New("Secret value not specified"))
+		}
+
+		err = client.SetSecret(ColonyID, SecretName, SecretValue, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"ColonyID": ColonyID, "Name": SecretName}).Info("Secret set")
+	},
+}
+
+var lsSecretsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List secret names",
+	Long:  "List secret names, secret values are never returned",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createSecretClient()
+
+		secrets, err := client.GetSecrets(ColonyID, ColonyPrvKey)
+		CheckError(err)
+
+		if len(secrets) == 0 {
+			log.Info("No secrets found")
+			os.Exit(0)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Added"})
+		for _, secret := range secrets {
+			table.Append([]string{secret.Name, secret.AddedTime.Format(TimeLayout)})
+		}
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	},
+}
+
+var deleteSecretCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a secret",
+	Long:  "Delete a secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createSecretClient()
+
+		err := client.DeleteSecret(ColonyID, SecretName, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"ColonyID": ColonyID, "Name": SecretName}).Info("Secret deleted")
+	},
+}
@@ -10,11 +10,11 @@ import (
 	"time"
 
 	"github.com/colonyos/colonies/pkg/build"
-	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/cluster"
 	"github.com/colonyos/colonies/pkg/database/postgresql"
-	"github.com/colonyos/colonies/pkg/security"
+	"github.com/colonyos/colonies/pkg/rpc"
 	"github.com/colonyos/colonies/pkg/server"
+	"github.com/colonyos/colonies/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -41,7 +41,24 @@ func init() {
 	serverCmd.PersistentFlags().IntVarP(&EtcdPeerPort, "etcdpeerport", "", 2380, "Etcd peer port")
 	serverCmd.PersistentFlags().IntVarP(&RelayPort, "relayport", "", 2381, "Colonies server relay port")
 	serverCmd.PersistentFlags().StringSliceVarP(&EtcdCluster, "initial-cluster", "", make([]string, 0), "Cluster config, e.g. --etcdcluster server1=localhost:peerport:relayport:apiport,server2=localhost:peerport:relayport:apiport")
+	serverCmd.PersistentFlags().BoolVarP(&EtcdJoin, "join", "", false, "Join a running cluster, the node must first be added with colonies cluster add-node")
 	serverCmd.PersistentFlags().StringVarP(&EtcdDataDir, "etcddatadir", "", "", "Etcd data dir")
+	serverCmd.PersistentFlags().StringVarP(&ClusterCACert, "clustercacert", "", "", "CA certificate used to verify other cluster nodes, enables mutual TLS for relay and etcd traffic")
+	serverCmd.PersistentFlags().StringVarP(&ClusterCert, "clustercert", "", "", "Certificate presented to other cluster nodes, must be signed by the cluster CA")
+	serverCmd.PersistentFlags().StringVarP(&ClusterKey, "clusterkey", "", "", "Key of the certificate presented to other cluster nodes")
+	serverCmd.PersistentFlags().IntVarP(&MinRPCVersion, "minrpcversion", "", rpc.LegacyProtocolVersion, "Minimum accepted RPC protocol version, set to "+strconv.Itoa(rpc.ProtocolVersion)+" to reject clients without replay protection")
+	serverCmd.PersistentFlags().StringVarP(&SecretsKey, "secretskey", "", "", "Hex encoded AES-256 key used to encrypt colony secrets, must be the same on all servers in a cluster")
+	serverCmd.PersistentFlags().IntVarP(&MaxClockSkew, "maxclockskew", "", server.MAX_CLOCK_SKEW, "Maximum allowed clock skew in seconds for signed RPC messages")
+	serverCmd.PersistentFlags().IntVarP(&GRPCPort, "grpcport", "", 0, "Port of the gRPC endpoint, the gRPC endpoint is disabled if not set")
+	serverCmd.PersistentFlags().StringSliceVarP(&WSAllowedOrigins, "wsallowedorigins", "", make([]string, 0), "Origins allowed to open WebSocket connections, e.g. --wsallowedorigins https://app.example.com, * allows any origin, default is same-origin only")
+	serverCmd.PersistentFlags().IntVarP(&WSMaxSubscriptions, "wsmaxsubscriptions", "", server.MAX_WS_SUBSCRIPTIONS, "Maximum number of WebSocket subscriptions on this server")
+	serverCmd.PersistentFlags().IntVarP(&WSMaxSubscriptionsPerRuntime, "wsmaxsubscriptionsperruntime", "", server.MAX_WS_SUBSCRIPTIONS_PER_RUNTIME, "Maximum number of WebSocket subscriptions per runtime")
+	serverCmd.PersistentFlags().IntVarP(&WSIdleTimeout, "wsidletimeout", "", server.WS_IDLE_TIMEOUT, "Seconds a WebSocket connection may be silent before it is closed")
+	serverCmd.PersistentFlags().IntVarP(&WSPingInterval, "wspinginterval", "", server.WS_PING_INTERVAL, "Seconds between WebSocket pings, must be shorter than the idle timeout")
+	serverCmd.PersistentFlags().IntVarP(&WebhookMaxAttempts, "webhookmaxattempts", "", server.WEBHOOK_MAX_ATTEMPTS, "Number of times a webhook delivery is attempted before it is marked as dead")
+	serverCmd.PersistentFlags().IntVarP(&WebhookBackoff, "webhookbackoff", "", server.WEBHOOK_BACKOFF, "Seconds before the first webhook delivery retry, doubled for every following retry")
+	serverCmd.PersistentFlags().BoolVarP(&WebhookAllowPrivate, "webhookallowprivate", "", false, "Allow webhooks to post to loopback, link-local and private addresses")
+	serverCmd.PersistentFlags().StringVarP(&Tracing, "tracing", "", "", "OpenTelemetry trace exporter, stdout, file:<path> or otlp:<host:port>, tracing is disabled if not set")
 
 	serverStatusCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", "localhost", "Server host")
 	serverStatusCmd.PersistentFlags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
@@ -92,6 +109,67 @@ func parseServerEnv() {
 		TLSCert = os.Getenv("COLONIES_TLSCERT")
 	}
 
+	MinRPCVersionEnvStr := os.Getenv("COLONIES_MINRPCVERSION")
+	if MinRPCVersionEnvStr != "" {
+		MinRPCVersion, err = strconv.Atoi(MinRPCVersionEnvStr)
+		CheckError(err)
+	}
+
+	if SecretsKey == "" {
+		SecretsKey = os.Getenv("COLONIES_SECRETSKEY")
+	}
+
+	if ClusterCACert == "" {
+		ClusterCACert = os.Getenv("COLONIES_CLUSTER_CACERT")
+	}
+
+	if ClusterCert == "" {
+		ClusterCert = os.Getenv("COLONIES_CLUSTER_CERT")
+	}
+
+	if ClusterKey == "" {
+		ClusterKey = os.Getenv("COLONIES_CLUSTER_KEY")
+	}
+
+	GRPCPortEnvStr := os.Getenv("COLONIES_GRPCPORT")
+	if GRPCPort == 0 && GRPCPortEnvStr != "" {
+		GRPCPort, err = strconv.Atoi(GRPCPortEnvStr)
+		CheckError(err)
+	}
+
+	WSAllowedOriginsEnv := os.Getenv("COLONIES_WS_ALLOWED_ORIGINS")
+	if len(WSAllowedOrigins) == 0 && WSAllowedOriginsEnv != "" {
+		WSAllowedOrigins = strings.Split(WSAllowedOriginsEnv, ",")
+	}
+
+	WSMaxSubscriptionsEnvStr := os.Getenv("COLONIES_WS_MAX_SUBSCRIPTIONS")
+	if WSMaxSubscriptionsEnvStr != "" {
+		WSMaxSubscriptions, err = strconv.Atoi(WSMaxSubscriptionsEnvStr)
+		CheckError(err)
+	}
+
+	WSMaxSubscriptionsPerRuntimeEnvStr := os.Getenv("COLONIES_WS_MAX_SUBSCRIPTIONS_PER_RUNTIME")
+	if WSMaxSubscriptionsPerRuntimeEnvStr != "" {
+		WSMaxSubscriptionsPerRuntime, err = strconv.Atoi(WSMaxSubscriptionsPerRuntimeEnvStr)
+		CheckError(err)
+	}
+
+	WSIdleTimeoutEnvStr := os.Getenv("COLONIES_WS_IDLE_TIMEOUT")
+	if WSIdleTimeoutEnvStr != "" {
+		WSIdleTimeout, err = strconv.Atoi(WSIdleTimeoutEnvStr)
+		CheckError(err)
+	}
+
+	WSPingIntervalEnvStr := os.Getenv("COLONIES_WS_PING_INTERVAL")
+	if WSPingIntervalEnvStr != "" {
+		WSPingInterval, err = strconv.Atoi(WSPingIntervalEnvStr)
+		CheckError(err)
+	}
+
+	if Tracing == "" {
+		Tracing = os.Getenv("COLONIES_TRACING")
+	}
+
 	VerboseEnv := os.Getenv("COLONIES_VERBOSE")
 	if VerboseEnv == "true" {
 		Verbose = true
@@ -109,7 +187,7 @@ var serverStatusCmd = &cobra.Command{
 		parseServerEnv()
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		serverBuildVersion, serverBuildTime, err := client.Version()
 		CheckError(err)
@@ -167,7 +245,7 @@ var serverStartCmd = &cobra.Command{
 		}
 
 		node := cluster.Node{Name: EtcdName, Host: EtcdHost, APIPort: ServerPort, EtcdClientPort: EtcdClientPort, EtcdPeerPort: EtcdPeerPort, RelayPort: RelayPort}
-		clusterConfig := cluster.Config{}
+		clusterConfig := cluster.Config{Join: EtcdJoin}
 
 		if len(EtcdCluster) > 0 {
 			// Parse EtcdCluster flag
@@ -199,18 +277,29 @@ var serverStartCmd = &cobra.Command{
 			clusterConfig.AddNode(node)
 		}
 
+		if ClusterCACert != "" || ClusterCert != "" || ClusterKey != "" {
+			clusterConfig.TLS = cluster.CreateTLSConfig(ClusterCACert, ClusterCert, ClusterKey)
+			CheckError(clusterConfig.TLS.Validate())
+		}
+
 		if EtcdDataDir == "" {
 			EtcdDataDir = "/tmp/colonies/prod/etcd"
 			log.Warning("EtcdDataDir not specified, setting it to " + EtcdDataDir)
 		}
 
 		log.WithFields(log.Fields{
-			"BuildVersion": build.BuildVersion,
-			"BuildTime":    build.BuildTime,
-			"ServerPort":   ServerPort,
-			"Verbose":      Verbose,
-			"UseTLS":       UseTLS,
-			"ServerID":     ServerID,
+			"BuildVersion":  build.BuildVersion,
+			"BuildTime":     build.BuildTime,
+			"ServerPort":    ServerPort,
+			"Verbose":       Verbose,
+			"UseTLS":        UseTLS,
+			"ServerID":      ServerID,
+			"MinRPCVersion": MinRPCVersion,
+			"MaxClockSkew":  MaxClockSkew,
+			"ClusterTLS":    clusterConfig.TLS != nil,
+			"WSOrigins":     WSAllowedOrigins,
+			"GRPCPort":      GRPCPort,
+			"Tracing":       Tracing,
 		}).Info("Starting a Colonies Server")
 
 		if Verbose {
@@ -220,7 +309,29 @@ var serverStartCmd = &cobra.Command{
 			gin.DefaultWriter = ioutil.Discard
 		}
 
+		shutdownTracing, err := tracing.Init(Tracing, "colonies-server")
+		CheckError(err)
+		defer shutdownTracing()
+
 		server := server.CreateColoniesServer(db, ServerPort, ServerID, UseTLS, TLSKey, TLSCert, node, clusterConfig, EtcdDataDir)
+		server.SetMinRPCVersion(MinRPCVersion)
+		server.SetMaxClockSkew(time.Duration(MaxClockSkew) * time.Second)
+		server.SetWSAllowedOrigins(WSAllowedOrigins)
+		server.SetMaxWSSubscriptions(WSMaxSubscriptions, WSMaxSubscriptionsPerRuntime)
+		err = server.SetWSKeepalive(time.Duration(WSPingInterval)*time.Second, time.Duration(WSIdleTimeout)*time.Second)
+		CheckError(err)
+		if SecretsKey == "" {
+			log.Warning("No secrets key specified, colony secrets are disabled")
+		}
+		err = server.SetSecretsKey(SecretsKey)
+		CheckError(err)
+		err = server.SetWebhookRetries(WebhookMaxAttempts, time.Duration(WebhookBackoff)*time.Second)
+		CheckError(err)
+		server.SetWebhookAllowPrivate(WebhookAllowPrivate)
+		if GRPCPort > 0 {
+			err = server.EnableGRPC(GRPCPort)
+			CheckError(err)
+		}
 		for {
 			err := server.ServeForever()
 			if err != nil {
@@ -238,7 +349,7 @@ var serverStatisticsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ServerID == "" {
@@ -254,7 +365,7 @@ var serverStatisticsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		stat, err := client.Statistics(ServerPrvKey)
 		CheckError(err)
@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/server"
+	"github.com/kataras/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	templateCmd.AddCommand(addTemplateCmd)
+	templateCmd.AddCommand(getTemplateCmd)
+	templateCmd.AddCommand(getTemplatesCmd)
+	templateCmd.AddCommand(getTemplateVersionsCmd)
+	templateCmd.AddCommand(delTemplateCmd)
+	templateCmd.AddCommand(submitTemplateCmd)
+	rootCmd.AddCommand(templateCmd)
+
+	templateCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", "localhost", "Server host")
+	templateCmd.PersistentFlags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
+	templateCmd.PersistentFlags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
+	templateCmd.PersistentFlags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
+	templateCmd.PersistentFlags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
+
+	addTemplateCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony workflow, values may refer to parameters using {{name}}")
+	addTemplateCmd.MarkFlagRequired("spec")
+	addTemplateCmd.Flags().StringVarP(&TemplateName, "name", "", "", "Workflow template name")
+	addTemplateCmd.MarkFlagRequired("name")
+	addTemplateCmd.Flags().StringSliceVarP(&TemplateParams, "param", "", make([]string, 0), "Template parameter, name or name=default")
+
+	getTemplateCmd.Flags().StringVarP(&TemplateName, "name", "", "", "Workflow template name")
+	getTemplateCmd.MarkFlagRequired("name")
+	getTemplateCmd.Flags().IntVarP(&TemplateVersion, "version", "", 0, "Workflow template version, 0 is the latest version")
+
+	getTemplatesCmd.Flags().IntVarP(&Count, "count", "", server.MAX_COUNT, "Number of workflow templates to list")
+
+	getTemplateVersionsCmd.Flags().StringVarP(&TemplateName, "name", "", "", "Workflow template name")
+	getTemplateVersionsCmd.MarkFlagRequired("name")
+
+	delTemplateCmd.Flags().StringVarP(&TemplateName, "name", "", "", "Workflow template name")
+	delTemplateCmd.MarkFlagRequired("name")
+	delTemplateCmd.Flags().IntVarP(&TemplateVersion, "version", "", 0, "Workflow template version, 0 deletes all versions")
+
+	submitTemplateCmd.Flags().StringVarP(&TemplateName, "name", "", "", "Workflow template name")
+	submitTemplateCmd.MarkFlagRequired("name")
+	submitTemplateCmd.Flags().IntVarP(&TemplateVersion, "version", "", 0, "Workflow template version, 0 is the latest version")
+	submitTemplateCmd.Flags().StringSliceVarP(&TemplateParams, "param", "", make([]string, 0), "Template parameter value, name=value")
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage workflow templates",
+	Long:  "Manage workflow templates",
+}
+
+func parseTemplateParams() (map[string]string, error) {
+	parameters := make(map[string]string)
+	for _, param := range TemplateParams {
+		s := strings.SplitN(param, "=", 2)
+		if len(s) != 2 {
+			return nil, errors.New("Invalid template parameter <" + param + ">, must be name=value")
+		}
+		parameters[s[0]] = s[1]
+	}
+
+	return parameters, nil
+}
+
+func parseTemplateRef() (*core.WorkflowTemplateRef, error) {
+	parameters, err := parseTemplateParams()
+	if err != nil {
+		return nil, err
+	}
+
+	return core.CreateWorkflowTemplateRef(TemplateName, TemplateVersion, parameters), nil
+}
+
+func createTemplateClient() *client.ColoniesClient {
+	parseServerEnv()
+
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	if ColonyID == "" {
+		ColonyID = os.Getenv("COLONIES_COLONYID")
+	}
+	if ColonyID == "" {
+		CheckError(errors.New("Unknown Colony Id"))
+	}
+
+	if RuntimeID == "" {
+		RuntimeID = os.Getenv("COLONIES_RUNTIMEID")
+	}
+	if RuntimeID == "" {
+		CheckError(errors.New("Unknown Runtime Id"))
+	}
+
+	if RuntimePrvKey == "" {
+		RuntimePrvKey, err = keychain.GetPrvKey(RuntimeID)
+		CheckError(err)
+	}
+
+	log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+	return createColoniesClient()
+}
+
+func printTemplates(workflowTemplates []*core.WorkflowTemplate) {
+	var data [][]string
+	for _, workflowTemplate := range workflowTemplates {
+		data = append(data, []string{workflowTemplate.Name, strconv.Itoa(workflowTemplate.Version), workflowTemplate.AddedTime.Format(TimeLayout)})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Version", "Added"})
+	for _, v := range data {
+		table.Append(v)
+	}
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.Render()
+}
+
+var addTemplateCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a workflow template to a Colony, or a new version of an existing template",
+	Long:  "Add a workflow template to a Colony, or a new version of an existing template",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createTemplateClient()
+
+		jsonSpec, err := ReadSpecFile(SpecFile)
+		CheckError(err)
+
+		jsonStr := "{\"processspecs\":" + jsonSpec + "}"
+		workflowSpec, err := core.ConvertJSONToWorkflowSpec(jsonStr)
+		CheckError(err)
+		workflowSpec.ColonyID = ColonyID
+
+		workflowSpecJSON, err := workflowSpec.ToJSON()
+		CheckError(err)
+
+		var parameters []core.TemplateParameter
+		for _, param := range TemplateParams {
+			s := strings.SplitN(param, "=", 2)
+			parameter := core.TemplateParameter{Name: s[0]}
+			if len(s) == 2 {
+				parameter.Default = s[1]
+			}
+			parameters = append(parameters, parameter)
+		}
+
+		workflowTemplate := core.CreateWorkflowTemplate(ColonyID, TemplateName, parameters, workflowSpecJSON)
+		addedWorkflowTemplate, err := client.AddWorkflowTemplate(workflowTemplate, RuntimePrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"Name": addedWorkflowTemplate.Name, "Version": addedWorkflowTemplate.Version}).Info("Workflow template added")
+	},
+}
+
+var getTemplateCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get info about a workflow template",
+	Long:  "Get info about a workflow template",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createTemplateClient()
+
+		workflowTemplate, err := client.GetWorkflowTemplate(ColonyID, TemplateName, TemplateVersion, RuntimePrvKey)
+		CheckError(err)
+
+		fmt.Println("Workflow template:")
+		templateData := [][]string{
+			[]string{"Id", workflowTemplate.ID},
+			[]string{"ColonyID", workflowTemplate.ColonyID},
+			[]string{"Name", workflowTemplate.Name},
+			[]string{"Version", strconv.Itoa(workflowTemplate.Version)},
+			[]string{"Added", workflowTemplate.AddedTime.Format(TimeLayout)},
+		}
+		templateTable := tablewriter.NewWriter(os.Stdout)
+		for _, v := range templateData {
+			templateTable.Append(v)
+		}
+		templateTable.SetAlignment(tablewriter.ALIGN_LEFT)
+		templateTable.SetAutoWrapText(false)
+		templateTable.Render()
+
+		if len(workflowTemplate.Parameters) > 0 {
+			fmt.Println()
+			fmt.Println("Parameters:")
+			paramTable := tablewriter.NewWriter(os.Stdout)
+			paramTable.SetHeader([]string{"Name", "Default"})
+			for _, parameter := range workflowTemplate.Parameters {
+				paramTable.Append([]string{parameter.Name, parameter.Default})
+			}
+			paramTable.SetAlignment(tablewriter.ALIGN_LEFT)
+			paramTable.Render()
+		}
+
+		fmt.Println()
+		fmt.Println("WorkflowSpec:")
+		workflowSpec, err := core.ConvertJSONToWorkflowSpec(workflowTemplate.WorkflowSpec)
+		CheckError(err)
+		for i, procesSpec := range workflowSpec.ProcessSpecs {
+			fmt.Println()
+			fmt.Println("ProcessSpec " + strconv.Itoa(i) + ":")
+			printProcessSpec(&procesSpec)
+		}
+	},
+}
+
+var getTemplatesCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List the latest version of all workflow templates in a colony",
+	Long:  "List the latest version of all workflow templates in a colony",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createTemplateClient()
+
+		workflowTemplates, err := client.GetWorkflowTemplates(ColonyID, Count, RuntimePrvKey)
+		CheckError(err)
+
+		if len(workflowTemplates) == 0 {
+			log.WithFields(log.Fields{"ColonyId": ColonyID}).Info("No workflow templates found")
+			os.Exit(0)
+		}
+
+		printTemplates(workflowTemplates)
+	},
+}
+
+var getTemplateVersionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List all versions of a workflow template",
+	Long:  "List all versions of a workflow template",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createTemplateClient()
+
+		workflowTemplates, err := client.GetWorkflowTemplateVersions(ColonyID, TemplateName, RuntimePrvKey)
+		CheckError(err)
+
+		if len(workflowTemplates) == 0 {
+			log.WithFields(log.Fields{"Name": TemplateName}).Info("No workflow templates found")
+			os.Exit(0)
+		}
+
+		printTemplates(workflowTemplates)
+	},
+}
+
+var delTemplateCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a workflow template",
+	Long:  "Delete a workflow template",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createTemplateClient()
+
+		err := client.DeleteWorkflowTemplate(ColonyID, TemplateName, TemplateVersion, RuntimePrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"Name": TemplateName, "Version": TemplateVersion}).Info("Deleting workflow template")
+	},
+}
+
+var submitTemplateCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit a workflow created from a workflow template",
+	Long:  "Submit a workflow created from a workflow template",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createTemplateClient()
+
+		workflowTemplateRef, err := parseTemplateRef()
+		CheckError(err)
+
+		graph, err := client.SubmitWorkflowTemplate(ColonyID, workflowTemplateRef, RuntimePrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"WorkflowID": graph.ID}).Info("Workflow submitted")
+	},
+}
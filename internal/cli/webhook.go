@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/server"
+	"github.com/kataras/tablewriter"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	webhookCmd.AddCommand(addWebhookCmd)
+	webhookCmd.AddCommand(lsWebhooksCmd)
+	webhookCmd.AddCommand(deleteWebhookCmd)
+	webhookCmd.AddCommand(lsWebhookDeliveriesCmd)
+	rootCmd.AddCommand(webhookCmd)
+
+	webhookCmd.PersistentFlags().StringVarP(&ServerHost, "host", "", DefaultServerHost, "Server host")
+	webhookCmd.PersistentFlags().IntVarP(&ServerPort, "port", "", -1, "Server HTTP port")
+	webhookCmd.PersistentFlags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
+	webhookCmd.PersistentFlags().StringVarP(&ColonyPrvKey, "colonyprvkey", "", "", "Colony private key")
+
+	addWebhookCmd.Flags().StringVarP(&WebhookURL, "url", "", "", "URL the events are posted to")
+	addWebhookCmd.MarkFlagRequired("url")
+	addWebhookCmd.Flags().StringSliceVarP(&WebhookEvents, "events", "", make([]string, 0), "Events to post, process and/or processgraph, default is all events")
+	addWebhookCmd.Flags().StringSliceVarP(&WebhookStates, "states", "", make([]string, 0), "States to post, waiting, running, successful and/or failed, default is all states")
+	addWebhookCmd.Flags().StringSliceVarP(&Funcs, "funcs", "", make([]string, 0), "Only post process events for these funcs")
+	addWebhookCmd.Flags().StringSliceVarP(&RuntimeTypes, "runtimetypes", "", make([]string, 0), "Only post process events for these runtime types")
+	addWebhookCmd.Flags().StringVarP(&WebhookSecret, "secret", "", "", "Secret used to sign the events, generated by the server if not specified")
+
+	deleteWebhookCmd.Flags().StringVarP(&WebhookID, "webhookid", "", "", "Webhook Id")
+	deleteWebhookCmd.MarkFlagRequired("webhookid")
+
+	lsWebhookDeliveriesCmd.Flags().StringVarP(&WebhookID, "webhookid", "", "", "Webhook Id")
+	lsWebhookDeliveriesCmd.MarkFlagRequired("webhookid")
+	lsWebhookDeliveriesCmd.Flags().BoolVarP(&Pending, "pending", "", false, "List pending deliveries instead of dead deliveries")
+	lsWebhookDeliveriesCmd.Flags().IntVarP(&Count, "count", "", server.MAX_COUNT, "Number of deliveries to list")
+}
+
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage webhooks",
+	Long:  "Manage webhooks, process and process graph state changes are posted as signed JSON to the webhook URL",
+}
+
+func createWebhookClient() *client.ColoniesClient {
+	parseServerEnv()
+
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	if ColonyID == "" {
+		ColonyID = os.Getenv("COLONIES_COLONYID")
+	}
+	if ColonyID == "" {
+		CheckError(errors.New("Unknown Colony Id"))
+	}
+
+	if ColonyPrvKey == "" {
+		ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
+		CheckError(err)
+	}
+
+	log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+	return createColoniesClient()
+}
+
+func parseWebhookState(stateStr string) (int, error) {
+	switch strings.ToLower(stateStr) {
+	case "waiting":
+		return core.WAITING, nil
+	case "running":
+		return core.RUNNING, nil
+	case "successful", "success":
+		return core.SUCCESS, nil
+	case "failed":
+		return core.FAILED, nil
+	default:
+		return -1, errors.New("Invalid state <" + stateStr + ">, must be waiting, running, successful or failed")
+	}
+}
+
+var addWebhookCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a webhook",
+	Long:  "Add a webhook",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createWebhookClient()
+
+		filter := core.WebhookFilter{Events: WebhookEvents, Funcs: Funcs, RuntimeTypes: RuntimeTypes}
+		for _, stateStr := range WebhookStates {
+			state, err := parseWebhookState(stateStr)
+			CheckError(err)
+			filter.States = append(filter.States, state)
+		}
+
+		webhook := core.CreateWebhook(ColonyID, WebhookURL, filter, WebhookSecret)
+		err := webhook.Validate()
+		CheckError(err)
+
+		addedWebhook, err := client.AddWebhook(webhook, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"WebhookID": addedWebhook.ID, "URL": addedWebhook.URL}).Info("Webhook added")
+
+		// The secret can not be retrieved later
+		if WebhookSecret == "" {
+			log.WithFields(log.Fields{"Secret": addedWebhook.Secret}).Info("Generated webhook secret, store it now as it is not shown again")
+		}
+	},
+}
+
+var lsWebhooksCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List webhooks",
+	Long:  "List webhooks, secrets are never returned",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createWebhookClient()
+
+		webhooks, err := client.GetWebhooks(ColonyID, ColonyPrvKey)
+		CheckError(err)
+
+		if len(webhooks) == 0 {
+			log.Info("No webhooks found")
+			os.Exit(0)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"WebhookID", "URL", "Events", "States", "Funcs", "Runtime types"})
+		for _, webhook := range webhooks {
+			var states []string
+			for _, state := range webhook.Filter.States {
+				states = append(states, State2String(state))
+			}
+			table.Append([]string{webhook.ID, webhook.URL, strings.Join(webhook.Filter.Events, ","), strings.Join(states, ","), strings.Join(webhook.Filter.Funcs, ","), strings.Join(webhook.Filter.RuntimeTypes, ",")})
+		}
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	},
+}
+
+var deleteWebhookCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a webhook",
+	Long:  "Delete a webhook and all its pending and dead deliveries",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createWebhookClient()
+
+		err := client.DeleteWebhook(WebhookID, ColonyPrvKey)
+		CheckError(err)
+
+		log.WithFields(log.Fields{"WebhookID": WebhookID}).Info("Webhook deleted")
+	},
+}
+
+var lsWebhookDeliveriesCmd = &cobra.Command{
+	Use:   "deliveries",
+	Short: "List webhook deliveries",
+	Long:  "List deliveries that failed all attempts, or with --pending, deliveries waiting to be posted",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createWebhookClient()
+
+		state := core.WEBHOOK_DELIVERY_DEAD
+		if Pending {
+			state = core.WEBHOOK_DELIVERY_PENDING
+		}
+
+		deliveries, err := client.GetWebhookDeliveries(WebhookID, state, Count, ColonyPrvKey)
+		CheckError(err)
+
+		if len(deliveries) == 0 {
+			log.Info("No deliveries found")
+			os.Exit(0)
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"DeliveryID", "Event", "Created", "Attempts", "Last error"})
+		for _, delivery := range deliveries {
+			table.Append([]string{delivery.ID, delivery.Event, delivery.CreatedTime.Format(TimeLayout), strconv.Itoa(delivery.Attempts), delivery.LastError})
+		}
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.Render()
+	},
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/colonyos/colonies/pkg/build"
 	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -81,7 +80,7 @@ var workerStartCmd = &cobra.Command{
 			ColonyPrvKey = os.Getenv("COLONIES_COLONYPRVKEY")
 		}
 		if ColonyPrvKey == "" {
-			keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+			keychain, err := createKeychain()
 			CheckError(err)
 
 			ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
@@ -101,12 +100,6 @@ var workerStartCmd = &cobra.Command{
 		runtimeID, err := crypto.GenerateID(runtimePrvKey)
 		CheckError(err)
 
-		err = os.WriteFile("/tmp/runtimeid", []byte(runtimeID), 0644)
-		CheckError(err)
-
-		err = os.WriteFile("/tmp/runtimeprvkey", []byte(runtimePrvKey), 0644)
-		CheckError(err)
-
 		if RuntimeName == "" {
 			RuntimeName = os.Getenv("COLONIES_RUNTIMENAME")
 		}
@@ -123,13 +116,7 @@ var workerStartCmd = &cobra.Command{
 			CheckError(errors.New("Runtime type not specified"))
 		}
 
-		log.Info("Saving runtimeID to /tmp/runtimeid")
-		err = os.WriteFile("/tmp/runtimeid", []byte(runtimeID), 0644)
-		CheckError(err)
-
-		log.Info("Saving runtimePrvKey to /tmp/runtimeprvkey")
-		err = os.WriteFile("/tmp/runtimeprvkey", []byte(runtimePrvKey), 0644)
-		CheckError(err)
+		saveWorkerIdentity(runtimeID, runtimePrvKey)
 
 		log.WithFields(log.Fields{"RuntimeID": runtimeID, "RuntimeName": RuntimeName, "RuntimeType": RuntimeType, "ColonyID": ColonyID, "CPU": CPU, "Cores": Cores, "Mem": Mem, "GPU": GPU, "GPUs": GPUs}).Info("Register a new Runtime")
 		runtime := core.CreateRuntime(runtimeID, RuntimeType, RuntimeName, ColonyID, CPU, Cores, Mem, GPU, GPUs, time.Now(), time.Now())
@@ -164,7 +151,7 @@ var workerStartCmd = &cobra.Command{
 					time.Sleep(2 * time.Second)
 					continue
 				default:
-					if strings.HasPrefix(err.Error(), "No processes can be selected for runtime with Id") {
+					if isNoWorkAvailable(err) {
 						continue
 					} else {
 						CheckError(err)
@@ -184,6 +171,18 @@ var workerStartCmd = &cobra.Command{
 				cmd.Env = append(cmd.Env, attribute.Key+"="+attribute.Value)
 			}
 
+			if len(assignedProcess.ProcessSpec.Secrets) > 0 {
+				secrets, err := client.GetProcessSecrets(assignedProcess.ID, runtimePrvKey)
+				if err != nil {
+					log.WithFields(log.Fields{"ProcessID": assignedProcess.ID, "Error": err}).Error("Failed to get process secrets")
+					client.CloseFailed(assignedProcess.ID, "Failed to get process secrets: "+err.Error(), runtimePrvKey)
+					continue
+				}
+				for name, value := range secrets {
+					cmd.Env = append(cmd.Env, name+"="+value)
+				}
+			}
+
 			cmd.Env = append(cmd.Env, "COLONIES_COLONYID="+ColonyID)
 			cmd.Env = append(cmd.Env, "COLONIES_PROCESSID="+assignedProcess.ID)
 			cmd.Env = append(cmd.Env, "COLONIES_SERVERHOST="+ServerHost)
@@ -259,7 +258,7 @@ var workerRegisterCmd = &cobra.Command{
 			ColonyPrvKey = os.Getenv("COLONIES_COLONYPRVKEY")
 		}
 		if ColonyPrvKey == "" {
-			keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+			keychain, err := createKeychain()
 			CheckError(err)
 
 			ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
@@ -273,13 +272,7 @@ var workerRegisterCmd = &cobra.Command{
 		runtimeID, err := crypto.GenerateID(runtimePrvKey)
 		CheckError(err)
 
-		log.Info("Saving runtimeID to /tmp/runtimeid")
-		err = os.WriteFile("/tmp/runtimeid", []byte(runtimeID), 0644)
-		CheckError(err)
-
-		err = os.WriteFile("/tmp/runtimeprvkey", []byte(runtimePrvKey), 0644)
-		CheckError(err)
-		log.Info("Saving runtimePrvKey to /tmp/runtimeprvkey")
+		saveWorkerIdentity(runtimeID, runtimePrvKey)
 
 		if RuntimeName == "" {
 			RuntimeName = os.Getenv("COLONIES_RUNTIMENAME")
@@ -334,7 +327,7 @@ var workerUnregisterCmd = &cobra.Command{
 			ColonyPrvKey = os.Getenv("COLONIES_COLONYPRVKEY")
 		}
 		if ColonyPrvKey == "" {
-			keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+			keychain, err := createKeychain()
 			CheckError(err)
 
 			ColonyPrvKey, err = keychain.GetPrvKey(ColonyID)
@@ -349,17 +342,43 @@ var workerUnregisterCmd = &cobra.Command{
 	},
 }
 
+// saveWorkerIdentity stores the generated runtime key in the keychain, labelled so that the worker can be
+// unregistered later
+func saveWorkerIdentity(runtimeID string, runtimePrvKey string) {
+	keychain, err := createKeychain()
+	CheckError(err)
+
+	log.WithFields(log.Fields{"RuntimeID": runtimeID}).Info("Saving runtime private key in keychain")
+	err = keychain.AddPrvKey(runtimeID, runtimePrvKey)
+	CheckError(err)
+
+	err = keychain.SetLabel(WORKER_LABEL, runtimeID)
+	CheckError(err)
+}
+
 func unregisterRuntime(client *client.ColoniesClient) {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	runtimeIDBytes, err := os.ReadFile("/tmp/runtimeid")
+	keychain, err := createKeychain()
 	CheckError(err)
 
-	runtimeID := string(runtimeIDBytes)
+	runtimeID, err := keychain.GetLabel(WORKER_LABEL)
+	CheckError(err)
 
 	err = client.DeleteRuntime(runtimeID, ColonyPrvKey)
 	CheckError(err)
 
+	err = keychain.RemovePrvKey(runtimeID)
+	CheckError(err)
+
+	err = keychain.RemoveLabel(WORKER_LABEL)
+	CheckError(err)
+
 	log.WithFields(log.Fields{"RuntimeID": runtimeID}).Info("Runtime unregistered")
 }
+
+// isNoWorkAvailable returns true if no process could be assigned before the timeout
+func isNoWorkAvailable(err error) bool {
+	return errors.Is(err, client.ErrNoWorkAvailable)
+}
@@ -4,13 +4,11 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strconv"
 
 	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
-	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/server"
 	"github.com/kataras/tablewriter"
 	log "github.com/sirupsen/logrus"
@@ -24,13 +22,14 @@ func init() {
 	workflowCmd.AddCommand(listSuccessfulWorkflowsCmd)
 	workflowCmd.AddCommand(listFailedWorkflowsCmd)
 	workflowCmd.AddCommand(getWorkflowCmd)
+	workflowCmd.AddCommand(graphWorkflowCmd)
 	workflowCmd.AddCommand(deleteWorkflowCmd)
 	workflowCmd.AddCommand(deleteAllWorkflowsCmd)
 	rootCmd.AddCommand(workflowCmd)
 
 	submitWorkflowCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
 	submitWorkflowCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
-	submitWorkflowCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON specification of a Colony workflow")
+	submitWorkflowCmd.Flags().StringVarP(&SpecFile, "spec", "", "", "JSON or YAML specification of a Colony workflow")
 	submitWorkflowCmd.Flags().StringVarP(&ColonyID, "colonyid", "", "", "Colony Id")
 	submitWorkflowCmd.Flags().BoolVarP(&Wait, "wait", "", false, "Colony Id")
 	submitWorkflowCmd.MarkFlagRequired("spec")
@@ -71,6 +70,11 @@ func init() {
 	getWorkflowCmd.Flags().StringVarP(&WorkflowID, "workflowid", "", "", "Workflow Id")
 	getWorkflowCmd.MarkFlagRequired("workflowid")
 	getWorkflowCmd.Flags().BoolVarP(&JSON, "json", "", false, "Print JSON instead of tables")
+
+	graphWorkflowCmd.Flags().StringVarP(&RuntimeID, "runtimeid", "", "", "Runtime Id")
+	graphWorkflowCmd.Flags().StringVarP(&RuntimePrvKey, "runtimeprvkey", "", "", "Runtime private key")
+	graphWorkflowCmd.Flags().StringVarP(&WorkflowID, "workflowid", "", "", "Workflow Id")
+	graphWorkflowCmd.Flags().StringVarP(&GraphFormat, "format", "", core.GRAPH_FORMAT_DOT, "Output format, dot or mermaid")
 }
 
 var workflowCmd = &cobra.Command{
@@ -86,10 +90,10 @@ var submitWorkflowCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		jsonSpecBytes, err := ioutil.ReadFile(SpecFile)
+		jsonSpec, err := ReadSpecFile(SpecFile)
 		CheckError(err)
 
-		jsonStr := "{\"processspecs\":" + string(jsonSpecBytes) + "}"
+		jsonStr := "{\"processspecs\":" + jsonSpec + "}"
 		workflowSpec, err := core.ConvertJSONToWorkflowSpec(jsonStr)
 		CheckError(err)
 
@@ -104,7 +108,7 @@ var submitWorkflowCmd = &cobra.Command{
 			workflowSpec.ColonyID = ColonyID
 		}
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -120,7 +124,7 @@ var submitWorkflowCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		graph, err := client.SubmitWorkflowSpec(workflowSpec, RuntimePrvKey)
 		CheckError(err)
@@ -136,7 +140,7 @@ var listWaitingWorkflowsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -159,7 +163,7 @@ var listWaitingWorkflowsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		graphs, err := client.GetWaitingProcessGraphs(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -197,7 +201,7 @@ var deleteWorkflowCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -213,7 +217,7 @@ var deleteWorkflowCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		log.WithFields(log.Fields{"WorkflowID": WorkflowID}).Info("ProcessGraph deleted")
 
@@ -231,7 +235,7 @@ var deleteAllWorkflowsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -252,7 +256,7 @@ var deleteAllWorkflowsCmd = &cobra.Command{
 		reply, _ := reader.ReadString('\n')
 		if reply == "YES\n" {
 			log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-			client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+			client := createColoniesClient()
 
 			err = client.DeleteAllProcessGraphs(ColonyID, ColonyPrvKey)
 			CheckError(err)
@@ -271,7 +275,7 @@ var listRunningWorkflowsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -294,7 +298,7 @@ var listRunningWorkflowsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		graphs, err := client.GetRunningProcessGraphs(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -332,7 +336,7 @@ var listSuccessfulWorkflowsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -355,7 +359,7 @@ var listSuccessfulWorkflowsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		graphs, err := client.GetSuccessfulProcessGraphs(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -393,7 +397,7 @@ var listFailedWorkflowsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if ColonyID == "" {
@@ -416,7 +420,7 @@ var listFailedWorkflowsCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		graphs, err := client.GetFailedProcessGraphs(ColonyID, Count, RuntimePrvKey)
 		CheckError(err)
@@ -520,7 +524,7 @@ var getWorkflowCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		parseServerEnv()
 
-		keychain, err := security.CreateKeychain(KEYCHAIN_PATH)
+		keychain, err := createKeychain()
 		CheckError(err)
 
 		if RuntimeID == "" {
@@ -536,7 +540,7 @@ var getWorkflowCmd = &cobra.Command{
 		}
 
 		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
-		client := client.CreateColoniesClient(ServerHost, ServerPort, Insecure, SkipTLSVerify)
+		client := createColoniesClient()
 
 		graph, err := client.GetProcessGraph(WorkflowID, RuntimePrvKey)
 		if err != nil {
@@ -552,3 +556,62 @@ var getWorkflowCmd = &cobra.Command{
 		printGraf(client, graph)
 	},
 }
+
+var graphWorkflowCmd = &cobra.Command{
+	Use:   "graph [workflowid]",
+	Short: "Render a workflow as a Graphviz DOT or Mermaid graph",
+	Long:  "Render a workflow as a Graphviz DOT or Mermaid graph, processes are coloured by state",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		parseServerEnv()
+
+		if len(args) == 1 {
+			WorkflowID = args[0]
+		}
+		if WorkflowID == "" {
+			CheckError(errors.New("Workflow Id not specified"))
+		}
+
+		if GraphFormat != core.GRAPH_FORMAT_DOT && GraphFormat != core.GRAPH_FORMAT_MERMAID {
+			CheckError(errors.New("Invalid format <" + GraphFormat + ">, must be " + core.GRAPH_FORMAT_DOT + " or " + core.GRAPH_FORMAT_MERMAID))
+		}
+
+		keychain, err := createKeychain()
+		CheckError(err)
+
+		if RuntimeID == "" {
+			RuntimeID = os.Getenv("COLONIES_RUNTIMEID")
+		}
+		if RuntimeID == "" {
+			CheckError(errors.New("Unknown Runtime Id"))
+		}
+
+		if RuntimePrvKey == "" {
+			RuntimePrvKey, err = keychain.GetPrvKey(RuntimeID)
+			CheckError(err)
+		}
+
+		log.WithFields(log.Fields{"ServerHost": ServerHost, "ServerPort": ServerPort, "Insecure": Insecure}).Info("Starting a Colonies client")
+		client := createColoniesClient()
+
+		graph, err := client.GetProcessGraph(WorkflowID, RuntimePrvKey)
+		if err != nil {
+			log.WithFields(log.Fields{"WorkflowID": WorkflowID, "Error": err}).Error("Workflow not found")
+			os.Exit(-1)
+		}
+
+		var processes []*core.Process
+		for _, processID := range graph.ProcessIDs {
+			process, err := client.GetProcess(processID, RuntimePrvKey)
+			CheckError(err)
+			processes = append(processes, process)
+		}
+
+		switch GraphFormat {
+		case core.GRAPH_FORMAT_DOT:
+			fmt.Print(graph.ToDOT(processes))
+		case core.GRAPH_FORMAT_MERMAID:
+			fmt.Print(graph.ToMermaid(processes))
+		}
+	},
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+const AESKeySize = 32
+
+// GenerateAESKey generates a random hex encoded AES-256 key
+func GenerateAESKey() (string, error) {
+	key := make([]byte, AESKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+func createAEAD(hexKey string) (cipher.AEAD, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, errors.New("Invalid AES key, key must be hex encoded")
+	}
+	if len(key) != AESKeySize {
+		return nil, errors.New("Invalid AES key, key must be 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts plaintext using AES-256-GCM, the returned hex string contains the nonce followed by the ciphertext.
+// The additional data is authenticated but not encrypted, and must be given again to decrypt the ciphertext.
+func Encrypt(hexKey string, plaintext []byte, additionalData []byte) (string, error) {
+	aead, err := createAEAD(hexKey)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(aead.Seal(nonce, nonce, plaintext, additionalData)), nil
+}
+
+// Decrypt decrypts a hex string created by Encrypt, using the same additional data
+func Decrypt(hexKey string, ciphertext string, additionalData []byte) ([]byte, error) {
+	aead, err := createAEAD(hexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := hex.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < aead.NonceSize() {
+		return nil, errors.New("Invalid ciphertext, too short")
+	}
+
+	return aead.Open(nil, buf[:aead.NonceSize()], buf[aead.NonceSize():], additionalData)
+}
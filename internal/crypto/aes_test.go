@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key, err := GenerateAESKey()
+	assert.Nil(t, err)
+
+	ciphertext, err := Encrypt(key, []byte("hello world"), nil)
+	assert.Nil(t, err)
+	assert.NotContains(t, ciphertext, "hello world")
+
+	plaintext, err := Decrypt(key, ciphertext, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", string(plaintext))
+
+	// Same plaintext should not result in the same ciphertext
+	ciphertext2, err := Encrypt(key, []byte("hello world"), nil)
+	assert.Nil(t, err)
+	assert.NotEqual(t, ciphertext, ciphertext2)
+
+	key2, err := GenerateAESKey()
+	assert.Nil(t, err)
+	_, err = Decrypt(key2, ciphertext, nil)
+	assert.NotNil(t, err)
+
+	_, err = Decrypt(key, ciphertext[:10], nil)
+	assert.NotNil(t, err)
+
+	_, err = Encrypt("invalid_key", []byte("hello world"), nil)
+	assert.NotNil(t, err)
+}
+
+func TestEncryptDecryptAdditionalData(t *testing.T) {
+	key, err := GenerateAESKey()
+	assert.Nil(t, err)
+
+	ciphertext, err := Encrypt(key, []byte("hello world"), []byte("colony1:secret1"))
+	assert.Nil(t, err)
+
+	plaintext, err := Decrypt(key, ciphertext, []byte("colony1:secret1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", string(plaintext))
+
+	_, err = Decrypt(key, ciphertext, []byte("colony1:secret2"))
+	assert.NotNil(t, err)
+
+	_, err = Decrypt(key, ciphertext, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,33 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const HMACPrefix = "sha256="
+
+func GenerateHMAC(secret string, buf []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(buf)
+
+	return HMACPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyHMAC(secret string, buf []byte, signature string) bool {
+	if !strings.HasPrefix(signature, HMACPrefix) {
+		return false
+	}
+
+	signatureBytes, err := hex.DecodeString(strings.TrimPrefix(signature, HMACPrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(buf)
+
+	return hmac.Equal(mac.Sum(nil), signatureBytes)
+}
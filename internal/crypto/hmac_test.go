@@ -0,0 +1,21 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateHMAC(t *testing.T) {
+	signature := GenerateHMAC("secret", []byte("hello world"))
+	assert.Equal(t, "sha256=734cc62f32841568f45715aeb9f4d7891324e6d948e4c6c60c0621cdac48623a", signature)
+}
+
+func TestVerifyHMAC(t *testing.T) {
+	signature := GenerateHMAC("secret", []byte("hello world"))
+	assert.True(t, VerifyHMAC("secret", []byte("hello world"), signature))
+	assert.False(t, VerifyHMAC("secret2", []byte("hello world"), signature))
+	assert.False(t, VerifyHMAC("secret", []byte("hello world2"), signature))
+	assert.False(t, VerifyHMAC("secret", []byte("hello world"), "734cc62f32841568f45715aeb9f4d7891324e6d948e4c6c60c0621cdac48623a"))
+	assert.False(t, VerifyHMAC("secret", []byte("hello world"), "sha256=invalid"))
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+)
+
+// Batch collects RPC messages which are sent to the server in a single request, see ColoniesClient.SendBatch.
+// Every message is signed on its own, so a batch may contain messages signed by different keys.
+type Batch struct {
+	client   *ColoniesClient
+	requests []*rpc.RPCMsg
+}
+
+// BatchResult is the result of a single message in a batch, JSON is the reply payload if the message succeeded
+type BatchResult struct {
+	PayloadType string
+	JSON        string
+	Err         error
+}
+
+func (client *ColoniesClient) CreateBatch() *Batch {
+	return &Batch{client: client}
+}
+
+// Add adds a message with the given payload type and JSON payload to the batch
+func (batch *Batch) Add(payloadType string, jsonString string, prvKey string) error {
+	rpcMsg, err := rpc.CreateDelegatedRPCMsg(payloadType, jsonString, prvKey, batch.client.delegations)
+	if err != nil {
+		return err
+	}
+
+	batch.requests = append(batch.requests, rpcMsg)
+
+	return nil
+}
+
+func (batch *Batch) SubmitProcessSpec(processSpec *core.ProcessSpec, prvKey string) error {
+	jsonString, err := rpc.CreateSubmitProcessSpecMsg(processSpec).ToJSON()
+	if err != nil {
+		return err
+	}
+
+	return batch.Add(rpc.SubmitProcessSpecPayloadType, jsonString, prvKey)
+}
+
+func (batch *Batch) AddAttribute(attribute core.Attribute, prvKey string) error {
+	jsonString, err := rpc.CreateAddAttributeMsg(attribute).ToJSON()
+	if err != nil {
+		return err
+	}
+
+	return batch.Add(rpc.AddAttributePayloadType, jsonString, prvKey)
+}
+
+func (batch *Batch) CloseSuccessful(processID string, prvKey string) error {
+	jsonString, err := rpc.CreateCloseSuccessfulMsg(processID).ToJSON()
+	if err != nil {
+		return err
+	}
+
+	return batch.Add(rpc.CloseSuccessfulPayloadType, jsonString, prvKey)
+}
+
+func (batch *Batch) CloseFailed(processID string, errorMsg string, prvKey string) error {
+	jsonString, err := rpc.CreateCloseFailedMsg(processID, errorMsg).ToJSON()
+	if err != nil {
+		return err
+	}
+
+	return batch.Add(rpc.CloseFailedPayloadType, jsonString, prvKey)
+}
+
+func (batch *Batch) Len() int {
+	return len(batch.requests)
+}
+
+// SendBatch sends all messages in the batch in a single request, the results are returned in the same order as
+// the messages were added. An error is only returned if the batch as a whole failed.
+func (client *ColoniesClient) SendBatch(batch *Batch, prvKey string) ([]*BatchResult, error) {
+	jsonString, err := rpc.CreateBatchMsg(batch.requests).ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// The messages in the batch carry the delegations, the batch itself is only signed
+	rpcMsg, err := client.createRPCMsg(rpc.BatchPayloadType, jsonString, prvKey, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendRPCMsg(rpcMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := rpc.ConvertJSONToRPCReplyMsgArray(respBodyString)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BatchResult, len(replies))
+	for i, reply := range replies {
+		results[i] = &BatchResult{PayloadType: reply.PayloadType}
+		if reply.Error {
+			results[i].Err = parseFailure(reply.DecodePayload())
+			continue
+		}
+		results[i].JSON = reply.DecodePayload()
+	}
+
+	return results, nil
+}
@@ -1,16 +1,22 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
-	"errors"
+	"encoding/json"
 	"net/url"
 	"strconv"
 
+	"github.com/colonyos/colonies/internal/crypto"
 	"github.com/colonyos/colonies/pkg/cluster"
 	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/rpc/pb"
+	"github.com/colonyos/colonies/pkg/tracing"
 	"github.com/go-resty/resty/v2"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 type ColoniesClient struct {
@@ -19,6 +25,10 @@ type ColoniesClient struct {
 	port          int
 	insecure      bool
 	skipTLSVerify bool
+	delegations   []*core.Delegation
+	grpcConn      *grpc.ClientConn
+	grpcClient    pb.ColoniesClient
+	ctx           context.Context
 }
 
 func CreateColoniesClient(host string, port int, insecure bool, skipTLSVerify bool) *ColoniesClient {
@@ -37,6 +47,29 @@ func CreateColoniesClient(host string, port int, insecure bool, skipTLSVerify bo
 	return client
 }
 
+// SetDelegations sets a delegation chain attached to all messages, which allows the client to act on behalf of the
+// root issuer of the chain when signing with the private key of the last delegate
+func (client *ColoniesClient) SetDelegations(delegations []*core.Delegation) {
+	client.delegations = delegations
+}
+
+// WithContext returns a copy of the client which sends the trace context of ctx with all messages, calls made with
+// the copy are recorded as part of the trace in ctx
+func (client *ColoniesClient) WithContext(ctx context.Context) *ColoniesClient {
+	clientCopy := *client
+	clientCopy.ctx = ctx
+
+	return &clientCopy
+}
+
+func (client *ColoniesClient) requestContext() context.Context {
+	if client.ctx == nil {
+		return context.Background()
+	}
+
+	return client.ctx
+}
+
 func (client *ColoniesClient) sendMessage(method string, jsonString string, prvKey string, insecure bool) (string, error) {
 	var rpcMsg *rpc.RPCMsg
 	var err error
@@ -46,12 +79,42 @@ func (client *ColoniesClient) sendMessage(method string, jsonString string, prvK
 			return "", err
 		}
 	} else {
-		rpcMsg, err = rpc.CreateRPCMsg(method, jsonString, prvKey)
+		rpcMsg, err = client.createRPCMsg(method, jsonString, prvKey, client.delegations)
 		if err != nil {
 			return "", err
 		}
 	}
-	jsonString, err = rpcMsg.ToJSON()
+
+	return client.sendRPCMsg(rpcMsg)
+}
+
+// createRPCMsg creates a signed RPC message, messages sent over gRPC sign the payload as is since it is not base64
+// encoded in transit
+func (client *ColoniesClient) createRPCMsg(payloadType string, jsonString string, prvKey string, delegations []*core.Delegation) (*rpc.RPCMsg, error) {
+	if client.grpcClient != nil {
+		return rpc.CreateRawRPCMsg(payloadType, jsonString, prvKey, delegations)
+	}
+
+	return rpc.CreateDelegatedRPCMsg(payloadType, jsonString, prvKey, delegations)
+}
+
+// sendRPCMsg sends a signed RPC message
+func (client *ColoniesClient) sendRPCMsg(rpcMsg *rpc.RPCMsg) (string, error) {
+	ctx, span := tracing.Start(client.requestContext(), "client."+rpcMsg.PayloadType, trace.SpanKindClient)
+	rpcMsg.TraceContext = tracing.Inject(ctx)
+
+	reply, err := client.sendTracedRPCMsg(ctx, rpcMsg)
+	tracing.End(span, err)
+
+	return reply, err
+}
+
+func (client *ColoniesClient) sendTracedRPCMsg(ctx context.Context, rpcMsg *rpc.RPCMsg) (string, error) {
+	if client.grpcClient != nil {
+		return client.sendGRPCMessage(ctx, rpcMsg)
+	}
+
+	jsonString, err := rpcMsg.ToJSON()
 	if err != nil {
 		return "", err
 	}
@@ -61,6 +124,7 @@ func (client *ColoniesClient) sendMessage(method string, jsonString string, prvK
 		protocol = "http"
 	}
 	resp, err := client.restyClient.R().
+		SetContext(ctx).
 		SetBody(jsonString).
 		Post(protocol + "://" + client.host + ":" + strconv.Itoa(client.port) + "/api")
 	if err != nil {
@@ -75,12 +139,7 @@ func (client *ColoniesClient) sendMessage(method string, jsonString string, prvK
 	}
 
 	if rpcReplyMsg.Error {
-		failure, err := core.ConvertJSONToFailure(rpcReplyMsg.DecodePayload())
-		if err != nil {
-			return "", err
-		}
-
-		return "", errors.New(failure.Message)
+		return "", parseFailure(rpcReplyMsg.DecodePayload())
 	}
 
 	return rpcReplyMsg.DecodePayload(), nil
@@ -119,68 +178,54 @@ func (client *ColoniesClient) SubscribeProcesses(runtimeType string, state int,
 		return nil, err
 	}
 
-	rpcMsg, err := rpc.CreateRPCMsg(rpc.SubscribeProcessesPayloadType, jsonString, prvKey)
-	if err != nil {
-		return nil, err
-	}
+	return client.subscribe(rpc.SubscribeProcessesPayloadType, jsonString, prvKey)
+}
 
-	jsonString, err = rpcMsg.ToJSON()
+func (client *ColoniesClient) SubscribeProcess(processID string, runtimeType string, state int, timeout int, prvKey string) (*ProcessSubscription, error) {
+	msg := rpc.CreateSubscribeProcessMsg(processID, runtimeType, state, timeout)
+	jsonString, err := msg.ToJSON()
 	if err != nil {
 		return nil, err
 	}
 
-	wsConn, err := client.establishWebSocketConn(jsonString)
+	return client.subscribe(rpc.SubscribeProcessPayloadType, jsonString, prvKey)
+}
+
+// SubscribeColony receives every state change of all processes in the colony, regardless of runtime type and state,
+// until the subscription times out
+func (client *ColoniesClient) SubscribeColony(colonyID string, timeout int, prvKey string) (*ProcessSubscription, error) {
+	msg := rpc.CreateSubscribeColonyMsg(colonyID, timeout)
+	jsonString, err := msg.ToJSON()
 	if err != nil {
 		return nil, err
 	}
 
-	subscription := createProcessSubscription(wsConn)
-	go func(subscription *ProcessSubscription) {
-		for {
-			_, jsonBytes, err := subscription.wsConn.ReadMessage()
-			if err != nil {
-				subscription.ErrChan <- err
-				continue
-			}
-
-			rpcReplyMsg, err := rpc.CreateRPCReplyMsgFromJSON(string(jsonBytes))
-			if err != nil {
-				subscription.ErrChan <- err
-				continue
-			}
-
-			if rpcReplyMsg.Error {
-				failureMsg, err := core.ConvertJSONToFailure(rpcReplyMsg.DecodePayload())
-				if err != nil {
-					subscription.ErrChan <- err
-				}
-				subscription.ErrChan <- errors.New(failureMsg.Message)
-			}
-
-			process, err := core.ConvertJSONToProcess(rpcReplyMsg.DecodePayload())
-			if err != nil {
-				subscription.ErrChan <- err
-				continue
-			}
-
-			subscription.ProcessChan <- process
-		}
-	}(subscription)
-
-	return subscription, nil
+	return client.subscribe(rpc.SubscribeColonyPayloadType, jsonString, prvKey)
 }
 
-func (client *ColoniesClient) SubscribeProcess(processID string, runtimeType string, state int, timeout int, prvKey string) (*ProcessSubscription, error) {
-	msg := rpc.CreateSubscribeProcessMsg(processID, runtimeType, state, timeout)
+// SubscribeProcessGraph receives every state change of the processes in the process graph until the subscription
+// times out
+func (client *ColoniesClient) SubscribeProcessGraph(processGraphID string, timeout int, prvKey string) (*ProcessSubscription, error) {
+	msg := rpc.CreateSubscribeProcessGraphMsg(processGraphID, timeout)
 	jsonString, err := msg.ToJSON()
 	if err != nil {
 		return nil, err
 	}
 
-	rpcMsg, err := rpc.CreateRPCMsg(rpc.SubscribeProcessPayloadType, jsonString, prvKey)
+	return client.subscribe(rpc.SubscribeProcessGraphPayloadType, jsonString, prvKey)
+}
+
+// subscribe sends a subscription message over gRPC or a WebSocket connection, jsonString is the payload of the message
+func (client *ColoniesClient) subscribe(payloadType string, jsonString string, prvKey string) (*ProcessSubscription, error) {
+	if client.grpcClient != nil {
+		return client.subscribeGRPC(payloadType, jsonString, prvKey)
+	}
+
+	rpcMsg, err := rpc.CreateDelegatedRPCMsg(payloadType, jsonString, prvKey, client.delegations)
 	if err != nil {
 		return nil, err
 	}
+	rpcMsg.TraceContext = tracing.Inject(client.requestContext())
 
 	jsonString, err = rpcMsg.ToJSON()
 	if err != nil {
@@ -200,6 +245,7 @@ func (client *ColoniesClient) SubscribeProcess(processID string, runtimeType str
 				subscription.ErrChan <- err
 				continue
 			}
+
 			rpcReplyMsg, err := rpc.CreateRPCReplyMsgFromJSON(string(jsonBytes))
 			if err != nil {
 				subscription.ErrChan <- err
@@ -207,11 +253,8 @@ func (client *ColoniesClient) SubscribeProcess(processID string, runtimeType str
 			}
 
 			if rpcReplyMsg.Error {
-				failureMsg, err := core.ConvertJSONToFailure(rpcReplyMsg.DecodePayload())
-				if err != nil {
-					subscription.ErrChan <- err
-				}
-				subscription.ErrChan <- errors.New(failureMsg.Message)
+				subscription.ErrChan <- parseFailure(rpcReplyMsg.DecodePayload())
+				continue
 			}
 
 			process, err := core.ConvertJSONToProcess(rpcReplyMsg.DecodePayload())
@@ -219,6 +262,7 @@ func (client *ColoniesClient) SubscribeProcess(processID string, runtimeType str
 				subscription.ErrChan <- err
 				continue
 			}
+
 			subscription.ProcessChan <- process
 		}
 	}(subscription)
@@ -226,6 +270,51 @@ func (client *ColoniesClient) SubscribeProcess(processID string, runtimeType str
 	return subscription, nil
 }
 
+// ColonyEventStreamURL returns the URL of a Server-Sent Events stream of the state changes of all processes in the
+// colony, e.g. to be opened by a browser or curl. The URL contains a signed token which can only be used once.
+func (client *ColoniesClient) ColonyEventStreamURL(colonyID string, prvKey string) (string, error) {
+	return client.eventStreamURL(rpc.CreateStreamEventsMsg(colonyID, "", ""), prvKey)
+}
+
+// ProcessGraphEventStreamURL works as ColonyEventStreamURL, but only streams the processes in the process graph
+func (client *ColoniesClient) ProcessGraphEventStreamURL(processGraphID string, prvKey string) (string, error) {
+	return client.eventStreamURL(rpc.CreateStreamEventsMsg("", processGraphID, ""), prvKey)
+}
+
+// ProcessEventStreamURL works as ColonyEventStreamURL, but only streams a single process
+func (client *ColoniesClient) ProcessEventStreamURL(processID string, prvKey string) (string, error) {
+	return client.eventStreamURL(rpc.CreateStreamEventsMsg("", "", processID), prvKey)
+}
+
+func (client *ColoniesClient) eventStreamURL(msg *rpc.StreamEventsMsg, prvKey string) (string, error) {
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return "", err
+	}
+
+	rpcMsg, err := rpc.CreateDelegatedRPCMsg(rpc.StreamEventsPayloadType, jsonString, prvKey, client.delegations)
+	if err != nil {
+		return "", err
+	}
+	rpcMsg.TraceContext = tracing.Inject(client.requestContext())
+
+	token, err := rpcMsg.ToToken()
+	if err != nil {
+		return "", err
+	}
+
+	protocol := "https"
+	if client.insecure {
+		protocol = "http"
+	}
+	u := url.URL{Scheme: protocol,
+		Host:     client.host + ":" + strconv.Itoa(client.port),
+		Path:     "/events",
+		RawQuery: url.Values{"token": []string{token}}.Encode()}
+
+	return u.String(), nil
+}
+
 func (client *ColoniesClient) AddColony(colony *core.Colony, prvKey string) (*core.Colony, error) {
 	msg := rpc.CreateAddColonyMsg(colony)
 	jsonString, err := msg.ToJSON()
@@ -261,6 +350,25 @@ func (client *ColoniesClient) DeleteColony(colonyID string, prvKey string) error
 	return nil
 }
 
+// RotateColonyKey hands over the ownership of a colony to newColonyPrvKey, prvKey must be the current colony key
+func (client *ColoniesClient) RotateColonyKey(colonyID string, newColonyPrvKey string, prvKey string) error {
+	msg, err := rpc.CreateSignedRotateColonyKeyMsg(colonyID, newColonyPrvKey)
+	if err != nil {
+		return err
+	}
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.RotateColonyKeyPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (client *ColoniesClient) GetColonies(prvKey string) ([]*core.Colony, error) {
 	msg := rpc.CreateGetColoniesMsg()
 	jsonString, err := msg.ToJSON()
@@ -381,6 +489,81 @@ func (client *ColoniesClient) DeleteRuntime(runtimeID string, prvKey string) err
 	return nil
 }
 
+func (client *ColoniesClient) RevokeRuntime(runtimeID string, reason string, prvKey string) (*core.Revocation, error) {
+	msg := rpc.CreateRevokeRuntimeMsg(runtimeID, reason)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.RevokeRuntimePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToRevocation(respBodyString)
+}
+
+func (client *ColoniesClient) GetRevocations(colonyID string, prvKey string) ([]*core.Revocation, error) {
+	msg := rpc.CreateGetRevocationsMsg(colonyID)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetRevocationsPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToRevocationArray(respBodyString)
+}
+
+func (client *ColoniesClient) AddRuntimeRole(runtimeID string, role string, prvKey string) error {
+	msg := rpc.CreateAddRuntimeRoleMsg(runtimeID, role)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.AddRuntimeRolePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (client *ColoniesClient) RemoveRuntimeRole(runtimeID string, role string, prvKey string) error {
+	msg := rpc.CreateRemoveRuntimeRoleMsg(runtimeID, role)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.RemoveRuntimeRolePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (client *ColoniesClient) GetRuntimeRoles(runtimeID string, prvKey string) ([]string, error) {
+	msg := rpc.CreateGetRuntimeRolesMsg(runtimeID)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetRuntimeRolesPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToRoleArray(respBodyString)
+}
+
 func (client *ColoniesClient) SubmitProcessSpec(processSpec *core.ProcessSpec, prvKey string) (*core.Process, error) {
 	msg := rpc.CreateSubmitProcessSpecMsg(processSpec)
 	jsonString, err := msg.ToJSON()
@@ -626,6 +809,137 @@ func (client *ColoniesClient) GetAttribute(attributeID string, prvKey string) (c
 	return core.ConvertJSONToAttribute(respBodyString)
 }
 
+func (client *ColoniesClient) SetSecret(colonyID string, name string, value string, prvKey string) error {
+	msg := rpc.CreateSetSecretMsg(colonyID, name, value)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.SetSecretPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (client *ColoniesClient) GetSecrets(colonyID string, prvKey string) ([]*core.Secret, error) {
+	msg := rpc.CreateGetSecretsMsg(colonyID)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetSecretsPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToSecretArray(respBodyString)
+}
+
+func (client *ColoniesClient) DeleteSecret(colonyID string, name string, prvKey string) error {
+	msg := rpc.CreateDeleteSecretMsg(colonyID, name)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.DeleteSecretPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddWebhook registers a webhook, the returned webhook contains the secret used to sign deliveries, which is
+// generated by the server if webhook.Secret is empty
+func (client *ColoniesClient) AddWebhook(webhook *core.Webhook, prvKey string) (*core.Webhook, error) {
+	msg := rpc.CreateAddWebhookMsg(webhook)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.AddWebhookPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWebhook(respBodyString)
+}
+
+func (client *ColoniesClient) GetWebhooks(colonyID string, prvKey string) ([]*core.Webhook, error) {
+	msg := rpc.CreateGetWebhooksMsg(colonyID)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetWebhooksPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWebhookArray(respBodyString)
+}
+
+func (client *ColoniesClient) DeleteWebhook(webhookID string, prvKey string) error {
+	msg := rpc.CreateDeleteWebhookMsg(webhookID)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.DeleteWebhookPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns the deliveries of a webhook in the given state, e.g. core.WEBHOOK_DELIVERY_DEAD for
+// deliveries that failed all attempts
+func (client *ColoniesClient) GetWebhookDeliveries(webhookID string, state int, count int, prvKey string) ([]*core.WebhookDelivery, error) {
+	msg := rpc.CreateGetWebhookDeliveriesMsg(webhookID, state, count)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetWebhookDeliveriesPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWebhookDeliveryArray(respBodyString)
+}
+
+// GetProcessSecrets returns the decrypted secrets referenced by a process, only the runtime assigned to the process is allowed to get them
+func (client *ColoniesClient) GetProcessSecrets(processID string, prvKey string) (map[string]string, error) {
+	msg := rpc.CreateGetProcessSecretsMsg(processID)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetProcessSecretsPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]string)
+	err = json.Unmarshal([]byte(respBodyString), &secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
 func (client *ColoniesClient) SubmitWorkflowSpec(workflowSpec *core.WorkflowSpec, prvKey string) (*core.ProcessGraph, error) {
 	msg := rpc.CreateSubmitWorkflowSpecMsg(workflowSpec)
 	jsonString, err := msg.ToJSON()
@@ -777,6 +1091,31 @@ func (client *ColoniesClient) PackGenerator(generatorID string, arg string, prvK
 	return nil
 }
 
+func (client *ColoniesClient) PackGeneratorWebhook(generatorID string, body string, webhookSecret string) error {
+	protocol := "https"
+	if client.insecure {
+		protocol = "http"
+	}
+	resp, err := client.restyClient.R().
+		SetHeader("X-Colonies-Signature", crypto.GenerateHMAC(webhookSecret, []byte(body))).
+		SetBody(body).
+		Post(protocol + "://" + client.host + ":" + strconv.Itoa(client.port) + "/generators/" + generatorID + "/webhook")
+	if err != nil {
+		return err
+	}
+
+	rpcReplyMsg, err := rpc.CreateRPCReplyMsgFromJSON(string(resp.Body()))
+	if err != nil {
+		return err
+	}
+
+	if rpcReplyMsg.Error {
+		return parseFailure(rpcReplyMsg.DecodePayload())
+	}
+
+	return nil
+}
+
 func (client *ColoniesClient) DeleteGenerator(generatorID string, prvKey string) error {
 	msg := rpc.CreateDeleteGeneratorMsg(generatorID)
 	jsonString, err := msg.ToJSON()
@@ -867,6 +1206,96 @@ func (client *ColoniesClient) DeleteCron(cronID string, prvKey string) error {
 	return nil
 }
 
+func (client *ColoniesClient) AddWorkflowTemplate(workflowTemplate *core.WorkflowTemplate, prvKey string) (*core.WorkflowTemplate, error) {
+	msg := rpc.CreateAddWorkflowTemplateMsg(workflowTemplate)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.AddWorkflowTemplatePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWorkflowTemplate(respBodyString)
+}
+
+func (client *ColoniesClient) GetWorkflowTemplate(colonyID string, name string, version int, prvKey string) (*core.WorkflowTemplate, error) {
+	msg := rpc.CreateGetWorkflowTemplateMsg(colonyID, name, version)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetWorkflowTemplatePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWorkflowTemplate(respBodyString)
+}
+
+func (client *ColoniesClient) GetWorkflowTemplates(colonyID string, count int, prvKey string) ([]*core.WorkflowTemplate, error) {
+	msg := rpc.CreateGetWorkflowTemplatesMsg(colonyID, count)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetWorkflowTemplatesPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWorkflowTemplateArray(respBodyString)
+}
+
+func (client *ColoniesClient) GetWorkflowTemplateVersions(colonyID string, name string, prvKey string) ([]*core.WorkflowTemplate, error) {
+	msg := rpc.CreateGetWorkflowTemplateVersionsMsg(colonyID, name)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetWorkflowTemplateVersionsPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToWorkflowTemplateArray(respBodyString)
+}
+
+func (client *ColoniesClient) DeleteWorkflowTemplate(colonyID string, name string, version int, prvKey string) error {
+	msg := rpc.CreateDeleteWorkflowTemplateMsg(colonyID, name, version)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.DeleteWorkflowTemplatePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (client *ColoniesClient) SubmitWorkflowTemplate(colonyID string, workflowTemplateRef *core.WorkflowTemplateRef, prvKey string) (*core.ProcessGraph, error) {
+	msg := rpc.CreateSubmitWorkflowTemplateMsg(colonyID, workflowTemplateRef)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.SubmitWorkflowTemplatePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToProcessGraph(respBodyString)
+}
+
 func (client *ColoniesClient) Version() (string, string, error) {
 	msg := rpc.CreateVersionMsg("", "")
 	jsonString, err := msg.ToJSON()
@@ -912,3 +1341,45 @@ func (client *ColoniesClient) GetClusterInfo(prvKey string) (*cluster.Config, er
 
 	return cluster.ConvertJSONToConfig(respBodyString)
 }
+
+func (client *ColoniesClient) AddClusterNode(node cluster.Node, prvKey string) (*cluster.Config, error) {
+	msg := rpc.CreateAddClusterNodeMsg(node)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.AddClusterNodePayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster.ConvertJSONToConfig(respBodyString)
+}
+
+func (client *ColoniesClient) RemoveClusterNode(name string, prvKey string) error {
+	msg := rpc.CreateRemoveClusterNodeMsg(name)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.sendMessage(rpc.RemoveClusterNodePayloadType, jsonString, prvKey, false)
+
+	return err
+}
+
+func (client *ColoniesClient) GetAuditLog(targetID string, fromSeq int64, count int, prvKey string) ([]*core.AuditEntry, error) {
+	msg := rpc.CreateGetAuditLogMsg(targetID, fromSeq, count)
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	respBodyString, err := client.sendMessage(rpc.GetAuditLogPayloadType, jsonString, prvKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToAuditEntryArray(respBodyString)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"github.com/colonyos/colonies/pkg/core"
+)
+
+// RPCError is returned when the server replies with an error, use errors.Is with the Err variables below to check
+// why a request failed, e.g. errors.Is(err, client.ErrNoWorkAvailable)
+type RPCError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+var (
+	ErrBadRequest       = &RPCError{Code: core.ErrorCodeBadRequest, Message: "bad request"}
+	ErrValidationFailed = &RPCError{Code: core.ErrorCodeValidationFailed, Message: "validation failed"}
+	ErrForbidden        = &RPCError{Code: core.ErrorCodeForbidden, Message: "forbidden"}
+	ErrNotFound         = &RPCError{Code: core.ErrorCodeNotFound, Message: "not found"}
+	ErrConflict         = &RPCError{Code: core.ErrorCodeConflict, Message: "conflict"}
+	ErrNoWorkAvailable  = &RPCError{Code: core.ErrorCodeNoWorkAvailable, Message: "no work available"}
+	ErrTooManyRequests  = &RPCError{Code: core.ErrorCodeTooManyRequests, Message: "too many requests"}
+	ErrInternal         = &RPCError{Code: core.ErrorCodeInternal, Message: "internal server error"}
+)
+
+func createRPCError(failure *core.Failure) *RPCError {
+	return &RPCError{Status: failure.Status, Code: failure.Code, Message: failure.Message}
+}
+
+// parseFailure converts the payload of an error reply to an RPCError
+func parseFailure(jsonString string) error {
+	failure, err := core.ConvertJSONToFailure(jsonString)
+	if err != nil {
+		return err
+	}
+	if failure == nil {
+		return ErrInternal
+	}
+
+	return createRPCError(failure)
+}
+
+func (err *RPCError) Error() string {
+	return err.Message
+}
+
+// Is reports whether target is an RPCError with the same error code
+func (err *RPCError) Is(target error) bool {
+	rpcErr, ok := target.(*RPCError)
+	if !ok {
+		return false
+	}
+
+	return err.Code == rpcErr.Code
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/rpc/pb"
+	"github.com/colonyos/colonies/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// UseGRPC makes the client send RPC messages to the gRPC endpoint of the server instead of the HTTP API. Payloads
+// are sent as is instead of being base64 encoded in a JSON envelope, and subscriptions are streamed over gRPC
+// instead of WebSockets.
+func (client *ColoniesClient) UseGRPC(port int) error {
+	var opts []grpc.DialOption
+	if client.insecure {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: client.skipTLSVerify})))
+	}
+
+	grpcConn, err := grpc.Dial(client.host+":"+strconv.Itoa(port), opts...)
+	if err != nil {
+		return err
+	}
+
+	client.grpcConn = grpcConn
+	client.grpcClient = pb.NewColoniesClient(grpcConn)
+
+	return nil
+}
+
+// CloseGRPC closes the gRPC connection, the client falls back to the HTTP API
+func (client *ColoniesClient) CloseGRPC() error {
+	if client.grpcConn == nil {
+		return nil
+	}
+
+	err := client.grpcConn.Close()
+	client.grpcConn = nil
+	client.grpcClient = nil
+
+	return err
+}
+
+func (client *ColoniesClient) createGRPCRequest(rpcMsg *rpc.RPCMsg) (*pb.RPCRequest, error) {
+	req := &pb.RPCRequest{Signature: rpcMsg.Signature,
+		PayloadType: rpcMsg.PayloadType,
+		Payload:     rpcMsg.RawPayload(),
+		Version:     int32(rpcMsg.Version),
+		Timestamp:   rpcMsg.Timestamp,
+		Nonce:       rpcMsg.Nonce}
+
+	if len(rpcMsg.Delegations) > 0 {
+		delegationsJSON, err := core.ConvertDelegationArrayToJSON(rpcMsg.Delegations)
+		if err != nil {
+			return nil, err
+		}
+		req.Delegations = []byte(delegationsJSON)
+	}
+
+	if len(rpcMsg.TraceContext) > 0 {
+		traceContextJSON, err := json.Marshal(rpcMsg.TraceContext)
+		if err != nil {
+			return nil, err
+		}
+		req.TraceContext = traceContextJSON
+	}
+
+	return req, nil
+}
+
+func parseGRPCReply(reply *pb.RPCReply) (string, error) {
+	if reply.Error {
+		return "", parseFailure(string(reply.Payload))
+	}
+
+	return string(reply.Payload), nil
+}
+
+func (client *ColoniesClient) sendGRPCMessage(ctx context.Context, rpcMsg *rpc.RPCMsg) (string, error) {
+	req, err := client.createGRPCRequest(rpcMsg)
+	if err != nil {
+		return "", err
+	}
+
+	reply, err := client.grpcClient.Call(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return parseGRPCReply(reply)
+}
+
+func (client *ColoniesClient) subscribeGRPC(payloadType string, jsonString string, prvKey string) (*ProcessSubscription, error) {
+	rpcMsg, err := rpc.CreateRawRPCMsg(payloadType, jsonString, prvKey, client.delegations)
+	if err != nil {
+		return nil, err
+	}
+	rpcMsg.TraceContext = tracing.Inject(client.requestContext())
+
+	req, err := client.createGRPCRequest(rpcMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	stream, err := client.grpcClient.Subscribe(ctx, req)
+	if err != nil {
+		cancelCtx()
+		return nil, err
+	}
+
+	subscription := createProcessSubscription(nil)
+	subscription.cancel = cancelCtx
+	go func(subscription *ProcessSubscription) {
+		defer cancelCtx()
+		for {
+			reply, err := stream.Recv()
+			if err == io.EOF {
+				subscription.ErrChan <- errors.New("Subscription ended")
+				return
+			}
+			if err != nil {
+				subscription.ErrChan <- err
+				return
+			}
+
+			jsonString, err := parseGRPCReply(reply)
+			if err != nil {
+				subscription.ErrChan <- err
+				continue
+			}
+
+			process, err := core.ConvertJSONToProcess(jsonString)
+			if err != nil {
+				subscription.ErrChan <- err
+				continue
+			}
+
+			subscription.ProcessChan <- process
+		}
+	}(subscription)
+
+	return subscription, nil
+}
+
+// ProcessAssigner assigns processes to a runtime over a single gRPC stream, see ColoniesClient.CreateProcessAssigner
+type ProcessAssigner struct {
+	client    *ColoniesClient
+	stream    pb.Colonies_AssignClient
+	cancelCtx context.CancelFunc
+	colonyID  string
+	prvKey    string
+}
+
+// CreateProcessAssigner opens an assign stream, the client must use gRPC, see UseGRPC
+func (client *ColoniesClient) CreateProcessAssigner(colonyID string, prvKey string) (*ProcessAssigner, error) {
+	if client.grpcClient == nil {
+		return nil, errors.New("Failed to create process assigner, gRPC is not enabled")
+	}
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	stream, err := client.grpcClient.Assign(ctx)
+	if err != nil {
+		cancelCtx()
+		return nil, err
+	}
+
+	return &ProcessAssigner{client: client, stream: stream, cancelCtx: cancelCtx, colonyID: colonyID, prvKey: prvKey}, nil
+}
+
+// Assign works as ColoniesClient.AssignProcess, but reuses the stream
+func (assigner *ProcessAssigner) Assign(timeout int, latest bool) (*core.Process, error) {
+	msg := rpc.CreateAssignProcessMsg(assigner.colonyID)
+	msg.Latest = latest
+	msg.Timeout = timeout
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcMsg, err := rpc.CreateRawRPCMsg(rpc.AssignProcessPayloadType, jsonString, assigner.prvKey, assigner.client.delegations)
+	if err != nil {
+		return nil, err
+	}
+	rpcMsg.TraceContext = tracing.Inject(assigner.client.requestContext())
+
+	req, err := assigner.client.createGRPCRequest(rpcMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = assigner.stream.Send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := assigner.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonString, err = parseGRPCReply(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.ConvertJSONToProcess(jsonString)
+}
+
+func (assigner *ProcessAssigner) Close() error {
+	err := assigner.stream.CloseSend()
+	assigner.cancelCtx()
+
+	return err
+}
@@ -9,6 +9,7 @@ type ProcessSubscription struct {
 	ProcessChan chan *core.Process
 	ErrChan     chan error
 	wsConn      *websocket.Conn
+	cancel      func() // Set instead of wsConn if the subscription is streamed over gRPC
 }
 
 func createProcessSubscription(wsConn *websocket.Conn) *ProcessSubscription {
@@ -21,5 +22,10 @@ func createProcessSubscription(wsConn *websocket.Conn) *ProcessSubscription {
 }
 
 func (subscription *ProcessSubscription) Close() error {
+	if subscription.cancel != nil {
+		subscription.cancel()
+		return nil
+	}
+
 	return subscription.wsConn.Close()
 }
@@ -1,6 +1,10 @@
 package cluster
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
 
 type Node struct {
 	Name           string `json:"name"`
@@ -36,9 +40,42 @@ func (node *Node) Equals(node2 *Node) bool {
 	return true
 }
 
+// Validate returns an error if the node can not be added to a cluster
+func (node *Node) Validate() error {
+	if node.Name == "" {
+		return errors.New("Invalid node, name must be set")
+	}
+	if node.Host == "" {
+		return errors.New("Invalid node <" + node.Name + ">, host must be set")
+	}
+	if node.EtcdClientPort <= 0 || node.EtcdPeerPort <= 0 || node.RelayPort <= 0 || node.APIPort <= 0 {
+		return errors.New("Invalid node <" + node.Name + ">, all ports must be set")
+	}
+
+	return nil
+}
+
 type Config struct {
-	Nodes  []Node `json:"nodes"`
-	Leader Node   `json:"leader"`
+	Nodes  []Node     `json:"nodes"`
+	Leader Node       `json:"leader"`
+	TLS    *TLSConfig `json:"tls,omitempty"`  // Plain HTTP is used between nodes if not set
+	Join   bool       `json:"join,omitempty"` // Join a running cluster, the node must first be added with EtcdServer.AddNode
+
+	// Relay metrics of the node that returned the config, see RelayServer.Metrics
+	RelayMetrics []RelayMetrics `json:"relaymetrics,omitempty"`
+}
+
+// Scheme returns the URL scheme used for relay and etcd traffic between nodes
+func (config *Config) Scheme() string {
+	if config.TLS != nil {
+		return "https"
+	}
+
+	return "http"
+}
+
+func (config *Config) peerURL(node Node) string {
+	return config.Scheme() + "://" + node.Host + ":" + strconv.Itoa(node.EtcdPeerPort)
 }
 
 func (config *Config) AddNode(node Node) {
@@ -73,7 +110,7 @@ func (config *Config) Equals(config2 *Config) bool {
 		}
 	}
 
-	if counter == len(config.Nodes) && counter == len(config.Nodes) {
+	if counter == len(config.Nodes) && counter == len(config2.Nodes) {
 		return true
 	}
 
@@ -1,23 +1,39 @@
 package cluster
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/embed"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3client"
 )
 
+// Nodes register themselves under this prefix, so that the relay and API ports of nodes added at runtime are known
+// by all nodes
+const NODES_PREFIX = "/colonies/nodes/"
+const ETCD_REQUEST_TIMEOUT = 10 * time.Second
+
 type EtcdServer struct {
-	thisNode Node
-	config   Config
-	ready    chan bool
-	stop     chan bool
-	stopped  chan bool
-	dataPath string
-	etcd     *embed.Etcd
-	cfg      *embed.Config
+	thisNode     Node
+	config       Config
+	ready        chan bool
+	stop         chan bool
+	stopped      chan bool
+	dataPath     string
+	etcd         *embed.Etcd
+	cfg          *embed.Config
+	client       *clientv3.Client
+	nodes        map[string]Node
+	nodesHandler func(nodes []Node)
+	mutex        sync.Mutex
 }
 
 func CreateEtcdServer(thisNode Node, config Config, dataPath string) *EtcdServer {
@@ -26,7 +42,8 @@ func CreateEtcdServer(thisNode Node, config Config, dataPath string) *EtcdServer
 		ready:    make(chan bool, 1),
 		stop:     make(chan bool, 1),
 		stopped:  make(chan bool, 1),
-		dataPath: dataPath}
+		dataPath: dataPath,
+		nodes:    make(map[string]Node)}
 
 	cfg := embed.NewConfig()
 	cfg.LogLevel = "fatal"
@@ -38,10 +55,11 @@ func CreateEtcdServer(thisNode Node, config Config, dataPath string) *EtcdServer
 	peerPort := strconv.Itoa(server.thisNode.EtcdPeerPort)
 	clientPort := strconv.Itoa(server.thisNode.EtcdClientPort)
 
-	initialAdvertisePeerURLs := "http://" + server.thisNode.Host + ":" + peerPort
-	listenPeerURLs := "http://0.0.0.0:" + peerPort
-	advertiseClientURLs := "http://" + server.thisNode.Host + ":" + clientPort
-	listenClientURLs := "http://0.0.0.0:" + clientPort
+	scheme := server.config.Scheme()
+	initialAdvertisePeerURLs := scheme + "://" + server.thisNode.Host + ":" + peerPort
+	listenPeerURLs := scheme + "://0.0.0.0:" + peerPort
+	advertiseClientURLs := scheme + "://" + server.thisNode.Host + ":" + clientPort
+	listenClientURLs := scheme + "://0.0.0.0:" + clientPort
 
 	lpurl, _ := url.Parse(listenPeerURLs)
 	apurl, _ := url.Parse(initialAdvertisePeerURLs)
@@ -55,6 +73,14 @@ func CreateEtcdServer(thisNode Node, config Config, dataPath string) *EtcdServer
 	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
 	cfg.InitialCluster = server.buildInitialClusterStr()
 	cfg.InitialClusterToken = "etcd-cluster-1"
+	if server.config.Join {
+		cfg.ClusterState = embed.ClusterStateFlagExisting
+	}
+
+	if server.config.TLS != nil {
+		cfg.PeerTLSInfo = server.config.TLS.etcdTLSInfo()
+		cfg.ClientTLSInfo = server.config.TLS.etcdTLSInfo()
+	}
 
 	server.cfg = cfg
 
@@ -64,7 +90,7 @@ func CreateEtcdServer(thisNode Node, config Config, dataPath string) *EtcdServer
 func (server *EtcdServer) buildInitialClusterStr() string {
 	var str string
 	for _, node := range server.config.Nodes {
-		str += node.Name + "=" + "http://" + node.Host + ":" + strconv.Itoa(node.EtcdPeerPort) + ","
+		str += node.Name + "=" + server.config.peerURL(node) + ","
 	}
 
 	if len(str) > 1 {
@@ -94,8 +120,14 @@ func (server *EtcdServer) Start() {
 				"DataPath":       server.dataPath,
 				"EtcdClientPort": server.thisNode.EtcdClientPort,
 				"EtcdPeerPort":   server.thisNode.EtcdPeerPort}).Info("EtcdServer is ready")
+			server.client = v3client.New(etcd.Server)
+			ctx, cancel := context.WithCancel(context.Background())
+			server.register(ctx)
+			go server.watchNodes(ctx)
 			server.ready <- true
 			<-server.stop
+			cancel()
+			server.client.Close()
 			etcd.Server.Stop()
 			log.WithFields(log.Fields{
 				"Name":           server.thisNode.Name,
@@ -129,7 +161,157 @@ func (server *EtcdServer) WaitToStop() {
 	<-server.stopped
 }
 
+// register stores this node in etcd, so that nodes that were not part of the initial cluster config learn its ports
+func (server *EtcdServer) register(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, ETCD_REQUEST_TIMEOUT)
+	defer cancel()
+
+	err := server.putNode(ctx, server.thisNode)
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err, "Name": server.thisNode.Name}).Error("EtcdServer failed to register node")
+	}
+}
+
+func (server *EtcdServer) putNode(ctx context.Context, node Node) error {
+	jsonBytes, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	_, err = server.client.Put(ctx, NODES_PREFIX+node.Name, string(jsonBytes))
+	return err
+}
+
+// watchNodes keeps the registered nodes up to date and calls the nodes handler every time they change
+func (server *EtcdServer) watchNodes(ctx context.Context) {
+	resp, err := server.client.Get(ctx, NODES_PREFIX, clientv3.WithPrefix())
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err}).Error("EtcdServer failed to get registered nodes")
+		return
+	}
+
+	server.mutex.Lock()
+	for _, kv := range resp.Kvs {
+		server.putRegisteredNode(kv.Value)
+	}
+	server.mutex.Unlock()
+	server.notifyNodesChanged()
+
+	watchChan := server.client.Watch(ctx, NODES_PREFIX, clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+	for watchResp := range watchChan {
+		server.mutex.Lock()
+		for _, event := range watchResp.Events {
+			switch event.Type {
+			case clientv3.EventTypePut:
+				server.putRegisteredNode(event.Kv.Value)
+			case clientv3.EventTypeDelete:
+				delete(server.nodes, strings.TrimPrefix(string(event.Kv.Key), NODES_PREFIX))
+			}
+		}
+		server.mutex.Unlock()
+		server.notifyNodesChanged()
+	}
+}
+
+// putRegisteredNode must be called with the mutex held
+func (server *EtcdServer) putRegisteredNode(jsonBytes []byte) {
+	var node Node
+	err := json.Unmarshal(jsonBytes, &node)
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err}).Error("EtcdServer failed to parse registered node")
+		return
+	}
+
+	server.nodes[node.Name] = node
+}
+
+func (server *EtcdServer) notifyNodesChanged() {
+	server.mutex.Lock()
+	handler := server.nodesHandler
+	server.mutex.Unlock()
+
+	if handler != nil {
+		handler(server.Members())
+	}
+}
+
+// OnNodesChanged sets a handler called with the current members every time a node is added to or removed from the
+// cluster. It must be called after the server has started, and the handler is also called directly with the current
+// members.
+func (server *EtcdServer) OnNodesChanged(handler func(nodes []Node)) {
+	server.mutex.Lock()
+	server.nodesHandler = handler
+	server.mutex.Unlock()
+
+	handler(server.Members())
+}
+
+// AddNode adds a node to the cluster. The node must then be started with Config.Join set and the current members
+// in Config.Nodes, and is returned by Members once it has started.
+func (server *EtcdServer) AddNode(ctx context.Context, node Node) error {
+	err := node.Validate()
+	if err != nil {
+		return err
+	}
+
+	for _, member := range server.Members() {
+		if member.Name == node.Name {
+			return errors.New("Failed to add node, a node named <" + node.Name + "> is already a member of the cluster")
+		}
+	}
+
+	_, err = server.client.MemberAdd(ctx, []string{server.config.peerURL(node)})
+	if err != nil {
+		return err
+	}
+
+	// Register the node directly so that it can be removed by name also if it never starts
+	return server.putNode(ctx, node)
+}
+
+// RemoveNode removes a node from the cluster, the etcd server of the removed node stops. A node can not remove
+// itself, since it would not be able to confirm that the removal succeeded.
+func (server *EtcdServer) RemoveNode(ctx context.Context, name string) error {
+	if name == server.thisNode.Name {
+		return errors.New("Failed to remove node, a node can not remove itself, send the request to another node")
+	}
+
+	resp, err := server.client.MemberList(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A member that has not yet started has no name, it is then found using its peer URL
+	var peerURL string
+	server.mutex.Lock()
+	if node, ok := server.nodes[name]; ok {
+		peerURL = server.config.peerURL(node)
+	}
+	server.mutex.Unlock()
+
+	for _, member := range resp.Members {
+		if member.Name == name || (member.Name == "" && peerURL != "" && len(member.PeerURLs) > 0 && member.PeerURLs[0] == peerURL) {
+			_, err = server.client.MemberRemove(ctx, member.ID)
+			if err != nil {
+				return err
+			}
+
+			_, err = server.client.Delete(ctx, NODES_PREFIX+name)
+			return err
+		}
+	}
+
+	return errors.New("Failed to remove node, <" + name + "> is not a member of the cluster")
+}
+
 func (server *EtcdServer) Leader() string {
+	// The etcd server stops if this node is removed from the cluster
+	select {
+	case <-server.etcd.Server.StopNotify():
+		return ""
+	default:
+	}
+
 	leader := server.etcd.Server.Leader()
 	for _, member := range server.etcd.Server.Cluster().Members() {
 		if member.ID == leader {
@@ -139,9 +321,17 @@ func (server *EtcdServer) Leader() string {
 	return ""
 }
 
+// Members returns the started members of the cluster, registered nodes take precedence over the initial config
 func (server *EtcdServer) Members() []Node {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
 	var nodes []Node
 	for _, member := range server.etcd.Server.Cluster().Members() {
+		if node, ok := server.nodes[member.Name]; ok && member.Name != "" {
+			nodes = append(nodes, node)
+			continue
+		}
 		for _, node := range server.config.Nodes {
 			if node.Name == member.Name {
 				nodes = append(nodes, node)
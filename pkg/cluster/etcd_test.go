@@ -1,9 +1,13 @@
 package cluster
 
 import (
+	"context"
+	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +72,179 @@ func TestCreateEtcdCluster(t *testing.T) {
 	os.RemoveAll(server3.StorageDir())
 	os.RemoveAll(server4.StorageDir())
 }
+
+func TestCreateEtcdClusterTLS(t *testing.T) {
+	tlsConfig := createTestTLSConfig(t, t.TempDir(), "node")
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24800, EtcdPeerPort: 23800, RelayPort: 25800, APIPort: 26800}
+	node2 := Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24900, EtcdPeerPort: 23900, RelayPort: 25900, APIPort: 26900}
+
+	config := Config{TLS: tlsConfig}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	server1 := CreateEtcdServer(node1, config, ".")
+	server2 := CreateEtcdServer(node2, config, ".")
+
+	assert.Equal(t, server1.buildInitialClusterStr(), "etcd1=https://localhost:23800,etcd2=https://localhost:23900")
+
+	server1.Start()
+	server2.Start()
+
+	server1.WaitToStart()
+	server2.WaitToStart()
+
+	assert.Equal(t, server1.Leader(), server2.Leader())
+	assert.Len(t, server1.Members(), 2)
+
+	server1.Stop()
+	server2.Stop()
+
+	server1.WaitToStop()
+	server2.WaitToStop()
+
+	os.RemoveAll(server1.StorageDir())
+	os.RemoveAll(server2.StorageDir())
+}
+
+func waitForNodes(t *testing.T, nodes func() []Node, expected int) {
+	for i := 0; i < 100; i++ {
+		if len(nodes()) == expected {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.Len(t, nodes(), expected)
+}
+
+func receiveRelayMsgs(relayServer *RelayServer) chan string {
+	msgs := make(chan string, 10)
+	go func() {
+		for msg := range relayServer.Receive() {
+			msgs <- string(msg)
+		}
+	}()
+
+	return msgs
+}
+
+func waitForRelayMsg(t *testing.T, msgs chan string) string {
+	select {
+	case msg := <-msgs:
+		return msg
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "Timeout waiting for relay message")
+		return ""
+	}
+}
+
+func TestAddRemoveEtcdNode(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = ioutil.Discard
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24510, EtcdPeerPort: 23510, RelayPort: 25510, APIPort: 26510}
+	node2 := Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24520, EtcdPeerPort: 23520, RelayPort: 25520, APIPort: 26520}
+	node3 := Node{Name: "etcd3", Host: "localhost", EtcdClientPort: 24530, EtcdPeerPort: 23530, RelayPort: 25530, APIPort: 26530}
+
+	config := Config{}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	server1 := CreateEtcdServer(node1, config, ".")
+	server2 := CreateEtcdServer(node2, config, ".")
+	server1.Start()
+	server2.Start()
+	server1.WaitToStart()
+	server2.WaitToStart()
+
+	relayServer1 := CreateRelayServer(node1, config)
+	relayServer2 := CreateRelayServer(node2, config)
+	defer relayServer1.Shutdown()
+	defer relayServer2.Shutdown()
+	server1.OnNodesChanged(relayServer1.SetNodes)
+	server2.OnNodesChanged(relayServer2.SetNodes)
+	msgs2 := receiveRelayMsgs(relayServer2)
+
+	ctx := context.Background()
+	err := server1.AddNode(ctx, Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 1, EtcdPeerPort: 1, RelayPort: 1, APIPort: 1})
+	assert.NotNil(t, err) // Name already taken
+	err = server1.AddNode(ctx, Node{Name: "etcd4"})
+	assert.NotNil(t, err) // Ports missing
+
+	// Etcd rejects membership changes until the members have been connected for a while
+	for i := 0; i < 30; i++ {
+		err = server1.AddNode(ctx, node3)
+		if err == nil {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	assert.Nil(t, err)
+
+	// The new node is only relayed to once it has started
+	assert.Len(t, relayServer1.Nodes(), 2)
+
+	joinConfig := Config{Join: true}
+	joinConfig.AddNode(node1)
+	joinConfig.AddNode(node2)
+	joinConfig.AddNode(node3)
+	server3 := CreateEtcdServer(node3, joinConfig, ".")
+	server3.Start()
+	server3.WaitToStart()
+
+	relayServer3 := CreateRelayServer(node3, joinConfig)
+	defer relayServer3.Shutdown()
+	server3.OnNodesChanged(relayServer3.SetNodes)
+	msgs3 := receiveRelayMsgs(relayServer3)
+
+	// Node 2 only knows node 3 from its registration
+	waitForNodes(t, server2.Members, 3)
+	waitForNodes(t, relayServer1.Nodes, 3)
+	waitForNodes(t, relayServer2.Nodes, 3)
+	for _, node := range server2.Members() {
+		if node.Name == node3.Name {
+			assert.True(t, node.Equals(&node3))
+		}
+	}
+
+	err = relayServer1.Broadcast(ctx, []byte("msg1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "msg1", waitForRelayMsg(t, msgs2))
+	assert.Equal(t, "msg1", waitForRelayMsg(t, msgs3))
+
+	err = server3.RemoveNode(ctx, node3.Name)
+	assert.NotNil(t, err) // A node can not remove itself
+	err = server1.RemoveNode(ctx, "etcd4")
+	assert.NotNil(t, err) // Not a member
+
+	for i := 0; i < 30; i++ {
+		err = server1.RemoveNode(ctx, node3.Name)
+		if err == nil {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	assert.Nil(t, err)
+
+	waitForNodes(t, server2.Members, 2)
+	waitForNodes(t, relayServer1.Nodes, 2)
+	waitForNodes(t, relayServer2.Nodes, 2)
+
+	// The removed node stops and is no longer relayed to
+	err = relayServer1.Broadcast(ctx, []byte("msg2"))
+	assert.Nil(t, err)
+	assert.Equal(t, "msg2", waitForRelayMsg(t, msgs2))
+	assert.Len(t, msgs3, 0)
+
+	server1.Stop()
+	server2.Stop()
+	server3.Stop()
+
+	server1.WaitToStop()
+	server2.WaitToStop()
+	server3.WaitToStop()
+
+	os.RemoveAll(server1.StorageDir())
+	os.RemoveAll(server2.StorageDir())
+	os.RemoveAll(server3.StorageDir())
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const RELAY_QUEUE_SIZE = 1000
+const RELAY_TIMEOUT = 5 * time.Second
+const RELAY_MAX_ATTEMPTS = 5
+const RELAY_BACKOFF = 100 * time.Millisecond
+const RELAY_MAX_BACKOFF = 2 * time.Second
+
+// RelayMetrics counts the messages relayed to another node, a message is dropped if the queue to the node is full or
+// if all attempts to relay it have failed
+type RelayMetrics struct {
+	Name      string `json:"name"`
+	Queued    int    `json:"queued"`
+	Delivered int64  `json:"delivered"`
+	Retried   int64  `json:"retried"`
+	Dropped   int64  `json:"dropped"`
+	LastError string `json:"lasterror,omitempty"`
+}
+
+type relayMsg struct {
+	msg          []byte
+	traceContext map[string]string
+}
+
+// A relayPeer relays the messages queued for a node in order, one at a time, so that an unreachable node does not
+// delay the messages to other nodes
+type relayPeer struct {
+	node    Node
+	queue   chan *relayMsg
+	stop    chan struct{}
+	metrics RelayMetrics
+	mutex   sync.Mutex
+}
+
+func createRelayPeer(node Node) *relayPeer {
+	return &relayPeer{node: node,
+		queue:   make(chan *relayMsg, RELAY_QUEUE_SIZE),
+		stop:    make(chan struct{}),
+		metrics: RelayMetrics{Name: node.Name}}
+}
+
+func (peer *relayPeer) enqueue(msg *relayMsg) error {
+	select {
+	case peer.queue <- msg:
+		return nil
+	default:
+		err := errors.New("Failed to relay message to <" + peer.node.Name + ">, relay queue is full")
+		peer.dropped(err)
+		return err
+	}
+}
+
+func (peer *relayPeer) delivered() {
+	peer.mutex.Lock()
+	defer peer.mutex.Unlock()
+
+	peer.metrics.Delivered++
+}
+
+func (peer *relayPeer) retried(err error) {
+	peer.mutex.Lock()
+	defer peer.mutex.Unlock()
+
+	peer.metrics.Retried++
+	peer.metrics.LastError = err.Error()
+}
+
+func (peer *relayPeer) dropped(err error) {
+	peer.mutex.Lock()
+	defer peer.mutex.Unlock()
+
+	peer.metrics.Dropped++
+	peer.metrics.LastError = err.Error()
+}
+
+func (peer *relayPeer) getMetrics() RelayMetrics {
+	peer.mutex.Lock()
+	defer peer.mutex.Unlock()
+
+	metrics := peer.metrics
+	metrics.Queued = len(peer.queue)
+
+	return metrics
+}
+
+// run relays queued messages until the peer is stopped. The backoff is kept between messages, so that messages to an
+// unreachable node are not attempted more often than the backoff allows.
+func (peer *relayPeer) run(relay func(node Node, msg *relayMsg) error, retries func() (int, time.Duration)) {
+	var backoff time.Duration
+	for {
+		select {
+		case <-peer.stop:
+			return
+		case msg := <-peer.queue:
+			maxAttempts, initialBackoff := retries()
+			for attempt := 1; ; attempt++ {
+				if backoff > 0 {
+					select {
+					case <-peer.stop:
+						return
+					case <-time.After(backoff):
+					}
+				}
+
+				err := relay(peer.node, msg)
+				if err == nil {
+					backoff = 0
+					peer.delivered()
+					break
+				}
+
+				backoff = nextRelayBackoff(backoff, initialBackoff)
+				if attempt >= maxAttempts {
+					log.WithFields(log.Fields{"Error": err, "Node": peer.node.Name, "Attempts": attempt}).Error("Failed to relay message, dropping it")
+					peer.dropped(err)
+					break
+				}
+				peer.retried(err)
+			}
+		}
+	}
+}
+
+func nextRelayBackoff(backoff time.Duration, initialBackoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return initialBackoff
+	}
+
+	backoff = backoff * 2
+	if backoff > RELAY_MAX_BACKOFF {
+		return RELAY_MAX_BACKOFF
+	}
+
+	return backoff
+}
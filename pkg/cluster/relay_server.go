@@ -4,14 +4,20 @@ import (
 	"context"
 	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"sort"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/colonyos/colonies/pkg/tracing"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-resty/resty/v2"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type RelayServer struct {
@@ -20,6 +26,12 @@ type RelayServer struct {
 	restyClient   *resty.Client
 	clusterConfig Config
 	thisNode      Node
+	nodes         []Node
+	peers         map[string]*relayPeer
+	maxAttempts   int
+	backoff       time.Duration
+	stopped       bool
+	mutex         sync.Mutex
 	incoming      chan []byte
 }
 
@@ -28,9 +40,14 @@ func CreateRelayServer(thisNode Node, clusterConfig Config) *RelayServer {
 	server.ginHandler = gin.Default()
 	server.ginHandler.Use(cors.Default())
 	server.restyClient = resty.New()
+	server.restyClient.SetTimeout(RELAY_TIMEOUT)
 	server.clusterConfig = clusterConfig
 	server.thisNode = thisNode
+	server.peers = make(map[string]*relayPeer)
+	server.maxAttempts = RELAY_MAX_ATTEMPTS
+	server.backoff = RELAY_BACKOFF
 	server.incoming = make(chan []byte)
+	server.SetNodes(clusterConfig.Nodes)
 
 	httpServer := &http.Server{
 		Addr:    ":" + strconv.Itoa(thisNode.RelayPort),
@@ -38,14 +55,44 @@ func CreateRelayServer(thisNode Node, clusterConfig Config) *RelayServer {
 	}
 	server.httpServer = httpServer
 
-	go server.serveForever()
+	if clusterConfig.TLS != nil {
+		serverTLSConfig, err := clusterConfig.TLS.ServerConfig()
+		if err != nil {
+			log.WithFields(log.Fields{"Error": err}).Fatal("Failed to load cluster TLS config for RelayServer")
+		}
+		httpServer.TLSConfig = serverTLSConfig
+
+		clientTLSConfig, err := clusterConfig.TLS.ClientConfig()
+		if err != nil {
+			log.WithFields(log.Fields{"Error": err}).Fatal("Failed to load cluster TLS config for RelayServer")
+		}
+		server.restyClient.SetTLSClientConfig(clientTLSConfig)
+	} else {
+		log.WithFields(log.Fields{"RelayPort": thisNode.RelayPort}).Warning("Cluster TLS not configured, relay traffic between nodes is not authenticated")
+	}
+
 	server.setupRoutes()
 
+	// Bind the port before returning, so that other nodes can relay messages as soon as the server is created
+	listener, err := net.Listen("tcp", httpServer.Addr)
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err, "RelayPort": thisNode.RelayPort}).Error("RelayServer failed to listen")
+	} else {
+		go server.serveForever(listener)
+	}
+
 	return server
 }
 
-func (server *RelayServer) serveForever() error {
-	if err := server.httpServer.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
+func (server *RelayServer) serveForever(listener net.Listener) error {
+	var err error
+	if server.httpServer.TLSConfig != nil {
+		// The certificate is already loaded in the TLSConfig
+		err = server.httpServer.ServeTLS(listener, "", "")
+	} else {
+		err = server.httpServer.Serve(listener)
+	}
+	if err != nil && errors.Is(err, http.ErrServerClosed) {
 		return err
 	}
 
@@ -62,34 +109,150 @@ func (server *RelayServer) handleRelayRequest(c *gin.Context) {
 		errMsg := "Bad relay request"
 		log.WithFields(log.Fields{"Error": err}).Error(errMsg)
 		c.String(http.StatusBadRequest, errMsg)
+		return
 	}
 
+	_, span := tracing.Start(tracing.ExtractHTTPHeader(c.Request.Context(), c.Request.Header), "relay.receive", trace.SpanKindServer)
 	server.incoming <- jsonBytes
+	span.End()
 
 	c.String(http.StatusOK, "")
 }
 
-// Send a message to all ReplayServers in the Cluster
-func (server *RelayServer) Broadcast(msg []byte) error {
-	for _, node := range server.clusterConfig.Nodes {
-		if node.Name != server.thisNode.Name {
-			_, err := server.restyClient.R().
-				SetBody(msg).
-				Post("http://" + node.Host + ":" + strconv.Itoa(node.RelayPort) + "/relay")
-			if err != nil {
-				return err
+// SetNodes replaces the nodes messages are broadcasted to, e.g. when nodes are added to or removed from the cluster.
+// Messages queued for a removed node are discarded.
+func (server *RelayServer) SetNodes(nodes []Node) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.stopped {
+		return
+	}
+
+	server.nodes = nodes
+
+	current := make(map[string]bool)
+	for _, node := range nodes {
+		if node.Name == server.thisNode.Name {
+			continue
+		}
+		current[node.Name] = true
+
+		if peer, ok := server.peers[node.Name]; ok {
+			if peer.node.Equals(&node) {
+				continue
 			}
+			close(peer.stop)
+		}
+
+		peer := createRelayPeer(node)
+		server.peers[node.Name] = peer
+		go peer.run(server.relay, server.retries)
+	}
+
+	for name, peer := range server.peers {
+		if !current[name] {
+			close(peer.stop)
+			delete(server.peers, name)
 		}
 	}
+}
+
+// Nodes returns the nodes messages are broadcasted to
+func (server *RelayServer) Nodes() []Node {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.nodes
+}
+
+// SetRetries sets how many times a message is relayed to a node before it is dropped, and the delay before the first
+// retry, which is doubled for every following retry
+func (server *RelayServer) SetRetries(maxAttempts int, backoff time.Duration) error {
+	if maxAttempts <= 0 || backoff <= 0 {
+		return errors.New("Invalid relay retries, max attempts and backoff must be positive")
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.maxAttempts = maxAttempts
+	server.backoff = backoff
 
 	return nil
 }
 
+func (server *RelayServer) retries() (int, time.Duration) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.maxAttempts, server.backoff
+}
+
+// Metrics returns the relay metrics of all other nodes in the cluster, sorted by node name
+func (server *RelayServer) Metrics() []RelayMetrics {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	metrics := make([]RelayMetrics, 0, len(server.peers))
+	for _, peer := range server.peers {
+		metrics = append(metrics, peer.getMetrics())
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+
+	return metrics
+}
+
+// Broadcast queues a message for all other RelayServers in the cluster and returns directly. The messages are relayed
+// to each node in order and retried with backoff, independently of the other nodes. An error is returned if the queue
+// to any node is full, the message is then still relayed to the other nodes. The trace context of ctx is sent along
+// with the message.
+func (server *RelayServer) Broadcast(ctx context.Context, msg []byte) error {
+	queuedMsg := &relayMsg{msg: msg, traceContext: tracing.Inject(ctx)}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	var err error
+	for _, peer := range server.peers {
+		if enqueueErr := peer.enqueue(queuedMsg); enqueueErr != nil {
+			err = enqueueErr
+		}
+	}
+
+	return err
+}
+
+// relay posts a message to a node, the request is not bound to the context of the broadcast since the broadcasting
+// request may already have finished
+func (server *RelayServer) relay(node Node, msg *relayMsg) error {
+	ctx, span := tracing.StartChild(tracing.Extract(context.Background(), msg.traceContext), "relay.broadcast", attribute.String("relay.node", node.Name))
+	resp, err := server.restyClient.R().
+		SetContext(ctx).
+		SetHeaders(tracing.Inject(ctx)).
+		SetBody(msg.msg).
+		Post(server.clusterConfig.Scheme() + "://" + node.Host + ":" + strconv.Itoa(node.RelayPort) + "/relay")
+	if err == nil && resp.IsError() {
+		err = errors.New("Relay to <" + node.Name + "> responded with status " + strconv.Itoa(resp.StatusCode()))
+	}
+	tracing.End(span, err)
+
+	return err
+}
+
 func (server *RelayServer) Receive() chan []byte {
 	return server.incoming
 }
 
 func (server *RelayServer) Shutdown() { // TODO: unittest
+	server.mutex.Lock()
+	server.stopped = true
+	for name, peer := range server.peers {
+		close(peer.stop)
+		delete(server.peers, name)
+	}
+	server.mutex.Unlock()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
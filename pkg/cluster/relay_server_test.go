@@ -1,8 +1,11 @@
 package cluster
 
 import (
+	"context"
 	"io/ioutil"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -101,11 +104,11 @@ func TestRelayServer(t *testing.T) {
 		}
 	}()
 
-	err := relayServer1.Broadcast([]byte("relayserver1"))
+	err := relayServer1.Broadcast(context.Background(), []byte("relayserver1"))
 	assert.Nil(t, err)
-	err = relayServer2.Broadcast([]byte("relayserver2"))
+	err = relayServer2.Broadcast(context.Background(), []byte("relayserver2"))
 	assert.Nil(t, err)
-	err = relayServer3.Broadcast([]byte("relayserver3"))
+	err = relayServer3.Broadcast(context.Background(), []byte("relayserver3"))
 	assert.Nil(t, err)
 
 	<-relayServer1Wait
@@ -124,3 +127,160 @@ func TestRelayServer(t *testing.T) {
 	assert.Equal(t, relayServer3Received["relayserver2"], 1)
 	assert.Len(t, relayServer2Received, 2)
 }
+
+func waitForRelayMetrics(t *testing.T, relayServer *RelayServer, name string, done func(metrics RelayMetrics) bool) RelayMetrics {
+	var metrics RelayMetrics
+	for i := 0; i < 100; i++ {
+		for _, m := range relayServer.Metrics() {
+			if m.Name == name {
+				metrics = m
+			}
+		}
+		if done(metrics) {
+			return metrics
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.Fail(t, "Timeout waiting for relay metrics", metrics)
+	return metrics
+}
+
+func TestRelayServerNodeDown(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = ioutil.Discard
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24610, EtcdPeerPort: 23610, RelayPort: 25610, APIPort: 26610}
+	node2 := Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24620, EtcdPeerPort: 23620, RelayPort: 25620, APIPort: 26620}
+	node3 := Node{Name: "etcd3", Host: "localhost", EtcdClientPort: 24630, EtcdPeerPort: 23630, RelayPort: 25630, APIPort: 26630}
+
+	config := Config{}
+	config.AddNode(node1)
+	config.AddNode(node2)
+	config.AddNode(node3)
+
+	relayServer1 := CreateRelayServer(node1, config)
+	relayServer2 := CreateRelayServer(node2, config)
+	relayServer3 := CreateRelayServer(node3, config)
+	defer relayServer1.Shutdown()
+	defer relayServer3.Shutdown()
+	msgs3 := receiveRelayMsgs(relayServer3)
+
+	err := relayServer1.SetRetries(2, 10*time.Millisecond)
+	assert.Nil(t, err)
+
+	// Kill node 2, node 3 must still get all messages in order
+	relayServer2.Shutdown()
+
+	nrOfMsgs := 5
+	for i := 0; i < nrOfMsgs; i++ {
+		err = relayServer1.Broadcast(context.Background(), []byte("msg"+strconv.Itoa(i)))
+		assert.Nil(t, err)
+	}
+
+	for i := 0; i < nrOfMsgs; i++ {
+		assert.Equal(t, "msg"+strconv.Itoa(i), waitForRelayMsg(t, msgs3))
+	}
+
+	metrics3 := waitForRelayMetrics(t, relayServer1, node3.Name, func(m RelayMetrics) bool { return m.Delivered == int64(nrOfMsgs) })
+	assert.Equal(t, int64(0), metrics3.Dropped)
+	assert.Equal(t, int64(0), metrics3.Retried)
+
+	metrics2 := waitForRelayMetrics(t, relayServer1, node2.Name, func(m RelayMetrics) bool { return m.Dropped == int64(nrOfMsgs) })
+	assert.Equal(t, int64(0), metrics2.Delivered)
+	assert.Equal(t, int64(nrOfMsgs), metrics2.Retried)
+	assert.Equal(t, 0, metrics2.Queued)
+	assert.NotEmpty(t, metrics2.LastError)
+}
+
+func TestRelayServerRetry(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = ioutil.Discard
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24640, EtcdPeerPort: 23640, RelayPort: 25640, APIPort: 26640}
+	node2 := Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24650, EtcdPeerPort: 23650, RelayPort: 25650, APIPort: 26650}
+
+	config := Config{}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	relayServer1 := CreateRelayServer(node1, config)
+	defer relayServer1.Shutdown()
+	err := relayServer1.SetRetries(100, 10*time.Millisecond)
+	assert.Nil(t, err)
+
+	// Node 2 is not yet started, the message is relayed when it has started
+	err = relayServer1.Broadcast(context.Background(), []byte("msg"))
+	assert.Nil(t, err)
+	time.Sleep(500 * time.Millisecond)
+
+	relayServer2 := CreateRelayServer(node2, config)
+	defer relayServer2.Shutdown()
+	msgs2 := receiveRelayMsgs(relayServer2)
+	assert.Equal(t, "msg", waitForRelayMsg(t, msgs2))
+
+	metrics := waitForRelayMetrics(t, relayServer1, node2.Name, func(m RelayMetrics) bool { return m.Delivered == 1 })
+	assert.True(t, metrics.Retried > 0)
+	assert.Equal(t, int64(0), metrics.Dropped)
+}
+
+func TestRelayServerQueueFull(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = ioutil.Discard
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24660, EtcdPeerPort: 23660, RelayPort: 25660, APIPort: 26660}
+	node2 := Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24670, EtcdPeerPort: 23670, RelayPort: 25670, APIPort: 26670}
+
+	config := Config{}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	relayServer1 := CreateRelayServer(node1, config)
+	defer relayServer1.Shutdown()
+
+	// Node 2 is never started, one message is being retried and the rest are queued
+	var err error
+	for i := 0; i < RELAY_QUEUE_SIZE+2; i++ {
+		err = relayServer1.Broadcast(context.Background(), []byte("msg"))
+	}
+	assert.NotNil(t, err)
+
+	metrics := relayServer1.Metrics()
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, RELAY_QUEUE_SIZE, metrics[0].Queued)
+	assert.True(t, metrics[0].Dropped > 0)
+
+	// Queued messages are discarded when the node is removed
+	relayServer1.SetNodes([]Node{node1})
+	assert.Len(t, relayServer1.Metrics(), 0)
+	err = relayServer1.Broadcast(context.Background(), []byte("msg"))
+	assert.Nil(t, err)
+}
+
+func TestSetRelayRetries(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = ioutil.Discard
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24680, EtcdPeerPort: 23680, RelayPort: 25680, APIPort: 26680}
+	config := Config{}
+	config.AddNode(node1)
+
+	relayServer := CreateRelayServer(node1, config)
+	defer relayServer.Shutdown()
+
+	assert.NotNil(t, relayServer.SetRetries(0, time.Second))
+	assert.NotNil(t, relayServer.SetRetries(3, 0))
+	assert.Nil(t, relayServer.SetRetries(3, time.Second))
+
+	maxAttempts, backoff := relayServer.retries()
+	assert.Equal(t, 3, maxAttempts)
+	assert.Equal(t, time.Second, backoff)
+}
+
+func TestNextRelayBackoff(t *testing.T) {
+	backoff := nextRelayBackoff(0, RELAY_BACKOFF)
+	assert.Equal(t, RELAY_BACKOFF, backoff)
+	backoff = nextRelayBackoff(backoff, RELAY_BACKOFF)
+	assert.Equal(t, 2*RELAY_BACKOFF, backoff)
+	backoff = nextRelayBackoff(RELAY_MAX_BACKOFF, RELAY_BACKOFF)
+	assert.Equal(t, RELAY_MAX_BACKOFF, backoff)
+}
@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+// TLSConfig holds the certificates cluster nodes use to authenticate each other. All nodes must have certificates
+// signed by the same CA, the relay servers and etcd only accept connections from clients presenting such a
+// certificate.
+type TLSConfig struct {
+	CACert string `json:"cacert"`
+	Cert   string `json:"cert"`
+	Key    string `json:"key"`
+}
+
+func CreateTLSConfig(caCert string, cert string, key string) *TLSConfig {
+	return &TLSConfig{CACert: caCert, Cert: cert, Key: key}
+}
+
+func (tlsConfig *TLSConfig) Validate() error {
+	if tlsConfig.CACert == "" || tlsConfig.Cert == "" || tlsConfig.Key == "" {
+		return errors.New("Invalid cluster TLS config, CA certificate, certificate and key must all be set")
+	}
+
+	return nil
+}
+
+func (tlsConfig *TLSConfig) loadCertPool() (*x509.CertPool, error) {
+	caPEM, err := ioutil.ReadFile(tlsConfig.CACert)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("Failed to parse cluster CA certificate <" + tlsConfig.CACert + ">")
+	}
+
+	return pool, nil
+}
+
+// ServerConfig returns a tls.Config that requires clients to present a certificate signed by the cluster CA
+func (tlsConfig *TLSConfig) ServerConfig() (*tls.Config, error) {
+	err := tlsConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(tlsConfig.Cert, tlsConfig.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := tlsConfig.loadCertPool()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ClientConfig returns a tls.Config that presents the node certificate and only trusts servers with a certificate
+// signed by the cluster CA
+func (tlsConfig *TLSConfig) ClientConfig() (*tls.Config, error) {
+	err := tlsConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(tlsConfig.Cert, tlsConfig.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := tlsConfig.loadCertPool()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func (tlsConfig *TLSConfig) etcdTLSInfo() transport.TLSInfo {
+	return transport.TLSInfo{
+		CertFile:       tlsConfig.Cert,
+		KeyFile:        tlsConfig.Key,
+		TrustedCAFile:  tlsConfig.CACert,
+		ClientCertAuth: true,
+	}
+}
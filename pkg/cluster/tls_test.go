@@ -0,0 +1,165 @@
+package cluster
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePEM(t *testing.T, path string, blockType string, bytes []byte) {
+	err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600)
+	assert.Nil(t, err)
+}
+
+func createTestCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "colonies-cluster-ca"},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+
+	return cert, key, der
+}
+
+// createTestTLSConfig creates a CA and a node certificate valid for localhost, signed by the CA
+func createTestTLSConfig(t *testing.T, dir string, name string) *TLSConfig {
+	caCert, caKey, caDER := createTestCA(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	tlsConfig := CreateTLSConfig(filepath.Join(dir, name+"-ca.pem"), filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem"))
+	writePEM(t, tlsConfig.CACert, "CERTIFICATE", caDER)
+	writePEM(t, tlsConfig.Cert, "CERTIFICATE", der)
+	writePEM(t, tlsConfig.Key, "EC PRIVATE KEY", keyDER)
+
+	return tlsConfig
+}
+
+func TestTLSConfig(t *testing.T) {
+	tlsConfig := createTestTLSConfig(t, t.TempDir(), "node1")
+
+	serverTLSConfig, err := tlsConfig.ServerConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, serverTLSConfig.ClientAuth)
+
+	clientTLSConfig, err := tlsConfig.ClientConfig()
+	assert.Nil(t, err)
+	assert.NotNil(t, clientTLSConfig.RootCAs)
+	assert.Len(t, clientTLSConfig.Certificates, 1)
+
+	_, err = CreateTLSConfig(tlsConfig.CACert, tlsConfig.Cert, "").ServerConfig()
+	assert.NotNil(t, err)
+
+	_, err = CreateTLSConfig(tlsConfig.Cert+"_missing", tlsConfig.Cert, tlsConfig.Key).ClientConfig()
+	assert.NotNil(t, err)
+
+	// A key file is not a CA certificate
+	_, err = CreateTLSConfig(tlsConfig.Key, tlsConfig.Cert, tlsConfig.Key).ClientConfig()
+	assert.NotNil(t, err)
+
+	config := Config{}
+	assert.Equal(t, "http", config.Scheme())
+	config.TLS = tlsConfig
+	assert.Equal(t, "https", config.Scheme())
+}
+
+func TestRelayServerTLS(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	gin.DefaultWriter = ioutil.Discard
+
+	dir := t.TempDir()
+	tlsConfig := createTestTLSConfig(t, dir, "node")
+
+	node1 := Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24600, EtcdPeerPort: 23600, RelayPort: 25600, APIPort: 26600}
+	node2 := Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24700, EtcdPeerPort: 23700, RelayPort: 25700, APIPort: 26700}
+
+	config := Config{TLS: tlsConfig}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	relayServer1 := CreateRelayServer(node1, config)
+	relayServer2 := CreateRelayServer(node2, config)
+	defer relayServer1.Shutdown()
+	defer relayServer2.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	go func() {
+		err := relayServer1.Broadcast(context.Background(), []byte("relayserver1"))
+		assert.Nil(t, err)
+	}()
+
+	select {
+	case msg := <-relayServer2.Receive():
+		assert.Equal(t, "relayserver1", string(msg))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Relayed message was not received")
+	}
+
+	url := "://localhost:" + strconv.Itoa(node2.RelayPort) + "/relay"
+
+	// Plain HTTP is not accepted
+	resp, err := http.Post("http"+url, "text/plain", nil)
+	if err == nil {
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	// A client without a certificate is not accepted
+	clientTLSConfig, err := tlsConfig.ClientConfig()
+	assert.Nil(t, err)
+	clientTLSConfig.Certificates = nil
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}}
+	_, err = client.Post("https"+url, "text/plain", nil)
+	assert.NotNil(t, err)
+
+	// A client with a certificate signed by another CA is not accepted
+	otherTLSConfig, err := createTestTLSConfig(t, dir, "other").ClientConfig()
+	assert.Nil(t, err)
+	otherTLSConfig.RootCAs = clientTLSConfig.RootCAs
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: otherTLSConfig}}
+	_, err = client.Post("https"+url, "text/plain", nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/security/crypto"
+)
+
+const AUDIT_SUCCESS = "success"
+const AUDIT_FAILURE = "failure"
+
+// An AuditEntry records a mutating RPC call. Entries are hash-chained, i.e. the hash of an entry covers the hash
+// of the previous entry, so modifying or removing an entry breaks the chain. CallerID is the Id the call was
+// authorized as, which is the root issuer if the call was sent using a delegation, and SignerID is the Id of the
+// key that signed the call.
+type AuditEntry struct {
+	Seq         int64     `json:"seq"`
+	Time        time.Time `json:"time"`
+	CallerID    string    `json:"callerid"`
+	SignerID    string    `json:"signerid"`
+	PayloadType string    `json:"payloadtype"`
+	TargetIDs   []string  `json:"targetids"`
+	Status      int       `json:"status"`
+	Result      string    `json:"result"`
+	PrevHash    string    `json:"prevhash"`
+	Hash        string    `json:"hash"`
+}
+
+func CreateAuditEntry(callerID string, signerID string, payloadType string, targetIDs []string, status int) *AuditEntry {
+	result := AUDIT_SUCCESS
+	if status >= 400 {
+		result = AUDIT_FAILURE
+	}
+	if targetIDs == nil {
+		targetIDs = []string{}
+	}
+
+	return &AuditEntry{CallerID: callerID, SignerID: signerID, PayloadType: payloadType, TargetIDs: targetIDs, Status: status, Result: result}
+}
+
+func ConvertJSONToAuditEntryArray(jsonString string) ([]*AuditEntry, error) {
+	var auditEntries []*AuditEntry
+	err := json.Unmarshal([]byte(jsonString), &auditEntries)
+	if err != nil {
+		return auditEntries, err
+	}
+
+	return auditEntries, nil
+}
+
+func ConvertAuditEntryArrayToJSON(auditEntries []*AuditEntry) (string, error) {
+	jsonBytes, err := json.MarshalIndent(auditEntries, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+// CalcHash calculates the hash of the entry, which covers all fields except the hash itself
+func (auditEntry *AuditEntry) CalcHash() string {
+	data := strconv.FormatInt(auditEntry.Seq, 10) + "|" +
+		auditEntry.Time.UTC().Format(time.RFC3339Nano) + "|" +
+		auditEntry.CallerID + "|" +
+		auditEntry.SignerID + "|" +
+		auditEntry.PayloadType + "|" +
+		strings.Join(auditEntry.TargetIDs, ",") + "|" +
+		strconv.Itoa(auditEntry.Status) + "|" +
+		auditEntry.Result + "|" +
+		auditEntry.PrevHash
+
+	return crypto.CreateCrypto().GenerateHash(data)
+}
+
+// Chain sets the sequence number, the previous hash and the hash of the entry so that it follows prev, prev is nil
+// for the first entry in the log
+func (auditEntry *AuditEntry) Chain(prev *AuditEntry) {
+	if prev == nil {
+		auditEntry.Seq = 1
+		auditEntry.PrevHash = ""
+	} else {
+		auditEntry.Seq = prev.Seq + 1
+		auditEntry.PrevHash = prev.Hash
+	}
+
+	auditEntry.Hash = auditEntry.CalcHash()
+}
+
+// VerifyAuditChain verifies the hash of every entry and that entries with consecutive sequence numbers are linked,
+// the entries are expected to be ordered by sequence number
+func VerifyAuditChain(auditEntries []*AuditEntry) error {
+	for i, auditEntry := range auditEntries {
+		if auditEntry.CalcHash() != auditEntry.Hash {
+			return errors.New("Audit entry <" + strconv.FormatInt(auditEntry.Seq, 10) + "> has been modified, hash mismatch")
+		}
+
+		if auditEntry.Seq == 1 && auditEntry.PrevHash != "" {
+			return errors.New("Audit entry <1> is not the first entry in the log")
+		}
+
+		if i > 0 {
+			prev := auditEntries[i-1]
+			if auditEntry.Seq <= prev.Seq {
+				return errors.New("Audit entries are not ordered by sequence number")
+			}
+			if auditEntry.Seq == prev.Seq+1 && auditEntry.PrevHash != prev.Hash {
+				return errors.New("Audit entry <" + strconv.FormatInt(auditEntry.Seq, 10) + "> is not linked to the previous entry")
+			}
+		}
+	}
+
+	return nil
+}
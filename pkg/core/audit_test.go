@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestAuditChain(length int) []*AuditEntry {
+	var auditEntries []*AuditEntry
+	var prev *AuditEntry
+	for i := 0; i < length; i++ {
+		auditEntry := CreateAuditEntry(GenerateRandomID(), GenerateRandomID(), "addcolonymsg", []string{GenerateRandomID()}, 200)
+		auditEntry.Time = time.Now()
+		auditEntry.Chain(prev)
+		auditEntries = append(auditEntries, auditEntry)
+		prev = auditEntry
+	}
+
+	return auditEntries
+}
+
+func TestCreateAuditEntry(t *testing.T) {
+	auditEntry := CreateAuditEntry(GenerateRandomID(), GenerateRandomID(), "addcolonymsg", nil, 200)
+	assert.Equal(t, AUDIT_SUCCESS, auditEntry.Result)
+	assert.NotNil(t, auditEntry.TargetIDs)
+
+	auditEntry = CreateAuditEntry(GenerateRandomID(), GenerateRandomID(), "addcolonymsg", nil, 403)
+	assert.Equal(t, AUDIT_FAILURE, auditEntry.Result)
+}
+
+func TestAuditEntryArrayToJSON(t *testing.T) {
+	auditEntries := createTestAuditChain(3)
+
+	jsonString, err := ConvertAuditEntryArrayToJSON(auditEntries)
+	assert.Nil(t, err)
+
+	_, err = ConvertJSONToAuditEntryArray(jsonString + "error")
+	assert.NotNil(t, err)
+
+	auditEntries2, err := ConvertJSONToAuditEntryArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries2, 3)
+	assert.Nil(t, VerifyAuditChain(auditEntries2))
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	auditEntries := createTestAuditChain(5)
+	assert.Equal(t, int64(1), auditEntries[0].Seq)
+	assert.Equal(t, "", auditEntries[0].PrevHash)
+	assert.Equal(t, auditEntries[0].Hash, auditEntries[1].PrevHash)
+	assert.Nil(t, VerifyAuditChain(auditEntries))
+
+	// A part of the chain can also be verified
+	assert.Nil(t, VerifyAuditChain(auditEntries[2:]))
+
+	// Modified entry
+	auditEntries = createTestAuditChain(5)
+	auditEntries[2].CallerID = GenerateRandomID()
+	assert.NotNil(t, VerifyAuditChain(auditEntries))
+
+	auditEntries = createTestAuditChain(5)
+	auditEntries[2].SignerID = GenerateRandomID()
+	assert.NotNil(t, VerifyAuditChain(auditEntries))
+
+	// Modified entry with a recalculated hash
+	auditEntries = createTestAuditChain(5)
+	auditEntries[2].Status = 403
+	auditEntries[2].Hash = auditEntries[2].CalcHash()
+	assert.NotNil(t, VerifyAuditChain(auditEntries))
+
+	// Removed entry, with the following entries renumbered and rehashed
+	auditEntries = createTestAuditChain(5)
+	auditEntries = append(auditEntries[:2], auditEntries[3:]...)
+	for i := 2; i < len(auditEntries); i++ {
+		auditEntries[i].Seq--
+		auditEntries[i].Hash = auditEntries[i].CalcHash()
+	}
+	assert.NotNil(t, VerifyAuditChain(auditEntries))
+}
@@ -5,12 +5,14 @@ import (
 )
 
 type Colony struct {
-	ID   string `json:"colonyid"`
-	Name string `json:"name"`
+	ID      string `json:"colonyid"`
+	Name    string `json:"name"`
+	OwnerID string `json:"ownerid"`
 }
 
+// CreateColony creates a new colony, the colony is initially owned by the key from which the colony Id was derived
 func CreateColony(id string, name string) *Colony {
-	colony := &Colony{ID: id, Name: name}
+	colony := &Colony{ID: id, Name: name, OwnerID: id}
 
 	return colony
 }
@@ -72,7 +74,8 @@ func (colony *Colony) Equals(colony2 *Colony) bool {
 	}
 
 	if colony.ID == colony2.ID &&
-		colony.Name == colony2.Name {
+		colony.Name == colony2.Name &&
+		colony.OwnerID == colony2.OwnerID {
 		return true
 	}
 
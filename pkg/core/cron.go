@@ -6,16 +6,17 @@ import (
 )
 
 type Cron struct {
-	ID                 string    `json:"cronid"`
-	ColonyID           string    `json:"colonyid"`
-	Name               string    `json:"name"`
-	CronExpression     string    `json:"cronexpression"`
-	Interval           int       `json:"interval"`
-	Random             bool      `json:"random"`
-	NextRun            time.Time `json:"nextrun"`
-	LastRun            time.Time `json:"lastrun"`
-	WorkflowSpec       string    `json:"workflowspec"`
-	LastProcessGraphID string    `json:"lastprocessgraphid"`
+	ID                 string               `json:"cronid"`
+	ColonyID           string               `json:"colonyid"`
+	Name               string               `json:"name"`
+	CronExpression     string               `json:"cronexpression"`
+	Interval           int                  `json:"interval"`
+	Random             bool                 `json:"random"`
+	NextRun            time.Time            `json:"nextrun"`
+	LastRun            time.Time            `json:"lastrun"`
+	WorkflowSpec       string               `json:"workflowspec"`
+	LastProcessGraphID string               `json:"lastprocessgraphid"`
+	WorkflowTemplate   *WorkflowTemplateRef `json:"workflowtemplate"`
 }
 
 func CreateCron(colonyID string, name string, cronExpression string, interval int, random bool, workflowSpec string) *Cron {
@@ -87,7 +88,8 @@ func (cron *Cron) Equals(cron2 *Cron) bool {
 		cron.NextRun.Unix() != cron2.NextRun.Unix() ||
 		cron.LastRun.Unix() != cron2.LastRun.Unix() ||
 		cron.WorkflowSpec != cron2.WorkflowSpec ||
-		cron.LastProcessGraphID != cron2.LastProcessGraphID {
+		cron.LastProcessGraphID != cron2.LastProcessGraphID ||
+		!cron.WorkflowTemplate.Equals(cron2.WorkflowTemplate) {
 		same = false
 	}
 
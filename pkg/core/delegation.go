@@ -0,0 +1,216 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/security/crypto"
+)
+
+const MAX_DELEGATION_CHAIN_LENGTH = 4
+
+// A Delegation is a certificate signed by an issuer allowing another key, the delegate, to send RPC messages on
+// behalf of the issuer. The delegate is restricted to a colony and a set of payload types, and optionally to a set
+// of functions and runtime types when submitting processes. A delegate can delegate further, but only a subset of
+// its own scope.
+type Delegation struct {
+	IssuerID     string    `json:"issuerid"`
+	DelegateID   string    `json:"delegateid"`
+	ColonyID     string    `json:"colonyid"`
+	PayloadTypes []string  `json:"payloadtypes"`
+	Funcs        []string  `json:"funcs"`
+	RuntimeTypes []string  `json:"runtimetypes"`
+	Expires      time.Time `json:"expires"`
+	Signature    string    `json:"signature"`
+}
+
+func CreateDelegation(delegateID string, colonyID string, payloadTypes []string, funcs []string, runtimeTypes []string, expires time.Time) *Delegation {
+	if funcs == nil {
+		funcs = []string{}
+	}
+	if runtimeTypes == nil {
+		runtimeTypes = []string{}
+	}
+
+	return &Delegation{DelegateID: delegateID,
+		ColonyID:     colonyID,
+		PayloadTypes: payloadTypes,
+		Funcs:        funcs,
+		RuntimeTypes: runtimeTypes,
+		Expires:      expires.UTC().Truncate(time.Second),
+	}
+}
+
+func ConvertJSONToDelegationArray(jsonString string) ([]*Delegation, error) {
+	var delegations []*Delegation
+	err := json.Unmarshal([]byte(jsonString), &delegations)
+	if err != nil {
+		return delegations, err
+	}
+
+	return delegations, nil
+}
+
+func ConvertDelegationArrayToJSON(delegations []*Delegation) (string, error) {
+	jsonBytes, err := json.MarshalIndent(delegations, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+// SignedData returns the data covered by the signature, i.e. all fields except the signature itself
+func (delegation *Delegation) SignedData() string {
+	return delegation.IssuerID + "|" +
+		delegation.DelegateID + "|" +
+		delegation.ColonyID + "|" +
+		strings.Join(delegation.PayloadTypes, ",") + "|" +
+		strings.Join(delegation.Funcs, ",") + "|" +
+		strings.Join(delegation.RuntimeTypes, ",") + "|" +
+		delegation.Expires.UTC().Format(time.RFC3339)
+}
+
+// Sign sets the issuer to the Id of prvKey and signs the delegation
+func (delegation *Delegation) Sign(prvKey string) error {
+	c := crypto.CreateCrypto()
+	issuerID, err := c.GenerateID(prvKey)
+	if err != nil {
+		return err
+	}
+	delegation.IssuerID = issuerID
+
+	signature, err := c.GenerateSignature(delegation.SignedData(), prvKey)
+	if err != nil {
+		return err
+	}
+	delegation.Signature = signature
+
+	return nil
+}
+
+// Verify verifies that the delegation was signed by the issuer
+func (delegation *Delegation) Verify() error {
+	recoveredID, err := crypto.CreateCrypto().RecoverID(delegation.SignedData(), delegation.Signature)
+	if err != nil {
+		return err
+	}
+	if recoveredID != delegation.IssuerID {
+		return errors.New("Invalid delegation, not signed by issuer with Id <" + delegation.IssuerID + ">")
+	}
+
+	return nil
+}
+
+func (delegation *Delegation) AllowsPayloadType(payloadType string) bool {
+	return contains(delegation.PayloadTypes, payloadType)
+}
+
+// AllowsFunc returns true if the delegate may submit processes executing fn, all functions are allowed if no
+// functions are specified
+func (delegation *Delegation) AllowsFunc(fn string) bool {
+	return len(delegation.Funcs) == 0 || contains(delegation.Funcs, fn)
+}
+
+// AllowsRuntimeType returns true if the delegate may submit processes to runtimeType, all runtime types are
+// allowed if no runtime types are specified
+func (delegation *Delegation) AllowsRuntimeType(runtimeType string) bool {
+	return len(delegation.RuntimeTypes) == 0 || contains(delegation.RuntimeTypes, runtimeType)
+}
+
+// IsRestricted returns true if the delegation restricts functions or runtime types
+func (delegation *Delegation) IsRestricted() bool {
+	return len(delegation.Funcs) > 0 || len(delegation.RuntimeTypes) > 0
+}
+
+// isSubsetOf returns true if the scope of the delegation does not exceed the scope of parent
+func (delegation *Delegation) isSubsetOf(parent *Delegation) bool {
+	if delegation.ColonyID != parent.ColonyID || delegation.Expires.After(parent.Expires) {
+		return false
+	}
+
+	for _, payloadType := range delegation.PayloadTypes {
+		if !parent.AllowsPayloadType(payloadType) {
+			return false
+		}
+	}
+
+	if len(parent.Funcs) > 0 {
+		if len(delegation.Funcs) == 0 {
+			return false
+		}
+		for _, fn := range delegation.Funcs {
+			if !parent.AllowsFunc(fn) {
+				return false
+			}
+		}
+	}
+
+	if len(parent.RuntimeTypes) > 0 {
+		if len(delegation.RuntimeTypes) == 0 {
+			return false
+		}
+		for _, runtimeType := range delegation.RuntimeTypes {
+			if !parent.AllowsRuntimeType(runtimeType) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// VerifyDelegationChain verifies a delegation chain starting at the root issuer and ending at signerID, i.e. the
+// Id of the key that signed the RPC message. Every delegation must be signed by the delegate of the previous
+// delegation, must not have expired, and must not widen the scope of the previous delegation.
+func VerifyDelegationChain(chain []*Delegation, signerID string, now time.Time) error {
+	if len(chain) == 0 {
+		return errors.New("Invalid delegation chain, chain is empty")
+	}
+	if len(chain) > MAX_DELEGATION_CHAIN_LENGTH {
+		return errors.New("Invalid delegation chain, chain is too long")
+	}
+
+	for i, delegation := range chain {
+		if delegation == nil {
+			return errors.New("Invalid delegation chain, delegation is nil")
+		}
+
+		err := delegation.Verify()
+		if err != nil {
+			return err
+		}
+
+		if now.After(delegation.Expires) {
+			return errors.New("Invalid delegation chain, delegation to <" + delegation.DelegateID + "> has expired")
+		}
+
+		if i > 0 {
+			parent := chain[i-1]
+			if delegation.IssuerID != parent.DelegateID {
+				return errors.New("Invalid delegation chain, delegation is not issued by the previous delegate")
+			}
+			if !delegation.isSubsetOf(parent) {
+				return errors.New("Invalid delegation chain, delegation to <" + delegation.DelegateID + "> exceeds the scope of its issuer")
+			}
+		}
+	}
+
+	if chain[len(chain)-1].DelegateID != signerID {
+		return errors.New("Invalid delegation chain, RPC message is not signed by the delegate")
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
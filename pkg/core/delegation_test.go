@@ -0,0 +1,114 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestKey(t *testing.T) (string, string) {
+	c := crypto.CreateCrypto()
+	prvKey, err := c.GeneratePrivateKey()
+	assert.Nil(t, err)
+	id, err := c.GenerateID(prvKey)
+	assert.Nil(t, err)
+
+	return id, prvKey
+}
+
+func TestDelegationSign(t *testing.T) {
+	_, issuerPrvKey := generateTestKey(t)
+	delegateID, _ := generateTestKey(t)
+
+	delegation := CreateDelegation(delegateID, GenerateRandomID(), []string{"submitprocessspecmsg"}, []string{"echo"}, nil, time.Now().Add(time.Hour))
+	err := delegation.Sign(issuerPrvKey)
+	assert.Nil(t, err)
+	assert.Nil(t, delegation.Verify())
+
+	delegation.Funcs = append(delegation.Funcs, "rm")
+	assert.NotNil(t, delegation.Verify())
+}
+
+func TestDelegationArrayToJSON(t *testing.T) {
+	_, issuerPrvKey := generateTestKey(t)
+	delegateID, _ := generateTestKey(t)
+
+	delegation := CreateDelegation(delegateID, GenerateRandomID(), []string{"submitprocessspecmsg"}, nil, []string{"cli"}, time.Now().Add(time.Hour))
+	err := delegation.Sign(issuerPrvKey)
+	assert.Nil(t, err)
+
+	jsonString, err := ConvertDelegationArrayToJSON([]*Delegation{delegation})
+	assert.Nil(t, err)
+
+	_, err = ConvertJSONToDelegationArray(jsonString + "error")
+	assert.NotNil(t, err)
+
+	delegations, err := ConvertJSONToDelegationArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, delegations, 1)
+	assert.Nil(t, delegations[0].Verify())
+}
+
+func TestDelegationScope(t *testing.T) {
+	delegation := CreateDelegation(GenerateRandomID(), GenerateRandomID(), []string{"submitprocessspecmsg"}, nil, nil, time.Now().Add(time.Hour))
+	assert.True(t, delegation.AllowsPayloadType("submitprocessspecmsg"))
+	assert.False(t, delegation.AllowsPayloadType("deleteprocessmsg"))
+	assert.True(t, delegation.AllowsFunc("echo"))
+	assert.True(t, delegation.AllowsRuntimeType("cli"))
+	assert.False(t, delegation.IsRestricted())
+
+	delegation = CreateDelegation(GenerateRandomID(), GenerateRandomID(), []string{"submitprocessspecmsg"}, []string{"echo"}, []string{"cli"}, time.Now().Add(time.Hour))
+	assert.True(t, delegation.AllowsFunc("echo"))
+	assert.False(t, delegation.AllowsFunc("rm"))
+	assert.True(t, delegation.AllowsRuntimeType("cli"))
+	assert.False(t, delegation.AllowsRuntimeType("gpu"))
+	assert.True(t, delegation.IsRestricted())
+}
+
+func TestVerifyDelegationChain(t *testing.T) {
+	_, rootPrvKey := generateTestKey(t)
+	delegate1ID, delegate1PrvKey := generateTestKey(t)
+	delegate2ID, _ := generateTestKey(t)
+	colonyID := GenerateRandomID()
+	expires := time.Now().Add(time.Hour)
+
+	delegation1 := CreateDelegation(delegate1ID, colonyID, []string{"submitprocessspecmsg", "getprocessmsg"}, []string{"echo", "sleep"}, nil, expires)
+	assert.Nil(t, delegation1.Sign(rootPrvKey))
+
+	delegation2 := CreateDelegation(delegate2ID, colonyID, []string{"submitprocessspecmsg"}, []string{"echo"}, nil, expires.Add(-time.Minute))
+	assert.Nil(t, delegation2.Sign(delegate1PrvKey))
+
+	assert.Nil(t, VerifyDelegationChain([]*Delegation{delegation1}, delegate1ID, time.Now()))
+	assert.Nil(t, VerifyDelegationChain([]*Delegation{delegation1, delegation2}, delegate2ID, time.Now()))
+
+	// Not signed by the delegate
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation1}, delegate2ID, time.Now()))
+
+	// Empty chain
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{}, delegate1ID, time.Now()))
+
+	// Expired
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation1}, delegate1ID, time.Now().Add(2*time.Hour)))
+
+	// Not linked
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation2, delegation1}, delegate1ID, time.Now()))
+
+	// Widened scope
+	widened := CreateDelegation(delegate2ID, colonyID, []string{"submitprocessspecmsg", "deleteprocessmsg"}, []string{"echo"}, nil, expires)
+	assert.Nil(t, widened.Sign(delegate1PrvKey))
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation1, widened}, delegate2ID, time.Now()))
+
+	widened = CreateDelegation(delegate2ID, colonyID, []string{"submitprocessspecmsg"}, nil, nil, expires)
+	assert.Nil(t, widened.Sign(delegate1PrvKey))
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation1, widened}, delegate2ID, time.Now()))
+
+	widened = CreateDelegation(delegate2ID, GenerateRandomID(), []string{"submitprocessspecmsg"}, []string{"echo"}, nil, expires)
+	assert.Nil(t, widened.Sign(delegate1PrvKey))
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation1, widened}, delegate2ID, time.Now()))
+
+	widened = CreateDelegation(delegate2ID, colonyID, []string{"submitprocessspecmsg"}, []string{"echo"}, nil, expires.Add(time.Hour))
+	assert.Nil(t, widened.Sign(delegate1PrvKey))
+	assert.NotNil(t, VerifyDelegationChain([]*Delegation{delegation1, widened}, delegate2ID, time.Now()))
+}
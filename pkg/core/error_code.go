@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error codes are sent in a Failure and identify why a request failed, unlike the message they never change, so
+// clients should check the code rather than parse the message
+const (
+	ErrorCodeBadRequest       = "badrequest"       // The RPC message could not be parsed
+	ErrorCodeValidationFailed = "validationfailed" // The RPC message was parsed, but contains invalid values
+	ErrorCodeForbidden        = "forbidden"        // The signer is not allowed to perform the operation
+	ErrorCodeNotFound         = "notfound"         // A referenced colony, runtime, process etc. does not exist
+	ErrorCodeConflict         = "conflict"         // The operation conflicts with the current state, e.g. a duplicate name
+	ErrorCodeNoWorkAvailable  = "noworkavailable"  // No process could be assigned before the timeout
+	ErrorCodeTooManyRequests  = "toomanyrequests"  // A limit has been reached, the request may be retried later
+	ErrorCodeInternal         = "internal"         // The server failed to handle a valid request
+)
+
+// CodedError attaches an error code to an error, it lets the database, the planner and the controller decide the
+// code the server replies with
+type CodedError struct {
+	Code string
+	Err  error
+}
+
+func (err *CodedError) Error() string {
+	return err.Err.Error()
+}
+
+func (err *CodedError) Unwrap() error {
+	return err.Err
+}
+
+// WithErrorCode returns err with an error code attached, or nil if err is nil
+func WithErrorCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &CodedError{Code: code, Err: err}
+}
+
+// ErrorCodeOf returns the error code attached to err using WithErrorCode, or an empty string if there is none
+func ErrorCodeOf(err error) string {
+	var codedErr *CodedError
+	if errors.As(err, &codedErr) {
+		return codedErr.Code
+	}
+
+	return ""
+}
+
+// ErrorCodeStatus returns the HTTP status used when replying with code
+func ErrorCodeStatus(code string) int {
+	switch code {
+	case ErrorCodeBadRequest, ErrorCodeValidationFailed:
+		return http.StatusBadRequest
+	case ErrorCodeForbidden:
+		return http.StatusForbidden
+	case ErrorCodeNotFound, ErrorCodeNoWorkAvailable:
+		return http.StatusNotFound
+	case ErrorCodeConflict:
+		return http.StatusConflict
+	case ErrorCodeTooManyRequests:
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ErrorStatus returns the HTTP status of the code attached to err, or status if err has no code
+func ErrorStatus(err error, status int) int {
+	if code := ErrorCodeOf(err); code != "" {
+		return ErrorCodeStatus(code)
+	}
+
+	return status
+}
+
+// ErrorCodeFromStatus returns the error code used for errors without an attached code
+func ErrorCodeFromStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrorCodeTooManyRequests
+	}
+
+	if status >= 400 && status < 500 {
+		return ErrorCodeBadRequest
+	}
+
+	return ErrorCodeInternal
+}
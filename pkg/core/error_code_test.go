@@ -0,0 +1,42 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithErrorCode(t *testing.T) {
+	assert.Nil(t, WithErrorCode(nil, ErrorCodeConflict))
+
+	err := errors.New("error_msg")
+	codedErr := WithErrorCode(err, ErrorCodeConflict)
+	assert.Equal(t, "error_msg", codedErr.Error())
+	assert.True(t, errors.Is(codedErr, err))
+	assert.Equal(t, ErrorCodeConflict, ErrorCodeOf(codedErr))
+	assert.Equal(t, ErrorCodeConflict, ErrorCodeOf(fmt.Errorf("wrapped: %w", codedErr)))
+	assert.Equal(t, "", ErrorCodeOf(err))
+	assert.Equal(t, "", ErrorCodeOf(nil))
+}
+
+func TestErrorCodeFromStatus(t *testing.T) {
+	assert.Equal(t, ErrorCodeBadRequest, ErrorCodeFromStatus(http.StatusBadRequest))
+	assert.Equal(t, ErrorCodeForbidden, ErrorCodeFromStatus(http.StatusForbidden))
+	assert.Equal(t, ErrorCodeNotFound, ErrorCodeFromStatus(http.StatusNotFound))
+	assert.Equal(t, ErrorCodeConflict, ErrorCodeFromStatus(http.StatusConflict))
+	assert.Equal(t, ErrorCodeTooManyRequests, ErrorCodeFromStatus(http.StatusTooManyRequests))
+	assert.Equal(t, ErrorCodeBadRequest, ErrorCodeFromStatus(http.StatusMethodNotAllowed))
+	assert.Equal(t, ErrorCodeInternal, ErrorCodeFromStatus(http.StatusInternalServerError))
+}
+
+func TestErrorStatus(t *testing.T) {
+	err := errors.New("error_msg")
+	assert.Equal(t, http.StatusBadRequest, ErrorStatus(err, http.StatusBadRequest))
+	assert.Equal(t, http.StatusNotFound, ErrorStatus(WithErrorCode(err, ErrorCodeNotFound), http.StatusBadRequest))
+	assert.Equal(t, http.StatusNotFound, ErrorStatus(WithErrorCode(err, ErrorCodeNoWorkAvailable), http.StatusBadRequest))
+	assert.Equal(t, http.StatusBadRequest, ErrorStatus(WithErrorCode(err, ErrorCodeValidationFailed), http.StatusInternalServerError))
+	assert.Equal(t, http.StatusConflict, ErrorStatus(WithErrorCode(err, ErrorCodeConflict), http.StatusBadRequest))
+}
@@ -6,11 +6,21 @@ import (
 
 type Failure struct {
 	Status  int    `json:"status"`
+	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
 func CreateFailure(status int, message string) *Failure {
-	return &Failure{Status: status, Message: message}
+	return &Failure{Status: status, Code: ErrorCodeFromStatus(status), Message: message}
+}
+
+// CreateFailureFromError creates a Failure from the error code attached to err, or from status if err has no code
+func CreateFailureFromError(status int, err error) *Failure {
+	if code := ErrorCodeOf(err); code != "" {
+		return &Failure{Status: ErrorCodeStatus(code), Code: code, Message: err.Error()}
+	}
+
+	return CreateFailure(status, err.Error())
 }
 
 func ConvertJSONToFailure(jsonString string) (*Failure, error) {
@@ -20,6 +30,11 @@ func ConvertJSONToFailure(jsonString string) (*Failure, error) {
 		return nil, err
 	}
 
+	// Failures from older servers have no code
+	if failure != nil && failure.Code == "" {
+		failure.Code = ErrorCodeFromStatus(failure.Status)
+	}
+
 	return failure, nil
 }
 
@@ -29,6 +44,7 @@ func (failure *Failure) Equals(failure2 *Failure) bool {
 	}
 
 	if failure.Status == failure2.Status &&
+		failure.Code == failure2.Code &&
 		failure.Message == failure2.Message {
 		return true
 	}
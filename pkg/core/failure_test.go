@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -41,3 +42,21 @@ func TestFailureParseJSON(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, failure2.Equals(failure1))
 }
+
+func TestCreateFailureFromError(t *testing.T) {
+	failure := CreateFailureFromError(404, errors.New("error_msg"))
+	assert.Equal(t, 404, failure.Status)
+	assert.Equal(t, ErrorCodeNotFound, failure.Code)
+	assert.Equal(t, "error_msg", failure.Message)
+
+	failure = CreateFailureFromError(400, WithErrorCode(errors.New("error_msg"), ErrorCodeNoWorkAvailable))
+	assert.Equal(t, 404, failure.Status)
+	assert.Equal(t, ErrorCodeNoWorkAvailable, failure.Code)
+	assert.Equal(t, "error_msg", failure.Message)
+}
+
+func TestFailureWithoutCode(t *testing.T) {
+	failure, err := ConvertJSONToFailure(`{"status":403,"message":"error_msg"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrorCodeForbidden, failure.Code)
+}
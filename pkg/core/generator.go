@@ -6,12 +6,15 @@ import (
 )
 
 type Generator struct {
-	ID           string    `json:"generatorid"`
-	ColonyID     string    `json:"colonyid"`
-	Name         string    `json:"name"`
-	WorkflowSpec string    `json:"workflowspec"`
-	Trigger      int       `json:"trigger"`
-	LastRun      time.Time `json:"lastrun"`
+	ID               string               `json:"generatorid"`
+	ColonyID         string               `json:"colonyid"`
+	Name             string               `json:"name"`
+	WorkflowSpec     string               `json:"workflowspec"`
+	Trigger          int                  `json:"trigger"`
+	LastRun          time.Time            `json:"lastrun"`
+	WebhookSecret    string               `json:"webhooksecret"`  // Only accepted when the generator is added, never returned
+	WebhookEnabled   bool                 `json:"webhookenabled"` // Set in replies if the generator has a webhook secret
+	WorkflowTemplate *WorkflowTemplateRef `json:"workflowtemplate"`
 }
 
 func CreateGenerator(colonyID string, name string, workflowSpec string, trigger int) *Generator {
@@ -85,7 +88,10 @@ func (generator *Generator) Equals(generator2 *Generator) bool {
 		generator.ColonyID != generator2.ColonyID ||
 		generator.Name != generator2.Name ||
 		generator.WorkflowSpec != generator2.WorkflowSpec ||
-		generator.Trigger != generator2.Trigger {
+		generator.Trigger != generator2.Trigger ||
+		generator.WebhookSecret != generator2.WebhookSecret ||
+		generator.WebhookEnabled != generator2.WebhookEnabled ||
+		!generator.WorkflowTemplate.Equals(generator2.WorkflowTemplate) {
 		same = false
 	}
 
@@ -1,10 +1,24 @@
 package core
 
 import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/google/uuid"
 )
 
+// Key of the IN attribute holding the generator args, encoded as a JSON array, attached to all root processes
+const GENERATOR_ARGS_KEY = "generatorargs"
+
+// An arg in a process spec equal to GENERATOR_ARGS_TEMPLATE is expanded to all generator args,
+// and {{generatorargs[i]}} is substituted with the i:th generator arg
+const GENERATOR_ARGS_TEMPLATE = "{{generatorargs}}"
+
+var generatorArgIndexRegexp = regexp.MustCompile(`\{\{generatorargs\[(\d+)\]\}\}`)
+
 type GeneratorArg struct {
 	ID          string
 	GeneratorID string
@@ -26,3 +40,56 @@ func CreateGeneratorArg(generatorID string, colonyID string, arg string) *Genera
 
 	return generatorArg
 }
+
+func ConvertGeneratorArgsToJSON(generatorArgs []string) (string, error) {
+	if generatorArgs == nil {
+		generatorArgs = []string{}
+	}
+
+	jsonBytes, err := json.Marshal(generatorArgs)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToGeneratorArgs(jsonString string) ([]string, error) {
+	var generatorArgs []string
+	err := json.Unmarshal([]byte(jsonString), &generatorArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return generatorArgs, nil
+}
+
+// SubstituteGeneratorArgs substitutes the generator args into args, an error is returned if an arg refers to a
+// generator arg that does not exist
+func SubstituteGeneratorArgs(args []string, generatorArgs []string) ([]string, error) {
+	substitutedArgs := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == GENERATOR_ARGS_TEMPLATE {
+			substitutedArgs = append(substitutedArgs, generatorArgs...)
+			continue
+		}
+
+		var err error
+		substitutedArg := generatorArgIndexRegexp.ReplaceAllStringFunc(arg, func(match string) string {
+			index, convErr := strconv.Atoi(generatorArgIndexRegexp.FindStringSubmatch(match)[1])
+			if convErr != nil || index >= len(generatorArgs) {
+				if err == nil {
+					err = WithErrorCode(errors.New("Failed to substitute "+match+", only "+strconv.Itoa(len(generatorArgs))+" generator args"), ErrorCodeValidationFailed)
+				}
+				return match
+			}
+			return generatorArgs[index]
+		})
+		if err != nil {
+			return nil, err
+		}
+		substitutedArgs = append(substitutedArgs, substitutedArg)
+	}
+
+	return substitutedArgs, nil
+}
@@ -15,3 +15,51 @@ func TestCreateGeneratorArg(t *testing.T) {
 	assert.Equal(t, generatorArg.ColonyID, colonyID)
 	assert.Len(t, generatorArg.ID, 64)
 }
+
+func TestGeneratorArgsJSON(t *testing.T) {
+	jsonStr, err := ConvertGeneratorArgsToJSON([]string{"arg1", "arg 2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "[\"arg1\",\"arg 2\"]", jsonStr)
+
+	generatorArgs, err := ConvertJSONToGeneratorArgs(jsonStr)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"arg1", "arg 2"}, generatorArgs)
+
+	jsonStr, err = ConvertGeneratorArgsToJSON(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", jsonStr)
+
+	_, err = ConvertJSONToGeneratorArgs("error")
+	assert.NotNil(t, err)
+}
+
+func TestSubstituteGeneratorArgs(t *testing.T) {
+	generatorArgs := []string{"arg1", "arg2"}
+
+	args, err := SubstituteGeneratorArgs([]string{"--verbose"}, generatorArgs)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--verbose"}, args)
+
+	args, err = SubstituteGeneratorArgs([]string{"--verbose", "{{generatorargs}}", "--end"}, generatorArgs)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--verbose", "arg1", "arg2", "--end"}, args)
+
+	args, err = SubstituteGeneratorArgs([]string{"--input={{generatorargs[1]}}", "{{generatorargs[0]}}"}, generatorArgs)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--input=arg2", "arg1"}, args)
+
+	args, err = SubstituteGeneratorArgs([]string{}, generatorArgs)
+	assert.Nil(t, err)
+	assert.Len(t, args, 0)
+}
+
+func TestSubstituteGeneratorArgsOutOfRange(t *testing.T) {
+	generatorArgs := []string{"arg1", "arg2"}
+
+	_, err := SubstituteGeneratorArgs([]string{"--input={{generatorargs[2]}}"}, generatorArgs)
+	assert.NotNil(t, err)
+	assert.Equal(t, ErrorCodeValidationFailed, ErrorCodeOf(err))
+
+	_, err = SubstituteGeneratorArgs([]string{"{{generatorargs[0]}}", "{{generatorargs[99999999999999999999]}}"}, generatorArgs)
+	assert.NotNil(t, err)
+}
@@ -19,12 +19,14 @@ func TestCreateGenerator(t *testing.T) {
 	assert.Nil(t, err)
 	generator := CreateGenerator(GenerateRandomID(), "test_genname", jsonStr, 10)
 	generator.ID = GenerateRandomID()
+	generator.WebhookSecret = "test_secret"
 	jsonStr, err = generator.ToJSON()
 	assert.Nil(t, err)
 
 	generator2, err := ConvertJSONToGenerator(jsonStr)
 	assert.Nil(t, err)
 	assert.True(t, generator.Equals(generator2))
+	assert.Equal(t, "test_secret", generator2.WebhookSecret)
 
 	workflowSpec2, err := ConvertJSONToWorkflowSpec(generator2.WorkflowSpec)
 	assert.Nil(t, err)
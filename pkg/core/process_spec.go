@@ -21,6 +21,7 @@ type ProcessSpec struct {
 	MaxRetries  int               `json:"maxretries"`
 	Conditions  Conditions        `json:"conditions"`
 	Env         map[string]string `json:"env"`
+	Secrets     []string          `json:"secrets"`
 }
 
 func CreateEmptyProcessSpec() *ProcessSpec {
@@ -37,6 +38,15 @@ func CreateProcessSpec(name string, fn string, args []string, colonyID string, r
 	return &ProcessSpec{Name: name, Func: fn, Args: args, MaxWaitTime: maxWaitTime, MaxExecTime: maxExecTime, MaxRetries: maxRetries, Conditions: conditions, Env: env, Priority: priority}
 }
 
+func ConvertYAMLToProcessSpec(yamlString string) (*ProcessSpec, error) {
+	jsonString, err := ConvertYAMLToJSON(yamlString)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertJSONToProcessSpec(jsonString)
+}
+
 func ConvertJSONToProcessSpec(jsonString string) (*ProcessSpec, error) {
 	processSpec := &ProcessSpec{}
 	processSpec.Env = make(map[string]string)
@@ -128,6 +138,16 @@ func (processSpec *ProcessSpec) Equals(processSpec2 *ProcessSpec) bool {
 		}
 	}
 
+	if len(processSpec.Secrets) != len(processSpec2.Secrets) {
+		same = false
+	} else {
+		for i := range processSpec.Secrets {
+			if processSpec.Secrets[i] != processSpec2.Secrets[i] {
+				same = false
+			}
+		}
+	}
+
 	if processSpec.Env != nil && processSpec2.Env == nil {
 		same = false
 	} else if processSpec.Env == nil && processSpec2.Env != nil {
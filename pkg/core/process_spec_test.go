@@ -66,3 +66,35 @@ func TestProcessSpecEquals(t *testing.T) {
 	assert.False(t, processSpec1.Equals(nil))
 	assert.False(t, processSpec1.Equals(processSpec2))
 }
+
+func TestProcessSpecYAML(t *testing.T) {
+	yamlString := `
+# Comments are allowed in YAML specs
+name: "test_name"
+func: "test_func"
+args:
+  - "test_arg"
+maxexectime: 10
+maxretries: 3
+conditions:
+  runtimetype: "test_runtime_type"
+  dependencies:
+    - "test_name2"
+env:
+  test_key: "test_value"
+`
+	processSpec, err := ConvertYAMLToProcessSpec(yamlString)
+	assert.Nil(t, err)
+	assert.Equal(t, "test_name", processSpec.Name)
+	assert.Equal(t, "test_func", processSpec.Func)
+	assert.Equal(t, []string{"test_arg"}, processSpec.Args)
+	assert.Equal(t, 10, processSpec.MaxExecTime)
+	assert.Equal(t, 3, processSpec.MaxRetries)
+	assert.Equal(t, -1, processSpec.MaxWaitTime)
+	assert.Equal(t, "test_runtime_type", processSpec.Conditions.RuntimeType)
+	assert.Equal(t, []string{"test_name2"}, processSpec.Conditions.Dependencies)
+	assert.Equal(t, "test_value", processSpec.Env["test_key"])
+
+	_, err = ConvertYAMLToProcessSpec("name: [error")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	GRAPH_FORMAT_DOT     = "dot"
+	GRAPH_FORMAT_MERMAID = "mermaid"
+)
+
+var stateColors = map[int]string{
+	WAITING: "#d3d3d3",
+	RUNNING: "#87cefa",
+	SUCCESS: "#90ee90",
+	FAILED:  "#f08080",
+}
+
+func stateToString(state int) string {
+	switch state {
+	case WAITING:
+		return "Waiting"
+	case RUNNING:
+		return "Running"
+	case SUCCESS:
+		return "Successful"
+	case FAILED:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+func processLabel(process *Process) string {
+	name := process.ProcessSpec.Name
+	if name == "" {
+		name = process.ID
+	}
+	return name
+}
+
+// ToDOT renders the graph in Graphviz DOT format, processes must contain all processes in the graph
+func (graph *ProcessGraph) ToDOT(processes []*Process) string {
+	escape := func(str string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(str, "\\", "\\\\"), "\"", "\\\"")
+	}
+
+	processIDs := make(map[string]bool)
+	for _, process := range processes {
+		processIDs[process.ID] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph \"" + graph.ID + "\" {\n")
+	sb.WriteString("    node [shape=box, style=\"rounded,filled\"];\n")
+	for _, process := range processes {
+		sb.WriteString("    \"" + process.ID + "\" [label=\"" + escape(processLabel(process)) + "\\n" + stateToString(process.State) + "\", fillcolor=\"" + stateColors[process.State] + "\"];\n")
+	}
+	for _, process := range processes {
+		for _, childID := range process.Children {
+			if processIDs[childID] {
+				sb.WriteString("    \"" + process.ID + "\" -> \"" + childID + "\";\n")
+			}
+		}
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// ToMermaid renders the graph as a Mermaid flowchart, processes must contain all processes in the graph
+func (graph *ProcessGraph) ToMermaid(processes []*Process) string {
+	nodeIDs := make(map[string]string)
+	for i, process := range processes {
+		nodeIDs[process.ID] = "p" + strconv.Itoa(i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for _, process := range processes {
+		label := strings.ReplaceAll(processLabel(process), "\"", "#quot;")
+		sb.WriteString("    " + nodeIDs[process.ID] + "[\"" + label + "<br/>" + stateToString(process.State) + "\"]:::" + strings.ToLower(stateToString(process.State)) + "\n")
+	}
+	for _, process := range processes {
+		for _, childID := range process.Children {
+			if childNodeID, ok := nodeIDs[childID]; ok {
+				sb.WriteString("    " + nodeIDs[process.ID] + " --> " + childNodeID + "\n")
+			}
+		}
+	}
+	for _, state := range []int{WAITING, RUNNING, SUCCESS, FAILED} {
+		sb.WriteString("    classDef " + strings.ToLower(stateToString(state)) + " fill:" + stateColors[state] + "\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createRenderTestProcesses() (*ProcessGraph, []*Process) {
+	//     task1
+	//      / \
+	//  task2 task3
+
+	process1 := createProcess()
+	process1.ProcessSpec.Name = "task1"
+	process1.State = SUCCESS
+	process2 := createProcess()
+	process2.ProcessSpec.Name = "task2"
+	process2.State = RUNNING
+	process3 := createProcess()
+	process3.ProcessSpec.Name = "task\"3"
+	process3.State = FAILED
+
+	process1.AddChild(process2.ID)
+	process1.AddChild(process3.ID)
+	process2.AddParent(process1.ID)
+	process3.AddParent(process1.ID)
+
+	graph, _ := CreateProcessGraph(GenerateRandomID())
+	graph.AddRoot(process1.ID)
+
+	return graph, []*Process{process1, process2, process3}
+}
+
+func TestProcessGraphToDOT(t *testing.T) {
+	graph, processes := createRenderTestProcesses()
+
+	dot := graph.ToDOT(processes)
+	assert.True(t, strings.HasPrefix(dot, "digraph \""+graph.ID+"\" {"))
+	assert.Contains(t, dot, "\""+processes[0].ID+"\" [label=\"task1\\nSuccessful\", fillcolor=\"#90ee90\"];")
+	assert.Contains(t, dot, "[label=\"task\\\"3\\nFailed\", fillcolor=\"#f08080\"];")
+	assert.Contains(t, dot, "\""+processes[0].ID+"\" -> \""+processes[1].ID+"\";")
+	assert.Contains(t, dot, "\""+processes[0].ID+"\" -> \""+processes[2].ID+"\";")
+	assert.Equal(t, 2, strings.Count(dot, "->"))
+
+	// Edges to processes not in the graph are not rendered
+	dot = graph.ToDOT(processes[:2])
+	assert.Contains(t, dot, "\""+processes[0].ID+"\" -> \""+processes[1].ID+"\";")
+	assert.NotContains(t, dot, processes[2].ID)
+}
+
+func TestProcessGraphToMermaid(t *testing.T) {
+	graph, processes := createRenderTestProcesses()
+
+	mermaid := graph.ToMermaid(processes)
+	assert.True(t, strings.HasPrefix(mermaid, "flowchart TD\n"))
+	assert.Contains(t, mermaid, "p0[\"task1<br/>Successful\"]:::successful")
+	assert.Contains(t, mermaid, "p1[\"task2<br/>Running\"]:::running")
+	assert.Contains(t, mermaid, "p2[\"task#quot;3<br/>Failed\"]:::failed")
+	assert.Contains(t, mermaid, "p0 --> p1")
+	assert.Contains(t, mermaid, "p0 --> p2")
+	assert.Contains(t, mermaid, "classDef waiting fill:#d3d3d3")
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// A Revocation records that a runtime has been revoked by the colony owner, a revoked runtime Id can never be
+// registered again
+type Revocation struct {
+	RuntimeID   string    `json:"runtimeid"`
+	ColonyID    string    `json:"colonyid"`
+	Reason      string    `json:"reason"`
+	RevokedTime time.Time `json:"revokedtime"`
+}
+
+func CreateRevocation(runtimeID string, colonyID string, reason string) *Revocation {
+	return &Revocation{RuntimeID: runtimeID, ColonyID: colonyID, Reason: reason}
+}
+
+func ConvertJSONToRevocation(jsonString string) (*Revocation, error) {
+	var revocation *Revocation
+	err := json.Unmarshal([]byte(jsonString), &revocation)
+	if err != nil {
+		return nil, err
+	}
+
+	return revocation, nil
+}
+
+func ConvertRevocationArrayToJSON(revocations []*Revocation) (string, error) {
+	jsonBytes, err := json.MarshalIndent(revocations, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToRevocationArray(jsonString string) ([]*Revocation, error) {
+	var revocations []*Revocation
+	err := json.Unmarshal([]byte(jsonString), &revocations)
+	if err != nil {
+		return revocations, err
+	}
+
+	return revocations, nil
+}
+
+func (revocation *Revocation) Equals(revocation2 *Revocation) bool {
+	if revocation2 == nil {
+		return false
+	}
+
+	if revocation.RuntimeID == revocation2.RuntimeID &&
+		revocation.ColonyID == revocation2.ColonyID &&
+		revocation.Reason == revocation2.Reason {
+		return true
+	}
+
+	return false
+}
+
+func (revocation *Revocation) ToJSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(revocation, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevocationToJSON(t *testing.T) {
+	revocation := CreateRevocation(GenerateRandomID(), GenerateRandomID(), "compromised")
+
+	jsonString, err := revocation.ToJSON()
+	assert.Nil(t, err)
+
+	revocation2, err := ConvertJSONToRevocation(jsonString + "error")
+	assert.NotNil(t, err)
+
+	revocation2, err = ConvertJSONToRevocation(jsonString)
+	assert.Nil(t, err)
+	assert.True(t, revocation.Equals(revocation2))
+	assert.False(t, revocation.Equals(nil))
+}
+
+func TestRevocationArrayToJSON(t *testing.T) {
+	revocation1 := CreateRevocation(GenerateRandomID(), GenerateRandomID(), "compromised")
+	revocation2 := CreateRevocation(GenerateRandomID(), GenerateRandomID(), "")
+
+	jsonString, err := ConvertRevocationArrayToJSON([]*Revocation{revocation1, revocation2})
+	assert.Nil(t, err)
+
+	revocations, err := ConvertJSONToRevocationArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, revocations, 2)
+	assert.True(t, revocation1.Equals(revocations[0]))
+	assert.True(t, revocation2.Equals(revocations[1]))
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"encoding/json"
+)
+
+// Roles restrict what an approved runtime is allowed to do in a colony. A runtime without any roles has full access.
+const (
+	ROLE_VIEWER    = "viewer"
+	ROLE_SUBMITTER = "submitter"
+	ROLE_EXECUTOR  = "executor"
+	ROLE_OPERATOR  = "operator"
+)
+
+var Roles = []string{ROLE_VIEWER, ROLE_SUBMITTER, ROLE_EXECUTOR, ROLE_OPERATOR}
+
+func IsValidRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ConvertRoleArrayToJSON(roles []string) (string, error) {
+	if roles == nil {
+		roles = []string{}
+	}
+
+	jsonBytes, err := json.MarshalIndent(roles, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToRoleArray(jsonString string) ([]string, error) {
+	var roles []string
+	err := json.Unmarshal([]byte(jsonString), &roles)
+	if err != nil {
+		return roles, err
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidRole(t *testing.T) {
+	assert.True(t, IsValidRole(ROLE_VIEWER))
+	assert.True(t, IsValidRole(ROLE_SUBMITTER))
+	assert.True(t, IsValidRole(ROLE_EXECUTOR))
+	assert.True(t, IsValidRole(ROLE_OPERATOR))
+	assert.False(t, IsValidRole("admin"))
+	assert.False(t, IsValidRole(""))
+}
+
+func TestRoleArrayJSON(t *testing.T) {
+	roles := []string{ROLE_SUBMITTER, ROLE_VIEWER}
+
+	jsonString, err := ConvertRoleArrayToJSON(roles)
+	assert.Nil(t, err)
+
+	roles2, err := ConvertJSONToRoleArray(jsonString)
+	assert.Nil(t, err)
+	assert.Equal(t, roles, roles2)
+
+	jsonString, err = ConvertRoleArrayToJSON(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", jsonString)
+
+	_, err = ConvertJSONToRoleArray("error")
+	assert.NotNil(t, err)
+}
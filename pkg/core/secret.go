@@ -0,0 +1,86 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+)
+
+var secretNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// A Secret is a named value stored encrypted by the server, the value is only returned to the runtime assigned
+// to a process that references the secret
+type Secret struct {
+	ID        string    `json:"secretid"`
+	ColonyID  string    `json:"colonyid"`
+	Name      string    `json:"name"`
+	Value     string    `json:"value,omitempty"`
+	AddedTime time.Time `json:"addedtime"`
+}
+
+func CreateSecret(colonyID string, name string, value string) *Secret {
+	return &Secret{ColonyID: colonyID, Name: name, Value: value}
+}
+
+func ConvertJSONToSecret(jsonString string) (*Secret, error) {
+	var secret *Secret
+	err := json.Unmarshal([]byte(jsonString), &secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func ConvertSecretArrayToJSON(secrets []*Secret) (string, error) {
+	jsonBytes, err := json.MarshalIndent(secrets, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToSecretArray(jsonString string) ([]*Secret, error) {
+	var secrets []*Secret
+	err := json.Unmarshal([]byte(jsonString), &secrets)
+	if err != nil {
+		return secrets, err
+	}
+
+	return secrets, nil
+}
+
+// ValidateSecretName checks that a secret name can be used as an environment variable name
+func ValidateSecretName(name string) error {
+	if !secretNameRegexp.MatchString(name) {
+		return errors.New("Invalid secret name <" + name + ">, only letters, digits and underscores are allowed")
+	}
+
+	return nil
+}
+
+func (secret *Secret) Equals(secret2 *Secret) bool {
+	if secret2 == nil {
+		return false
+	}
+
+	if secret.ID == secret2.ID &&
+		secret.ColonyID == secret2.ColonyID &&
+		secret.Name == secret2.Name &&
+		secret.Value == secret2.Value {
+		return true
+	}
+
+	return false
+}
+
+func (secret *Secret) ToJSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(secret, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretToJSON(t *testing.T) {
+	secret := CreateSecret(GenerateRandomID(), "DB_PASSWORD", "password")
+	secret.ID = GenerateRandomID()
+
+	jsonString, err := secret.ToJSON()
+	assert.Nil(t, err)
+
+	secret2, err := ConvertJSONToSecret(jsonString + "error")
+	assert.NotNil(t, err)
+
+	secret2, err = ConvertJSONToSecret(jsonString)
+	assert.Nil(t, err)
+	assert.True(t, secret.Equals(secret2))
+	assert.False(t, secret.Equals(nil))
+}
+
+func TestSecretArrayToJSON(t *testing.T) {
+	secret1 := CreateSecret(GenerateRandomID(), "DB_PASSWORD", "password")
+	secret2 := CreateSecret(GenerateRandomID(), "API_TOKEN", "")
+
+	jsonString, err := ConvertSecretArrayToJSON([]*Secret{secret1, secret2})
+	assert.Nil(t, err)
+	assert.NotContains(t, jsonString, `"value": ""`)
+
+	secrets, err := ConvertJSONToSecretArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, secrets, 2)
+	assert.True(t, secret1.Equals(secrets[0]))
+	assert.True(t, secret2.Equals(secrets[1]))
+}
+
+func TestValidateSecretName(t *testing.T) {
+	assert.Nil(t, ValidateSecretName("DB_PASSWORD"))
+	assert.Nil(t, ValidateSecretName("_token2"))
+	assert.NotNil(t, ValidateSecretName(""))
+	assert.NotNil(t, ValidateSecretName("2TOKEN"))
+	assert.NotNil(t, ValidateSecretName("DB-PASSWORD"))
+	assert.NotNil(t, ValidateSecretName("A=B"))
+}
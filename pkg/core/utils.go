@@ -2,6 +2,7 @@ package core
 
 import (
 	"github.com/colonyos/colonies/pkg/security/crypto"
+	"sigs.k8s.io/yaml"
 
 	"github.com/google/uuid"
 )
@@ -11,3 +12,13 @@ func GenerateRandomID() string {
 	crypto := crypto.CreateCrypto()
 	return crypto.GenerateHash(uuid.String())
 }
+
+// ConvertYAMLToJSON converts a YAML document to JSON, YAML keys are expected to be the same as the JSON tags
+func ConvertYAMLToJSON(yamlString string) (string, error) {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(yamlString))
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
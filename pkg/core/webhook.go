@@ -0,0 +1,265 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+)
+
+const (
+	WEBHOOK_EVENT_PROCESS      = "process"
+	WEBHOOK_EVENT_PROCESSGRAPH = "processgraph"
+)
+
+const (
+	WEBHOOK_DELIVERY_PENDING int = 0
+	WEBHOOK_DELIVERY_DEAD        = 1
+)
+
+// A WebhookFilter selects the state changes delivered to a webhook, an empty list matches everything. Funcs and
+// RuntimeTypes only apply to process events.
+type WebhookFilter struct {
+	Events       []string `json:"events"`
+	States       []int    `json:"states"`
+	Funcs        []string `json:"funcs"`
+	RuntimeTypes []string `json:"runtimetypes"`
+}
+
+// A Webhook is an URL that the server posts process and process graph state changes to, each request is signed
+// with the secret
+type Webhook struct {
+	ID        string        `json:"webhookid"`
+	ColonyID  string        `json:"colonyid"`
+	URL       string        `json:"url"`
+	Filter    WebhookFilter `json:"filter"`
+	Secret    string        `json:"secret,omitempty"`
+	AddedTime time.Time     `json:"addedtime"`
+}
+
+// A WebhookEvent is the JSON body posted to a webhook
+type WebhookEvent struct {
+	DeliveryID   string        `json:"deliveryid"`
+	WebhookID    string        `json:"webhookid"`
+	ColonyID     string        `json:"colonyid"`
+	Event        string        `json:"event"`
+	Time         time.Time     `json:"time"`
+	Process      *Process      `json:"process,omitempty"`
+	ProcessGraph *ProcessGraph `json:"processgraph,omitempty"`
+}
+
+// A WebhookDelivery is a webhook event waiting to be posted, deliveries are removed when posted and marked as dead
+// when all attempts have failed
+type WebhookDelivery struct {
+	ID          string    `json:"deliveryid"`
+	WebhookID   string    `json:"webhookid"`
+	ColonyID    string    `json:"colonyid"`
+	Event       string    `json:"event"`
+	Payload     string    `json:"payload"`
+	State       int       `json:"state"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"nextattempt"`
+	LastError   string    `json:"lasterror"`
+	CreatedTime time.Time `json:"createdtime"`
+}
+
+func CreateWebhook(colonyID string, url string, filter WebhookFilter, secret string) *Webhook {
+	return &Webhook{ColonyID: colonyID, URL: url, Filter: filter, Secret: secret}
+}
+
+func ConvertJSONToWebhook(jsonString string) (*Webhook, error) {
+	var webhook *Webhook
+	err := json.Unmarshal([]byte(jsonString), &webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func ConvertWebhookArrayToJSON(webhooks []*Webhook) (string, error) {
+	jsonBytes, err := json.MarshalIndent(webhooks, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToWebhookArray(jsonString string) ([]*Webhook, error) {
+	var webhooks []*Webhook
+	err := json.Unmarshal([]byte(jsonString), &webhooks)
+	if err != nil {
+		return webhooks, err
+	}
+
+	return webhooks, nil
+}
+
+// Validate checks that the URL is an absolute HTTP or HTTPS URL and that the filter only contains known events
+func (webhook *Webhook) Validate() error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("Invalid webhook URL <" + webhook.URL + ">, must be an absolute http or https URL")
+	}
+
+	for _, event := range webhook.Filter.Events {
+		if event != WEBHOOK_EVENT_PROCESS && event != WEBHOOK_EVENT_PROCESSGRAPH {
+			return errors.New("Invalid webhook event <" + event + ">, must be " + WEBHOOK_EVENT_PROCESS + " or " + WEBHOOK_EVENT_PROCESSGRAPH)
+		}
+	}
+
+	return nil
+}
+
+func (webhook *Webhook) matchEvent(event string, state int) bool {
+	if len(webhook.Filter.Events) > 0 && !contains(webhook.Filter.Events, event) {
+		return false
+	}
+
+	if len(webhook.Filter.States) > 0 {
+		for _, s := range webhook.Filter.States {
+			if s == state {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// MatchProcess returns true if a state change of the process should be delivered to the webhook
+func (webhook *Webhook) MatchProcess(process *Process) bool {
+	if process == nil || !webhook.matchEvent(WEBHOOK_EVENT_PROCESS, process.State) {
+		return false
+	}
+
+	if len(webhook.Filter.Funcs) > 0 && !contains(webhook.Filter.Funcs, process.ProcessSpec.Func) {
+		return false
+	}
+
+	if len(webhook.Filter.RuntimeTypes) > 0 && !contains(webhook.Filter.RuntimeTypes, process.ProcessSpec.Conditions.RuntimeType) {
+		return false
+	}
+
+	return true
+}
+
+// MatchProcessGraph returns true if a state change of the process graph should be delivered to the webhook
+func (webhook *Webhook) MatchProcessGraph(processGraph *ProcessGraph) bool {
+	if processGraph == nil {
+		return false
+	}
+
+	return webhook.matchEvent(WEBHOOK_EVENT_PROCESSGRAPH, processGraph.State)
+}
+
+func (webhook *Webhook) Equals(webhook2 *Webhook) bool {
+	if webhook2 == nil {
+		return false
+	}
+
+	if webhook.ID != webhook2.ID ||
+		webhook.ColonyID != webhook2.ColonyID ||
+		webhook.URL != webhook2.URL ||
+		webhook.Secret != webhook2.Secret ||
+		!equalStrings(webhook.Filter.Events, webhook2.Filter.Events) ||
+		!equalStrings(webhook.Filter.Funcs, webhook2.Filter.Funcs) ||
+		!equalStrings(webhook.Filter.RuntimeTypes, webhook2.Filter.RuntimeTypes) ||
+		len(webhook.Filter.States) != len(webhook2.Filter.States) {
+		return false
+	}
+
+	for i := range webhook.Filter.States {
+		if webhook.Filter.States[i] != webhook2.Filter.States[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (webhook *Webhook) ToJSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(webhook, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToWebhookEvent(jsonString string) (*WebhookEvent, error) {
+	var event *WebhookEvent
+	err := json.Unmarshal([]byte(jsonString), &event)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (event *WebhookEvent) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func CreateWebhookDelivery(webhook *Webhook, event string, payload string) *WebhookDelivery {
+	return &WebhookDelivery{WebhookID: webhook.ID, ColonyID: webhook.ColonyID, Event: event, Payload: payload, State: WEBHOOK_DELIVERY_PENDING}
+}
+
+func ConvertWebhookDeliveryArrayToJSON(deliveries []*WebhookDelivery) (string, error) {
+	jsonBytes, err := json.MarshalIndent(deliveries, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToWebhookDeliveryArray(jsonString string) ([]*WebhookDelivery, error) {
+	var deliveries []*WebhookDelivery
+	err := json.Unmarshal([]byte(jsonString), &deliveries)
+	if err != nil {
+		return deliveries, err
+	}
+
+	return deliveries, nil
+}
+
+func (delivery *WebhookDelivery) Equals(delivery2 *WebhookDelivery) bool {
+	if delivery2 == nil {
+		return false
+	}
+
+	if delivery.ID == delivery2.ID &&
+		delivery.WebhookID == delivery2.WebhookID &&
+		delivery.ColonyID == delivery2.ColonyID &&
+		delivery.Event == delivery2.Event &&
+		delivery.Payload == delivery2.Payload &&
+		delivery.State == delivery2.State &&
+		delivery.Attempts == delivery2.Attempts &&
+		delivery.LastError == delivery2.LastError {
+		return true
+	}
+
+	return false
+}
+
+func equalStrings(values []string, values2 []string) bool {
+	if len(values) != len(values2) {
+		return false
+	}
+
+	for i := range values {
+		if values[i] != values2[i] {
+			return false
+		}
+	}
+
+	return true
+}
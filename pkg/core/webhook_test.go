@@ -0,0 +1,126 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookToJSON(t *testing.T) {
+	filter := WebhookFilter{Events: []string{WEBHOOK_EVENT_PROCESS}, States: []int{SUCCESS, FAILED}, Funcs: []string{"train"}}
+	webhook := CreateWebhook(GenerateRandomID(), "https://example.com/hook", filter, "secret")
+	webhook.ID = GenerateRandomID()
+
+	jsonString, err := webhook.ToJSON()
+	assert.Nil(t, err)
+
+	webhook2, err := ConvertJSONToWebhook(jsonString + "error")
+	assert.NotNil(t, err)
+
+	webhook2, err = ConvertJSONToWebhook(jsonString)
+	assert.Nil(t, err)
+	assert.True(t, webhook.Equals(webhook2))
+	assert.False(t, webhook.Equals(nil))
+
+	webhook2.Filter.States = []int{SUCCESS}
+	assert.False(t, webhook.Equals(webhook2))
+}
+
+func TestWebhookArrayToJSON(t *testing.T) {
+	webhook1 := CreateWebhook(GenerateRandomID(), "https://example.com/hook1", WebhookFilter{}, "secret")
+	webhook2 := CreateWebhook(GenerateRandomID(), "https://example.com/hook2", WebhookFilter{}, "")
+
+	jsonString, err := ConvertWebhookArrayToJSON([]*Webhook{webhook1, webhook2})
+	assert.Nil(t, err)
+	assert.NotContains(t, jsonString, `"secret": ""`)
+
+	webhooks, err := ConvertJSONToWebhookArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 2)
+	assert.True(t, webhook1.Equals(webhooks[0]))
+	assert.True(t, webhook2.Equals(webhooks[1]))
+}
+
+func TestWebhookValidate(t *testing.T) {
+	colonyID := GenerateRandomID()
+	assert.Nil(t, CreateWebhook(colonyID, "https://example.com/hook", WebhookFilter{}, "").Validate())
+	assert.Nil(t, CreateWebhook(colonyID, "http://localhost:8080", WebhookFilter{Events: []string{WEBHOOK_EVENT_PROCESSGRAPH}}, "").Validate())
+	assert.NotNil(t, CreateWebhook(colonyID, "ftp://example.com/hook", WebhookFilter{}, "").Validate())
+	assert.NotNil(t, CreateWebhook(colonyID, "/hook", WebhookFilter{}, "").Validate())
+	assert.NotNil(t, CreateWebhook(colonyID, "https://example.com/hook", WebhookFilter{Events: []string{"runtime"}}, "").Validate())
+}
+
+func TestWebhookMatchProcess(t *testing.T) {
+	colonyID := GenerateRandomID()
+	processSpec := CreateProcessSpec("name", "train", []string{}, colonyID, []string{}, "gpu", -1, -1, 3, make(map[string]string), []string{}, 1)
+	process := CreateProcess(processSpec)
+	process.State = SUCCESS
+
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{}, "").MatchProcess(process))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{}, "").MatchProcess(nil))
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{Events: []string{WEBHOOK_EVENT_PROCESS}}, "").MatchProcess(process))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{Events: []string{WEBHOOK_EVENT_PROCESSGRAPH}}, "").MatchProcess(process))
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{States: []int{SUCCESS, FAILED}}, "").MatchProcess(process))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{States: []int{FAILED}}, "").MatchProcess(process))
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{Funcs: []string{"train"}}, "").MatchProcess(process))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{Funcs: []string{"predict"}}, "").MatchProcess(process))
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{RuntimeTypes: []string{"cpu", "gpu"}}, "").MatchProcess(process))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{RuntimeTypes: []string{"cpu"}}, "").MatchProcess(process))
+}
+
+func TestWebhookMatchProcessGraph(t *testing.T) {
+	colonyID := GenerateRandomID()
+	processGraph, err := CreateProcessGraph(colonyID)
+	assert.Nil(t, err)
+	processGraph.State = FAILED
+
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{}, "").MatchProcessGraph(processGraph))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{}, "").MatchProcessGraph(nil))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{Events: []string{WEBHOOK_EVENT_PROCESS}}, "").MatchProcessGraph(processGraph))
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{States: []int{FAILED}}, "").MatchProcessGraph(processGraph))
+	assert.False(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{States: []int{SUCCESS}}, "").MatchProcessGraph(processGraph))
+
+	// Funcs and runtime types only apply to process events
+	assert.True(t, CreateWebhook(colonyID, "https://example.com", WebhookFilter{Funcs: []string{"train"}}, "").MatchProcessGraph(processGraph))
+}
+
+func TestWebhookEventToJSON(t *testing.T) {
+	event := &WebhookEvent{DeliveryID: GenerateRandomID(), WebhookID: GenerateRandomID(), ColonyID: GenerateRandomID(), Event: WEBHOOK_EVENT_PROCESS}
+	event.Process = CreateProcess(CreateProcessSpec("name", "train", []string{}, event.ColonyID, []string{}, "gpu", -1, -1, 3, make(map[string]string), []string{}, 1))
+
+	jsonString, err := event.ToJSON()
+	assert.Nil(t, err)
+	assert.NotContains(t, jsonString, `"processgraph":`)
+
+	event2, err := ConvertJSONToWebhookEvent(jsonString + "error")
+	assert.NotNil(t, err)
+
+	event2, err = ConvertJSONToWebhookEvent(jsonString)
+	assert.Nil(t, err)
+	assert.Equal(t, event.DeliveryID, event2.DeliveryID)
+	assert.True(t, event.Process.Equals(event2.Process))
+}
+
+func TestWebhookDeliveryArrayToJSON(t *testing.T) {
+	webhook := CreateWebhook(GenerateRandomID(), "https://example.com/hook", WebhookFilter{}, "secret")
+	webhook.ID = GenerateRandomID()
+	delivery1 := CreateWebhookDelivery(webhook, WEBHOOK_EVENT_PROCESS, "{}")
+	delivery1.ID = GenerateRandomID()
+	delivery2 := CreateWebhookDelivery(webhook, WEBHOOK_EVENT_PROCESSGRAPH, "{}")
+	delivery2.ID = GenerateRandomID()
+	delivery2.State = WEBHOOK_DELIVERY_DEAD
+
+	jsonString, err := ConvertWebhookDeliveryArrayToJSON([]*WebhookDelivery{delivery1, delivery2})
+	assert.Nil(t, err)
+
+	deliveries, err := ConvertJSONToWebhookDeliveryArray(jsonString + "error")
+	assert.NotNil(t, err)
+
+	deliveries, err = ConvertJSONToWebhookDeliveryArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 2)
+	assert.True(t, delivery1.Equals(deliveries[0]))
+	assert.True(t, delivery2.Equals(deliveries[1]))
+	assert.False(t, delivery1.Equals(delivery2))
+	assert.False(t, delivery1.Equals(nil))
+}
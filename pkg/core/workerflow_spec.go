@@ -26,6 +26,15 @@ func ConvertJSONToWorkflowSpec(jsonString string) (*WorkflowSpec, error) {
 	return workflowSpec, nil
 }
 
+func ConvertYAMLToWorkflowSpec(yamlString string) (*WorkflowSpec, error) {
+	jsonString, err := ConvertYAMLToJSON(yamlString)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertJSONToWorkflowSpec(jsonString)
+}
+
 func (workflowSpec *WorkflowSpec) Equals(workflowSpec2 *WorkflowSpec) bool {
 	same := true
 	if workflowSpec.ColonyID != workflowSpec2.ColonyID {
@@ -44,3 +44,35 @@ func TestWorkflowSpecJSON(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, workflowSpec.Equals(workflowSpec2))
 }
+
+func TestWorkflowSpecYAML(t *testing.T) {
+	colonyID := GenerateRandomID()
+	yamlString := `
+colonyid: "` + colonyID + `"
+processspecs:
+  - name: "task1"
+    func: "echo"
+    args: ["task1"]
+  - name: "task2"
+    func: "echo"
+    conditions:
+      dependencies:
+        - "task1"
+`
+	workflowSpec, err := ConvertYAMLToWorkflowSpec(yamlString)
+	assert.Nil(t, err)
+	assert.Equal(t, colonyID, workflowSpec.ColonyID)
+	assert.Len(t, workflowSpec.ProcessSpecs, 2)
+	assert.Equal(t, "task1", workflowSpec.ProcessSpecs[0].Name)
+	assert.Equal(t, []string{"task1"}, workflowSpec.ProcessSpecs[0].Args)
+	assert.Equal(t, []string{"task1"}, workflowSpec.ProcessSpecs[1].Conditions.Dependencies)
+
+	jsonStr, err := workflowSpec.ToJSON()
+	assert.Nil(t, err)
+	workflowSpec2, err := ConvertJSONToWorkflowSpec(jsonStr)
+	assert.Nil(t, err)
+	assert.True(t, workflowSpec.Equals(workflowSpec2))
+
+	_, err = ConvertYAMLToWorkflowSpec("processspecs: [error")
+	assert.NotNil(t, err)
+}
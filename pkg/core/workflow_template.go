@@ -0,0 +1,264 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"time"
+)
+
+var templateParameterNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+var templateParameterRefRegexp = regexp.MustCompile(`\{\{([A-Za-z_][A-Za-z0-9_]*)\}\}`)
+
+type TemplateParameter struct {
+	Name    string `json:"name"`
+	Default string `json:"default"`
+}
+
+// A WorkflowTemplate is a WorkflowSpec where Func, Args and Env values may refer to parameters using {{name}}
+type WorkflowTemplate struct {
+	ID           string              `json:"workflowtemplateid"`
+	ColonyID     string              `json:"colonyid"`
+	Name         string              `json:"name"`
+	Version      int                 `json:"version"`
+	Parameters   []TemplateParameter `json:"parameters"`
+	WorkflowSpec string              `json:"workflowspec"`
+	AddedTime    time.Time           `json:"addedtime"`
+}
+
+// A WorkflowTemplateRef references a WorkflowTemplate by name, Version 0 refers to the latest version
+type WorkflowTemplateRef struct {
+	Name       string            `json:"name"`
+	Version    int               `json:"version"`
+	Parameters map[string]string `json:"parameters"`
+}
+
+func CreateWorkflowTemplate(colonyID string, name string, parameters []TemplateParameter, workflowSpec string) *WorkflowTemplate {
+	return &WorkflowTemplate{ColonyID: colonyID, Name: name, Parameters: parameters, WorkflowSpec: workflowSpec}
+}
+
+func CreateWorkflowTemplateRef(name string, version int, parameters map[string]string) *WorkflowTemplateRef {
+	if parameters == nil {
+		parameters = make(map[string]string)
+	}
+	return &WorkflowTemplateRef{Name: name, Version: version, Parameters: parameters}
+}
+
+func ConvertJSONToWorkflowTemplate(jsonString string) (*WorkflowTemplate, error) {
+	var workflowTemplate *WorkflowTemplate
+	err := json.Unmarshal([]byte(jsonString), &workflowTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflowTemplate, nil
+}
+
+func ConvertWorkflowTemplateArrayToJSON(workflowTemplates []*WorkflowTemplate) (string, error) {
+	jsonBytes, err := json.MarshalIndent(workflowTemplates, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToWorkflowTemplateArray(jsonString string) ([]*WorkflowTemplate, error) {
+	var workflowTemplates []*WorkflowTemplate
+	err := json.Unmarshal([]byte(jsonString), &workflowTemplates)
+	if err != nil {
+		return workflowTemplates, err
+	}
+
+	return workflowTemplates, nil
+}
+
+func ConvertTemplateParametersToJSON(parameters []TemplateParameter) (string, error) {
+	if parameters == nil {
+		parameters = []TemplateParameter{}
+	}
+
+	jsonBytes, err := json.Marshal(parameters)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToTemplateParameters(jsonString string) ([]TemplateParameter, error) {
+	var parameters []TemplateParameter
+	err := json.Unmarshal([]byte(jsonString), &parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+func ConvertJSONToWorkflowTemplateRef(jsonString string) (*WorkflowTemplateRef, error) {
+	var workflowTemplateRef *WorkflowTemplateRef
+	err := json.Unmarshal([]byte(jsonString), &workflowTemplateRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflowTemplateRef, nil
+}
+
+func IsWorkflowTemplateArraysEqual(workflowTemplates1 []*WorkflowTemplate, workflowTemplates2 []*WorkflowTemplate) bool {
+	if workflowTemplates1 == nil || workflowTemplates2 == nil {
+		return false
+	}
+
+	counter := 0
+	for _, workflowTemplate1 := range workflowTemplates1 {
+		for _, workflowTemplate2 := range workflowTemplates2 {
+			if workflowTemplate1.Equals(workflowTemplate2) {
+				counter++
+			}
+		}
+	}
+
+	if counter == len(workflowTemplates1) && counter == len(workflowTemplates2) {
+		return true
+	}
+
+	return false
+}
+
+func (workflowTemplate *WorkflowTemplate) Equals(workflowTemplate2 *WorkflowTemplate) bool {
+	if workflowTemplate2 == nil {
+		return false
+	}
+
+	same := true
+	if workflowTemplate.ID != workflowTemplate2.ID ||
+		workflowTemplate.ColonyID != workflowTemplate2.ColonyID ||
+		workflowTemplate.Name != workflowTemplate2.Name ||
+		workflowTemplate.Version != workflowTemplate2.Version ||
+		workflowTemplate.WorkflowSpec != workflowTemplate2.WorkflowSpec ||
+		len(workflowTemplate.Parameters) != len(workflowTemplate2.Parameters) {
+		same = false
+	} else {
+		for i := range workflowTemplate.Parameters {
+			if workflowTemplate.Parameters[i] != workflowTemplate2.Parameters[i] {
+				same = false
+			}
+		}
+	}
+
+	return same
+}
+
+func (workflowTemplate *WorkflowTemplate) Validate() error {
+	if workflowTemplate.Name == "" {
+		return errors.New("Workflow template name must be specified")
+	}
+
+	_, err := ConvertJSONToWorkflowSpec(workflowTemplate.WorkflowSpec)
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	for _, parameter := range workflowTemplate.Parameters {
+		if !templateParameterNameRegexp.MatchString(parameter.Name) {
+			return errors.New("Invalid workflow template parameter name <" + parameter.Name + ">")
+		}
+		if parameter.Name == GENERATOR_ARGS_KEY {
+			return errors.New("Workflow template parameter name <" + parameter.Name + "> is reserved")
+		}
+		if names[parameter.Name] {
+			return errors.New("Workflow template parameter <" + parameter.Name + "> declared more than once")
+		}
+		names[parameter.Name] = true
+	}
+
+	return nil
+}
+
+// Instantiate creates a WorkflowSpec by substituting parameter values, or the declared defaults, into the template
+func (workflowTemplate *WorkflowTemplate) Instantiate(parameters map[string]string) (*WorkflowSpec, error) {
+	values := make(map[string]string)
+	for _, parameter := range workflowTemplate.Parameters {
+		values[parameter.Name] = parameter.Default
+	}
+	for name, value := range parameters {
+		if _, ok := values[name]; !ok {
+			return nil, errors.New("Workflow template <" + workflowTemplate.Name + "> has no parameter named <" + name + ">")
+		}
+		values[name] = value
+	}
+
+	workflowSpec, err := ConvertJSONToWorkflowSpec(workflowTemplate.WorkflowSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	// All references are substituted in a single pass, so references in parameter values are never expanded
+	substitute := func(str string) string {
+		return templateParameterRefRegexp.ReplaceAllStringFunc(str, func(ref string) string {
+			if value, ok := values[ref[2:len(ref)-2]]; ok {
+				return value
+			}
+			return ref
+		})
+	}
+
+	for i := range workflowSpec.ProcessSpecs {
+		processSpec := &workflowSpec.ProcessSpecs[i]
+		processSpec.Func = substitute(processSpec.Func)
+		for j := range processSpec.Args {
+			processSpec.Args[j] = substitute(processSpec.Args[j])
+		}
+		for key, value := range processSpec.Env {
+			processSpec.Env[key] = substitute(value)
+		}
+	}
+
+	workflowSpec.ColonyID = workflowTemplate.ColonyID
+
+	return workflowSpec, nil
+}
+
+func (workflowTemplate *WorkflowTemplate) ToJSON() (string, error) {
+	jsonBytes, err := json.MarshalIndent(workflowTemplate, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (workflowTemplateRef *WorkflowTemplateRef) Equals(workflowTemplateRef2 *WorkflowTemplateRef) bool {
+	if workflowTemplateRef == nil && workflowTemplateRef2 == nil {
+		return true
+	}
+	if workflowTemplateRef == nil || workflowTemplateRef2 == nil {
+		return false
+	}
+
+	if workflowTemplateRef.Name != workflowTemplateRef2.Name ||
+		workflowTemplateRef.Version != workflowTemplateRef2.Version ||
+		len(workflowTemplateRef.Parameters) != len(workflowTemplateRef2.Parameters) {
+		return false
+	}
+
+	for name, value := range workflowTemplateRef.Parameters {
+		if value2, ok := workflowTemplateRef2.Parameters[name]; !ok || value != value2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (workflowTemplateRef *WorkflowTemplateRef) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(workflowTemplateRef)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestWorkflowTemplate(t *testing.T) *WorkflowTemplate {
+	colonyID := GenerateRandomID()
+	workflowSpec := CreateWorkflowSpec(colonyID)
+	processSpec1 := CreateEmptyProcessSpec()
+	processSpec1.Name = "task1"
+	processSpec1.Func = "{{cmd}}"
+	processSpec1.Args = []string{"--input={{input}}", "{{generatorargs}}"}
+	processSpec1.Env["OUTPUT"] = "{{output}}"
+	processSpec2 := CreateEmptyProcessSpec()
+	processSpec2.Name = "task2"
+	processSpec2.Func = "echo"
+	processSpec2.Args = []string{"{{input}}"}
+	processSpec2.AddDependency("task1")
+	workflowSpec.AddProcessSpec(processSpec1)
+	workflowSpec.AddProcessSpec(processSpec2)
+	jsonStr, err := workflowSpec.ToJSON()
+	assert.Nil(t, err)
+
+	parameters := []TemplateParameter{{Name: "cmd", Default: "ls"}, {Name: "input", Default: "/tmp"}, {Name: "output"}}
+	workflowTemplate := CreateWorkflowTemplate(colonyID, "test_template", parameters, jsonStr)
+	workflowTemplate.ID = GenerateRandomID()
+	workflowTemplate.Version = 1
+
+	return workflowTemplate
+}
+
+func TestWorkflowTemplateJSON(t *testing.T) {
+	workflowTemplate := createTestWorkflowTemplate(t)
+
+	jsonStr, err := workflowTemplate.ToJSON()
+	assert.Nil(t, err)
+
+	workflowTemplate2, err := ConvertJSONToWorkflowTemplate(jsonStr)
+	assert.Nil(t, err)
+	assert.True(t, workflowTemplate.Equals(workflowTemplate2))
+
+	_, err = ConvertJSONToWorkflowTemplate(jsonStr + "error")
+	assert.NotNil(t, err)
+}
+
+func TestWorkflowTemplateArrayJSON(t *testing.T) {
+	workflowTemplate1 := createTestWorkflowTemplate(t)
+	workflowTemplate2 := createTestWorkflowTemplate(t)
+	arr := []*WorkflowTemplate{workflowTemplate1, workflowTemplate2}
+
+	jsonStr, err := ConvertWorkflowTemplateArrayToJSON(arr)
+	assert.Nil(t, err)
+
+	_, err = ConvertJSONToWorkflowTemplateArray(jsonStr + "error")
+	assert.NotNil(t, err)
+
+	arr2, err := ConvertJSONToWorkflowTemplateArray(jsonStr)
+	assert.Nil(t, err)
+	assert.True(t, IsWorkflowTemplateArraysEqual(arr, arr2))
+	assert.False(t, IsWorkflowTemplateArraysEqual(arr, nil))
+	assert.False(t, IsWorkflowTemplateArraysEqual(nil, arr2))
+}
+
+func TestWorkflowTemplateEquals(t *testing.T) {
+	workflowTemplate1 := createTestWorkflowTemplate(t)
+	workflowTemplate2 := createTestWorkflowTemplate(t)
+	assert.True(t, workflowTemplate1.Equals(workflowTemplate1))
+	assert.False(t, workflowTemplate1.Equals(workflowTemplate2))
+	assert.False(t, workflowTemplate1.Equals(nil))
+}
+
+func TestTemplateParametersJSON(t *testing.T) {
+	parameters := []TemplateParameter{{Name: "cmd", Default: "ls"}, {Name: "input"}}
+	jsonStr, err := ConvertTemplateParametersToJSON(parameters)
+	assert.Nil(t, err)
+
+	parameters2, err := ConvertJSONToTemplateParameters(jsonStr)
+	assert.Nil(t, err)
+	assert.Equal(t, parameters, parameters2)
+
+	jsonStr, err = ConvertTemplateParametersToJSON(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", jsonStr)
+}
+
+func TestWorkflowTemplateValidate(t *testing.T) {
+	workflowTemplate := createTestWorkflowTemplate(t)
+	assert.Nil(t, workflowTemplate.Validate())
+
+	workflowTemplate.Parameters = append(workflowTemplate.Parameters, TemplateParameter{Name: "cmd"})
+	assert.NotNil(t, workflowTemplate.Validate())
+
+	workflowTemplate = createTestWorkflowTemplate(t)
+	workflowTemplate.Parameters = append(workflowTemplate.Parameters, TemplateParameter{Name: "invalid name"})
+	assert.NotNil(t, workflowTemplate.Validate())
+
+	workflowTemplate = createTestWorkflowTemplate(t)
+	workflowTemplate.Parameters = append(workflowTemplate.Parameters, TemplateParameter{Name: GENERATOR_ARGS_KEY})
+	assert.NotNil(t, workflowTemplate.Validate())
+
+	workflowTemplate = createTestWorkflowTemplate(t)
+	workflowTemplate.Name = ""
+	assert.NotNil(t, workflowTemplate.Validate())
+
+	workflowTemplate = createTestWorkflowTemplate(t)
+	workflowTemplate.WorkflowSpec = "error"
+	assert.NotNil(t, workflowTemplate.Validate())
+}
+
+func TestWorkflowTemplateInstantiate(t *testing.T) {
+	workflowTemplate := createTestWorkflowTemplate(t)
+
+	workflowSpec, err := workflowTemplate.Instantiate(map[string]string{"input": "/data", "output": "/results"})
+	assert.Nil(t, err)
+	assert.Equal(t, workflowTemplate.ColonyID, workflowSpec.ColonyID)
+	assert.Len(t, workflowSpec.ProcessSpecs, 2)
+
+	for _, processSpec := range workflowSpec.ProcessSpecs {
+		if processSpec.Name == "task1" {
+			assert.Equal(t, "ls", processSpec.Func)
+			assert.Equal(t, []string{"--input=/data", "{{generatorargs}}"}, processSpec.Args)
+			assert.Equal(t, "/results", processSpec.Env["OUTPUT"])
+		} else {
+			assert.Equal(t, "echo", processSpec.Func)
+			assert.Equal(t, []string{"/data"}, processSpec.Args)
+		}
+	}
+
+	workflowSpec, err = workflowTemplate.Instantiate(nil)
+	assert.Nil(t, err)
+	for _, processSpec := range workflowSpec.ProcessSpecs {
+		if processSpec.Name == "task1" {
+			assert.Equal(t, []string{"--input=/tmp", "{{generatorargs}}"}, processSpec.Args)
+			assert.Equal(t, "", processSpec.Env["OUTPUT"])
+		}
+	}
+
+	_, err = workflowTemplate.Instantiate(map[string]string{"unknown": "value"})
+	assert.NotNil(t, err)
+}
+
+func TestWorkflowTemplateInstantiateNoReexpansion(t *testing.T) {
+	workflowTemplate := createTestWorkflowTemplate(t)
+
+	// Parameter values referring to other parameters must be used as is, regardless of substitution order
+	for i := 0; i < 20; i++ {
+		workflowSpec, err := workflowTemplate.Instantiate(map[string]string{"input": "{{output}}", "output": "{{input}}"})
+		assert.Nil(t, err)
+		for _, processSpec := range workflowSpec.ProcessSpecs {
+			if processSpec.Name == "task1" {
+				assert.Equal(t, []string{"--input={{output}}", "{{generatorargs}}"}, processSpec.Args)
+				assert.Equal(t, "{{input}}", processSpec.Env["OUTPUT"])
+			}
+		}
+	}
+}
+
+func TestWorkflowTemplateRef(t *testing.T) {
+	workflowTemplateRef := CreateWorkflowTemplateRef("test_template", 2, map[string]string{"input": "/data"})
+	jsonStr, err := workflowTemplateRef.ToJSON()
+	assert.Nil(t, err)
+
+	workflowTemplateRef2, err := ConvertJSONToWorkflowTemplateRef(jsonStr)
+	assert.Nil(t, err)
+	assert.True(t, workflowTemplateRef.Equals(workflowTemplateRef2))
+
+	workflowTemplateRef3 := CreateWorkflowTemplateRef("test_template", 2, nil)
+	assert.False(t, workflowTemplateRef.Equals(workflowTemplateRef3))
+	assert.False(t, workflowTemplateRef.Equals(nil))
+
+	var nilRef *WorkflowTemplateRef
+	assert.True(t, nilRef.Equals(nil))
+
+	_, err = ConvertJSONToWorkflowTemplateRef(jsonStr + "error")
+	assert.NotNil(t, err)
+}
@@ -14,6 +14,7 @@ type Database interface {
 	AddColony(colony *core.Colony) error
 	GetColonies() ([]*core.Colony, error)
 	GetColonyByID(id string) (*core.Colony, error)
+	SetColonyOwner(colonyID string, ownerID string) error
 	DeleteColonyByID(colonyID string) error
 	CountColonies() (int, error)
 
@@ -30,6 +31,16 @@ type Database interface {
 	CountRuntimes() (int, error)
 	CountRuntimesByColonyID(colonyID string) (int, error)
 
+	// Runtime role functions ...
+	AddRuntimeRole(runtimeID string, colonyID string, role string) error
+	RemoveRuntimeRole(runtimeID string, role string) error
+	GetRuntimeRoles(runtimeID string) ([]string, error)
+
+	// Revocation functions ...
+	AddRevocation(revocation *core.Revocation) error
+	GetRevocation(runtimeID string) (*core.Revocation, error)
+	GetRevocationsByColonyID(colonyID string) ([]*core.Revocation, error)
+
 	// Process functions ...
 	AddProcess(process *core.Process) error
 	GetProcesses() ([]*core.Process, error)
@@ -38,6 +49,7 @@ type Database interface {
 	FindProcessesByRuntimeID(colonyID string, runtimeID string, seconds int, state int) ([]*core.Process, error)
 	FindWaitingProcesses(colonyID string, count int) ([]*core.Process, error)
 	FindRunningProcesses(colonyID string, count int) ([]*core.Process, error)
+	FindRunningProcessesByRuntimeID(runtimeID string) ([]*core.Process, error)
 	FindAllRunningProcesses() ([]*core.Process, error)
 	FindAllWaitingProcesses() ([]*core.Process, error)
 	FindSuccessfulProcesses(colonyID string, count int) ([]*core.Process, error)
@@ -129,6 +141,43 @@ type Database interface {
 	DeleteCronByID(cronID string) error
 	DeleteAllCronsByColonyID(colonyID string) error
 
+	// Workflow template functions
+	AddWorkflowTemplate(workflowTemplate *core.WorkflowTemplate) error
+	GetWorkflowTemplateByID(workflowTemplateID string) (*core.WorkflowTemplate, error)
+	GetWorkflowTemplate(colonyID string, name string, version int) (*core.WorkflowTemplate, error)
+	FindWorkflowTemplatesByColonyID(colonyID string, count int) ([]*core.WorkflowTemplate, error)
+	FindWorkflowTemplateVersions(colonyID string, name string) ([]*core.WorkflowTemplate, error)
+	DeleteWorkflowTemplate(colonyID string, name string, version int) error
+	DeleteAllWorkflowTemplatesByColonyID(colonyID string) error
+
+	// Secret functions
+	SetSecret(secret *core.Secret) error
+	GetSecret(colonyID string, name string) (*core.Secret, error)
+	GetSecrets(colonyID string) ([]*core.Secret, error)
+	DeleteSecret(colonyID string, name string) error
+	DeleteAllSecretsByColonyID(colonyID string) error
+
+	// Webhook functions
+	AddWebhook(webhook *core.Webhook) error
+	GetWebhookByID(webhookID string) (*core.Webhook, error)
+	GetWebhooksByColonyID(colonyID string) ([]*core.Webhook, error)
+	DeleteWebhookByID(webhookID string) error
+	DeleteAllWebhooksByColonyID(colonyID string) error
+	AddWebhookDelivery(delivery *core.WebhookDelivery) error
+	FindPendingWebhookDeliveries(count int) ([]*core.WebhookDelivery, error)
+	FindWebhookDeliveries(webhookID string, state int, count int) ([]*core.WebhookDelivery, error)
+	UpdateWebhookDelivery(delivery *core.WebhookDelivery) error
+	DeleteWebhookDeliveryByID(deliveryID string) error
+
+	// Audit log functions
+	AppendAuditEntry(auditEntry *core.AuditEntry) error
+	AppendAuditEntries(auditEntries []*core.AuditEntry) error
+	FindAuditEntries(targetID string, fromSeq int64, count int) ([]*core.AuditEntry, error)
+
+	// Nonce functions
+	AddNonce(nonce string, expires time.Time) (bool, error)
+	DeleteExpiredNonces() error
+
 	// Distributed locking
 	Lock(timeout int) error
 	Unlock() error
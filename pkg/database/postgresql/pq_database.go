@@ -98,6 +98,54 @@ func (db *PQDatabase) Drop() error {
 		return err
 	}
 
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `WORKFLOWTEMPLATES`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `RUNTIMEROLES`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `NONCES`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `SECRETS`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `AUDITLOG`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `REVOCATIONS`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `WEBHOOKS`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DROP TABLE ` + db.dbPrefix + `WEBHOOKDELIVERIES`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
 	sqlStatement = `DROP INDEX PROCESSES_INDEX1`
 	_, err = db.postgresql.Exec(sqlStatement)
 	if err != nil {
@@ -126,7 +174,7 @@ func (db *PQDatabase) Drop() error {
 }
 
 func (db *PQDatabase) Initialize() error {
-	sqlStatement := `CREATE TABLE ` + db.dbPrefix + `COLONIES (COLONY_ID TEXT PRIMARY KEY NOT NULL, NAME TEXT NOT NULL)`
+	sqlStatement := `CREATE TABLE ` + db.dbPrefix + `COLONIES (COLONY_ID TEXT PRIMARY KEY NOT NULL, NAME TEXT NOT NULL, OWNER_ID TEXT NOT NULL)`
 	_, err := db.postgresql.Exec(sqlStatement)
 	if err != nil {
 		return err
@@ -138,7 +186,7 @@ func (db *PQDatabase) Initialize() error {
 		return err
 	}
 
-	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `PROCESSES (PROCESS_ID TEXT PRIMARY KEY NOT NULL, TARGET_COLONY_ID TEXT NOT NULL, TARGET_RUNTIME_IDS TEXT[], ASSIGNED_RUNTIME_ID TEXT, STATE INTEGER, IS_ASSIGNED BOOLEAN, RUNTIME_TYPE TEXT, SUBMISSION_TIME TIMESTAMPTZ, START_TIME TIMESTAMPTZ, END_TIME TIMESTAMPTZ, WAIT_DEADLINE TIMESTAMPTZ, EXEC_DEADLINE TIMESTAMPTZ, ERROR_MSG TEXT, NAME TEXT, FUNC TEXT, ARGS TEXT[], MAX_WAIT_TIME INTEGER, MAX_EXEC_TIME INTEGER, RETRIES INTEGER, MAX_RETRIES INTEGER, DEPENDENCIES TEXT[], PRIORITY INTEGER, WAIT_FOR_PARENTS BOOLEAN, PARENTS TEXT[], CHILDREN TEXT[], PROCESSGRAPH_ID TEXT)`
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `PROCESSES (PROCESS_ID TEXT PRIMARY KEY NOT NULL, TARGET_COLONY_ID TEXT NOT NULL, TARGET_RUNTIME_IDS TEXT[], ASSIGNED_RUNTIME_ID TEXT, STATE INTEGER, IS_ASSIGNED BOOLEAN, RUNTIME_TYPE TEXT, SUBMISSION_TIME TIMESTAMPTZ, START_TIME TIMESTAMPTZ, END_TIME TIMESTAMPTZ, WAIT_DEADLINE TIMESTAMPTZ, EXEC_DEADLINE TIMESTAMPTZ, ERROR_MSG TEXT, NAME TEXT, FUNC TEXT, ARGS TEXT[], MAX_WAIT_TIME INTEGER, MAX_EXEC_TIME INTEGER, RETRIES INTEGER, MAX_RETRIES INTEGER, DEPENDENCIES TEXT[], PRIORITY INTEGER, WAIT_FOR_PARENTS BOOLEAN, PARENTS TEXT[], CHILDREN TEXT[], PROCESSGRAPH_ID TEXT, SECRETS TEXT[])`
 	_, err = db.postgresql.Exec(sqlStatement)
 	if err != nil {
 		return err
@@ -156,7 +204,7 @@ func (db *PQDatabase) Initialize() error {
 		return err
 	}
 
-	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `GENERATORS (GENERATOR_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, NAME TEXT NOT NULL, WORKFLOW_SPEC TEXT NOT NULL, TRIGGER INTEGER, LASTRUN TIMESTAMPTZ)`
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `GENERATORS (GENERATOR_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, NAME TEXT NOT NULL, WORKFLOW_SPEC TEXT NOT NULL, TRIGGER INTEGER, LASTRUN TIMESTAMPTZ, WEBHOOK_SECRET TEXT NOT NULL, WORKFLOW_TEMPLATE TEXT NOT NULL)`
 	_, err = db.postgresql.Exec(sqlStatement)
 	if err != nil {
 		return err
@@ -168,7 +216,55 @@ func (db *PQDatabase) Initialize() error {
 		return err
 	}
 
-	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `CRONS (CRON_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, NAME TEXT NOT NULL, CRON_EXPR TEXT NOT NULL, INTERVALL INT, RANDOM BOOLEAN, NEXT_RUN TIMESTAMPTZ, LAST_RUN TIMESTAMPTZ, WORKFLOW_SPEC TEXT NOT NULL, LAST_PROCESSGRAPH_ID TEXT NOT NULL)`
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `CRONS (CRON_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, NAME TEXT NOT NULL, CRON_EXPR TEXT NOT NULL, INTERVALL INT, RANDOM BOOLEAN, NEXT_RUN TIMESTAMPTZ, LAST_RUN TIMESTAMPTZ, WORKFLOW_SPEC TEXT NOT NULL, LAST_PROCESSGRAPH_ID TEXT NOT NULL, WORKFLOW_TEMPLATE TEXT NOT NULL)`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `WORKFLOWTEMPLATES (WORKFLOWTEMPLATE_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, NAME TEXT NOT NULL, VERSION INTEGER NOT NULL, PARAMETERS TEXT NOT NULL, WORKFLOW_SPEC TEXT NOT NULL, ADDED_TIME TIMESTAMPTZ, UNIQUE (COLONY_ID, NAME, VERSION))`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `RUNTIMEROLES (RUNTIME_ID TEXT NOT NULL, COLONY_ID TEXT NOT NULL, ROLE TEXT NOT NULL, PRIMARY KEY (RUNTIME_ID, ROLE))`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `NONCES (NONCE TEXT PRIMARY KEY NOT NULL, EXPIRES TIMESTAMPTZ NOT NULL)`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `SECRETS (SECRET_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, NAME TEXT NOT NULL, VALUE TEXT NOT NULL, ADDED_TIME TIMESTAMPTZ, UNIQUE (COLONY_ID, NAME))`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `AUDITLOG (SEQ BIGINT PRIMARY KEY NOT NULL, TIME TIMESTAMPTZ NOT NULL, CALLER_ID TEXT NOT NULL, SIGNER_ID TEXT NOT NULL, PAYLOAD_TYPE TEXT NOT NULL, TARGET_IDS TEXT[], STATUS INTEGER, RESULT TEXT, PREV_HASH TEXT NOT NULL, HASH TEXT NOT NULL)`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `REVOCATIONS (RUNTIME_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, REASON TEXT, REVOKED_TIME TIMESTAMPTZ)`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `WEBHOOKS (WEBHOOK_ID TEXT PRIMARY KEY NOT NULL, COLONY_ID TEXT NOT NULL, URL TEXT NOT NULL, EVENTS TEXT[], STATES INTEGER[], FUNCS TEXT[], RUNTIME_TYPES TEXT[], SECRET TEXT NOT NULL, ADDED_TIME TIMESTAMPTZ)`
+	_, err = db.postgresql.Exec(sqlStatement)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `CREATE TABLE ` + db.dbPrefix + `WEBHOOKDELIVERIES (DELIVERY_ID TEXT PRIMARY KEY NOT NULL, WEBHOOK_ID TEXT NOT NULL, COLONY_ID TEXT NOT NULL, EVENT TEXT NOT NULL, PAYLOAD TEXT NOT NULL, STATE INTEGER, ATTEMPTS INTEGER, NEXT_ATTEMPT TIMESTAMPTZ, LAST_ERROR TEXT, CREATED_TIME TIMESTAMPTZ)`
 	_, err = db.postgresql.Exec(sqlStatement)
 	if err != nil {
 		return err
@@ -67,7 +67,7 @@ func (db *PQDatabase) GetAttributeByID(attributeID string) (core.Attribute, erro
 	if len(attributes) > 1 {
 		return core.Attribute{}, errors.New("Expected attributes to be unique")
 	} else if len(attributes) == 0 {
-		return core.Attribute{}, errors.New("Attribute does not exists")
+		return core.Attribute{}, core.WithErrorCode(errors.New("Attribute does not exists"), core.ErrorCodeNotFound)
 	}
 
 	return attributes[0], nil
@@ -89,7 +89,7 @@ func (db *PQDatabase) GetAttribute(targetID string, key string, attributeType in
 	if len(attributes) > 1 {
 		return core.Attribute{}, errors.New("Expected attributes to be unique")
 	} else if len(attributes) == 0 {
-		return core.Attribute{}, errors.New("Attribute does not exists")
+		return core.Attribute{}, core.WithErrorCode(errors.New("Attribute does not exists"), core.ErrorCodeNotFound)
 	}
 
 	return attributes[0], nil
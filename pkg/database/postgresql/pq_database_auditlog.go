@@ -0,0 +1,121 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/lib/pq"
+)
+
+// AppendAuditEntry appends an entry to the audit log
+func (db *PQDatabase) AppendAuditEntry(auditEntry *core.AuditEntry) error {
+	if auditEntry == nil {
+		return errors.New("Audit entry is nil")
+	}
+
+	return db.AppendAuditEntries([]*core.AuditEntry{auditEntry})
+}
+
+// AppendAuditEntries appends entries to the audit log in order using a single transaction. The table is locked while
+// the entries are chained to the last entry in the log, so the chain stays consistent when several servers in a
+// cluster append entries concurrently.
+func (db *PQDatabase) AppendAuditEntries(auditEntries []*core.AuditEntry) error {
+	for _, auditEntry := range auditEntries {
+		if auditEntry == nil {
+			return errors.New("Audit entry is nil")
+		}
+	}
+
+	if len(auditEntries) == 0 {
+		return nil
+	}
+
+	tx, err := db.postgresql.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`LOCK TABLE ` + db.dbPrefix + `AUDITLOG IN EXCLUSIVE MODE`)
+	if err != nil {
+		return err
+	}
+
+	var prev *core.AuditEntry
+	var prevSeq int64
+	var prevHash string
+	err = tx.QueryRow(`SELECT SEQ, HASH FROM `+db.dbPrefix+`AUDITLOG ORDER BY SEQ DESC LIMIT 1`).Scan(&prevSeq, &prevHash)
+	if err == nil {
+		prev = &core.AuditEntry{Seq: prevSeq, Hash: prevHash}
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+
+	// Postgres stores timestamps with microsecond precision, the hash must be calculated on the stored time
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `AUDITLOG (SEQ, TIME, CALLER_ID, SIGNER_ID, PAYLOAD_TYPE, TARGET_IDS, STATUS, RESULT, PREV_HASH, HASH) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	for _, auditEntry := range auditEntries {
+		auditEntry.Time = now
+		auditEntry.Chain(prev)
+
+		_, err = tx.Exec(sqlStatement, auditEntry.Seq, auditEntry.Time, auditEntry.CallerID, auditEntry.SignerID, auditEntry.PayloadType, pq.Array(auditEntry.TargetIDs), auditEntry.Status, auditEntry.Result, auditEntry.PrevHash, auditEntry.Hash)
+		if err != nil {
+			return err
+		}
+		prev = auditEntry
+	}
+
+	return tx.Commit()
+}
+
+func (db *PQDatabase) parseAuditEntries(rows *sql.Rows) ([]*core.AuditEntry, error) {
+	var auditEntries []*core.AuditEntry
+
+	for rows.Next() {
+		var seq int64
+		var t time.Time
+		var callerID string
+		var signerID string
+		var payloadType string
+		var targetIDs []string
+		var status int
+		var result string
+		var prevHash string
+		var hash string
+		if err := rows.Scan(&seq, &t, &callerID, &signerID, &payloadType, pq.Array(&targetIDs), &status, &result, &prevHash, &hash); err != nil {
+			return nil, err
+		}
+
+		if targetIDs == nil {
+			targetIDs = []string{}
+		}
+
+		auditEntry := &core.AuditEntry{Seq: seq, Time: t.UTC(), CallerID: callerID, SignerID: signerID, PayloadType: payloadType, TargetIDs: targetIDs, Status: status, Result: result, PrevHash: prevHash, Hash: hash}
+		auditEntries = append(auditEntries, auditEntry)
+	}
+
+	return auditEntries, nil
+}
+
+// FindAuditEntries returns at most count entries ordered by sequence number, starting at fromSeq, or the latest
+// entries if fromSeq is 0. If targetID is set, only entries targeting that ID are returned.
+func (db *PQDatabase) FindAuditEntries(targetID string, fromSeq int64, count int) ([]*core.AuditEntry, error) {
+	var sqlStatement string
+	if fromSeq > 0 {
+		sqlStatement = `SELECT * FROM ` + db.dbPrefix + `AUDITLOG WHERE ($1='' OR $1=ANY(TARGET_IDS)) AND SEQ>=$2 ORDER BY SEQ ASC LIMIT $3`
+	} else {
+		sqlStatement = `SELECT * FROM (SELECT * FROM ` + db.dbPrefix + `AUDITLOG WHERE ($1='' OR $1=ANY(TARGET_IDS)) AND SEQ>=$2 ORDER BY SEQ DESC LIMIT $3) AS LATEST ORDER BY SEQ ASC`
+	}
+
+	rows, err := db.postgresql.Query(sqlStatement, targetID, fromSeq, count)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return db.parseAuditEntries(rows)
+}
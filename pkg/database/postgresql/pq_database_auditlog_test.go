@@ -0,0 +1,104 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendAuditEntry(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	err = db.AppendAuditEntry(nil)
+	assert.NotNil(t, err)
+
+	callerID := core.GenerateRandomID()
+	signerID := core.GenerateRandomID()
+	targetID := core.GenerateRandomID()
+	for i := 0; i < 5; i++ {
+		err = db.AppendAuditEntry(core.CreateAuditEntry(callerID, signerID, "addcolonymsg", []string{targetID}, 200))
+		assert.Nil(t, err)
+	}
+
+	auditEntries, err := db.FindAuditEntries("", 0, 100)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 5)
+	assert.Equal(t, int64(1), auditEntries[0].Seq)
+	assert.Equal(t, int64(5), auditEntries[4].Seq)
+	assert.Equal(t, callerID, auditEntries[0].CallerID)
+	assert.Equal(t, signerID, auditEntries[0].SignerID)
+	assert.Equal(t, []string{targetID}, auditEntries[0].TargetIDs)
+	assert.Nil(t, core.VerifyAuditChain(auditEntries))
+}
+
+func TestAppendAuditEntries(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	err = db.AppendAuditEntries([]*core.AuditEntry{nil})
+	assert.NotNil(t, err)
+
+	err = db.AppendAuditEntries([]*core.AuditEntry{})
+	assert.Nil(t, err)
+
+	err = db.AppendAuditEntry(core.CreateAuditEntry(core.GenerateRandomID(), core.GenerateRandomID(), "addcolonymsg", []string{}, 200))
+	assert.Nil(t, err)
+
+	var auditEntries []*core.AuditEntry
+	for i := 0; i < 5; i++ {
+		auditEntries = append(auditEntries, core.CreateAuditEntry(core.GenerateRandomID(), core.GenerateRandomID(), "submitprocessspecmsg", []string{core.GenerateRandomID()}, 200))
+	}
+	err = db.AppendAuditEntries(auditEntries)
+	assert.Nil(t, err)
+
+	auditEntriesFromDB, err := db.FindAuditEntries("", 0, 100)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntriesFromDB, 6)
+	assert.Equal(t, int64(6), auditEntriesFromDB[5].Seq)
+	assert.Equal(t, auditEntries[4].CallerID, auditEntriesFromDB[5].CallerID)
+	assert.Nil(t, core.VerifyAuditChain(auditEntriesFromDB))
+}
+
+func TestFindAuditEntries(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	targetID := core.GenerateRandomID()
+	for i := 0; i < 10; i++ {
+		targetIDs := []string{core.GenerateRandomID()}
+		if i%2 == 0 {
+			targetIDs = append(targetIDs, targetID)
+		}
+		err = db.AppendAuditEntry(core.CreateAuditEntry(core.GenerateRandomID(), core.GenerateRandomID(), "addruntimemsg", targetIDs, 200))
+		assert.Nil(t, err)
+	}
+
+	// Latest entries
+	auditEntries, err := db.FindAuditEntries("", 0, 3)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 3)
+	assert.Equal(t, int64(8), auditEntries[0].Seq)
+	assert.Equal(t, int64(10), auditEntries[2].Seq)
+	assert.Nil(t, core.VerifyAuditChain(auditEntries))
+
+	// Entries from a sequence number
+	auditEntries, err = db.FindAuditEntries("", 4, 3)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 3)
+	assert.Equal(t, int64(4), auditEntries[0].Seq)
+	assert.Equal(t, int64(6), auditEntries[2].Seq)
+
+	// Entries by target
+	auditEntries, err = db.FindAuditEntries(targetID, 0, 100)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 5)
+	assert.Nil(t, core.VerifyAuditChain(auditEntries))
+}
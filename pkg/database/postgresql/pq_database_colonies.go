@@ -13,8 +13,13 @@ func (db *PQDatabase) AddColony(colony *core.Colony) error {
 		return errors.New("Colony is nil")
 	}
 
-	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `COLONIES (COLONY_ID, NAME) VALUES ($1, $2)`
-	_, err := db.postgresql.Exec(sqlStatement, colony.ID, colony.Name)
+	ownerID := colony.OwnerID
+	if ownerID == "" {
+		ownerID = colony.ID
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `COLONIES (COLONY_ID, NAME, OWNER_ID) VALUES ($1, $2, $3)`
+	_, err := db.postgresql.Exec(sqlStatement, colony.ID, colony.Name, ownerID)
 	if err != nil {
 		return err
 	}
@@ -28,11 +33,13 @@ func (db *PQDatabase) parseColonies(rows *sql.Rows) ([]*core.Colony, error) {
 	for rows.Next() {
 		var colonyID string
 		var name string
-		if err := rows.Scan(&colonyID, &name); err != nil {
+		var ownerID string
+		if err := rows.Scan(&colonyID, &name, &ownerID); err != nil {
 			return nil, err
 		}
 
 		colony := core.CreateColony(colonyID, name)
+		colony.OwnerID = ownerID
 		colonies = append(colonies, colony)
 	}
 
@@ -76,6 +83,27 @@ func (db *PQDatabase) GetColonyByID(id string) (*core.Colony, error) {
 	return colonies[0], nil
 }
 
+// SetColonyOwner hands over the ownership of a colony to another key, the colony Id is kept so that all
+// runtimes, processes and other resources remain associated with the colony
+func (db *PQDatabase) SetColonyOwner(colonyID string, ownerID string) error {
+	sqlStatement := `UPDATE ` + db.dbPrefix + `COLONIES SET OWNER_ID=$1 WHERE COLONY_ID=$2`
+	result, err := db.postgresql.Exec(sqlStatement, ownerID, colonyID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return core.WithErrorCode(errors.New("Colony with Id <"+colonyID+"> does not exists"), core.ErrorCodeNotFound)
+	}
+
+	return nil
+}
+
 func (db *PQDatabase) DeleteColonyByID(colonyID string) error {
 	err := db.DeleteRuntimesByColonyID(colonyID)
 	if err != nil {
@@ -108,6 +136,21 @@ func (db *PQDatabase) DeleteColonyByID(colonyID string) error {
 		return err
 	}
 
+	err = db.DeleteAllWorkflowTemplatesByColonyID(colonyID)
+	if err != nil {
+		return err
+	}
+
+	err = db.DeleteAllSecretsByColonyID(colonyID)
+	if err != nil {
+		return err
+	}
+
+	err = db.DeleteAllWebhooksByColonyID(colonyID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -77,6 +77,33 @@ func TestGetColonyByID(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestSetColonyOwner(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colony := core.CreateColony(core.GenerateRandomID(), "test_colony_name")
+	err = db.AddColony(colony)
+	assert.Nil(t, err)
+
+	colonyFromDB, err := db.GetColonyByID(colony.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, colony.ID, colonyFromDB.OwnerID)
+
+	newOwnerID := core.GenerateRandomID()
+	err = db.SetColonyOwner(colony.ID, newOwnerID)
+	assert.Nil(t, err)
+
+	colonyFromDB, err = db.GetColonyByID(colony.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, colony.ID, colonyFromDB.ID)
+	assert.Equal(t, newOwnerID, colonyFromDB.OwnerID)
+
+	err = db.SetColonyOwner(core.GenerateRandomID(), newOwnerID)
+	assert.NotNil(t, err) // Colony does not exist
+}
+
 func TestDeleteColonies(t *testing.T) {
 	db, err := PrepareTests()
 	assert.Nil(t, err)
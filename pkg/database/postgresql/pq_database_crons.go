@@ -9,8 +9,13 @@ import (
 )
 
 func (db *PQDatabase) AddCron(cron *core.Cron) error {
-	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `CRONS (CRON_ID, COLONY_ID, NAME, CRON_EXPR, INTERVALL, RANDOM, NEXT_RUN, LAST_RUN, WORKFLOW_SPEC, LAST_PROCESSGRAPH_ID) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
-	_, err := db.postgresql.Exec(sqlStatement, cron.ID, cron.ColonyID, cron.Name, cron.CronExpression, cron.Interval, cron.Random, cron.NextRun, cron.LastRun, cron.WorkflowSpec, cron.LastProcessGraphID)
+	workflowTemplate, err := encodeWorkflowTemplateRef(cron.WorkflowTemplate)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `CRONS (CRON_ID, COLONY_ID, NAME, CRON_EXPR, INTERVALL, RANDOM, NEXT_RUN, LAST_RUN, WORKFLOW_SPEC, LAST_PROCESSGRAPH_ID, WORKFLOW_TEMPLATE) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err = db.postgresql.Exec(sqlStatement, cron.ID, cron.ColonyID, cron.Name, cron.CronExpression, cron.Interval, cron.Random, cron.NextRun, cron.LastRun, cron.WorkflowSpec, cron.LastProcessGraphID, workflowTemplate)
 	if err != nil {
 		return err
 	}
@@ -42,12 +47,18 @@ func (db *PQDatabase) parseCrons(rows *sql.Rows) ([]*core.Cron, error) {
 		var lastRun time.Time
 		var workflowSpec string
 		var lastProcessGraphID string
+		var workflowTemplateJSON string
+
+		if err := rows.Scan(&cronID, &colonyID, &name, &cronExpr, &interval, &random, &nextRun, &lastRun, &workflowSpec, &lastProcessGraphID, &workflowTemplateJSON); err != nil {
+			return nil, err
+		}
 
-		if err := rows.Scan(&cronID, &colonyID, &name, &cronExpr, &interval, &random, &nextRun, &lastRun, &workflowSpec, &lastProcessGraphID); err != nil {
+		workflowTemplate, err := decodeWorkflowTemplateRef(workflowTemplateJSON)
+		if err != nil {
 			return nil, err
 		}
 
-		cron := &core.Cron{ID: cronID, ColonyID: colonyID, Name: name, CronExpression: cronExpr, Interval: interval, Random: random, NextRun: nextRun, LastRun: lastRun, WorkflowSpec: workflowSpec, LastProcessGraphID: lastProcessGraphID}
+		cron := &core.Cron{ID: cronID, ColonyID: colonyID, Name: name, CronExpression: cronExpr, Interval: interval, Random: random, NextRun: nextRun, LastRun: lastRun, WorkflowSpec: workflowSpec, LastProcessGraphID: lastProcessGraphID, WorkflowTemplate: workflowTemplate}
 
 		crons = append(crons, cron)
 	}
@@ -20,6 +20,26 @@ func TestAddCron(t *testing.T) {
 	err = db.AddCron(cron)
 	assert.Nil(t, err)
 
+	cronFromDB, err := db.GetCronByID(cron.ID)
+	assert.Nil(t, err)
+	assert.NotNil(t, cronFromDB)
+	assert.True(t, cron.Equals(cronFromDB))
+	assert.Nil(t, cronFromDB.WorkflowTemplate)
+}
+
+func TestAddCronWithWorkflowTemplate(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	cron := core.CreateCron(core.GenerateRandomID(), "test_name", "* * * * * *", 0, false, "")
+	cron.ID = core.GenerateRandomID()
+	cron.WorkflowTemplate = core.CreateWorkflowTemplateRef("test_template", 1, map[string]string{"input": "test_input"})
+
+	err = db.AddCron(cron)
+	assert.Nil(t, err)
+
 	cronFromDB, err := db.GetCronByID(cron.ID)
 	assert.Nil(t, err)
 	assert.NotNil(t, cronFromDB)
@@ -9,8 +9,13 @@ import (
 )
 
 func (db *PQDatabase) AddGenerator(generator *core.Generator) error {
-	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `GENERATORS (GENERATOR_ID, COLONY_ID, NAME, WORKFLOW_SPEC, TRIGGER, LASTRUN) VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := db.postgresql.Exec(sqlStatement, generator.ID, generator.ColonyID, generator.Name, generator.WorkflowSpec, generator.Trigger, time.Time{})
+	workflowTemplate, err := encodeWorkflowTemplateRef(generator.WorkflowTemplate)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `GENERATORS (GENERATOR_ID, COLONY_ID, NAME, WORKFLOW_SPEC, TRIGGER, LASTRUN, WEBHOOK_SECRET, WORKFLOW_TEMPLATE) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err = db.postgresql.Exec(sqlStatement, generator.ID, generator.ColonyID, generator.Name, generator.WorkflowSpec, generator.Trigger, time.Time{}, generator.WebhookSecret, workflowTemplate)
 	if err != nil {
 		return err
 	}
@@ -28,11 +33,18 @@ func (db *PQDatabase) parseGenerators(rows *sql.Rows) ([]*core.Generator, error)
 		var workflowSpec string
 		var trigger int
 		var lastRun time.Time
-		if err := rows.Scan(&generatorID, &colonyID, &name, &workflowSpec, &trigger, &lastRun); err != nil {
+		var webhookSecret string
+		var workflowTemplateJSON string
+		if err := rows.Scan(&generatorID, &colonyID, &name, &workflowSpec, &trigger, &lastRun, &webhookSecret, &workflowTemplateJSON); err != nil {
+			return nil, err
+		}
+
+		workflowTemplate, err := decodeWorkflowTemplateRef(workflowTemplateJSON)
+		if err != nil {
 			return nil, err
 		}
 
-		generator := &core.Generator{ID: generatorID, ColonyID: colonyID, Name: name, WorkflowSpec: workflowSpec, Trigger: trigger, LastRun: lastRun}
+		generator := &core.Generator{ID: generatorID, ColonyID: colonyID, Name: name, WorkflowSpec: workflowSpec, Trigger: trigger, LastRun: lastRun, WebhookSecret: webhookSecret, WorkflowTemplate: workflowTemplate}
 
 		generators = append(generators, generator)
 	}
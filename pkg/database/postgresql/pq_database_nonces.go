@@ -0,0 +1,32 @@
+package postgresql
+
+import (
+	"time"
+)
+
+// AddNonce stores a nonce until it expires. It returns false if the nonce has already been stored, i.e. if the
+// message carrying the nonce is a replay.
+func (db *PQDatabase) AddNonce(nonce string, expires time.Time) (bool, error) {
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `NONCES (NONCE, EXPIRES) VALUES ($1, $2) ON CONFLICT (NONCE) DO NOTHING`
+	result, err := db.postgresql.Exec(sqlStatement, nonce, expires)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (db *PQDatabase) DeleteExpiredNonces() error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `NONCES WHERE EXPIRES < $1`
+	_, err := db.postgresql.Exec(sqlStatement, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
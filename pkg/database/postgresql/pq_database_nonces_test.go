@@ -0,0 +1,53 @@
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddNonce(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	nonce := core.GenerateRandomID()
+	added, err := db.AddNonce(nonce, time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	added, err = db.AddNonce(nonce, time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.False(t, added) // Replay
+}
+
+func TestDeleteExpiredNonces(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	expiredNonce := core.GenerateRandomID()
+	added, err := db.AddNonce(expiredNonce, time.Now().Add(-time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	nonce := core.GenerateRandomID()
+	added, err = db.AddNonce(nonce, time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	err = db.DeleteExpiredNonces()
+	assert.Nil(t, err)
+
+	added, err = db.AddNonce(expiredNonce, time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.True(t, added) // Expired nonce was removed
+
+	added, err = db.AddNonce(nonce, time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.False(t, added)
+}
@@ -19,8 +19,8 @@ func (db *PQDatabase) AddProcess(process *core.Process) error {
 
 	submissionTime := time.Now()
 
-	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `PROCESSES (PROCESS_ID, TARGET_COLONY_ID, TARGET_RUNTIME_IDS, ASSIGNED_RUNTIME_ID, STATE, IS_ASSIGNED, RUNTIME_TYPE, SUBMISSION_TIME, START_TIME, END_TIME, WAIT_DEADLINE, EXEC_DEADLINE, ERROR_MSG, RETRIES, NAME, FUNC, ARGS, MAX_WAIT_TIME, MAX_EXEC_TIME, MAX_RETRIES, DEPENDENCIES, PRIORITY, WAIT_FOR_PARENTS, PARENTS, CHILDREN, PROCESSGRAPH_ID) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`
-	_, err := db.postgresql.Exec(sqlStatement, process.ID, process.ProcessSpec.Conditions.ColonyID, pq.Array(targetRuntimeIDs), process.AssignedRuntimeID, process.State, process.IsAssigned, process.ProcessSpec.Conditions.RuntimeType, submissionTime, time.Time{}, time.Time{}, process.WaitDeadline, process.ExecDeadline, process.ErrorMsg, 0, process.ProcessSpec.Name, process.ProcessSpec.Func, pq.Array(process.ProcessSpec.Args), process.ProcessSpec.MaxWaitTime, process.ProcessSpec.MaxExecTime, process.ProcessSpec.MaxRetries, pq.Array(process.ProcessSpec.Conditions.Dependencies), process.ProcessSpec.Priority, process.WaitForParents, pq.Array(process.Parents), pq.Array(process.Children), process.ProcessGraphID)
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `PROCESSES (PROCESS_ID, TARGET_COLONY_ID, TARGET_RUNTIME_IDS, ASSIGNED_RUNTIME_ID, STATE, IS_ASSIGNED, RUNTIME_TYPE, SUBMISSION_TIME, START_TIME, END_TIME, WAIT_DEADLINE, EXEC_DEADLINE, ERROR_MSG, RETRIES, NAME, FUNC, ARGS, MAX_WAIT_TIME, MAX_EXEC_TIME, MAX_RETRIES, DEPENDENCIES, PRIORITY, WAIT_FOR_PARENTS, PARENTS, CHILDREN, PROCESSGRAPH_ID, SECRETS) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)`
+	_, err := db.postgresql.Exec(sqlStatement, process.ID, process.ProcessSpec.Conditions.ColonyID, pq.Array(targetRuntimeIDs), process.AssignedRuntimeID, process.State, process.IsAssigned, process.ProcessSpec.Conditions.RuntimeType, submissionTime, time.Time{}, time.Time{}, process.WaitDeadline, process.ExecDeadline, process.ErrorMsg, 0, process.ProcessSpec.Name, process.ProcessSpec.Func, pq.Array(process.ProcessSpec.Args), process.ProcessSpec.MaxWaitTime, process.ProcessSpec.MaxExecTime, process.ProcessSpec.MaxRetries, pq.Array(process.ProcessSpec.Conditions.Dependencies), process.ProcessSpec.Priority, process.WaitForParents, pq.Array(process.Parents), pq.Array(process.Children), process.ProcessGraphID, pq.Array(process.ProcessSpec.Secrets))
 	if err != nil {
 		return err
 	}
@@ -70,8 +70,9 @@ func (db *PQDatabase) parseProcesses(rows *sql.Rows) ([]*core.Process, error) {
 		var parents []string
 		var children []string
 		var processGraphID string
+		var secrets []string
 
-		if err := rows.Scan(&processID, &targetColonyID, pq.Array(&targetRuntimeIDs), &assignedRuntimeID, &state, &isAssigned, &runtimeType, &submissionTime, &startTime, &endTime, &waitDeadline, &execDeadline, &errorMsg, &name, &fn, pq.Array(&args), &maxWaitTime, &maxExecTime, &retries, &maxRetries, pq.Array(&dependencies), &priority, &waitForParent, pq.Array(&parents), pq.Array(&children), &processGraphID); err != nil {
+		if err := rows.Scan(&processID, &targetColonyID, pq.Array(&targetRuntimeIDs), &assignedRuntimeID, &state, &isAssigned, &runtimeType, &submissionTime, &startTime, &endTime, &waitDeadline, &execDeadline, &errorMsg, &name, &fn, pq.Array(&args), &maxWaitTime, &maxExecTime, &retries, &maxRetries, pq.Array(&dependencies), &priority, &waitForParent, pq.Array(&parents), pq.Array(&children), &processGraphID, pq.Array(&secrets)); err != nil {
 			return nil, err
 		}
 
@@ -104,6 +105,9 @@ func (db *PQDatabase) parseProcesses(rows *sql.Rows) ([]*core.Process, error) {
 		}
 
 		processSpec := core.CreateProcessSpec(name, fn, args, targetColonyID, targetRuntimeIDs, runtimeType, maxWaitTime, maxExecTime, maxRetries, env, dependencies, priority)
+		if len(secrets) > 0 {
+			processSpec.Secrets = secrets
+		}
 		process := core.CreateProcessFromDB(processSpec, processID, assignedRuntimeID, isAssigned, state, submissionTime, startTime, endTime, waitDeadline, execDeadline, errorMsg, retries, attributes)
 		processes = append(processes, process)
 
@@ -201,6 +205,22 @@ func (db *PQDatabase) FindProcessesByRuntimeID(colonyID string, runtimeID string
 	return matches, nil
 }
 
+func (db *PQDatabase) FindRunningProcessesByRuntimeID(runtimeID string) ([]*core.Process, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `PROCESSES WHERE ASSIGNED_RUNTIME_ID=$1 AND STATE=$2 ORDER BY START_TIME DESC`
+	rows, err := db.postgresql.Query(sqlStatement, runtimeID, core.RUNNING)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches, err := db.parseProcesses(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
 func (db *PQDatabase) FindWaitingProcesses(colonyID string, count int) ([]*core.Process, error) {
 	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `PROCESSES WHERE TARGET_COLONY_ID=$1 AND STATE=$2 ORDER BY SUBMISSION_TIME DESC LIMIT $3`
 	rows, err := db.postgresql.Query(sqlStatement, colonyID, core.WAITING, count)
@@ -485,7 +505,7 @@ func (db *PQDatabase) AssignRuntime(runtimeID string, process *core.Process) err
 	}
 
 	if processFromDB.IsAssigned {
-		return errors.New("Process already assigned")
+		return core.WithErrorCode(errors.New("Process already assigned"), core.ErrorCodeConflict)
 	}
 
 	startTime := time.Now()
@@ -521,11 +541,11 @@ func (db *PQDatabase) UnassignRuntime(process *core.Process) error {
 
 func (db *PQDatabase) MarkSuccessful(process *core.Process) error {
 	if process.State == core.FAILED {
-		return errors.New("Tried to set failed process as completed")
+		return core.WithErrorCode(errors.New("Tried to set failed process as completed"), core.ErrorCodeConflict)
 	}
 
 	if process.State == core.WAITING {
-		return errors.New("Tried to set waiting process as completed without being running")
+		return core.WithErrorCode(errors.New("Tried to set waiting process as completed without being running"), core.ErrorCodeConflict)
 	}
 
 	processFromDB, err := db.GetProcessByID(process.ID)
@@ -534,11 +554,11 @@ func (db *PQDatabase) MarkSuccessful(process *core.Process) error {
 	}
 
 	if processFromDB.State == core.FAILED {
-		return errors.New("Tried to set failed process (from db) as successful")
+		return core.WithErrorCode(errors.New("Tried to set failed process (from db) as successful"), core.ErrorCodeConflict)
 	}
 
 	if processFromDB.State == core.WAITING {
-		return errors.New("Tried to set waiting process (from db) as successful without being running")
+		return core.WithErrorCode(errors.New("Tried to set waiting process (from db) as successful without being running"), core.ErrorCodeConflict)
 	}
 
 	endTime := time.Now()
@@ -559,11 +579,11 @@ func (db *PQDatabase) MarkFailed(process *core.Process, errorMsg string) error {
 	endTime := time.Now()
 
 	if process.State == core.SUCCESS {
-		return errors.New("Tried to set successful process as failed")
+		return core.WithErrorCode(errors.New("Tried to set successful process as failed"), core.ErrorCodeConflict)
 	}
 
 	if process.State == core.FAILED {
-		return errors.New("Tried to set failed process as failed")
+		return core.WithErrorCode(errors.New("Tried to set failed process as failed"), core.ErrorCodeConflict)
 	}
 
 	processFromDB, err := db.GetProcessByID(process.ID)
@@ -572,11 +592,11 @@ func (db *PQDatabase) MarkFailed(process *core.Process, errorMsg string) error {
 	}
 
 	if processFromDB.State == core.SUCCESS {
-		return errors.New("Tried to set successful (from db) as failed")
+		return core.WithErrorCode(errors.New("Tried to set successful (from db) as failed"), core.ErrorCodeConflict)
 	}
 
 	if processFromDB.State == core.FAILED {
-		return errors.New("Tried to set failed (from db) as failed")
+		return core.WithErrorCode(errors.New("Tried to set failed (from db) as failed"), core.ErrorCodeConflict)
 	}
 
 	sqlStatement := `UPDATE ` + db.dbPrefix + `PROCESSES SET END_TIME=$1, STATE=$2 WHERE PROCESS_ID=$3`
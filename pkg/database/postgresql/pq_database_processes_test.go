@@ -1139,6 +1139,55 @@ func TestFindProcessesByRuntimeID(t *testing.T) {
 	assert.Equal(t, len(processesFromDB), 20)
 }
 
+func TestFindRunningProcessesByRuntimeID(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colony := core.CreateColony(core.GenerateRandomID(), "test_colony_name")
+	err = db.AddColony(colony)
+	assert.Nil(t, err)
+
+	runtime1 := utils.CreateTestRuntime(colony.ID)
+	err = db.AddRuntime(runtime1)
+	assert.Nil(t, err)
+
+	runtime2 := utils.CreateTestRuntime(colony.ID)
+	err = db.AddRuntime(runtime2)
+	assert.Nil(t, err)
+
+	for i := 0; i < 3; i++ {
+		process := utils.CreateTestProcess(colony.ID)
+		err = db.AddProcess(process)
+		assert.Nil(t, err)
+		err = db.AssignRuntime(runtime1.ID, process)
+		assert.Nil(t, err)
+	}
+
+	process := utils.CreateTestProcess(colony.ID)
+	err = db.AddProcess(process)
+	assert.Nil(t, err)
+	err = db.AssignRuntime(runtime1.ID, process)
+	assert.Nil(t, err)
+	err = db.MarkSuccessful(process)
+	assert.Nil(t, err)
+
+	process = utils.CreateTestProcess(colony.ID)
+	err = db.AddProcess(process)
+	assert.Nil(t, err)
+	err = db.AssignRuntime(runtime2.ID, process)
+	assert.Nil(t, err)
+
+	processesFromDB, err := db.FindRunningProcessesByRuntimeID(runtime1.ID)
+	assert.Nil(t, err)
+	assert.Len(t, processesFromDB, 3)
+
+	processesFromDB, err = db.FindRunningProcessesByRuntimeID(runtime2.ID)
+	assert.Nil(t, err)
+	assert.Len(t, processesFromDB, 1)
+}
+
 func TestFindProcessesByColonyID(t *testing.T) {
 	db, err := PrepareTests()
 	assert.Nil(t, err)
@@ -0,0 +1,80 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+)
+
+// AddRevocation adds a runtime to the revocation list. Revocations are never removed, not even when the colony is
+// deleted, since a revoked runtime Id must never be registered again. Revoking an already revoked runtime is a no-op.
+func (db *PQDatabase) AddRevocation(revocation *core.Revocation) error {
+	if revocation == nil {
+		return errors.New("Revocation is nil")
+	}
+
+	revokedTime := time.Now()
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `REVOCATIONS (RUNTIME_ID, COLONY_ID, REASON, REVOKED_TIME) VALUES ($1, $2, $3, $4) ON CONFLICT (RUNTIME_ID) DO NOTHING`
+	_, err := db.postgresql.Exec(sqlStatement, revocation.RuntimeID, revocation.ColonyID, revocation.Reason, revokedTime)
+	if err != nil {
+		return err
+	}
+
+	revocation.RevokedTime = revokedTime
+
+	return nil
+}
+
+func (db *PQDatabase) parseRevocations(rows *sql.Rows) ([]*core.Revocation, error) {
+	var revocations []*core.Revocation
+
+	for rows.Next() {
+		var runtimeID string
+		var colonyID string
+		var reason string
+		var revokedTime time.Time
+		if err := rows.Scan(&runtimeID, &colonyID, &reason, &revokedTime); err != nil {
+			return nil, err
+		}
+
+		revocation := &core.Revocation{RuntimeID: runtimeID, ColonyID: colonyID, Reason: reason, RevokedTime: revokedTime}
+		revocations = append(revocations, revocation)
+	}
+
+	return revocations, nil
+}
+
+func (db *PQDatabase) GetRevocation(runtimeID string) (*core.Revocation, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `REVOCATIONS WHERE RUNTIME_ID=$1`
+	rows, err := db.postgresql.Query(sqlStatement, runtimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	revocations, err := db.parseRevocations(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revocations) == 0 {
+		return nil, nil
+	}
+
+	return revocations[0], nil
+}
+
+func (db *PQDatabase) GetRevocationsByColonyID(colonyID string) ([]*core.Revocation, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `REVOCATIONS WHERE COLONY_ID=$1 ORDER BY REVOKED_TIME`
+	rows, err := db.postgresql.Query(sqlStatement, colonyID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return db.parseRevocations(rows)
+}
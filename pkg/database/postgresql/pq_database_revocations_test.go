@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRevocation(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	runtimeID := core.GenerateRandomID()
+
+	revocationFromDB, err := db.GetRevocation(runtimeID)
+	assert.Nil(t, err)
+	assert.Nil(t, revocationFromDB)
+
+	revocation := core.CreateRevocation(runtimeID, colonyID, "compromised")
+	err = db.AddRevocation(revocation)
+	assert.Nil(t, err)
+
+	revocationFromDB, err = db.GetRevocation(runtimeID)
+	assert.Nil(t, err)
+	assert.True(t, revocation.Equals(revocationFromDB))
+
+	// Revoking the same runtime again keeps the first revocation
+	err = db.AddRevocation(core.CreateRevocation(runtimeID, colonyID, "again"))
+	assert.Nil(t, err)
+
+	revocationFromDB, err = db.GetRevocation(runtimeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "compromised", revocationFromDB.Reason)
+
+	err = db.AddRevocation(nil)
+	assert.NotNil(t, err)
+}
+
+func TestGetRevocationsByColonyID(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	revocation1 := core.CreateRevocation(core.GenerateRandomID(), colonyID, "compromised")
+	err = db.AddRevocation(revocation1)
+	assert.Nil(t, err)
+
+	revocation2 := core.CreateRevocation(core.GenerateRandomID(), colonyID, "decommissioned")
+	err = db.AddRevocation(revocation2)
+	assert.Nil(t, err)
+
+	err = db.AddRevocation(core.CreateRevocation(core.GenerateRandomID(), core.GenerateRandomID(), ""))
+	assert.Nil(t, err)
+
+	revocations, err := db.GetRevocationsByColonyID(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, revocations, 2)
+	assert.True(t, revocation1.Equals(revocations[0]))
+	assert.True(t, revocation2.Equals(revocations[1]))
+}
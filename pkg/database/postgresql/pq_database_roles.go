@@ -0,0 +1,52 @@
+package postgresql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/core"
+)
+
+func (db *PQDatabase) AddRuntimeRole(runtimeID string, colonyID string, role string) error {
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `RUNTIMEROLES (RUNTIME_ID, COLONY_ID, ROLE) VALUES ($1, $2, $3)`
+	_, err := db.postgresql.Exec(sqlStatement, runtimeID, colonyID, role)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "pq: duplicate key value violates unique constraint") {
+			return core.WithErrorCode(errors.New("Runtime with Id <"+runtimeID+"> already has role <"+role+">"), core.ErrorCodeConflict)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) RemoveRuntimeRole(runtimeID string, role string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `RUNTIMEROLES WHERE RUNTIME_ID=$1 AND ROLE=$2`
+	_, err := db.postgresql.Exec(sqlStatement, runtimeID, role)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) GetRuntimeRoles(runtimeID string) ([]string, error) {
+	sqlStatement := `SELECT ROLE FROM ` + db.dbPrefix + `RUNTIMEROLES WHERE RUNTIME_ID=$1 ORDER BY ROLE`
+	rows, err := db.postgresql.Query(sqlStatement, runtimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,81 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRuntimeRole(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	runtime := utils.CreateTestRuntime(colonyID)
+	err = db.AddRuntime(runtime)
+	assert.Nil(t, err)
+
+	roles, err := db.GetRuntimeRoles(runtime.ID)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 0)
+
+	err = db.AddRuntimeRole(runtime.ID, colonyID, core.ROLE_VIEWER)
+	assert.Nil(t, err)
+	err = db.AddRuntimeRole(runtime.ID, colonyID, core.ROLE_SUBMITTER)
+	assert.Nil(t, err)
+	err = db.AddRuntimeRole(runtime.ID, colonyID, core.ROLE_SUBMITTER)
+	assert.NotNil(t, err) // Already added
+
+	roles, err = db.GetRuntimeRoles(runtime.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{core.ROLE_SUBMITTER, core.ROLE_VIEWER}, roles)
+
+	err = db.RemoveRuntimeRole(runtime.ID, core.ROLE_VIEWER)
+	assert.Nil(t, err)
+
+	roles, err = db.GetRuntimeRoles(runtime.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{core.ROLE_SUBMITTER}, roles)
+}
+
+func TestDeleteRuntimeRoles(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	runtime1 := utils.CreateTestRuntime(colonyID)
+	err = db.AddRuntime(runtime1)
+	assert.Nil(t, err)
+	runtime2 := utils.CreateTestRuntime(colonyID)
+	err = db.AddRuntime(runtime2)
+	assert.Nil(t, err)
+
+	err = db.AddRuntimeRole(runtime1.ID, colonyID, core.ROLE_VIEWER)
+	assert.Nil(t, err)
+	err = db.AddRuntimeRole(runtime2.ID, colonyID, core.ROLE_VIEWER)
+	assert.Nil(t, err)
+
+	err = db.DeleteRuntimeByID(runtime1.ID)
+	assert.Nil(t, err)
+
+	roles, err := db.GetRuntimeRoles(runtime1.ID)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 0)
+
+	roles, err = db.GetRuntimeRoles(runtime2.ID)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+
+	err = db.DeleteRuntimesByColonyID(colonyID)
+	assert.Nil(t, err)
+
+	roles, err = db.GetRuntimeRoles(runtime2.ID)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 0)
+}
@@ -15,7 +15,7 @@ func (db *PQDatabase) AddRuntime(runtime *core.Runtime) error {
 	_, err := db.postgresql.Exec(sqlStatement, runtime.ID, runtime.RuntimeType, runtime.Name, runtime.ColonyID, runtime.CPU, runtime.Cores, runtime.Mem, runtime.GPU, runtime.GPUs, 0, time.Now(), runtime.LastHeardFromTime)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "pq: duplicate key value violates unique constraint") {
-			return errors.New("Runtime name has to be unique")
+			return core.WithErrorCode(errors.New("Runtime name has to be unique"), core.ErrorCodeConflict)
 		}
 		return err
 	}
@@ -147,6 +147,12 @@ func (db *PQDatabase) DeleteRuntimeByID(runtimeID string) error {
 		return err
 	}
 
+	sqlStatement = `DELETE FROM ` + db.dbPrefix + `RUNTIMEROLES WHERE RUNTIME_ID=$1`
+	_, err = db.postgresql.Exec(sqlStatement, runtimeID)
+	if err != nil {
+		return err
+	}
+
 	// Move back the runtime currently running process back to the queue
 	sqlStatement = `UPDATE ` + db.dbPrefix + `PROCESSES SET IS_ASSIGNED=FALSE, START_TIME=$1, END_TIME=$2, ASSIGNED_RUNTIME_ID=$3, STATE=$4 WHERE ASSIGNED_RUNTIME_ID=$5 AND STATE=$6`
 	_, err = db.postgresql.Exec(sqlStatement, time.Time{}, time.Time{}, "", core.WAITING, runtimeID, core.RUNNING)
@@ -164,6 +170,12 @@ func (db *PQDatabase) DeleteRuntimesByColonyID(colonyID string) error {
 		return err
 	}
 
+	sqlStatement = `DELETE FROM ` + db.dbPrefix + `RUNTIMEROLES WHERE COLONY_ID=$1`
+	_, err = db.postgresql.Exec(sqlStatement, colonyID)
+	if err != nil {
+		return err
+	}
+
 	// Move back the runtime currently running process back to the queue
 	sqlStatement = `UPDATE ` + db.dbPrefix + `PROCESSES SET IS_ASSIGNED=FALSE, START_TIME=$1, END_TIME=$2, ASSIGNED_RUNTIME_ID=$3, STATE=$4 WHERE TARGET_COLONY_ID=$5 AND STATE=$6`
 	_, err = db.postgresql.Exec(sqlStatement, time.Time{}, time.Time{}, "", core.WAITING, colonyID, core.RUNNING)
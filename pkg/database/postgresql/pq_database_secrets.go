@@ -0,0 +1,98 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+)
+
+// SetSecret adds a secret, or replaces the value of an existing secret with the same name in the colony. The value
+// is stored as is, so it is expected to already be encrypted.
+func (db *PQDatabase) SetSecret(secret *core.Secret) error {
+	if secret == nil {
+		return errors.New("Secret is nil")
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `SECRETS (SECRET_ID, COLONY_ID, NAME, VALUE, ADDED_TIME) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (COLONY_ID, NAME) DO UPDATE SET VALUE=EXCLUDED.VALUE, ADDED_TIME=EXCLUDED.ADDED_TIME`
+	_, err := db.postgresql.Exec(sqlStatement, secret.ID, secret.ColonyID, secret.Name, secret.Value, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) parseSecrets(rows *sql.Rows) ([]*core.Secret, error) {
+	var secrets []*core.Secret
+
+	for rows.Next() {
+		var secretID string
+		var colonyID string
+		var name string
+		var value string
+		var addedTime time.Time
+		if err := rows.Scan(&secretID, &colonyID, &name, &value, &addedTime); err != nil {
+			return nil, err
+		}
+
+		secret := &core.Secret{ID: secretID, ColonyID: colonyID, Name: name, Value: value, AddedTime: addedTime}
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+func (db *PQDatabase) GetSecret(colonyID string, name string) (*core.Secret, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `SECRETS WHERE COLONY_ID=$1 AND NAME=$2`
+	rows, err := db.postgresql.Query(sqlStatement, colonyID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	secrets, err := db.parseSecrets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	return secrets[0], nil
+}
+
+func (db *PQDatabase) GetSecrets(colonyID string) ([]*core.Secret, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `SECRETS WHERE COLONY_ID=$1 ORDER BY NAME`
+	rows, err := db.postgresql.Query(sqlStatement, colonyID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return db.parseSecrets(rows)
+}
+
+func (db *PQDatabase) DeleteSecret(colonyID string, name string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `SECRETS WHERE COLONY_ID=$1 AND NAME=$2`
+	_, err := db.postgresql.Exec(sqlStatement, colonyID, name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) DeleteAllSecretsByColonyID(colonyID string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `SECRETS WHERE COLONY_ID=$1`
+	_, err := db.postgresql.Exec(sqlStatement, colonyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSecret(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	secret := core.CreateSecret(colonyID, "DB_PASSWORD", "encrypted_value")
+	secret.ID = core.GenerateRandomID()
+	err = db.SetSecret(secret)
+	assert.Nil(t, err)
+
+	secretFromDB, err := db.GetSecret(colonyID, "DB_PASSWORD")
+	assert.Nil(t, err)
+	assert.True(t, secret.Equals(secretFromDB))
+
+	// Setting a secret with the same name replaces the value
+	secret2 := core.CreateSecret(colonyID, "DB_PASSWORD", "encrypted_value2")
+	secret2.ID = core.GenerateRandomID()
+	err = db.SetSecret(secret2)
+	assert.Nil(t, err)
+
+	secretFromDB, err = db.GetSecret(colonyID, "DB_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "encrypted_value2", secretFromDB.Value)
+
+	secretFromDB, err = db.GetSecret(colonyID, "API_TOKEN")
+	assert.Nil(t, err)
+	assert.Nil(t, secretFromDB)
+}
+
+func TestGetSecrets(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	for _, name := range []string{"DB_PASSWORD", "API_TOKEN"} {
+		secret := core.CreateSecret(colonyID, name, "encrypted_value")
+		secret.ID = core.GenerateRandomID()
+		err = db.SetSecret(secret)
+		assert.Nil(t, err)
+	}
+
+	secret := core.CreateSecret(core.GenerateRandomID(), "DB_PASSWORD", "encrypted_value")
+	secret.ID = core.GenerateRandomID()
+	err = db.SetSecret(secret)
+	assert.Nil(t, err)
+
+	secrets, err := db.GetSecrets(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, secrets, 2)
+	assert.Equal(t, "API_TOKEN", secrets[0].Name)
+	assert.Equal(t, "DB_PASSWORD", secrets[1].Name)
+}
+
+func TestDeleteSecrets(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	for _, name := range []string{"DB_PASSWORD", "API_TOKEN", "SSH_KEY"} {
+		secret := core.CreateSecret(colonyID, name, "encrypted_value")
+		secret.ID = core.GenerateRandomID()
+		err = db.SetSecret(secret)
+		assert.Nil(t, err)
+	}
+
+	err = db.DeleteSecret(colonyID, "DB_PASSWORD")
+	assert.Nil(t, err)
+
+	secrets, err := db.GetSecrets(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, secrets, 2)
+
+	err = db.DeleteAllSecretsByColonyID(colonyID)
+	assert.Nil(t, err)
+
+	secrets, err = db.GetSecrets(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, secrets, 0)
+}
@@ -0,0 +1,199 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/lib/pq"
+)
+
+func (db *PQDatabase) AddWebhook(webhook *core.Webhook) error {
+	if webhook == nil {
+		return errors.New("Webhook is nil")
+	}
+
+	states := make([]int64, len(webhook.Filter.States))
+	for i, state := range webhook.Filter.States {
+		states[i] = int64(state)
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `WEBHOOKS (WEBHOOK_ID, COLONY_ID, URL, EVENTS, STATES, FUNCS, RUNTIME_TYPES, SECRET, ADDED_TIME) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := db.postgresql.Exec(sqlStatement, webhook.ID, webhook.ColonyID, webhook.URL, pq.Array(webhook.Filter.Events), pq.Array(states), pq.Array(webhook.Filter.Funcs), pq.Array(webhook.Filter.RuntimeTypes), webhook.Secret, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) parseWebhooks(rows *sql.Rows) ([]*core.Webhook, error) {
+	var webhooks []*core.Webhook
+
+	for rows.Next() {
+		var webhookID string
+		var colonyID string
+		var url string
+		var events []string
+		var states pq.Int64Array
+		var funcs []string
+		var runtimeTypes []string
+		var secret string
+		var addedTime time.Time
+		if err := rows.Scan(&webhookID, &colonyID, &url, pq.Array(&events), &states, pq.Array(&funcs), pq.Array(&runtimeTypes), &secret, &addedTime); err != nil {
+			return nil, err
+		}
+
+		filter := core.WebhookFilter{Events: events, Funcs: funcs, RuntimeTypes: runtimeTypes}
+		for _, state := range states {
+			filter.States = append(filter.States, int(state))
+		}
+
+		webhook := &core.Webhook{ID: webhookID, ColonyID: colonyID, URL: url, Filter: filter, Secret: secret, AddedTime: addedTime}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (db *PQDatabase) GetWebhookByID(webhookID string) (*core.Webhook, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WEBHOOKS WHERE WEBHOOK_ID=$1`
+	rows, err := db.postgresql.Query(sqlStatement, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	webhooks, err := db.parseWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+
+	return webhooks[0], nil
+}
+
+func (db *PQDatabase) GetWebhooksByColonyID(colonyID string) ([]*core.Webhook, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WEBHOOKS WHERE COLONY_ID=$1 ORDER BY ADDED_TIME`
+	rows, err := db.postgresql.Query(sqlStatement, colonyID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return db.parseWebhooks(rows)
+}
+
+// DeleteWebhookByID deletes a webhook and all its deliveries
+func (db *PQDatabase) DeleteWebhookByID(webhookID string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `WEBHOOKDELIVERIES WHERE WEBHOOK_ID=$1`
+	_, err := db.postgresql.Exec(sqlStatement, webhookID)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DELETE FROM ` + db.dbPrefix + `WEBHOOKS WHERE WEBHOOK_ID=$1`
+	_, err = db.postgresql.Exec(sqlStatement, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) DeleteAllWebhooksByColonyID(colonyID string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `WEBHOOKDELIVERIES WHERE COLONY_ID=$1`
+	_, err := db.postgresql.Exec(sqlStatement, colonyID)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement = `DELETE FROM ` + db.dbPrefix + `WEBHOOKS WHERE COLONY_ID=$1`
+	_, err = db.postgresql.Exec(sqlStatement, colonyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) AddWebhookDelivery(delivery *core.WebhookDelivery) error {
+	if delivery == nil {
+		return errors.New("Webhook delivery is nil")
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `WEBHOOKDELIVERIES (DELIVERY_ID, WEBHOOK_ID, COLONY_ID, EVENT, PAYLOAD, STATE, ATTEMPTS, NEXT_ATTEMPT, LAST_ERROR, CREATED_TIME) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := db.postgresql.Exec(sqlStatement, delivery.ID, delivery.WebhookID, delivery.ColonyID, delivery.Event, delivery.Payload, delivery.State, delivery.Attempts, delivery.NextAttempt, delivery.LastError, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) parseWebhookDeliveries(rows *sql.Rows) ([]*core.WebhookDelivery, error) {
+	var deliveries []*core.WebhookDelivery
+
+	for rows.Next() {
+		delivery := &core.WebhookDelivery{}
+		if err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.ColonyID, &delivery.Event, &delivery.Payload, &delivery.State, &delivery.Attempts, &delivery.NextAttempt, &delivery.LastError, &delivery.CreatedTime); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// FindPendingWebhookDeliveries returns pending deliveries that are due to be attempted, oldest first
+func (db *PQDatabase) FindPendingWebhookDeliveries(count int) ([]*core.WebhookDelivery, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WEBHOOKDELIVERIES WHERE STATE=$1 AND NEXT_ATTEMPT<=$2 ORDER BY NEXT_ATTEMPT LIMIT $3`
+	rows, err := db.postgresql.Query(sqlStatement, core.WEBHOOK_DELIVERY_PENDING, time.Now(), count)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return db.parseWebhookDeliveries(rows)
+}
+
+func (db *PQDatabase) FindWebhookDeliveries(webhookID string, state int, count int) ([]*core.WebhookDelivery, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WEBHOOKDELIVERIES WHERE WEBHOOK_ID=$1 AND STATE=$2 ORDER BY CREATED_TIME DESC LIMIT $3`
+	rows, err := db.postgresql.Query(sqlStatement, webhookID, state, count)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return db.parseWebhookDeliveries(rows)
+}
+
+// UpdateWebhookDelivery stores the state, number of attempts, time of the next attempt and last error of a delivery
+func (db *PQDatabase) UpdateWebhookDelivery(delivery *core.WebhookDelivery) error {
+	sqlStatement := `UPDATE ` + db.dbPrefix + `WEBHOOKDELIVERIES SET STATE=$1, ATTEMPTS=$2, NEXT_ATTEMPT=$3, LAST_ERROR=$4 WHERE DELIVERY_ID=$5`
+	_, err := db.postgresql.Exec(sqlStatement, delivery.State, delivery.Attempts, delivery.NextAttempt, delivery.LastError, delivery.ID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) DeleteWebhookDeliveryByID(deliveryID string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `WEBHOOKDELIVERIES WHERE DELIVERY_ID=$1`
+	_, err := db.postgresql.Exec(sqlStatement, deliveryID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWebhook(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	filter := core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESS}, States: []int{core.SUCCESS, core.FAILED}, Funcs: []string{"train"}, RuntimeTypes: []string{"gpu"}}
+	webhook := core.CreateWebhook(colonyID, "https://example.com/hook", filter, "secret")
+	webhook.ID = core.GenerateRandomID()
+	err = db.AddWebhook(webhook)
+	assert.Nil(t, err)
+
+	err = db.AddWebhook(nil)
+	assert.NotNil(t, err)
+
+	webhookFromDB, err := db.GetWebhookByID(webhook.ID)
+	assert.Nil(t, err)
+	assert.True(t, webhook.Equals(webhookFromDB))
+
+	webhookFromDB, err = db.GetWebhookByID(core.GenerateRandomID())
+	assert.Nil(t, err)
+	assert.Nil(t, webhookFromDB)
+
+	// An empty filter matches everything
+	webhook2 := core.CreateWebhook(colonyID, "https://example.com/hook2", core.WebhookFilter{}, "secret2")
+	webhook2.ID = core.GenerateRandomID()
+	err = db.AddWebhook(webhook2)
+	assert.Nil(t, err)
+
+	webhooks, err := db.GetWebhooksByColonyID(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 2)
+	assert.True(t, webhook.Equals(webhooks[0]))
+	assert.True(t, webhook2.Equals(webhooks[1]))
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+	webhook1 := core.CreateWebhook(colonyID, "https://example.com/hook1", core.WebhookFilter{}, "secret")
+	webhook1.ID = core.GenerateRandomID()
+	err = db.AddWebhook(webhook1)
+	assert.Nil(t, err)
+
+	webhook2 := core.CreateWebhook(colonyID, "https://example.com/hook2", core.WebhookFilter{}, "secret")
+	webhook2.ID = core.GenerateRandomID()
+	err = db.AddWebhook(webhook2)
+	assert.Nil(t, err)
+
+	delivery := core.CreateWebhookDelivery(webhook1, core.WEBHOOK_EVENT_PROCESS, "{}")
+	delivery.ID = core.GenerateRandomID()
+	err = db.AddWebhookDelivery(delivery)
+	assert.Nil(t, err)
+
+	err = db.DeleteWebhookByID(webhook1.ID)
+	assert.Nil(t, err)
+
+	webhooks, err := db.GetWebhooksByColonyID(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 1)
+
+	// Deliveries are deleted together with the webhook
+	deliveries, err := db.FindPendingWebhookDeliveries(100)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 0)
+
+	err = db.DeleteAllWebhooksByColonyID(colonyID)
+	assert.Nil(t, err)
+
+	webhooks, err = db.GetWebhooksByColonyID(colonyID)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 0)
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	webhook := core.CreateWebhook(core.GenerateRandomID(), "https://example.com/hook", core.WebhookFilter{}, "secret")
+	webhook.ID = core.GenerateRandomID()
+	err = db.AddWebhook(webhook)
+	assert.Nil(t, err)
+
+	err = db.AddWebhookDelivery(nil)
+	assert.NotNil(t, err)
+
+	delivery1 := core.CreateWebhookDelivery(webhook, core.WEBHOOK_EVENT_PROCESS, `{"event":"process"}`)
+	delivery1.ID = core.GenerateRandomID()
+	delivery1.NextAttempt = time.Now()
+	err = db.AddWebhookDelivery(delivery1)
+	assert.Nil(t, err)
+
+	// Not due yet
+	delivery2 := core.CreateWebhookDelivery(webhook, core.WEBHOOK_EVENT_PROCESSGRAPH, `{"event":"processgraph"}`)
+	delivery2.ID = core.GenerateRandomID()
+	delivery2.NextAttempt = time.Now().Add(time.Hour)
+	err = db.AddWebhookDelivery(delivery2)
+	assert.Nil(t, err)
+
+	deliveries, err := db.FindPendingWebhookDeliveries(100)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.True(t, delivery1.Equals(deliveries[0]))
+
+	delivery1.Attempts = 3
+	delivery1.LastError = "connection refused"
+	delivery1.State = core.WEBHOOK_DELIVERY_DEAD
+	err = db.UpdateWebhookDelivery(delivery1)
+	assert.Nil(t, err)
+
+	deliveries, err = db.FindPendingWebhookDeliveries(100)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 0)
+
+	deliveries, err = db.FindWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_DEAD, 100)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.True(t, delivery1.Equals(deliveries[0]))
+
+	deliveries, err = db.FindWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_PENDING, 100)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.True(t, delivery2.Equals(deliveries[0]))
+
+	err = db.DeleteWebhookDeliveryByID(delivery2.ID)
+	assert.Nil(t, err)
+
+	deliveries, err = db.FindWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_PENDING, 100)
+	assert.Nil(t, err)
+	assert.Len(t, deliveries, 0)
+}
@@ -0,0 +1,181 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+)
+
+func (db *PQDatabase) AddWorkflowTemplate(workflowTemplate *core.WorkflowTemplate) error {
+	parameters, err := core.ConvertTemplateParametersToJSON(workflowTemplate.Parameters)
+	if err != nil {
+		return err
+	}
+
+	sqlStatement := `INSERT INTO  ` + db.dbPrefix + `WORKFLOWTEMPLATES (WORKFLOWTEMPLATE_ID, COLONY_ID, NAME, VERSION, PARAMETERS, WORKFLOW_SPEC, ADDED_TIME) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err = db.postgresql.Exec(sqlStatement, workflowTemplate.ID, workflowTemplate.ColonyID, workflowTemplate.Name, workflowTemplate.Version, parameters, workflowTemplate.WorkflowSpec, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) parseWorkflowTemplates(rows *sql.Rows) ([]*core.WorkflowTemplate, error) {
+	var workflowTemplates []*core.WorkflowTemplate
+
+	for rows.Next() {
+		var workflowTemplateID string
+		var colonyID string
+		var name string
+		var version int
+		var parametersJSON string
+		var workflowSpec string
+		var addedTime time.Time
+		if err := rows.Scan(&workflowTemplateID, &colonyID, &name, &version, &parametersJSON, &workflowSpec, &addedTime); err != nil {
+			return nil, err
+		}
+
+		parameters, err := core.ConvertJSONToTemplateParameters(parametersJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		workflowTemplate := &core.WorkflowTemplate{ID: workflowTemplateID, ColonyID: colonyID, Name: name, Version: version, Parameters: parameters, WorkflowSpec: workflowSpec, AddedTime: addedTime}
+
+		workflowTemplates = append(workflowTemplates, workflowTemplate)
+	}
+
+	return workflowTemplates, nil
+}
+
+func (db *PQDatabase) GetWorkflowTemplateByID(workflowTemplateID string) (*core.WorkflowTemplate, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE WORKFLOWTEMPLATE_ID=$1`
+	rows, err := db.postgresql.Query(sqlStatement, workflowTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workflowTemplates, err := db.parseWorkflowTemplates(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workflowTemplates) > 1 {
+		return nil, errors.New("Expected one workflow template, workflow template id should be unique")
+	}
+
+	if len(workflowTemplates) == 0 {
+		return nil, nil
+	}
+
+	return workflowTemplates[0], nil
+}
+
+func (db *PQDatabase) GetWorkflowTemplate(colonyID string, name string, version int) (*core.WorkflowTemplate, error) {
+	var rows *sql.Rows
+	var err error
+	if version > 0 {
+		sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1 AND NAME=$2 AND VERSION=$3`
+		rows, err = db.postgresql.Query(sqlStatement, colonyID, name, version)
+	} else {
+		sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1 AND NAME=$2 ORDER BY VERSION DESC LIMIT 1`
+		rows, err = db.postgresql.Query(sqlStatement, colonyID, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workflowTemplates, err := db.parseWorkflowTemplates(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(workflowTemplates) > 1 {
+		return nil, errors.New("Expected one workflow template, workflow template name and version should be unique")
+	}
+
+	if len(workflowTemplates) == 0 {
+		return nil, nil
+	}
+
+	return workflowTemplates[0], nil
+}
+
+func (db *PQDatabase) FindWorkflowTemplatesByColonyID(colonyID string, count int) ([]*core.WorkflowTemplate, error) {
+	sqlStatement := `SELECT DISTINCT ON (NAME) * FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1 ORDER BY NAME, VERSION DESC LIMIT $2`
+	rows, err := db.postgresql.Query(sqlStatement, colonyID, count)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workflowTemplates, err := db.parseWorkflowTemplates(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflowTemplates, nil
+}
+
+func (db *PQDatabase) FindWorkflowTemplateVersions(colonyID string, name string) ([]*core.WorkflowTemplate, error) {
+	sqlStatement := `SELECT * FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1 AND NAME=$2 ORDER BY VERSION`
+	rows, err := db.postgresql.Query(sqlStatement, colonyID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workflowTemplates, err := db.parseWorkflowTemplates(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflowTemplates, nil
+}
+
+func (db *PQDatabase) DeleteWorkflowTemplate(colonyID string, name string, version int) error {
+	var err error
+	if version > 0 {
+		sqlStatement := `DELETE FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1 AND NAME=$2 AND VERSION=$3`
+		_, err = db.postgresql.Exec(sqlStatement, colonyID, name, version)
+	} else {
+		sqlStatement := `DELETE FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1 AND NAME=$2`
+		_, err = db.postgresql.Exec(sqlStatement, colonyID, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db *PQDatabase) DeleteAllWorkflowTemplatesByColonyID(colonyID string) error {
+	sqlStatement := `DELETE FROM ` + db.dbPrefix + `WORKFLOWTEMPLATES WHERE COLONY_ID=$1`
+	_, err := db.postgresql.Exec(sqlStatement, colonyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func encodeWorkflowTemplateRef(workflowTemplateRef *core.WorkflowTemplateRef) (string, error) {
+	if workflowTemplateRef == nil {
+		return "", nil
+	}
+
+	return workflowTemplateRef.ToJSON()
+}
+
+func decodeWorkflowTemplateRef(jsonString string) (*core.WorkflowTemplateRef, error) {
+	if jsonString == "" {
+		return nil, nil
+	}
+
+	return core.ConvertJSONToWorkflowTemplateRef(jsonString)
+}
@@ -0,0 +1,156 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWorkflowTemplate(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, core.GenerateRandomID())
+	workflowTemplate.ID = core.GenerateRandomID()
+	workflowTemplate.Version = 1
+	err = db.AddWorkflowTemplate(workflowTemplate)
+	assert.Nil(t, err)
+
+	workflowTemplateFromDB, err := db.GetWorkflowTemplateByID(workflowTemplate.ID)
+	assert.Nil(t, err)
+	assert.True(t, workflowTemplate.Equals(workflowTemplateFromDB))
+
+	// Name and version must be unique within a colony
+	workflowTemplate.ID = core.GenerateRandomID()
+	err = db.AddWorkflowTemplate(workflowTemplate)
+	assert.NotNil(t, err)
+
+	workflowTemplateFromDB, err = db.GetWorkflowTemplateByID(core.GenerateRandomID())
+	assert.Nil(t, err)
+	assert.Nil(t, workflowTemplateFromDB)
+}
+
+func TestGetWorkflowTemplate(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+
+	workflowTemplate1 := utils.FakeWorkflowTemplate(t, colonyID)
+	workflowTemplate1.ID = core.GenerateRandomID()
+	workflowTemplate1.Version = 1
+	err = db.AddWorkflowTemplate(workflowTemplate1)
+	assert.Nil(t, err)
+
+	workflowTemplate2 := utils.FakeWorkflowTemplate(t, colonyID)
+	workflowTemplate2.ID = core.GenerateRandomID()
+	workflowTemplate2.Version = 2
+	err = db.AddWorkflowTemplate(workflowTemplate2)
+	assert.Nil(t, err)
+
+	workflowTemplateFromDB, err := db.GetWorkflowTemplate(colonyID, workflowTemplate1.Name, 1)
+	assert.Nil(t, err)
+	assert.True(t, workflowTemplate1.Equals(workflowTemplateFromDB))
+
+	workflowTemplateFromDB, err = db.GetWorkflowTemplate(colonyID, workflowTemplate1.Name, 0)
+	assert.Nil(t, err)
+	assert.True(t, workflowTemplate2.Equals(workflowTemplateFromDB))
+
+	workflowTemplateFromDB, err = db.GetWorkflowTemplate(colonyID, workflowTemplate1.Name, 3)
+	assert.Nil(t, err)
+	assert.Nil(t, workflowTemplateFromDB)
+
+	workflowTemplateFromDB, err = db.GetWorkflowTemplate(core.GenerateRandomID(), workflowTemplate1.Name, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, workflowTemplateFromDB)
+}
+
+func TestFindWorkflowTemplates(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+
+	workflowTemplate1 := utils.FakeWorkflowTemplate(t, colonyID)
+	workflowTemplate1.ID = core.GenerateRandomID()
+	workflowTemplate1.Name = "test_template1"
+	workflowTemplate1.Version = 1
+	err = db.AddWorkflowTemplate(workflowTemplate1)
+	assert.Nil(t, err)
+
+	workflowTemplate2 := utils.FakeWorkflowTemplate(t, colonyID)
+	workflowTemplate2.ID = core.GenerateRandomID()
+	workflowTemplate2.Name = "test_template1"
+	workflowTemplate2.Version = 2
+	err = db.AddWorkflowTemplate(workflowTemplate2)
+	assert.Nil(t, err)
+
+	workflowTemplate3 := utils.FakeWorkflowTemplate(t, colonyID)
+	workflowTemplate3.ID = core.GenerateRandomID()
+	workflowTemplate3.Name = "test_template2"
+	workflowTemplate3.Version = 1
+	err = db.AddWorkflowTemplate(workflowTemplate3)
+	assert.Nil(t, err)
+
+	workflowTemplateFromDB, err := db.FindWorkflowTemplatesByColonyID(colonyID, 100)
+	assert.Nil(t, err)
+	assert.True(t, core.IsWorkflowTemplateArraysEqual([]*core.WorkflowTemplate{workflowTemplate2, workflowTemplate3}, workflowTemplateFromDB))
+
+	workflowTemplateFromDB, err = db.FindWorkflowTemplateVersions(colonyID, "test_template1")
+	assert.Nil(t, err)
+	assert.Len(t, workflowTemplateFromDB, 2)
+	assert.Equal(t, 1, workflowTemplateFromDB[0].Version)
+	assert.Equal(t, 2, workflowTemplateFromDB[1].Version)
+}
+
+func TestDeleteWorkflowTemplate(t *testing.T) {
+	db, err := PrepareTests()
+	assert.Nil(t, err)
+
+	defer db.Close()
+
+	colonyID := core.GenerateRandomID()
+
+	for version := 1; version <= 3; version++ {
+		workflowTemplate := utils.FakeWorkflowTemplate(t, colonyID)
+		workflowTemplate.ID = core.GenerateRandomID()
+		workflowTemplate.Version = version
+		err = db.AddWorkflowTemplate(workflowTemplate)
+		assert.Nil(t, err)
+	}
+
+	err = db.DeleteWorkflowTemplate(colonyID, "test_template", 2)
+	assert.Nil(t, err)
+
+	workflowTemplates, err := db.FindWorkflowTemplateVersions(colonyID, "test_template")
+	assert.Nil(t, err)
+	assert.Len(t, workflowTemplates, 2)
+
+	err = db.DeleteWorkflowTemplate(colonyID, "test_template", 0)
+	assert.Nil(t, err)
+
+	workflowTemplates, err = db.FindWorkflowTemplateVersions(colonyID, "test_template")
+	assert.Nil(t, err)
+	assert.Len(t, workflowTemplates, 0)
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, colonyID)
+	workflowTemplate.ID = core.GenerateRandomID()
+	workflowTemplate.Version = 1
+	err = db.AddWorkflowTemplate(workflowTemplate)
+	assert.Nil(t, err)
+
+	err = db.DeleteAllWorkflowTemplatesByColonyID(colonyID)
+	assert.Nil(t, err)
+
+	workflowTemplates, err = db.FindWorkflowTemplatesByColonyID(colonyID, 100)
+	assert.Nil(t, err)
+	assert.Len(t, workflowTemplates, 0)
+}
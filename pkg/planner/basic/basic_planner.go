@@ -53,7 +53,7 @@ func (planner *BasicPlanner) printCandidates(candidates []*core.Process) {
 func (planner *BasicPlanner) Select(runtimeID string, candidates []*core.Process, latest bool) (*core.Process, error) {
 	prioritizedProcesses := planner.Prioritize(runtimeID, candidates, 1, latest)
 	if len(prioritizedProcesses) < 1 {
-		return nil, errors.New("No processes can be selected for runtime with Id <" + runtimeID + ">")
+		return nil, core.WithErrorCode(errors.New("No processes can be selected for runtime with Id <"+runtimeID+">"), core.ErrorCodeNoWorkAvailable)
 	}
 
 	return prioritizedProcesses[0], nil
@@ -87,6 +87,7 @@ func TestSelectProcessNoProcesss(t *testing.T) {
 	planner := CreatePlanner()
 	selectedProcess, err := planner.Select("runtimeid_1", candidates, false)
 	assert.NotNil(t, err)
+	assert.Equal(t, core.ErrorCodeNoWorkAvailable, core.ErrorCodeOf(err))
 	assert.Nil(t, selectedProcess)
 }
 
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/colonyos/colonies/pkg/cluster"
+)
+
+const AddClusterNodePayloadType = "addclusternodemsg"
+
+type AddClusterNodeMsg struct {
+	Node    cluster.Node `json:"node"`
+	MsgType string       `json:"msgtype"`
+}
+
+func CreateAddClusterNodeMsg(node cluster.Node) *AddClusterNodeMsg {
+	msg := &AddClusterNodeMsg{}
+	msg.Node = node
+	msg.MsgType = AddClusterNodePayloadType
+
+	return msg
+}
+
+func (msg *AddClusterNodeMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *AddClusterNodeMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *AddClusterNodeMsg) Equals(msg2 *AddClusterNodeMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.Node.Equals(&msg2.Node) {
+		return true
+	}
+
+	return false
+}
+
+func CreateAddClusterNodeMsgFromJSON(jsonString string) (*AddClusterNodeMsg, error) {
+	var msg *AddClusterNodeMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
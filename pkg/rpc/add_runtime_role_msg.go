@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const AddRuntimeRolePayloadType = "addruntimerolemsg"
+
+type AddRuntimeRoleMsg struct {
+	RuntimeID string `json:"runtimeid"`
+	Role      string `json:"role"`
+	MsgType   string `json:"msgtype"`
+}
+
+func CreateAddRuntimeRoleMsg(runtimeID string, role string) *AddRuntimeRoleMsg {
+	msg := &AddRuntimeRoleMsg{}
+	msg.RuntimeID = runtimeID
+	msg.Role = role
+	msg.MsgType = AddRuntimeRolePayloadType
+
+	return msg
+}
+
+func (msg *AddRuntimeRoleMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *AddRuntimeRoleMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *AddRuntimeRoleMsg) Equals(msg2 *AddRuntimeRoleMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.RuntimeID == msg2.RuntimeID &&
+		msg.Role == msg2.Role {
+		return true
+	}
+
+	return false
+}
+
+func CreateAddRuntimeRoleMsgFromJSON(jsonString string) (*AddRuntimeRoleMsg, error) {
+	var msg *AddRuntimeRoleMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
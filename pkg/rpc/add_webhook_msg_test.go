@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCAddWebhookMsg(t *testing.T) {
+	msg := CreateAddWebhookMsg(core.CreateWebhook(core.GenerateRandomID(), "https://example.com/hook", core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESS}}, "secret"))
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateAddWebhookMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateAddWebhookMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCAddWebhookMsgIndent(t *testing.T) {
+	msg := CreateAddWebhookMsg(core.CreateWebhook(core.GenerateRandomID(), "https://example.com/hook", core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESS}}, "secret"))
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateAddWebhookMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateAddWebhookMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCAddWebhookMsgEquals(t *testing.T) {
+	msg := CreateAddWebhookMsg(core.CreateWebhook(core.GenerateRandomID(), "https://example.com/hook", core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESS}}, "secret"))
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
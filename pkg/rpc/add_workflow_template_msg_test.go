@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCAddWorkflowTemplateMsg(t *testing.T) {
+	workflowTemplate := core.CreateWorkflowTemplate(core.GenerateRandomID(), "test_template", []core.TemplateParameter{{Name: "input", Default: "test_input"}}, "workflow")
+	msg := CreateAddWorkflowTemplateMsg(workflowTemplate)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateAddWorkflowTemplateMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateAddWorkflowTemplateMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCAddWorkflowTemplateMsgIndent(t *testing.T) {
+	workflowTemplate := core.CreateWorkflowTemplate(core.GenerateRandomID(), "test_template", []core.TemplateParameter{{Name: "input", Default: "test_input"}}, "workflow")
+	msg := CreateAddWorkflowTemplateMsg(workflowTemplate)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateAddWorkflowTemplateMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateAddWorkflowTemplateMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCAddWorkflowTemplateMsgEquals(t *testing.T) {
+	workflowTemplate := core.CreateWorkflowTemplate(core.GenerateRandomID(), "test_template", []core.TemplateParameter{{Name: "input", Default: "test_input"}}, "workflow")
+	msg := CreateAddWorkflowTemplateMsg(workflowTemplate)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const BatchPayloadType = "batchmsg"
+
+// BatchMsg carries a number of RPC messages, each signed on its own, which are handled in order by the server.
+// The reply is an array with one RPCReplyMsg per message.
+type BatchMsg struct {
+	Requests []*RPCMsg `json:"requests"`
+	MsgType  string    `json:"msgtype"`
+}
+
+func CreateBatchMsg(requests []*RPCMsg) *BatchMsg {
+	msg := &BatchMsg{}
+	msg.Requests = requests
+	msg.MsgType = BatchPayloadType
+
+	return msg
+}
+
+func (msg *BatchMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *BatchMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *BatchMsg) Equals(msg2 *BatchMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType != msg2.MsgType || len(msg.Requests) != len(msg2.Requests) {
+		return false
+	}
+
+	for i := range msg.Requests {
+		if !msg.Requests[i].Equals(msg2.Requests[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func CreateBatchMsgFromJSON(jsonString string) (*BatchMsg, error) {
+	var msg *BatchMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
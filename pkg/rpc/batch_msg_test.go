@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestBatchMsg(t *testing.T) *BatchMsg {
+	prvKey, err := crypto.CreateCrypto().GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	rpcMsg1, err := CreateRPCMsg(GetColoniesPayloadType, "{}", prvKey)
+	assert.Nil(t, err)
+	rpcMsg2, err := CreateRPCMsg(GetColoniesPayloadType, "{}", prvKey)
+	assert.Nil(t, err)
+
+	return CreateBatchMsg([]*RPCMsg{rpcMsg1, rpcMsg2})
+}
+
+func TestRPCBatchMsg(t *testing.T) {
+	msg := createTestBatchMsg(t)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateBatchMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateBatchMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCBatchMsgIndent(t *testing.T) {
+	msg := createTestBatchMsg(t)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateBatchMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateBatchMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCBatchMsgEquals(t *testing.T) {
+	msg := createTestBatchMsg(t)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+	assert.False(t, msg.Equals(createTestBatchMsg(t)))
+}
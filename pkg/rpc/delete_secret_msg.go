@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const DeleteSecretPayloadType = "deletesecretmsg"
+
+type DeleteSecretMsg struct {
+	ColonyID string `json:"colonyid"`
+	Name     string `json:"name"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateDeleteSecretMsg(colonyID string, name string) *DeleteSecretMsg {
+	msg := &DeleteSecretMsg{}
+	msg.ColonyID = colonyID
+	msg.Name = name
+	msg.MsgType = DeleteSecretPayloadType
+
+	return msg
+}
+
+func (msg *DeleteSecretMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *DeleteSecretMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *DeleteSecretMsg) Equals(msg2 *DeleteSecretMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.Name == msg2.Name {
+		return true
+	}
+
+	return false
+}
+
+func CreateDeleteSecretMsgFromJSON(jsonString string) (*DeleteSecretMsg, error) {
+	var msg *DeleteSecretMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
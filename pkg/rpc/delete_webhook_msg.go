@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const DeleteWebhookPayloadType = "deletewebhookmsg"
+
+type DeleteWebhookMsg struct {
+	WebhookID string `json:"webhookid"`
+	MsgType   string `json:"msgtype"`
+}
+
+func CreateDeleteWebhookMsg(webhookID string) *DeleteWebhookMsg {
+	msg := &DeleteWebhookMsg{}
+	msg.WebhookID = webhookID
+	msg.MsgType = DeleteWebhookPayloadType
+
+	return msg
+}
+
+func (msg *DeleteWebhookMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *DeleteWebhookMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *DeleteWebhookMsg) Equals(msg2 *DeleteWebhookMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.WebhookID == msg2.WebhookID {
+		return true
+	}
+
+	return false
+}
+
+func CreateDeleteWebhookMsgFromJSON(jsonString string) (*DeleteWebhookMsg, error) {
+	var msg *DeleteWebhookMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const DeleteWorkflowTemplatePayloadType = "deleteworkflowtemplatemsg"
+
+type DeleteWorkflowTemplateMsg struct {
+	ColonyID string `json:"colonyid"`
+	Name     string `json:"name"`
+	Version  int    `json:"version"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateDeleteWorkflowTemplateMsg(colonyID string, name string, version int) *DeleteWorkflowTemplateMsg {
+	msg := &DeleteWorkflowTemplateMsg{}
+	msg.ColonyID = colonyID
+	msg.Name = name
+	msg.Version = version
+	msg.MsgType = DeleteWorkflowTemplatePayloadType
+
+	return msg
+}
+
+func (msg *DeleteWorkflowTemplateMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *DeleteWorkflowTemplateMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *DeleteWorkflowTemplateMsg) Equals(msg2 *DeleteWorkflowTemplateMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.Name == msg2.Name &&
+		msg.Version == msg2.Version {
+		return true
+	}
+
+	return false
+}
+
+func CreateDeleteWorkflowTemplateMsgFromJSON(jsonString string) (*DeleteWorkflowTemplateMsg, error) {
+	var msg *DeleteWorkflowTemplateMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
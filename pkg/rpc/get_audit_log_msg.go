@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetAuditLogPayloadType = "getauditlogmsg"
+
+type GetAuditLogMsg struct {
+	TargetID string `json:"targetid"`
+	FromSeq  int64  `json:"fromseq"`
+	Count    int    `json:"count"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateGetAuditLogMsg(targetID string, fromSeq int64, count int) *GetAuditLogMsg {
+	msg := &GetAuditLogMsg{}
+	msg.TargetID = targetID
+	msg.FromSeq = fromSeq
+	msg.Count = count
+	msg.MsgType = GetAuditLogPayloadType
+
+	return msg
+}
+
+func (msg *GetAuditLogMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetAuditLogMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetAuditLogMsg) Equals(msg2 *GetAuditLogMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.TargetID == msg2.TargetID &&
+		msg.FromSeq == msg2.FromSeq &&
+		msg.Count == msg2.Count {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetAuditLogMsgFromJSON(jsonString string) (*GetAuditLogMsg, error) {
+	var msg *GetAuditLogMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCGetAuditLogMsg(t *testing.T) {
+	msg := CreateGetAuditLogMsg(core.GenerateRandomID(), 1, 100)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateGetAuditLogMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateGetAuditLogMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCGetAuditLogMsgIndent(t *testing.T) {
+	msg := CreateGetAuditLogMsg(core.GenerateRandomID(), 1, 100)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateGetAuditLogMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateGetAuditLogMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCGetAuditLogMsgEquals(t *testing.T) {
+	msg := CreateGetAuditLogMsg(core.GenerateRandomID(), 1, 100)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
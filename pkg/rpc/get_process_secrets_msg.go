@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetProcessSecretsPayloadType = "getprocesssecretsmsg"
+
+type GetProcessSecretsMsg struct {
+	ProcessID string `json:"processid"`
+	MsgType   string `json:"msgtype"`
+}
+
+func CreateGetProcessSecretsMsg(processID string) *GetProcessSecretsMsg {
+	msg := &GetProcessSecretsMsg{}
+	msg.ProcessID = processID
+	msg.MsgType = GetProcessSecretsPayloadType
+
+	return msg
+}
+
+func (msg *GetProcessSecretsMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetProcessSecretsMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetProcessSecretsMsg) Equals(msg2 *GetProcessSecretsMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ProcessID == msg2.ProcessID {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetProcessSecretsMsgFromJSON(jsonString string) (*GetProcessSecretsMsg, error) {
+	var msg *GetProcessSecretsMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
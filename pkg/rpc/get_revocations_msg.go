@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetRevocationsPayloadType = "getrevocationsmsg"
+
+type GetRevocationsMsg struct {
+	ColonyID string `json:"colonyid"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateGetRevocationsMsg(colonyID string) *GetRevocationsMsg {
+	msg := &GetRevocationsMsg{}
+	msg.ColonyID = colonyID
+	msg.MsgType = GetRevocationsPayloadType
+
+	return msg
+}
+
+func (msg *GetRevocationsMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetRevocationsMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetRevocationsMsg) Equals(msg2 *GetRevocationsMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetRevocationsMsgFromJSON(jsonString string) (*GetRevocationsMsg, error) {
+	var msg *GetRevocationsMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
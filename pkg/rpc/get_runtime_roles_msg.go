@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetRuntimeRolesPayloadType = "getruntimerolesmsg"
+
+type GetRuntimeRolesMsg struct {
+	RuntimeID string `json:"runtimeid"`
+	MsgType   string `json:"msgtype"`
+}
+
+func CreateGetRuntimeRolesMsg(runtimeID string) *GetRuntimeRolesMsg {
+	msg := &GetRuntimeRolesMsg{}
+	msg.RuntimeID = runtimeID
+	msg.MsgType = GetRuntimeRolesPayloadType
+
+	return msg
+}
+
+func (msg *GetRuntimeRolesMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetRuntimeRolesMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetRuntimeRolesMsg) Equals(msg2 *GetRuntimeRolesMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.RuntimeID == msg2.RuntimeID {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetRuntimeRolesMsgFromJSON(jsonString string) (*GetRuntimeRolesMsg, error) {
+	var msg *GetRuntimeRolesMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetSecretsPayloadType = "getsecretsmsg"
+
+type GetSecretsMsg struct {
+	ColonyID string `json:"colonyid"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateGetSecretsMsg(colonyID string) *GetSecretsMsg {
+	msg := &GetSecretsMsg{}
+	msg.ColonyID = colonyID
+	msg.MsgType = GetSecretsPayloadType
+
+	return msg
+}
+
+func (msg *GetSecretsMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetSecretsMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetSecretsMsg) Equals(msg2 *GetSecretsMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetSecretsMsgFromJSON(jsonString string) (*GetSecretsMsg, error) {
+	var msg *GetSecretsMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCGetWebhookDeliveriesMsg(t *testing.T) {
+	msg := CreateGetWebhookDeliveriesMsg(core.GenerateRandomID(), core.WEBHOOK_DELIVERY_DEAD, 10)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateGetWebhookDeliveriesMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateGetWebhookDeliveriesMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCGetWebhookDeliveriesMsgIndent(t *testing.T) {
+	msg := CreateGetWebhookDeliveriesMsg(core.GenerateRandomID(), core.WEBHOOK_DELIVERY_DEAD, 10)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateGetWebhookDeliveriesMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateGetWebhookDeliveriesMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCGetWebhookDeliveriesMsgEquals(t *testing.T) {
+	msg := CreateGetWebhookDeliveriesMsg(core.GenerateRandomID(), core.WEBHOOK_DELIVERY_DEAD, 10)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
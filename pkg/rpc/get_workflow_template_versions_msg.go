@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetWorkflowTemplateVersionsPayloadType = "getworkflowtemplateversionsmsg"
+
+type GetWorkflowTemplateVersionsMsg struct {
+	ColonyID string `json:"colonyid"`
+	Name     string `json:"name"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateGetWorkflowTemplateVersionsMsg(colonyID string, name string) *GetWorkflowTemplateVersionsMsg {
+	msg := &GetWorkflowTemplateVersionsMsg{}
+	msg.ColonyID = colonyID
+	msg.Name = name
+	msg.MsgType = GetWorkflowTemplateVersionsPayloadType
+
+	return msg
+}
+
+func (msg *GetWorkflowTemplateVersionsMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetWorkflowTemplateVersionsMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetWorkflowTemplateVersionsMsg) Equals(msg2 *GetWorkflowTemplateVersionsMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.Name == msg2.Name {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetWorkflowTemplateVersionsMsgFromJSON(jsonString string) (*GetWorkflowTemplateVersionsMsg, error) {
+	var msg *GetWorkflowTemplateVersionsMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCGetWorkflowTemplateVersionsMsg(t *testing.T) {
+	msg := CreateGetWorkflowTemplateVersionsMsg(core.GenerateRandomID(), "test_template")
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateGetWorkflowTemplateVersionsMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateGetWorkflowTemplateVersionsMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCGetWorkflowTemplateVersionsMsgIndent(t *testing.T) {
+	msg := CreateGetWorkflowTemplateVersionsMsg(core.GenerateRandomID(), "test_template")
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateGetWorkflowTemplateVersionsMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateGetWorkflowTemplateVersionsMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCGetWorkflowTemplateVersionsMsgEquals(t *testing.T) {
+	msg := CreateGetWorkflowTemplateVersionsMsg(core.GenerateRandomID(), "test_template")
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
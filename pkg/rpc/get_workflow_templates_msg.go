@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const GetWorkflowTemplatesPayloadType = "getworkflowtemplatesmsg"
+
+type GetWorkflowTemplatesMsg struct {
+	ColonyID string `json:"colonyid"`
+	Count    int    `json:"count"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateGetWorkflowTemplatesMsg(colonyID string, count int) *GetWorkflowTemplatesMsg {
+	msg := &GetWorkflowTemplatesMsg{}
+	msg.ColonyID = colonyID
+	msg.Count = count
+	msg.MsgType = GetWorkflowTemplatesPayloadType
+
+	return msg
+}
+
+func (msg *GetWorkflowTemplatesMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetWorkflowTemplatesMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *GetWorkflowTemplatesMsg) Equals(msg2 *GetWorkflowTemplatesMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.Count == msg2.Count {
+		return true
+	}
+
+	return false
+}
+
+func CreateGetWorkflowTemplatesMsgFromJSON(jsonString string) (*GetWorkflowTemplatesMsg, error) {
+	var msg *GetWorkflowTemplatesMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,62 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const RemoveClusterNodePayloadType = "removeclusternodemsg"
+
+type RemoveClusterNodeMsg struct {
+	Name    string `json:"name"`
+	MsgType string `json:"msgtype"`
+}
+
+func CreateRemoveClusterNodeMsg(name string) *RemoveClusterNodeMsg {
+	msg := &RemoveClusterNodeMsg{}
+	msg.Name = name
+	msg.MsgType = RemoveClusterNodePayloadType
+
+	return msg
+}
+
+func (msg *RemoveClusterNodeMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RemoveClusterNodeMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RemoveClusterNodeMsg) Equals(msg2 *RemoveClusterNodeMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.Name == msg2.Name {
+		return true
+	}
+
+	return false
+}
+
+func CreateRemoveClusterNodeMsgFromJSON(jsonString string) (*RemoveClusterNodeMsg, error) {
+	var msg *RemoveClusterNodeMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
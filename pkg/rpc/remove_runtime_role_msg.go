@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const RemoveRuntimeRolePayloadType = "removeruntimerolemsg"
+
+type RemoveRuntimeRoleMsg struct {
+	RuntimeID string `json:"runtimeid"`
+	Role      string `json:"role"`
+	MsgType   string `json:"msgtype"`
+}
+
+func CreateRemoveRuntimeRoleMsg(runtimeID string, role string) *RemoveRuntimeRoleMsg {
+	msg := &RemoveRuntimeRoleMsg{}
+	msg.RuntimeID = runtimeID
+	msg.Role = role
+	msg.MsgType = RemoveRuntimeRolePayloadType
+
+	return msg
+}
+
+func (msg *RemoveRuntimeRoleMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RemoveRuntimeRoleMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RemoveRuntimeRoleMsg) Equals(msg2 *RemoveRuntimeRoleMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.RuntimeID == msg2.RuntimeID &&
+		msg.Role == msg2.Role {
+		return true
+	}
+
+	return false
+}
+
+func CreateRemoveRuntimeRoleMsgFromJSON(jsonString string) (*RemoveRuntimeRoleMsg, error) {
+	var msg *RemoveRuntimeRoleMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCRemoveRuntimeRoleMsg(t *testing.T) {
+	msg := CreateRemoveRuntimeRoleMsg(core.GenerateRandomID(), core.ROLE_SUBMITTER)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRemoveRuntimeRoleMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateRemoveRuntimeRoleMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCRemoveRuntimeRoleMsgIndent(t *testing.T) {
+	msg := CreateRemoveRuntimeRoleMsg(core.GenerateRandomID(), core.ROLE_SUBMITTER)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRemoveRuntimeRoleMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateRemoveRuntimeRoleMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCRemoveRuntimeRoleMsgEquals(t *testing.T) {
+	msg := CreateRemoveRuntimeRoleMsg(core.GenerateRandomID(), core.ROLE_SUBMITTER)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const RevokeRuntimePayloadType = "revokeruntimemsg"
+
+type RevokeRuntimeMsg struct {
+	RuntimeID string `json:"runtimeid"`
+	Reason    string `json:"reason"`
+	MsgType   string `json:"msgtype"`
+}
+
+func CreateRevokeRuntimeMsg(runtimeID string, reason string) *RevokeRuntimeMsg {
+	msg := &RevokeRuntimeMsg{}
+	msg.RuntimeID = runtimeID
+	msg.Reason = reason
+	msg.MsgType = RevokeRuntimePayloadType
+
+	return msg
+}
+
+func (msg *RevokeRuntimeMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RevokeRuntimeMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RevokeRuntimeMsg) Equals(msg2 *RevokeRuntimeMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.RuntimeID == msg2.RuntimeID &&
+		msg.Reason == msg2.Reason {
+		return true
+	}
+
+	return false
+}
+
+func CreateRevokeRuntimeMsgFromJSON(jsonString string) (*RevokeRuntimeMsg, error) {
+	var msg *RevokeRuntimeMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
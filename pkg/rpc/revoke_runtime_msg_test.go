@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCRevokeRuntimeMsg(t *testing.T) {
+	msg := CreateRevokeRuntimeMsg(core.GenerateRandomID(), "compromised")
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRevokeRuntimeMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateRevokeRuntimeMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCRevokeRuntimeMsgIndent(t *testing.T) {
+	msg := CreateRevokeRuntimeMsg(core.GenerateRandomID(), "compromised")
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRevokeRuntimeMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateRevokeRuntimeMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCRevokeRuntimeMsgEquals(t *testing.T) {
+	msg := CreateRevokeRuntimeMsg(core.GenerateRandomID(), "compromised")
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/colonyos/colonies/pkg/security/crypto"
+)
+
+const RotateColonyKeyPayloadType = "rotatecolonykeymsg"
+
+type RotateColonyKeyMsg struct {
+	ColonyID   string `json:"colonyid"`
+	NewOwnerID string `json:"newownerid"`
+	Proof      string `json:"proof"`
+	MsgType    string `json:"msgtype"`
+}
+
+func CreateRotateColonyKeyMsg(colonyID string, newOwnerID string, proof string) *RotateColonyKeyMsg {
+	msg := &RotateColonyKeyMsg{}
+	msg.ColonyID = colonyID
+	msg.NewOwnerID = newOwnerID
+	msg.Proof = proof
+	msg.MsgType = RotateColonyKeyPayloadType
+
+	return msg
+}
+
+// RotateColonyKeyHandoverData returns the data that must be signed with the new colony key to prove that the
+// new owner possesses it, otherwise a mistyped owner Id would lock everyone out of the colony
+func RotateColonyKeyHandoverData(colonyID string, newOwnerID string) string {
+	return RotateColonyKeyPayloadType + ":" + colonyID + ":" + newOwnerID
+}
+
+// CreateSignedRotateColonyKeyMsg creates a message handing over a colony to the given new colony key, the
+// message itself has to be sent using the current colony key
+func CreateSignedRotateColonyKeyMsg(colonyID string, newColonyPrvKey string) (*RotateColonyKeyMsg, error) {
+	crypto := crypto.CreateCrypto()
+	newOwnerID, err := crypto.GenerateID(newColonyPrvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := crypto.GenerateSignature(RotateColonyKeyHandoverData(colonyID, newOwnerID), newColonyPrvKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateRotateColonyKeyMsg(colonyID, newOwnerID, proof), nil
+}
+
+func (msg *RotateColonyKeyMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RotateColonyKeyMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *RotateColonyKeyMsg) Equals(msg2 *RotateColonyKeyMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.NewOwnerID == msg2.NewOwnerID &&
+		msg.Proof == msg2.Proof {
+		return true
+	}
+
+	return false
+}
+
+func CreateRotateColonyKeyMsgFromJSON(jsonString string) (*RotateColonyKeyMsg, error) {
+	var msg *RotateColonyKeyMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCRotateColonyKeyMsg(t *testing.T) {
+	msg := CreateRotateColonyKeyMsg(core.GenerateRandomID(), core.GenerateRandomID(), core.GenerateRandomID())
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRotateColonyKeyMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateRotateColonyKeyMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCRotateColonyKeyMsgIndent(t *testing.T) {
+	msg := CreateRotateColonyKeyMsg(core.GenerateRandomID(), core.GenerateRandomID(), core.GenerateRandomID())
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRotateColonyKeyMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateRotateColonyKeyMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCRotateColonyKeyMsgEquals(t *testing.T) {
+	msg := CreateRotateColonyKeyMsg(core.GenerateRandomID(), core.GenerateRandomID(), core.GenerateRandomID())
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
+
+func TestCreateSignedRotateColonyKeyMsg(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	newColonyPrvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	newOwnerID, err := crypto.GenerateID(newColonyPrvKey)
+	assert.Nil(t, err)
+
+	colonyID := core.GenerateRandomID()
+	msg, err := CreateSignedRotateColonyKeyMsg(colonyID, newColonyPrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, colonyID, msg.ColonyID)
+	assert.Equal(t, newOwnerID, msg.NewOwnerID)
+
+	recoveredID, err := crypto.RecoverID(RotateColonyKeyHandoverData(colonyID, newOwnerID), msg.Proof)
+	assert.Nil(t, err)
+	assert.Equal(t, newOwnerID, recoveredID)
+}
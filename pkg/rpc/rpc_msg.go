@@ -1,25 +1,79 @@
 package rpc
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 )
 
+// LegacyProtocolVersion messages only sign the payload, ProtocolVersion messages also sign the payload type,
+// a timestamp and a nonce, which makes it possible for the server to detect replayed messages. RawProtocolVersion
+// messages sign a hash of the payload as is instead of the base64 encoded payload, which is used over gRPC where the
+// payload is sent as bytes.
+const LegacyProtocolVersion = 1
+const ProtocolVersion = 2
+const RawProtocolVersion = 3
+
 type RPCMsg struct {
 	Signature   string `json:"signature"`
 	PayloadType string `json:"payloadtype"`
 	Payload     string `json:"payload"`
+	Version     int    `json:"version,omitempty"`
+	Timestamp   int64  `json:"timestamp,omitempty"`
+	Nonce       string `json:"nonce,omitempty"`
+
+	// Delegations is a chain of delegation certificates allowing the signer to act on behalf of the root issuer, a hash
+	// of the chain is covered by the signature so that a chain can not be attached to someone else's message
+	Delegations []*core.Delegation `json:"delegations,omitempty"`
+
+	// TraceContext is the W3C trace context of the caller, it is not covered by the signature and is only used to
+	// correlate the spans of a request
+	TraceContext map[string]string `json:"tracecontext,omitempty"`
+
+	// rawPayload is the payload as is, it is set instead of Payload for messages sent or received over gRPC
+	rawPayload []byte
 }
 
 func CreateRPCMsg(payloadType string, payload string, prvKey string) (*RPCMsg, error) {
+	return CreateDelegatedRPCMsg(payloadType, payload, prvKey, nil)
+}
+
+// CreateDelegatedRPCMsg works as CreateRPCMsg, but attaches a delegation chain before the message is signed
+func CreateDelegatedRPCMsg(payloadType string, payload string, prvKey string, delegations []*core.Delegation) (*RPCMsg, error) {
 	msg := &RPCMsg{}
 	msg.PayloadType = payloadType
 	msg.Payload = base64.StdEncoding.EncodeToString([]byte(payload))
+	msg.Delegations = delegations
+	msg.Version = ProtocolVersion
+
+	return signRPCMsg(msg, prvKey)
+}
 
-	signature, err := crypto.CreateCrypto().GenerateSignature(msg.Payload, prvKey)
+// CreateRawRPCMsg works as CreateDelegatedRPCMsg, but creates a RawProtocolVersion message, i.e. the payload is not
+// base64 encoded until the message is converted to JSON
+func CreateRawRPCMsg(payloadType string, payload string, prvKey string, delegations []*core.Delegation) (*RPCMsg, error) {
+	msg := &RPCMsg{}
+	msg.PayloadType = payloadType
+	msg.rawPayload = []byte(payload)
+	msg.Delegations = delegations
+	msg.Version = RawProtocolVersion
+
+	return signRPCMsg(msg, prvKey)
+}
+
+func signRPCMsg(msg *RPCMsg, prvKey string) (*RPCMsg, error) {
+	msg.Timestamp = time.Now().UnixNano()
+	msg.Nonce = core.GenerateRandomID()
+
+	signature, err := crypto.CreateCrypto().GenerateSignature(msg.SignedData(), prvKey)
 	if err != nil {
 		return nil, errors.New("Failed to generate signature")
 	}
@@ -48,14 +102,105 @@ func CreateRPCMsgFromJSON(jsonString string) (*RPCMsg, error) {
 	return msg, nil
 }
 
-func (msg *RPCMsg) DecodePayload() string {
+// ProtocolVersion returns the protocol version of the message, messages without a version are legacy messages
+func (msg *RPCMsg) ProtocolVersion() int {
+	if msg.Version == 0 {
+		return LegacyProtocolVersion
+	}
+
+	return msg.Version
+}
+
+// Time returns the time when the message was signed
+func (msg *RPCMsg) Time() time.Time {
+	return time.Unix(0, msg.Timestamp)
+}
+
+// SetRawPayload sets the payload as is, e.g. when a message is received over gRPC, the payload is then only base64
+// encoded if the message is converted to JSON or if the signature of an older protocol version covers it
+func (msg *RPCMsg) SetRawPayload(payload []byte) {
+	msg.rawPayload = payload
+	msg.Payload = ""
+}
+
+// SignedData returns the data covered by the signature
+func (msg *RPCMsg) SignedData() string {
+	if msg.ProtocolVersion() == LegacyProtocolVersion {
+		return msg.encodedPayload()
+	}
+
+	payload := msg.encodedPayload()
+	if msg.Version == RawProtocolVersion {
+		payload = msg.payloadHash()
+	}
+
+	signedData := strconv.Itoa(msg.Version) + ":" + msg.PayloadType + ":" + strconv.FormatInt(msg.Timestamp, 10) + ":" + msg.Nonce + ":" + payload
+	if len(msg.Delegations) > 0 {
+		signedData += ":" + msg.delegationsHash()
+	}
+
+	return signedData
+}
+
+// delegationsHash returns a hex encoded SHA-256 hash of the delegation chain, each delegation is serialized as its
+// signed data followed by its signature, so the hash does not depend on how the chain was encoded in transit
+func (msg *RPCMsg) delegationsHash() string {
+	delegations := make([]string, len(msg.Delegations))
+	for i, delegation := range msg.Delegations {
+		if delegation != nil {
+			delegations[i] = delegation.SignedData() + "|" + delegation.Signature
+		}
+	}
+	hash := sha256.Sum256([]byte(strings.Join(delegations, "\n")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// payloadHash returns a hex encoded SHA-256 hash of the payload as is
+func (msg *RPCMsg) payloadHash() string {
+	hash := sha256.Sum256(msg.RawPayload())
+
+	return hex.EncodeToString(hash[:])
+}
+
+func (msg *RPCMsg) encodedPayload() string {
+	if msg.rawPayload == nil {
+		return msg.Payload
+	}
+
+	return base64.StdEncoding.EncodeToString(msg.rawPayload)
+}
+
+// encoded returns the message with the payload base64 encoded as it is sent in JSON
+func (msg *RPCMsg) encoded() *RPCMsg {
+	if msg.rawPayload == nil {
+		return msg
+	}
+
+	encodedMsg := *msg
+	encodedMsg.Payload = msg.encodedPayload()
+	encodedMsg.rawPayload = nil
+
+	return &encodedMsg
+}
+
+// RawPayload returns the payload as is
+func (msg *RPCMsg) RawPayload() []byte {
+	if msg.rawPayload != nil {
+		return msg.rawPayload
+	}
+
 	jsonBytes, _ := base64.StdEncoding.DecodeString(msg.Payload)
 
-	return string(jsonBytes)
+	return jsonBytes
+}
+
+func (msg *RPCMsg) DecodePayload() string {
+	return string(msg.RawPayload())
 }
 
 func (msg *RPCMsg) ToJSON() (string, error) {
-	jsonBytes, err := json.Marshal(msg)
+	jsonBytes, err := json.Marshal(msg.encoded())
 	if err != nil {
 		return "", err
 	}
@@ -63,8 +208,28 @@ func (msg *RPCMsg) ToJSON() (string, error) {
 	return string(jsonBytes), nil
 }
 
+// ToToken encodes the message as URL-safe base64, so that it can be sent as a query parameter by clients that
+// cannot send a request body, e.g. browsers opening a Server-Sent Events stream
+func (msg *RPCMsg) ToToken() (string, error) {
+	jsonString, err := msg.ToJSON()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(jsonString)), nil
+}
+
+func CreateRPCMsgFromToken(token string) (*RPCMsg, error) {
+	jsonBytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateRPCMsgFromJSON(string(jsonBytes))
+}
+
 func (msg *RPCMsg) ToJSONIndent() (string, error) {
-	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	jsonBytes, err := json.MarshalIndent(msg.encoded(), "", "    ")
 	if err != nil {
 		return "", err
 	}
@@ -79,7 +244,16 @@ func (msg *RPCMsg) Equals(msg2 *RPCMsg) bool {
 
 	if msg.Signature == msg2.Signature &&
 		msg.PayloadType == msg2.PayloadType &&
-		msg.Payload == msg2.Payload {
+		msg.encodedPayload() == msg2.encodedPayload() &&
+		msg.Version == msg2.Version &&
+		msg.Timestamp == msg2.Timestamp &&
+		msg.Nonce == msg2.Nonce &&
+		len(msg.Delegations) == len(msg2.Delegations) {
+		for i := range msg.Delegations {
+			if msg.Delegations[i].Signature != msg2.Delegations[i].Signature {
+				return false
+			}
+		}
 		return true
 	}
 
@@ -2,7 +2,9 @@ package rpc
 
 import (
 	"testing"
+	"time"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/stretchr/testify/assert"
 )
@@ -75,3 +77,162 @@ func TestRPCMsgEquals(t *testing.T) {
 	assert.True(t, msg.Equals(msg))
 	assert.False(t, msg.Equals(nil))
 }
+
+func TestRPCMsgSignedData(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	prvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	id, err := crypto.GenerateID(prvKey)
+	assert.Nil(t, err)
+
+	msg, err := CreateRPCMsg("test_method", "test_payload", prvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, ProtocolVersion, msg.ProtocolVersion())
+	assert.NotEmpty(t, msg.Nonce)
+	assert.WithinDuration(t, time.Now(), msg.Time(), time.Minute)
+
+	recoveredID, err := crypto.RecoverID(msg.SignedData(), msg.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, id, recoveredID)
+
+	// Tampering with the signed envelope should result in another recovered Id
+	msg.Timestamp++
+	recoveredID, err = crypto.RecoverID(msg.SignedData(), msg.Signature)
+	assert.Nil(t, err)
+	assert.NotEqual(t, id, recoveredID)
+
+	legacyMsg := &RPCMsg{PayloadType: "test_method", Payload: "test_payload"}
+	assert.Equal(t, LegacyProtocolVersion, legacyMsg.ProtocolVersion())
+	assert.Equal(t, legacyMsg.Payload, legacyMsg.SignedData())
+}
+
+func TestRawRPCMsg(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	prvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	id, err := crypto.GenerateID(prvKey)
+	assert.Nil(t, err)
+
+	msg, err := CreateRawRPCMsg("test_method", "test_payload", prvKey, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, RawProtocolVersion, msg.ProtocolVersion())
+	assert.Empty(t, msg.Payload)
+	assert.Equal(t, "test_payload", msg.DecodePayload())
+
+	// The payload is received as is, e.g. over gRPC
+	receivedMsg := &RPCMsg{Signature: msg.Signature, PayloadType: msg.PayloadType, Version: msg.Version, Timestamp: msg.Timestamp, Nonce: msg.Nonce}
+	receivedMsg.SetRawPayload([]byte("test_payload"))
+	recoveredID, err := crypto.RecoverID(receivedMsg.SignedData(), receivedMsg.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, id, recoveredID)
+
+	// The payload is base64 encoded when the message is sent as JSON
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+	msg2, err := CreateRPCMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, msg2.Payload)
+	assert.True(t, msg.Equals(msg2))
+	recoveredID, err = crypto.RecoverID(msg2.SignedData(), msg2.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, id, recoveredID)
+
+	// Tampering with the payload should result in another recovered Id
+	receivedMsg.SetRawPayload([]byte("test_payload2"))
+	recoveredID, err = crypto.RecoverID(receivedMsg.SignedData(), receivedMsg.Signature)
+	assert.Nil(t, err)
+	assert.NotEqual(t, id, recoveredID)
+
+	// Version 2 messages received as is are still verified over the base64 encoded payload
+	msg, err = CreateRPCMsg("test_method", "test_payload", prvKey)
+	assert.Nil(t, err)
+	receivedMsg = &RPCMsg{Signature: msg.Signature, PayloadType: msg.PayloadType, Version: msg.Version, Timestamp: msg.Timestamp, Nonce: msg.Nonce}
+	receivedMsg.SetRawPayload([]byte("test_payload"))
+	recoveredID, err = crypto.RecoverID(receivedMsg.SignedData(), receivedMsg.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, id, recoveredID)
+}
+
+func TestRPCMsgDelegations(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	issuerPrvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	delegatePrvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	delegateID, err := crypto.GenerateID(delegatePrvKey)
+	assert.Nil(t, err)
+
+	delegation := core.CreateDelegation(delegateID, core.GenerateRandomID(), []string{"test_method"}, nil, nil, time.Now().Add(time.Hour))
+	assert.Nil(t, delegation.Sign(issuerPrvKey))
+
+	msg, err := CreateDelegatedRPCMsg("test_method", "test_payload", delegatePrvKey, []*core.Delegation{delegation})
+	assert.Nil(t, err)
+
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateRPCMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+	assert.True(t, msg.Equals(msg2))
+	assert.Len(t, msg2.Delegations, 1)
+	assert.Nil(t, msg2.Delegations[0].Verify())
+
+	msg2.Delegations = nil
+	assert.False(t, msg.Equals(msg2))
+
+	// The delegation chain is covered by the signature
+	delegateID2, err := crypto.RecoverID(msg.SignedData(), msg.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, delegateID, delegateID2)
+
+	undelegatedMsg, err := CreateRPCMsg("test_method", "test_payload", delegatePrvKey)
+	assert.Nil(t, err)
+	undelegatedMsg.Delegations = []*core.Delegation{delegation}
+	delegateID2, err = crypto.RecoverID(undelegatedMsg.SignedData(), undelegatedMsg.Signature)
+	assert.Nil(t, err)
+	assert.NotEqual(t, delegateID, delegateID2)
+}
+
+func TestRPCMsgTraceContext(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	prvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	msg, err := CreateRPCMsg("test_method", "test_payload", prvKey)
+	assert.Nil(t, err)
+	signedData := msg.SignedData()
+	msg.TraceContext = map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}
+
+	// The trace context is not covered by the signature
+	assert.Equal(t, signedData, msg.SignedData())
+
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+	assert.Contains(t, jsonString, "tracecontext")
+
+	msg2, err := CreateRPCMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+	assert.Equal(t, msg.TraceContext, msg2.TraceContext)
+}
+
+func TestRPCMsgToken(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	prvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	msg, err := CreateRPCMsg("test_method", "test_payload", prvKey)
+	assert.Nil(t, err)
+
+	token, err := msg.ToToken()
+	assert.Nil(t, err)
+	assert.NotContains(t, token, "+")
+	assert.NotContains(t, token, "/")
+	assert.NotContains(t, token, "=")
+
+	_, err = CreateRPCMsgFromToken(token + "!")
+	assert.NotNil(t, err)
+
+	msg2, err := CreateRPCMsgFromToken(token)
+	assert.Nil(t, err)
+	assert.True(t, msg.Equals(msg2))
+}
@@ -81,3 +81,22 @@ func (msg *RPCReplyMsg) Equals(msg2 *RPCReplyMsg) bool {
 
 	return false
 }
+
+func ConvertRPCReplyMsgArrayToJSON(msgs []*RPCReplyMsg) (string, error) {
+	jsonBytes, err := json.Marshal(msgs)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func ConvertJSONToRPCReplyMsgArray(jsonString string) ([]*RPCReplyMsg, error) {
+	var msgs []*RPCReplyMsg
+	err := json.Unmarshal([]byte(jsonString), &msgs)
+	if err != nil {
+		return msgs, err
+	}
+
+	return msgs, nil
+}
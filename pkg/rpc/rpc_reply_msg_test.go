@@ -48,3 +48,23 @@ func TestRPCReplyMsgEquals(t *testing.T) {
 	assert.True(t, msg.Equals(msg))
 	assert.False(t, msg.Equals(nil))
 }
+
+func TestRPCReplyMsgArray(t *testing.T) {
+	msg1, err := CreateRPCReplyMsg("test_method", "test_payload")
+	assert.Nil(t, err)
+	msg2, err := CreateRPCErrorReplyMsg(ErrorPayloadType, "test_error")
+	assert.Nil(t, err)
+
+	jsonString, err := ConvertRPCReplyMsgArrayToJSON([]*RPCReplyMsg{msg1, msg2})
+	assert.Nil(t, err)
+
+	_, err = ConvertJSONToRPCReplyMsgArray(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msgs, err := ConvertJSONToRPCReplyMsgArray(jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, msgs, 2)
+	assert.True(t, msg1.Equals(msgs[0]))
+	assert.True(t, msg2.Equals(msgs[1]))
+	assert.True(t, msgs[1].Error)
+}
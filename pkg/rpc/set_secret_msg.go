@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const SetSecretPayloadType = "setsecretmsg"
+
+type SetSecretMsg struct {
+	ColonyID string `json:"colonyid"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateSetSecretMsg(colonyID string, name string, value string) *SetSecretMsg {
+	msg := &SetSecretMsg{}
+	msg.ColonyID = colonyID
+	msg.Name = name
+	msg.Value = value
+	msg.MsgType = SetSecretPayloadType
+
+	return msg
+}
+
+func (msg *SetSecretMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SetSecretMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SetSecretMsg) Equals(msg2 *SetSecretMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.Name == msg2.Name &&
+		msg.Value == msg2.Value {
+		return true
+	}
+
+	return false
+}
+
+func CreateSetSecretMsgFromJSON(jsonString string) (*SetSecretMsg, error) {
+	var msg *SetSecretMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCSetSecretMsg(t *testing.T) {
+	msg := CreateSetSecretMsg(core.GenerateRandomID(), "DB_PASSWORD", "password")
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateSetSecretMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateSetSecretMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCSetSecretMsgIndent(t *testing.T) {
+	msg := CreateSetSecretMsg(core.GenerateRandomID(), "DB_PASSWORD", "password")
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateSetSecretMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateSetSecretMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCSetSecretMsgEquals(t *testing.T) {
+	msg := CreateSetSecretMsg(core.GenerateRandomID(), "DB_PASSWORD", "password")
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
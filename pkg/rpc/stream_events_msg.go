@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const StreamEventsPayloadType = "streameventsmsg"
+
+// StreamEventsMsg is signed and sent as a token when opening a Server-Sent Events stream, exactly one of the Ids
+// selects whether state changes of all processes in a colony, of the processes in a process graph, or of a single
+// process are streamed
+type StreamEventsMsg struct {
+	ColonyID       string `json:"colonyid"`
+	ProcessGraphID string `json:"processgraphid"`
+	ProcessID      string `json:"processid"`
+	MsgType        string `json:"msgtype"`
+}
+
+func CreateStreamEventsMsg(colonyID string, processGraphID string, processID string) *StreamEventsMsg {
+	msg := &StreamEventsMsg{}
+	msg.ColonyID = colonyID
+	msg.ProcessGraphID = processGraphID
+	msg.ProcessID = processID
+	msg.MsgType = StreamEventsPayloadType
+
+	return msg
+}
+
+func (msg *StreamEventsMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *StreamEventsMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *StreamEventsMsg) Equals(msg2 *StreamEventsMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.ProcessGraphID == msg2.ProcessGraphID &&
+		msg.ProcessID == msg2.ProcessID {
+		return true
+	}
+
+	return false
+}
+
+func CreateStreamEventsMsgFromJSON(jsonString string) (*StreamEventsMsg, error) {
+	var msg *StreamEventsMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
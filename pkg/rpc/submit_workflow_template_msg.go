@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/colonyos/colonies/pkg/core"
+)
+
+const SubmitWorkflowTemplatePayloadType = "submitworkflowtemplatemsg"
+
+type SubmitWorkflowTemplateMsg struct {
+	ColonyID         string                    `json:"colonyid"`
+	WorkflowTemplate *core.WorkflowTemplateRef `json:"workflowtemplate"`
+	MsgType          string                    `json:"msgtype"`
+}
+
+func CreateSubmitWorkflowTemplateMsg(colonyID string, workflowTemplate *core.WorkflowTemplateRef) *SubmitWorkflowTemplateMsg {
+	msg := &SubmitWorkflowTemplateMsg{}
+	msg.ColonyID = colonyID
+	msg.WorkflowTemplate = workflowTemplate
+	msg.MsgType = SubmitWorkflowTemplatePayloadType
+
+	return msg
+}
+
+func (msg *SubmitWorkflowTemplateMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SubmitWorkflowTemplateMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SubmitWorkflowTemplateMsg) Equals(msg2 *SubmitWorkflowTemplateMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.WorkflowTemplate.Equals(msg2.WorkflowTemplate) {
+		return true
+	}
+
+	return false
+}
+
+func CreateSubmitWorkflowTemplateMsgFromJSON(jsonString string) (*SubmitWorkflowTemplateMsg, error) {
+	var msg *SubmitWorkflowTemplateMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
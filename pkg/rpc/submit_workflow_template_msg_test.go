@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCSubmitWorkflowTemplateMsg(t *testing.T) {
+	workflowTemplate := core.CreateWorkflowTemplateRef("test_template", 2, map[string]string{"input": "test_input"})
+	msg := CreateSubmitWorkflowTemplateMsg(core.GenerateRandomID(), workflowTemplate)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateSubmitWorkflowTemplateMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateSubmitWorkflowTemplateMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCSubmitWorkflowTemplateMsgIndent(t *testing.T) {
+	workflowTemplate := core.CreateWorkflowTemplateRef("test_template", 2, map[string]string{"input": "test_input"})
+	msg := CreateSubmitWorkflowTemplateMsg(core.GenerateRandomID(), workflowTemplate)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateSubmitWorkflowTemplateMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateSubmitWorkflowTemplateMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCSubmitWorkflowTemplateMsgEquals(t *testing.T) {
+	workflowTemplate := core.CreateWorkflowTemplateRef("test_template", 2, map[string]string{"input": "test_input"})
+	msg := CreateSubmitWorkflowTemplateMsg(core.GenerateRandomID(), workflowTemplate)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const SubscribeColonyPayloadType = "subscribecolonymsg"
+
+type SubscribeColonyMsg struct {
+	ColonyID string `json:"colonyid"`
+	Timeout  int    `json:"timeout"`
+	MsgType  string `json:"msgtype"`
+}
+
+func CreateSubscribeColonyMsg(colonyID string, timeout int) *SubscribeColonyMsg {
+	msg := &SubscribeColonyMsg{}
+	msg.ColonyID = colonyID
+	msg.Timeout = timeout
+	msg.MsgType = SubscribeColonyPayloadType
+
+	return msg
+}
+
+func (msg *SubscribeColonyMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SubscribeColonyMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SubscribeColonyMsg) Equals(msg2 *SubscribeColonyMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ColonyID == msg2.ColonyID &&
+		msg.Timeout == msg2.Timeout {
+		return true
+	}
+
+	return false
+}
+
+func CreateSubscribeColonyMsgFromJSON(jsonString string) (*SubscribeColonyMsg, error) {
+	var msg *SubscribeColonyMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+const SubscribeProcessGraphPayloadType = "subscribeprocessgraphmsg"
+
+type SubscribeProcessGraphMsg struct {
+	ProcessGraphID string `json:"processgraphid"`
+	Timeout        int    `json:"timeout"`
+	MsgType        string `json:"msgtype"`
+}
+
+func CreateSubscribeProcessGraphMsg(processGraphID string, timeout int) *SubscribeProcessGraphMsg {
+	msg := &SubscribeProcessGraphMsg{}
+	msg.ProcessGraphID = processGraphID
+	msg.Timeout = timeout
+	msg.MsgType = SubscribeProcessGraphPayloadType
+
+	return msg
+}
+
+func (msg *SubscribeProcessGraphMsg) ToJSON() (string, error) {
+	jsonBytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SubscribeProcessGraphMsg) ToJSONIndent() (string, error) {
+	jsonBytes, err := json.MarshalIndent(msg, "", "    ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonBytes), nil
+}
+
+func (msg *SubscribeProcessGraphMsg) Equals(msg2 *SubscribeProcessGraphMsg) bool {
+	if msg2 == nil {
+		return false
+	}
+
+	if msg.MsgType == msg2.MsgType &&
+		msg.ProcessGraphID == msg2.ProcessGraphID &&
+		msg.Timeout == msg2.Timeout {
+		return true
+	}
+
+	return false
+}
+
+func CreateSubscribeProcessGraphMsgFromJSON(jsonString string) (*SubscribeProcessGraphMsg, error) {
+	var msg *SubscribeProcessGraphMsg
+
+	err := json.Unmarshal([]byte(jsonString), &msg)
+	if err != nil {
+		return msg, err
+	}
+
+	return msg, nil
+}
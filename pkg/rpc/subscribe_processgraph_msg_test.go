@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRPCSubscribeProcessGraphMsg(t *testing.T) {
+	msg := CreateSubscribeProcessGraphMsg(core.GenerateRandomID(), 2)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	msg2, err := CreateSubscribeProcessGraphMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateSubscribeProcessGraphMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCSubscribeProcessGraphMsgIndent(t *testing.T) {
+	msg := CreateSubscribeProcessGraphMsg(core.GenerateRandomID(), 2)
+	jsonString, err := msg.ToJSONIndent()
+	assert.Nil(t, err)
+
+	msg2, err := CreateSubscribeProcessGraphMsgFromJSON(jsonString + "error")
+	assert.NotNil(t, err)
+
+	msg2, err = CreateSubscribeProcessGraphMsgFromJSON(jsonString)
+	assert.Nil(t, err)
+
+	assert.True(t, msg.Equals(msg2))
+}
+
+func TestRPCSubscribeProcessGraphMsgEquals(t *testing.T) {
+	msg := CreateSubscribeProcessGraphMsg(core.GenerateRandomID(), 2)
+	assert.True(t, msg.Equals(msg))
+	assert.False(t, msg.Equals(nil))
+}
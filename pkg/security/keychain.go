@@ -1,13 +1,34 @@
 package security
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"io"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
+
+	aes "github.com/colonyos/colonies/internal/crypto"
+	"golang.org/x/crypto/scrypt"
 )
 
+// Encrypted entries are stored as ENCRYPTED_PREFIX<salt>:<ciphertext>, the key is derived from a passphrase and
+// the salt using scrypt
+const ENCRYPTED_PREFIX = "encrypted:"
+const SALT_SIZE = 16
+const LABELS_DIR = "labels"
+
+type KeychainEntry struct {
+	ID        string `json:"id"`
+	Encrypted bool   `json:"encrypted"`
+}
+
 type Keychain struct {
-	dirName string
+	dirName        string
+	passphrase     string
+	passphraseFunc func() (string, error)
 }
 
 func (keychain *Keychain) ensureColoniesDirExists() error {
@@ -47,18 +68,221 @@ func CreateKeychain(coloniesDirName string) (*Keychain, error) {
 	return keychain, nil
 }
 
+// SetPassphrase sets the passphrase used to decrypt entries, new entries are encrypted once a passphrase is set
+func (keychain *Keychain) SetPassphrase(passphrase string) {
+	keychain.passphrase = passphrase
+}
+
+// SetPassphraseFunc sets a function called to get the passphrase when an encrypted entry is read and no passphrase
+// has been set, e.g. to prompt the user
+func (keychain *Keychain) SetPassphraseFunc(passphraseFunc func() (string, error)) {
+	keychain.passphraseFunc = passphraseFunc
+}
+
+func (keychain *Keychain) getPassphrase() (string, error) {
+	if keychain.passphrase != "" {
+		return keychain.passphrase, nil
+	}
+
+	if keychain.passphraseFunc == nil {
+		return "", errors.New("Keychain entry is encrypted, but no passphrase has been set")
+	}
+
+	passphrase, err := keychain.passphraseFunc()
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("Keychain entry is encrypted, but no passphrase has been set")
+	}
+	keychain.passphrase = passphrase
+
+	return passphrase, nil
+}
+
+func deriveKey(passphrase string, salt []byte) (string, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 32768, 8, 1, aes.AESKeySize)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
+func encryptEntry(passphrase string, prvKey string) (string, error) {
+	salt := make([]byte, SALT_SIZE)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := aes.Encrypt(key, []byte(prvKey), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return ENCRYPTED_PREFIX + hex.EncodeToString(salt) + ":" + ciphertext, nil
+}
+
+func decryptEntry(passphrase string, entry string) (string, error) {
+	s := strings.Split(strings.TrimPrefix(entry, ENCRYPTED_PREFIX), ":")
+	if len(s) != 2 {
+		return "", errors.New("Invalid encrypted keychain entry")
+	}
+
+	salt, err := hex.DecodeString(s[0])
+	if err != nil {
+		return "", errors.New("Invalid encrypted keychain entry")
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	prvKey, err := aes.Decrypt(key, s[1], nil)
+	if err != nil {
+		return "", errors.New("Failed to decrypt keychain entry, wrong passphrase")
+	}
+
+	return string(prvKey), nil
+}
+
+func isEncrypted(entry string) bool {
+	return strings.HasPrefix(entry, ENCRYPTED_PREFIX)
+}
+
+// IsEncryptedEntry returns true if an entry returned by GetEntry is encrypted
+func (keychain *Keychain) IsEncryptedEntry(entry string) bool {
+	return isEncrypted(entry)
+}
+
+// AddPrvKey stores a private key, the key is encrypted if a passphrase has been set
 func (keychain *Keychain) AddPrvKey(id string, prvKey string) error {
-	prvKeyBytes := []byte(prvKey)
-	return os.WriteFile(keychain.dirName+"/"+id, prvKeyBytes, 0600)
+	entry := prvKey
+	if keychain.passphrase != "" {
+		var err error
+		entry, err = encryptEntry(keychain.passphrase, prvKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return keychain.AddEntry(id, entry)
 }
 
 func (keychain *Keychain) GetPrvKey(id string) (string, error) {
-	prvKeyBytes, err := ioutil.ReadFile(keychain.dirName + "/" + id)
+	entry, err := keychain.GetEntry(id)
+	if err != nil {
+		return "", err
+	}
+
+	if !isEncrypted(entry) {
+		return entry, nil
+	}
+
+	passphrase, err := keychain.getPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	return decryptEntry(passphrase, entry)
+}
+
+// AddEntry stores an entry as is, i.e. an encrypted entry stays encrypted with the passphrase it was encrypted with
+func (keychain *Keychain) AddEntry(id string, entry string) error {
+	if id == "" || strings.ContainsAny(id, "/\\") || strings.HasPrefix(id, ".") || id == LABELS_DIR {
+		return errors.New("Invalid keychain identity <" + id + ">")
+	}
+
+	return os.WriteFile(keychain.dirName+"/"+id, []byte(entry), 0600)
+}
+
+// GetEntry returns an entry as stored, without decrypting it
+func (keychain *Keychain) GetEntry(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, "/\\") {
+		return "", errors.New("Invalid keychain identity <" + id + ">")
+	}
+
+	entryBytes, err := ioutil.ReadFile(keychain.dirName + "/" + id)
+	if err != nil {
+		return "", err
+	}
+
+	return string(entryBytes), nil
+}
+
+func (keychain *Keychain) RemovePrvKey(id string) error {
+	if id == "" || strings.ContainsAny(id, "/\\") {
+		return errors.New("Invalid keychain identity <" + id + ">")
+	}
+
+	return os.Remove(keychain.dirName + "/" + id)
+}
+
+// List returns all entries in the keychain ordered by identity
+func (keychain *Keychain) List() ([]*KeychainEntry, error) {
+	files, err := ioutil.ReadDir(keychain.dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*KeychainEntry
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		entry, err := keychain.GetEntry(file.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &KeychainEntry{ID: file.Name(), Encrypted: isEncrypted(entry)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	return entries, nil
+}
+
+// SetLabel associates a label with an identity, e.g. to find the identity of a worker started on this host
+func (keychain *Keychain) SetLabel(label string, id string) error {
+	if label == "" || strings.ContainsAny(label, "/\\") || strings.HasPrefix(label, ".") {
+		return errors.New("Invalid keychain label <" + label + ">")
+	}
+
+	err := os.MkdirAll(keychain.dirName+"/"+LABELS_DIR, 0700)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(keychain.dirName+"/"+LABELS_DIR+"/"+label, []byte(id), 0600)
+}
+
+func (keychain *Keychain) GetLabel(label string) (string, error) {
+	if label == "" || strings.ContainsAny(label, "/\\") {
+		return "", errors.New("Invalid keychain label <" + label + ">")
+	}
+
+	idBytes, err := ioutil.ReadFile(keychain.dirName + "/" + LABELS_DIR + "/" + label)
 	if err != nil {
 		return "", err
 	}
 
-	return string(prvKeyBytes), nil
+	return string(idBytes), nil
+}
+
+func (keychain *Keychain) RemoveLabel(label string) error {
+	if label == "" || strings.ContainsAny(label, "/\\") {
+		return errors.New("Invalid keychain label <" + label + ">")
+	}
+
+	return os.Remove(keychain.dirName + "/" + LABELS_DIR + "/" + label)
 }
 
 func (keychain *Keychain) Remove() error {
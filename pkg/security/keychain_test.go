@@ -50,3 +50,98 @@ func TestKeychainFailure(t *testing.T) {
 	err = os.Remove(keychain.dirName)
 	assert.Nil(t, err)
 }
+
+func TestKeychainEncrypted(t *testing.T) {
+	keychain, err := CreateKeychain(".colonies_test")
+	assert.Nil(t, err)
+
+	crypto := crypto.CreateCrypto()
+	prvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	id := core.GenerateRandomID()
+	keychain.SetPassphrase("secret")
+	err = keychain.AddPrvKey(id, prvKey)
+	assert.Nil(t, err)
+
+	entry, err := keychain.GetEntry(id)
+	assert.Nil(t, err)
+	assert.NotContains(t, entry, prvKey)
+
+	prvKeyFromKeychain, err := keychain.GetPrvKey(id)
+	assert.Nil(t, err)
+	assert.Equal(t, prvKey, prvKeyFromKeychain)
+
+	// No passphrase
+	keychain2, err := CreateKeychain(".colonies_test")
+	assert.Nil(t, err)
+	_, err = keychain2.GetPrvKey(id)
+	assert.NotNil(t, err)
+
+	// Wrong passphrase
+	keychain2.SetPassphrase("wrong")
+	_, err = keychain2.GetPrvKey(id)
+	assert.NotNil(t, err)
+
+	// Passphrase from a function, e.g. a prompt
+	keychain3, err := CreateKeychain(".colonies_test")
+	assert.Nil(t, err)
+	keychain3.SetPassphraseFunc(func() (string, error) { return "secret", nil })
+	prvKeyFromKeychain, err = keychain3.GetPrvKey(id)
+	assert.Nil(t, err)
+	assert.Equal(t, prvKey, prvKeyFromKeychain)
+
+	keychain.Remove()
+}
+
+func TestKeychainList(t *testing.T) {
+	keychain, err := CreateKeychain(".colonies_test")
+	assert.Nil(t, err)
+
+	id1 := core.GenerateRandomID()
+	err = keychain.AddPrvKey(id1, "prvkey1")
+	assert.Nil(t, err)
+
+	keychain.SetPassphrase("secret")
+	id2 := core.GenerateRandomID()
+	err = keychain.AddPrvKey(id2, "prvkey2")
+	assert.Nil(t, err)
+
+	err = keychain.SetLabel("worker", id2)
+	assert.Nil(t, err)
+
+	entries, err := keychain.List()
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		if entry.ID == id1 {
+			assert.False(t, entry.Encrypted)
+		} else {
+			assert.Equal(t, id2, entry.ID)
+			assert.True(t, entry.Encrypted)
+		}
+	}
+
+	id, err := keychain.GetLabel("worker")
+	assert.Nil(t, err)
+	assert.Equal(t, id2, id)
+
+	err = keychain.RemoveLabel("worker")
+	assert.Nil(t, err)
+	_, err = keychain.GetLabel("worker")
+	assert.NotNil(t, err)
+
+	err = keychain.RemovePrvKey(id1)
+	assert.Nil(t, err)
+	_, err = keychain.GetPrvKey(id1)
+	assert.NotNil(t, err)
+
+	entries, err = keychain.List()
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+
+	err = keychain.AddPrvKey("../escape", "prvkey")
+	assert.NotNil(t, err)
+
+	keychain.Remove()
+}
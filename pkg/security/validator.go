@@ -1,7 +1,11 @@
 package security
 
+import "github.com/colonyos/colonies/pkg/core"
+
 type Validator interface {
 	RequireServerOwner(recoveredID string, serverID string) error
 	RequireColonyOwner(recoveredID string, colonyID string) error
 	RequireRuntimeMembership(recoveredID string, colonyID string, approved bool) error
+	RequireRuntimeRole(recoveredID string, colonyID string, roles []string) error
+	RequireDelegation(chain []*core.Delegation, signerID string) (string, error)
 }
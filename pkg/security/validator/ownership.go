@@ -2,5 +2,7 @@ package validator
 
 type ownership interface {
 	checkIfColonyExists(colonyID string) error
+	checkIfColonyOwner(ownerID string, colonyID string) error
 	checkIfRuntimeIsValid(runtimeID string, colonyID string, approved bool) error
+	getRuntimeRoles(runtimeID string) ([]string, error)
 }
@@ -30,6 +30,23 @@ func (ownership *ownershipImpl) checkIfColonyExists(colonyID string) error {
 	return nil
 }
 
+func (ownership *ownershipImpl) checkIfColonyOwner(ownerID string, colonyID string) error {
+	colony, err := ownership.db.GetColonyByID(colonyID)
+	if err != nil {
+		return err
+	}
+
+	if colony == nil {
+		return errors.New("Colony <" + colonyID + "> does not exists")
+	}
+
+	if colony.OwnerID != ownerID {
+		return errors.New("RecoveredID is not the owner of Colony with Id <" + colonyID + ">")
+	}
+
+	return nil
+}
+
 func (ownership *ownershipImpl) checkIfRuntimeIsValid(runtimeID string, colonyID string, approved bool) error {
 	colony, err := ownership.db.GetColonyByID(colonyID)
 	if err != nil {
@@ -61,3 +78,7 @@ func (ownership *ownershipImpl) checkIfRuntimeIsValid(runtimeID string, colonyID
 
 	return nil
 }
+
+func (ownership *ownershipImpl) getRuntimeRoles(runtimeID string) ([]string, error) {
+	return ownership.db.GetRuntimeRoles(runtimeID)
+}
@@ -6,21 +6,30 @@ import (
 
 type OwnershipMock struct {
 	colonies         map[string]bool
+	colonyOwners     map[string]string
 	runtimes         map[string]string
 	approvedRuntimes map[string]bool
+	runtimeRoles     map[string][]string
 }
 
 func createOwnershipMock() *OwnershipMock {
 	ownership := &OwnershipMock{}
 	ownership.colonies = make(map[string]bool)
+	ownership.colonyOwners = make(map[string]string)
 	ownership.runtimes = make(map[string]string)
 	ownership.approvedRuntimes = make(map[string]bool)
+	ownership.runtimeRoles = make(map[string][]string)
 
 	return ownership
 }
 
 func (ownership *OwnershipMock) addColony(colonyID string) {
 	ownership.colonies[colonyID] = true
+	ownership.colonyOwners[colonyID] = colonyID
+}
+
+func (ownership *OwnershipMock) setColonyOwner(colonyID string, ownerID string) {
+	ownership.colonyOwners[colonyID] = ownerID
 }
 
 func (ownership *OwnershipMock) addRuntime(runtimeID string, colonyID string) {
@@ -31,6 +40,10 @@ func (ownership *OwnershipMock) approveRuntime(runtimeID string, colonyID string
 	ownership.approvedRuntimes[runtimeID] = true
 }
 
+func (ownership *OwnershipMock) addRuntimeRole(runtimeID string, role string) {
+	ownership.runtimeRoles[runtimeID] = append(ownership.runtimeRoles[runtimeID], role)
+}
+
 func (ownership *OwnershipMock) checkIfColonyExists(colonyID string) error {
 	colonyIDFromDB := ownership.colonies[colonyID]
 	if !colonyIDFromDB {
@@ -40,6 +53,19 @@ func (ownership *OwnershipMock) checkIfColonyExists(colonyID string) error {
 	return nil
 }
 
+func (ownership *OwnershipMock) checkIfColonyOwner(ownerID string, colonyID string) error {
+	err := ownership.checkIfColonyExists(colonyID)
+	if err != nil {
+		return err
+	}
+
+	if ownership.colonyOwners[colonyID] != ownerID {
+		return errors.New("Not colony owner")
+	}
+
+	return nil
+}
+
 func (ownership *OwnershipMock) checkIfRuntimeBelongsToColony(runtimeID string, colonyID string) error {
 	colonyIDFromDB := ownership.runtimes[runtimeID]
 	if colonyIDFromDB == "" {
@@ -57,6 +83,10 @@ func (ownership *OwnershipMock) checkIfRuntimeIsValid(runtimeID string, colonyID
 		return errors.New("Runtime does not exists")
 	}
 
+	if ownership.runtimes[runtimeID] != colonyID {
+		return errors.New("Runtime is not a member of the colony")
+	}
+
 	if approved {
 		if ownership.approvedRuntimes[runtimeID] == false {
 			return errors.New("Runtime is not approved")
@@ -65,3 +95,7 @@ func (ownership *OwnershipMock) checkIfRuntimeIsValid(runtimeID string, colonyID
 
 	return nil
 }
+
+func (ownership *OwnershipMock) getRuntimeRoles(runtimeID string) ([]string, error) {
+	return ownership.runtimeRoles[runtimeID], nil
+}
@@ -2,7 +2,10 @@ package validator
 
 import (
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/database"
 )
 
@@ -26,14 +29,60 @@ func (validator *StandaloneValidator) RequireServerOwner(recoveredID string, ser
 	return nil
 }
 
+// RequireColonyOwner requires that the recovered Id matches the current owner of the colony, which is initially
+// the colony Id itself, but changes when the colony key is rotated
 func (validator *StandaloneValidator) RequireColonyOwner(recoveredID string, colonyID string) error {
-	if recoveredID != colonyID {
-		return errors.New("RecoveredID does not match Colony Id")
-	}
-
-	return validator.ownership.checkIfColonyExists(colonyID)
+	return validator.ownership.checkIfColonyOwner(recoveredID, colonyID)
 }
 
 func (validator *StandaloneValidator) RequireRuntimeMembership(recoveredID string, colonyID string, approved bool) error {
 	return validator.ownership.checkIfRuntimeIsValid(recoveredID, colonyID, approved)
 }
+
+// RequireRuntimeRole requires an approved runtime member having at least one of the given roles, runtimes without any roles are not restricted
+func (validator *StandaloneValidator) RequireRuntimeRole(recoveredID string, colonyID string, roles []string) error {
+	err := validator.ownership.checkIfRuntimeIsValid(recoveredID, colonyID, true)
+	if err != nil {
+		return err
+	}
+
+	runtimeRoles, err := validator.ownership.getRuntimeRoles(recoveredID)
+	if err != nil {
+		return err
+	}
+
+	if len(runtimeRoles) == 0 {
+		return nil
+	}
+
+	for _, runtimeRole := range runtimeRoles {
+		for _, role := range roles {
+			if runtimeRole == role {
+				return nil
+			}
+		}
+	}
+
+	return errors.New("Runtime with Id <" + recoveredID + "> does not have any of the required roles <" + strings.Join(roles, ", ") + ">")
+}
+
+// RequireDelegation verifies a delegation chain for an RPC message signed by signerID and returns the Id of the
+// root issuer, which must be the owner or an approved runtime member of the colony the chain is restricted to
+func (validator *StandaloneValidator) RequireDelegation(chain []*core.Delegation, signerID string) (string, error) {
+	err := core.VerifyDelegationChain(chain, signerID, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	root := chain[0]
+	if validator.ownership.checkIfColonyOwner(root.IssuerID, root.ColonyID) == nil {
+		return root.IssuerID, nil
+	}
+
+	err = validator.ownership.checkIfRuntimeIsValid(root.IssuerID, root.ColonyID, true)
+	if err != nil {
+		return "", errors.New("Invalid delegation chain, issuer with Id <" + root.IssuerID + "> is neither owner nor member of colony with Id <" + root.ColonyID + ">")
+	}
+
+	return root.IssuerID, nil
+}
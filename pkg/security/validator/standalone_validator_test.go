@@ -2,9 +2,11 @@ package validator
 
 import (
 	"testing"
+	"time"
 
 	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/database/postgresql"
+	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,6 +37,12 @@ func TestRequireColonyOwner(t *testing.T) {
 	ownership.addColony(colonyID)
 	assert.Nil(t, security.RequireColonyOwner(colonyID, colonyID))
 	assert.NotNil(t, security.RequireColonyOwner(core.GenerateRandomID(), colonyID))
+
+	// After a key rotation, only the new owner is accepted
+	newOwnerID := core.GenerateRandomID()
+	ownership.setColonyOwner(colonyID, newOwnerID)
+	assert.Nil(t, security.RequireColonyOwner(newOwnerID, colonyID))
+	assert.NotNil(t, security.RequireColonyOwner(colonyID, colonyID))
 }
 
 func TestRequireRuntimeMembership(t *testing.T) {
@@ -56,3 +64,76 @@ func TestRequireRuntimeMembership(t *testing.T) {
 	assert.Nil(t, security.RequireRuntimeMembership(runtime1ID, colonyID, true))    // Should work
 	assert.NotNil(t, security.RequireRuntimeMembership(runtime2ID, colonyID, true)) // Should not work, not approved
 }
+
+func TestRequireRuntimeRole(t *testing.T) {
+	ownership := createOwnershipMock()
+	security := createTestValidator(ownership)
+
+	colonyID := core.GenerateRandomID()
+	ownership.addColony(colonyID)
+	runtime1ID := core.GenerateRandomID()
+	runtime2ID := core.GenerateRandomID()
+	ownership.addRuntime(runtime1ID, colonyID)
+	ownership.addRuntime(runtime2ID, colonyID)
+
+	assert.NotNil(t, security.RequireRuntimeRole(runtime1ID, colonyID, []string{core.ROLE_VIEWER})) // Should not work, not approved
+
+	ownership.approveRuntime(runtime1ID, colonyID)
+	ownership.approveRuntime(runtime2ID, colonyID)
+	assert.Nil(t, security.RequireRuntimeRole(runtime1ID, colonyID, []string{core.ROLE_OPERATOR})) // Should work, runtimes without roles are not restricted
+
+	ownership.addRuntimeRole(runtime2ID, core.ROLE_SUBMITTER)
+	assert.Nil(t, security.RequireRuntimeRole(runtime2ID, colonyID, []string{core.ROLE_SUBMITTER, core.ROLE_OPERATOR})) // Should work
+	assert.NotNil(t, security.RequireRuntimeRole(runtime2ID, colonyID, []string{core.ROLE_EXECUTOR}))                   // Should not work, missing role
+}
+
+func TestRequireDelegation(t *testing.T) {
+	ownership := createOwnershipMock()
+	security := createTestValidator(ownership)
+
+	c := crypto.CreateCrypto()
+	colonyPrvKey, err := c.GeneratePrivateKey()
+	assert.Nil(t, err)
+	colonyID, err := c.GenerateID(colonyPrvKey)
+	assert.Nil(t, err)
+	ownership.addColony(colonyID)
+
+	runtimePrvKey, err := c.GeneratePrivateKey()
+	assert.Nil(t, err)
+	runtimeID, err := c.GenerateID(runtimePrvKey)
+	assert.Nil(t, err)
+	ownership.addRuntime(runtimeID, colonyID)
+
+	delegateID := core.GenerateRandomID()
+	payloadTypes := []string{"submitprocessspecmsg"}
+	expires := time.Now().Add(time.Hour)
+
+	// Issued by the colony owner
+	delegation := core.CreateDelegation(delegateID, colonyID, payloadTypes, nil, nil, expires)
+	assert.Nil(t, delegation.Sign(colonyPrvKey))
+	issuerID, err := security.RequireDelegation([]*core.Delegation{delegation}, delegateID)
+	assert.Nil(t, err) // Should work
+	assert.Equal(t, colonyID, issuerID)
+
+	_, err = security.RequireDelegation([]*core.Delegation{delegation}, core.GenerateRandomID())
+	assert.NotNil(t, err) // Should not work, not signed by the delegate
+
+	// Issued by a runtime
+	delegation = core.CreateDelegation(delegateID, colonyID, payloadTypes, nil, nil, expires)
+	assert.Nil(t, delegation.Sign(runtimePrvKey))
+	_, err = security.RequireDelegation([]*core.Delegation{delegation}, delegateID)
+	assert.NotNil(t, err) // Should not work, runtime not approved
+
+	ownership.approveRuntime(runtimeID, colonyID)
+	issuerID, err = security.RequireDelegation([]*core.Delegation{delegation}, delegateID)
+	assert.Nil(t, err) // Should work
+	assert.Equal(t, runtimeID, issuerID)
+
+	// Issued for another colony
+	otherColonyID := core.GenerateRandomID()
+	ownership.addColony(otherColonyID)
+	delegation = core.CreateDelegation(delegateID, otherColonyID, payloadTypes, nil, nil, expires)
+	assert.Nil(t, delegation.Sign(runtimePrvKey))
+	_, err = security.RequireDelegation([]*core.Delegation{delegation}, delegateID)
+	assert.NotNil(t, err) // Should not work, runtime is not a member of the colony
+}
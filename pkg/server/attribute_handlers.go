@@ -1,51 +1,58 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/rpc"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
-func (server *ColoniesServer) handleAddAttributeHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+// prepareAddAttribute parses and authorizes an addattributemsg, on failure the HTTP status code to reply with is
+// returned together with the error
+func (server *ColoniesServer) prepareAddAttribute(ctx context.Context, recoveredID string, payloadType string, jsonString string) (core.Attribute, int, error) {
 	msg, err := rpc.CreateAddAttributeMsgFromJSON(jsonString)
 	if err != nil {
-		if server.handleHTTPError(c, errors.New("Failed to add attribute, invalid JSON"), http.StatusBadRequest) {
-			return
-		}
+		return core.Attribute{}, http.StatusBadRequest, errors.New("Failed to add attribute, invalid JSON")
 	}
 
 	if msg.MsgType != payloadType {
-		server.handleHTTPError(c, errors.New("Failed to add attribute, msg.MsgType does not match payloadType"), http.StatusBadRequest)
-		return
+		return core.Attribute{}, http.StatusBadRequest, errors.New("Failed to add attribute, msg.MsgType does not match payloadType")
 	}
 
-	process, err := server.controller.getProcess(msg.Attribute.TargetID)
-	if server.handleHTTPError(c, err, http.StatusBadRequest) {
-		return
+	process, err := server.controller.getProcess(ctx, msg.Attribute.TargetID)
+	if err != nil {
+		return core.Attribute{}, http.StatusBadRequest, err
 	}
 	if process == nil {
-		server.handleHTTPError(c, errors.New("Failed to add attribute, process is nil"), http.StatusInternalServerError)
-		return
+		return core.Attribute{}, http.StatusNotFound, errors.New("Failed to add attribute, process not found")
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, process.ProcessSpec.Conditions.ColonyID, true)
-	if server.handleHTTPError(c, err, http.StatusForbidden) {
-		return
+	err = server.requireRuntimeRole(recoveredID, process.ProcessSpec.Conditions.ColonyID, payloadType)
+	if err != nil {
+		return core.Attribute{}, http.StatusForbidden, err
 	}
 
 	if process.AssignedRuntimeID != recoveredID {
-		err := errors.New("Failed to add attribute, only runtime with id <" + process.AssignedRuntimeID + "> is allowed to set attributes")
-		server.handleHTTPError(c, err, http.StatusForbidden)
-		return
+		return core.Attribute{}, http.StatusForbidden, errors.New("Failed to add attribute, only runtime with id <" + process.AssignedRuntimeID + "> is allowed to set attributes")
 	}
 
 	msg.Attribute.GenerateID()
 	msg.Attribute.TargetProcessGraphID = process.ProcessGraphID
 
-	addedAttribute, err := server.controller.addAttribute(msg.Attribute)
+	return msg.Attribute, http.StatusOK, nil
+}
+
+func (server *ColoniesServer) handleAddAttributeHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	attribute, errorCode, err := server.prepareAddAttribute(c.Request.Context(), recoveredID, payloadType, jsonString)
+	if server.handleHTTPError(c, err, errorCode) {
+		return
+	}
+
+	addedAttribute, err := server.controller.addAttribute(c.Request.Context(), attribute)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -55,7 +62,7 @@ func (server *ColoniesServer) handleAddAttributeHTTPRequest(c *gin.Context, reco
 		return
 	}
 
-	log.WithFields(log.Fields{"AttributeID": msg.Attribute.ID}).Debug("Adding attribute")
+	log.WithFields(log.Fields{"AttributeID": attribute.ID}).Debug("Adding attribute")
 
 	server.sendHTTPReply(c, payloadType, jsonString)
 }
@@ -73,21 +80,21 @@ func (server *ColoniesServer) handleGetAttributeHTTPRequest(c *gin.Context, reco
 		return
 	}
 
-	attribute, err := server.controller.getAttribute(msg.AttributeID)
+	attribute, err := server.controller.getAttribute(c.Request.Context(), msg.AttributeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 
-	process, err := server.controller.getProcess(attribute.TargetID)
+	process, err := server.controller.getProcess(c.Request.Context(), attribute.TargetID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if process == nil {
-		server.handleHTTPError(c, errors.New("Failed to get attribute, process is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get attribute, process not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, process.ProcessSpec.Conditions.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, process.ProcessSpec.Conditions.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
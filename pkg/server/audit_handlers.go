@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const MAX_AUDIT_TARGET_DEPTH = 3
+
+var auditedPayloadTypes = map[string]bool{
+	rpc.AddColonyPayloadType:              true,
+	rpc.DeleteColonyPayloadType:           true,
+	rpc.RotateColonyKeyPayloadType:        true,
+	rpc.AddRuntimePayloadType:             true,
+	rpc.ApproveRuntimePayloadType:         true,
+	rpc.RejectRuntimePayloadType:          true,
+	rpc.DeleteRuntimePayloadType:          true,
+	rpc.RevokeRuntimePayloadType:          true,
+	rpc.AddRuntimeRolePayloadType:         true,
+	rpc.RemoveRuntimeRolePayloadType:      true,
+	rpc.SubmitProcessSpecPayloadType:      true,
+	rpc.DeleteProcessPayloadType:          true,
+	rpc.DeleteAllProcessesPayloadType:     true,
+	rpc.CloseSuccessfulPayloadType:        true,
+	rpc.CloseFailedPayloadType:            true,
+	rpc.AddAttributePayloadType:           true,
+	rpc.SetSecretPayloadType:              true,
+	rpc.DeleteSecretPayloadType:           true,
+	rpc.AddWebhookPayloadType:             true,
+	rpc.DeleteWebhookPayloadType:          true,
+	rpc.SubmitWorkflowSpecPayloadType:     true,
+	rpc.DeleteProcessGraphPayloadType:     true,
+	rpc.DeleteAllProcessGraphsPayloadType: true,
+	rpc.AddGeneratorPayloadType:           true,
+	rpc.PackGeneratorPayloadType:          true,
+	rpc.DeleteGeneratorPayloadType:        true,
+	rpc.AddCronPayloadType:                true,
+	rpc.RunCronPayloadType:                true,
+	rpc.DeleteCronPayloadType:             true,
+	rpc.AddWorkflowTemplatePayloadType:    true,
+	rpc.DeleteWorkflowTemplatePayloadType: true,
+	rpc.SubmitWorkflowTemplatePayloadType: true,
+	rpc.AddClusterNodePayloadType:         true,
+	rpc.RemoveClusterNodePayloadType:      true,
+}
+
+// extractTargetIDs collects the IDs a payload refers to, i.e. the values of all keys ending with id or ids, also
+// in nested objects. Other values, e.g. secret values, are never recorded in the audit log.
+func extractTargetIDs(jsonString string) []string {
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonString), &payload); err != nil {
+		return []string{}
+	}
+
+	targetIDs := []string{}
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			targetIDs = append(targetIDs, id)
+		}
+	}
+
+	var walk func(obj map[string]interface{}, depth int)
+	walk = func(obj map[string]interface{}, depth int) {
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			switch value := obj[key].(type) {
+			case string:
+				if strings.HasSuffix(key, "id") {
+					add(value)
+				}
+			case []interface{}:
+				if strings.HasSuffix(key, "ids") {
+					for _, v := range value {
+						if id, ok := v.(string); ok {
+							add(id)
+						}
+					}
+				}
+			case map[string]interface{}:
+				if depth < MAX_AUDIT_TARGET_DEPTH {
+					walk(value, depth+1)
+				}
+			}
+		}
+	}
+	walk(payload, 1)
+
+	return targetIDs
+}
+
+type auditBatchKey struct{}
+
+// auditBatch collects the audit entries of the messages in a batch so that they are appended in one transaction
+type auditBatch struct {
+	auditEntries []*core.AuditEntry
+}
+
+func withAuditBatch(ctx context.Context) (context.Context, *auditBatch) {
+	batch := &auditBatch{}
+	return context.WithValue(ctx, auditBatchKey{}, batch), batch
+}
+
+// audit appends a mutating RPC call to the audit log, it must be called after the request has been handled so that
+// the result is known
+func (server *ColoniesServer) audit(c *gin.Context, recoveredID string, signerID string, payloadType string, jsonString string) {
+	server.auditWithStatus(c.Request.Context(), recoveredID, signerID, payloadType, jsonString, c.Writer.Status())
+}
+
+// auditWithStatus appends an audit entry, or adds it to the batch in ctx if the call is part of a batch
+func (server *ColoniesServer) auditWithStatus(ctx context.Context, recoveredID string, signerID string, payloadType string, jsonString string, status int) {
+	if !auditedPayloadTypes[payloadType] {
+		return
+	}
+
+	auditEntry := core.CreateAuditEntry(recoveredID, signerID, payloadType, extractTargetIDs(jsonString), status)
+	if batch, ok := ctx.Value(auditBatchKey{}).(*auditBatch); ok {
+		batch.auditEntries = append(batch.auditEntries, auditEntry)
+		return
+	}
+
+	err := tracing.DatabaseWithContext(server.db, ctx).AppendAuditEntry(auditEntry)
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err, "CallerID": recoveredID, "SignerID": signerID, "PayloadType": payloadType}).Error("Failed to append audit entry")
+	}
+}
+
+func (server *ColoniesServer) appendAuditBatch(ctx context.Context, batch *auditBatch) {
+	if len(batch.auditEntries) == 0 {
+		return
+	}
+
+	err := tracing.DatabaseWithContext(server.db, ctx).AppendAuditEntries(batch.auditEntries)
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err, "Entries": len(batch.auditEntries)}).Error("Failed to append audit entries")
+	}
+}
+
+func (server *ColoniesServer) handleGetAuditLogHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetAuditLogMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get audit log, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get audit log, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireServerOwner(recoveredID, server.serverID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	count := msg.Count
+	if count <= 0 || count > MAX_COUNT {
+		count = MAX_COUNT
+	}
+
+	auditEntries, err := tracing.DatabaseWithContext(server.db, c.Request.Context()).FindAuditEntries(msg.TargetID, msg.FromSeq, count)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = core.ConvertAuditEntryArrayToJSON(auditEntries)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
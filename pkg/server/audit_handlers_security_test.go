@@ -0,0 +1,33 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAuditLogSecurity(t *testing.T) {
+	env, client, server, serverPrvKey, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	_, err := client.GetAuditLog("", 0, 100, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.GetAuditLog("", 0, 100, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.GetAuditLog(env.colony1ID, 0, 100, env.colony1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.GetAuditLog(env.colony2ID, 0, 100, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.GetAuditLog("", 0, 100, serverPrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
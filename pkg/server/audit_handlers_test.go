@@ -0,0 +1,76 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTargetIDs(t *testing.T) {
+	msg := rpc.CreateSubmitProcessSpecMsg(utils.CreateTestProcessSpec(core.GenerateRandomID()))
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+	targetIDs := extractTargetIDs(jsonString)
+	assert.Equal(t, []string{msg.ProcessSpec.Conditions.ColonyID}, targetIDs)
+
+	secretMsg := rpc.CreateSetSecretMsg(core.GenerateRandomID(), "DB_PASSWORD", "password")
+	jsonString, err = secretMsg.ToJSON()
+	assert.Nil(t, err)
+	targetIDs = extractTargetIDs(jsonString)
+	assert.Equal(t, []string{secretMsg.ColonyID}, targetIDs)
+
+	assert.Len(t, extractTargetIDs("invalid json"), 0)
+}
+
+func TestGetAuditLog(t *testing.T) {
+	env, client, server, serverPrvKey, done := setupTestEnv2(t)
+
+	processSpec := utils.CreateTestProcessSpec(env.colonyID)
+	_, err := client.SubmitProcessSpec(processSpec, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	// Should fail and be recorded as a failure
+	err = client.DeleteProcess(core.GenerateRandomID(), env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	// Queries and assignments are not recorded
+	_, err = client.GetColonyByID(env.colonyID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	auditEntries, err := client.GetAuditLog("", 0, 100, serverPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 5) // Add colony, add runtime, approve runtime, submit, delete
+	assert.Nil(t, core.VerifyAuditChain(auditEntries))
+
+	assert.Equal(t, rpc.AddColonyPayloadType, auditEntries[0].PayloadType)
+	assert.Equal(t, env.colonyID, auditEntries[1].CallerID)
+	assert.Contains(t, auditEntries[1].TargetIDs, env.runtimeID)
+
+	submitEntry := auditEntries[3]
+	assert.Equal(t, rpc.SubmitProcessSpecPayloadType, submitEntry.PayloadType)
+	assert.Equal(t, env.runtimeID, submitEntry.CallerID)
+	assert.Equal(t, env.runtimeID, submitEntry.SignerID)
+	assert.Equal(t, []string{env.colonyID}, submitEntry.TargetIDs)
+	assert.Equal(t, core.AUDIT_SUCCESS, submitEntry.Result)
+
+	deleteEntry := auditEntries[4]
+	assert.Equal(t, rpc.DeleteProcessPayloadType, deleteEntry.PayloadType)
+	assert.Equal(t, core.AUDIT_FAILURE, deleteEntry.Result)
+
+	auditEntries, err = client.GetAuditLog(env.runtimeID, 0, 100, serverPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 2) // Add runtime, approve runtime
+
+	auditEntries, err = client.GetAuditLog("", 4, 100, serverPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 2)
+	assert.Equal(t, int64(4), auditEntries[0].Seq)
+
+	server.Shutdown()
+	<-done
+}
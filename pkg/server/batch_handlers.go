@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// handleBatchHTTPRequest handles the RPC messages in a batch in order. Every message is verified and authorized
+// on its own, i.e. the signer of the batch itself does not matter. Consecutive process submissions and
+// consecutive attributes are added using a single controller command. The audit entries of all messages are appended
+// to the audit log in a single transaction.
+func (server *ColoniesServer) handleBatchHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateBatchMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to handle batch, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to handle batch, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	if len(msg.Requests) == 0 {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to handle batch, batch is empty"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	if len(msg.Requests) > MAX_BATCH_SIZE {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to handle batch, a batch may contain at most "+strconv.Itoa(MAX_BATCH_SIZE)+" messages"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	ctx, batch := withAuditBatch(c.Request.Context())
+	replies := make([]*rpc.RPCReplyMsg, len(msg.Requests))
+	for i := 0; i < len(msg.Requests); {
+		request := msg.Requests[i]
+		if request == nil {
+			replies[i] = createBatchErrorReply(errors.New("Failed to handle batch, message is null"), http.StatusBadRequest)
+			i++
+			continue
+		}
+
+		switch request.PayloadType {
+		case rpc.SubmitProcessSpecPayloadType, rpc.AddAttributePayloadType:
+			j := i + 1
+			for j < len(msg.Requests) && msg.Requests[j] != nil && msg.Requests[j].PayloadType == request.PayloadType {
+				j++
+			}
+			if request.PayloadType == rpc.SubmitProcessSpecPayloadType {
+				server.handleSubmitProcessSpecBatch(ctx, msg.Requests[i:j], replies[i:j])
+			} else {
+				server.handleAddAttributeBatch(ctx, msg.Requests[i:j], replies[i:j])
+			}
+			i = j
+		case rpc.BatchPayloadType:
+			replies[i] = createBatchErrorReply(errors.New("Failed to handle batch, batches cannot be nested"), http.StatusBadRequest)
+			i++
+		default:
+			writer := server.handleRPCMsgWithReplyWriter(ctx, request, request.DecodePayload())
+			replies[i] = createBatchReply(writer.result())
+			i++
+		}
+	}
+	server.appendAuditBatch(c.Request.Context(), batch)
+
+	jsonString, err = rpc.ConvertRPCReplyMsgArrayToJSON(replies)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"Messages": len(msg.Requests)}).Debug("Handled batch")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleSubmitProcessSpecBatch(ctx context.Context, requests []*rpc.RPCMsg, replies []*rpc.RPCReplyMsg) {
+	var processes []*core.Process
+	var indices []int
+	recoveredIDs := make([]string, len(requests))
+	signerIDs := make([]string, len(requests))
+	jsonStrings := make([]string, len(requests))
+	for i, request := range requests {
+		jsonStrings[i] = request.DecodePayload()
+		recoveredID, signerID, err := server.verifyRPCMsg(ctx, request)
+		if err != nil {
+			replies[i] = createBatchErrorReply(err, http.StatusForbidden)
+			continue
+		}
+		recoveredIDs[i] = recoveredID
+		signerIDs[i] = signerID
+
+		process, errorCode, err := server.prepareSubmitProcessSpec(recoveredID, request.PayloadType, jsonStrings[i])
+		if err != nil {
+			replies[i] = createBatchErrorReply(err, errorCode)
+			server.auditWithStatus(ctx, recoveredID, signerID, request.PayloadType, jsonStrings[i], errorCode)
+			continue
+		}
+
+		processes = append(processes, process)
+		indices = append(indices, i)
+	}
+
+	if len(processes) == 0 {
+		return
+	}
+
+	addedProcesses, errs := server.controller.addProcesses(ctx, processes)
+	for k, i := range indices {
+		status := http.StatusOK
+		if errs[k] != nil {
+			status = http.StatusBadRequest
+			replies[i] = createBatchErrorReply(errs[k], status)
+		} else {
+			jsonString, err := addedProcesses[k].ToJSON()
+			if err != nil {
+				status = http.StatusInternalServerError
+				replies[i] = createBatchErrorReply(err, status)
+			} else {
+				log.WithFields(log.Fields{"ProcessID": addedProcesses[k].ID}).Debug("Submitting process")
+				replies[i] = createBatchReply(requests[i].PayloadType, jsonString, false)
+			}
+		}
+		server.auditWithStatus(ctx, recoveredIDs[i], signerIDs[i], requests[i].PayloadType, jsonStrings[i], status)
+	}
+}
+
+func (server *ColoniesServer) handleAddAttributeBatch(ctx context.Context, requests []*rpc.RPCMsg, replies []*rpc.RPCReplyMsg) {
+	var attributes []core.Attribute
+	var indices []int
+	recoveredIDs := make([]string, len(requests))
+	signerIDs := make([]string, len(requests))
+	jsonStrings := make([]string, len(requests))
+	for i, request := range requests {
+		jsonStrings[i] = request.DecodePayload()
+		recoveredID, signerID, err := server.verifyRPCMsg(ctx, request)
+		if err != nil {
+			replies[i] = createBatchErrorReply(err, http.StatusForbidden)
+			continue
+		}
+		recoveredIDs[i] = recoveredID
+		signerIDs[i] = signerID
+
+		attribute, errorCode, err := server.prepareAddAttribute(ctx, recoveredID, request.PayloadType, jsonStrings[i])
+		if err != nil {
+			replies[i] = createBatchErrorReply(err, errorCode)
+			server.auditWithStatus(ctx, recoveredID, signerID, request.PayloadType, jsonStrings[i], errorCode)
+			continue
+		}
+
+		attributes = append(attributes, attribute)
+		indices = append(indices, i)
+	}
+
+	if len(attributes) == 0 {
+		return
+	}
+
+	addedAttributes, errs := server.controller.addAttributes(ctx, attributes)
+	for k, i := range indices {
+		status := http.StatusOK
+		if errs[k] != nil {
+			status = http.StatusBadRequest
+			replies[i] = createBatchErrorReply(errs[k], status)
+		} else {
+			jsonString, err := addedAttributes[k].ToJSON()
+			if err != nil {
+				status = http.StatusInternalServerError
+				replies[i] = createBatchErrorReply(err, status)
+			} else {
+				log.WithFields(log.Fields{"AttributeID": addedAttributes[k].ID}).Debug("Adding attribute")
+				replies[i] = createBatchReply(requests[i].PayloadType, jsonString, false)
+			}
+		}
+		server.auditWithStatus(ctx, recoveredIDs[i], signerIDs[i], requests[i].PayloadType, jsonStrings[i], status)
+	}
+}
+
+func createBatchReply(payloadType string, jsonString string, isError bool) *rpc.RPCReplyMsg {
+	if isError {
+		rpcReplyMsg, _ := rpc.CreateRPCErrorReplyMsg(payloadType, jsonString)
+		return rpcReplyMsg
+	}
+
+	rpcReplyMsg, _ := rpc.CreateRPCReplyMsg(payloadType, jsonString)
+	return rpcReplyMsg
+}
+
+func createBatchErrorReply(err error, errorCode int) *rpc.RPCReplyMsg {
+	writer := createReplyWriter()
+	writer.setErrorReply(err, errorCode)
+
+	return createBatchReply(writer.result())
+}
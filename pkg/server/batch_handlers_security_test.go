@@ -0,0 +1,54 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The following calls should work
+	// Runtime 1 submits to colony 1
+	// The following calls should not work
+	// Runtime 2 submits to colony 1
+	// An unknown key submits to colony 1
+	// A nested batch
+
+	crypto := crypto.CreateCrypto()
+	invalidPrivateKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	nestedBatchJSON, err := rpc.CreateBatchMsg([]*rpc.RPCMsg{}).ToJSON()
+	assert.Nil(t, err)
+
+	batch := client.CreateBatch()
+	err = batch.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), env.runtime1PrvKey)
+	assert.Nil(t, err)
+	err = batch.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), env.runtime2PrvKey)
+	assert.Nil(t, err)
+	err = batch.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), invalidPrivateKey)
+	assert.Nil(t, err)
+	err = batch.Add(rpc.BatchPayloadType, nestedBatchJSON, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	// The signer of the batch itself does not matter
+	results, err := client.SendBatch(batch, invalidPrivateKey)
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+	assert.Nil(t, results[0].Err)
+	assert.NotNil(t, results[1].Err)
+	assert.NotNil(t, results[2].Err)
+	assert.NotNil(t, results[3].Err)
+
+	// Should not work, empty batch
+	_, err = client.SendBatch(client.CreateBatch(), env.runtime1PrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
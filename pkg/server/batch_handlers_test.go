@@ -0,0 +1,100 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSubmitProcessSpecs(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	batch := client.CreateBatch()
+	for i := 0; i < 10; i++ {
+		err := batch.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 10, batch.Len())
+
+	results, err := client.SendBatch(batch, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, results, 10)
+
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+		assert.Equal(t, rpc.SubmitProcessSpecPayloadType, result.PayloadType)
+		process, err := core.ConvertJSONToProcess(result.JSON)
+		assert.Nil(t, err)
+		assert.Equal(t, core.WAITING, process.State)
+	}
+
+	processes, err := client.GetWaitingProcesses(env.colonyID, 100, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, processes, 10)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestBatchMixed(t *testing.T) {
+	env, client, server, serverPrvKey, done := setupTestEnv2(t)
+
+	addedProcess1, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+	addedProcess2, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	batch := client.CreateBatch()
+	err = batch.AddAttribute(core.CreateAttribute(addedProcess1.ID, env.colonyID, "", core.OUT, "result", "1"), env.runtimePrvKey)
+	assert.Nil(t, err)
+	err = batch.AddAttribute(core.CreateAttribute(addedProcess2.ID, env.colonyID, "", core.OUT, "result", "2"), env.runtimePrvKey)
+	assert.Nil(t, err)
+	err = batch.CloseSuccessful(addedProcess1.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	err = batch.CloseFailed(addedProcess2.ID, "error", env.runtimePrvKey)
+	assert.Nil(t, err)
+	err = batch.CloseSuccessful(core.GenerateRandomID(), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	results, err := client.SendBatch(batch, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, results, 5)
+	assert.Nil(t, results[0].Err)
+	assert.Nil(t, results[1].Err)
+	assert.Nil(t, results[2].Err)
+	assert.Nil(t, results[3].Err)
+	assert.NotNil(t, results[4].Err) // Should not work, the process does not exist
+
+	attribute, err := core.ConvertJSONToAttribute(results[0].JSON)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", attribute.Value)
+
+	process1, err := client.GetProcess(addedProcess1.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, core.SUCCESS, process1.State)
+	assert.Len(t, process1.Attributes, 1)
+
+	process2, err := client.GetProcess(addedProcess2.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, core.FAILED, process2.State)
+
+	// Add colony, add runtime, approve runtime, 2 submits and the 5 messages in the batch, assignments are not recorded
+	auditEntries, err := client.GetAuditLog("", 0, 100, serverPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 10)
+	assert.Nil(t, core.VerifyAuditChain(auditEntries))
+	assert.Equal(t, rpc.AddAttributePayloadType, auditEntries[5].PayloadType)
+	assert.Equal(t, rpc.CloseSuccessfulPayloadType, auditEntries[7].PayloadType)
+	assert.Equal(t, core.AUDIT_FAILURE, auditEntries[9].Result)
+
+	server.Shutdown()
+	<-done
+}
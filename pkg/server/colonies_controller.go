@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"os"
 	"strconv"
@@ -13,14 +14,18 @@ import (
 	"github.com/colonyos/colonies/pkg/database"
 	"github.com/colonyos/colonies/pkg/planner"
 	"github.com/colonyos/colonies/pkg/planner/basic"
+	"github.com/colonyos/colonies/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
 const TIMEOUT_RELEASE_INTERVALL = 1
 const TIMEOUT_GENERATOR_TRIGGER_INTERVALL = 1
 const TIMEOUT_CRON_TRIGGER_INTERVALL = 1
+const TIMEOUT_NONCE_CLEANUP_INTERVALL = 60
+const TIMEOUT_WEBHOOK_DELIVERY_INTERVALL = 1
 
 type command struct {
+	ctx                    context.Context
 	stop                   bool
 	errorChan              chan error
 	process                *core.Process
@@ -41,28 +46,43 @@ type command struct {
 	generatorsReplyChan    chan []*core.Generator
 	cronReplyChan          chan *core.Cron
 	cronsReplyChan         chan []*core.Cron
+	templateReplyChan      chan *core.WorkflowTemplate
+	templatesReplyChan     chan []*core.WorkflowTemplate
+	rolesReplyChan         chan []string
+	secretReplyChan        chan *core.Secret
+	secretsReplyChan       chan []*core.Secret
+	revocationReplyChan    chan *core.Revocation
+	revocationsReplyChan   chan []*core.Revocation
+	webhookReplyChan       chan *core.Webhook
+	webhooksReplyChan      chan []*core.Webhook
+	deliveriesReplyChan    chan []*core.WebhookDelivery
+	attributesReplyChan    chan []core.Attribute
+	errorsReplyChan        chan []error
 	handler                func(cmd *command)
 }
 
 type coloniesController struct {
-	db            database.Database
-	cmdQueue      chan *command
-	planner       planner.Planner
-	wsSubCtrl     *wsSubscriptionController
-	relayServer   *cluster.RelayServer
-	eventHandler  *eventHandler
-	stopFlag      bool
-	stopMutex     sync.Mutex
-	leaderMutex   sync.Mutex
-	thisNode      cluster.Node
-	clusterConfig cluster.Config
-	etcdServer    *cluster.EtcdServer
-	leader        bool
+	db               database.Database
+	cmdQueue         chan *command
+	planner          planner.Planner
+	wsSubCtrl        *wsSubscriptionController
+	relayServer      *cluster.RelayServer
+	eventHandler     *eventHandler
+	webhookDeliverer *webhookDeliverer
+	stopFlag         bool
+	stopMutex        sync.Mutex
+	leaderMutex      sync.Mutex
+	thisNode         cluster.Node
+	clusterConfig    cluster.Config
+	etcdServer       *cluster.EtcdServer
+	leader           bool
+	cmdCtx           context.Context
+	cmdCtxMutex      sync.Mutex
 }
 
 func createColoniesController(db database.Database, thisNode cluster.Node, clusterConfig cluster.Config, etcdDataPath string) *coloniesController {
 	controller := &coloniesController{}
-	controller.db = db
+	controller.db = tracing.TraceDatabase(db, controller.commandContext)
 	controller.thisNode = thisNode
 	controller.clusterConfig = clusterConfig
 	controller.etcdServer = cluster.CreateEtcdServer(controller.thisNode, controller.clusterConfig, etcdDataPath)
@@ -71,9 +91,11 @@ func createColoniesController(db database.Database, thisNode cluster.Node, clust
 	controller.leader = false
 
 	controller.relayServer = cluster.CreateRelayServer(controller.thisNode, controller.clusterConfig)
+	controller.etcdServer.OnNodesChanged(controller.relayServer.SetNodes)
 	controller.eventHandler = createEventHandler(controller.relayServer)
 	controller.wsSubCtrl = createWSSubscriptionController(controller.eventHandler)
 	controller.planner = basic.CreatePlanner()
+	controller.webhookDeliverer = createWebhookDeliverer()
 
 	controller.cmdQueue = make(chan *command)
 
@@ -82,12 +104,30 @@ func createColoniesController(db database.Database, thisNode cluster.Node, clust
 	go controller.timeoutLoop()
 	go controller.generatorTriggerLoop()
 	go controller.cronTriggerLoop()
+	go controller.nonceCleanupLoop()
+	go controller.webhookDeliveryLoop()
 
 	return controller
 }
 
+func (controller *coloniesController) resolveWorkflowSpec(colonyID string, workflowSpecJSON string, workflowTemplateRef *core.WorkflowTemplateRef) (*core.WorkflowSpec, error) {
+	if workflowTemplateRef == nil {
+		return core.ConvertJSONToWorkflowSpec(workflowSpecJSON)
+	}
+
+	workflowTemplate, err := controller.db.GetWorkflowTemplate(colonyID, workflowTemplateRef.Name, workflowTemplateRef.Version)
+	if err != nil {
+		return nil, err
+	}
+	if workflowTemplate == nil {
+		return nil, core.WithErrorCode(errors.New("Workflow template <"+workflowTemplateRef.Name+"> with version <"+strconv.Itoa(workflowTemplateRef.Version)+"> not found"), core.ErrorCodeNotFound)
+	}
+
+	return workflowTemplate.Instantiate(workflowTemplateRef.Parameters)
+}
+
 func (controller *coloniesController) submitWorkflow(generator *core.Generator) {
-	workflowSpec, err := core.ConvertJSONToWorkflowSpec(generator.WorkflowSpec)
+	workflowSpec, err := controller.resolveWorkflowSpec(generator.ColonyID, generator.WorkflowSpec, generator.WorkflowTemplate)
 	if err != nil {
 		log.WithFields(log.Fields{"Error": err}).Error("Failed to parse workflow spec")
 		return
@@ -177,13 +217,15 @@ func (controller *coloniesController) calcNextRun(cron *core.Cron) time.Time {
 }
 
 func (controller *coloniesController) startCron(cron *core.Cron) {
-	workflowSpec, err := core.ConvertJSONToWorkflowSpec(cron.WorkflowSpec)
+	workflowSpec, err := controller.resolveWorkflowSpec(cron.ColonyID, cron.WorkflowSpec, cron.WorkflowTemplate)
 	if err != nil {
 		log.WithFields(log.Fields{"Error": err}).Error("Failed to parsing WorkflowSpec")
+		return
 	}
 	processGraph, err := controller.createProcessGraph(workflowSpec, []string{})
 	if err != nil {
 		log.WithFields(log.Fields{"Error": err}).Error("Failed to parse workflow spec")
+		return
 	}
 
 	nextRun := controller.calcNextRun(cron)
@@ -214,8 +256,8 @@ func (controller *coloniesController) triggerCrons() {
 	controller.cmdQueue <- cmd
 }
 
-func (controller *coloniesController) addGenerator(generator *core.Generator) (*core.Generator, error) {
-	cmd := &command{generatorReplyChan: make(chan *core.Generator, 1),
+func (controller *coloniesController) addGenerator(ctx context.Context, generator *core.Generator) (*core.Generator, error) {
+	cmd := &command{ctx: ctx, generatorReplyChan: make(chan *core.Generator, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.AddGenerator(generator)
@@ -240,8 +282,8 @@ func (controller *coloniesController) addGenerator(generator *core.Generator) (*
 	}
 }
 
-func (controller *coloniesController) getGenerator(generatorID string) (*core.Generator, error) {
-	cmd := &command{generatorReplyChan: make(chan *core.Generator, 1),
+func (controller *coloniesController) getGenerator(ctx context.Context, generatorID string) (*core.Generator, error) {
+	cmd := &command{ctx: ctx, generatorReplyChan: make(chan *core.Generator, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			generator, err := controller.db.GetGeneratorByID(generatorID)
@@ -261,8 +303,8 @@ func (controller *coloniesController) getGenerator(generatorID string) (*core.Ge
 	}
 }
 
-func (controller *coloniesController) getGenerators(colonyID string, count int) ([]*core.Generator, error) {
-	cmd := &command{generatorsReplyChan: make(chan []*core.Generator, 1),
+func (controller *coloniesController) getGenerators(ctx context.Context, colonyID string, count int) ([]*core.Generator, error) {
+	cmd := &command{ctx: ctx, generatorsReplyChan: make(chan []*core.Generator, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			generators, err := controller.db.FindGeneratorsByColonyID(colonyID, count)
@@ -282,8 +324,8 @@ func (controller *coloniesController) getGenerators(colonyID string, count int)
 	}
 }
 
-func (controller *coloniesController) packGenerator(generatorID string, colonyID, arg string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) packGenerator(ctx context.Context, generatorID string, colonyID, arg string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			generatorArg := core.CreateGeneratorArg(generatorID, colonyID, arg)
 			cmd.errorChan <- controller.db.AddGeneratorArg(generatorArg)
@@ -296,8 +338,8 @@ func (controller *coloniesController) packGenerator(generatorID string, colonyID
 	}
 }
 
-func (controller *coloniesController) deleteGenerator(generatorID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteGenerator(ctx context.Context, generatorID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			cmd.errorChan <- controller.db.DeleteGeneratorByID(generatorID)
 		}}
@@ -306,8 +348,8 @@ func (controller *coloniesController) deleteGenerator(generatorID string) error
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) addCron(cron *core.Cron) (*core.Cron, error) {
-	cmd := &command{cronReplyChan: make(chan *core.Cron, 1),
+func (controller *coloniesController) addCron(ctx context.Context, cron *core.Cron) (*core.Cron, error) {
+	cmd := &command{ctx: ctx, cronReplyChan: make(chan *core.Cron, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.AddCron(cron)
@@ -332,8 +374,8 @@ func (controller *coloniesController) addCron(cron *core.Cron) (*core.Cron, erro
 	}
 }
 
-func (controller *coloniesController) getCron(cronID string) (*core.Cron, error) {
-	cmd := &command{cronReplyChan: make(chan *core.Cron, 1),
+func (controller *coloniesController) getCron(ctx context.Context, cronID string) (*core.Cron, error) {
+	cmd := &command{ctx: ctx, cronReplyChan: make(chan *core.Cron, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			cron, err := controller.db.GetCronByID(cronID)
@@ -353,8 +395,8 @@ func (controller *coloniesController) getCron(cronID string) (*core.Cron, error)
 	}
 }
 
-func (controller *coloniesController) getCrons(colonyID string, count int) ([]*core.Cron, error) {
-	cmd := &command{cronsReplyChan: make(chan []*core.Cron, 1),
+func (controller *coloniesController) getCrons(ctx context.Context, colonyID string, count int) ([]*core.Cron, error) {
+	cmd := &command{ctx: ctx, cronsReplyChan: make(chan []*core.Cron, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			crons, err := controller.db.FindCronsByColonyID(colonyID, count)
@@ -374,8 +416,8 @@ func (controller *coloniesController) getCrons(colonyID string, count int) ([]*c
 	}
 }
 
-func (controller *coloniesController) runCron(cronID string) (*core.Cron, error) {
-	cmd := &command{cronReplyChan: make(chan *core.Cron, 1),
+func (controller *coloniesController) runCron(ctx context.Context, cronID string) (*core.Cron, error) {
+	cmd := &command{ctx: ctx, cronReplyChan: make(chan *core.Cron, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			cron, err := controller.db.GetCronByID(cronID)
@@ -396,8 +438,8 @@ func (controller *coloniesController) runCron(cronID string) (*core.Cron, error)
 	}
 }
 
-func (controller *coloniesController) deleteCron(cronID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteCron(ctx context.Context, cronID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteCronByID(cronID)
 			cmd.errorChan <- err
@@ -407,8 +449,397 @@ func (controller *coloniesController) deleteCron(cronID string) error {
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) subscribeProcesses(runtimeID string, subscription *subscription) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) addWorkflowTemplate(ctx context.Context, workflowTemplate *core.WorkflowTemplate) (*core.WorkflowTemplate, error) {
+	cmd := &command{ctx: ctx, templateReplyChan: make(chan *core.WorkflowTemplate, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			latestWorkflowTemplate, err := controller.db.GetWorkflowTemplate(workflowTemplate.ColonyID, workflowTemplate.Name, 0)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			workflowTemplate.Version = 1
+			if latestWorkflowTemplate != nil {
+				workflowTemplate.Version = latestWorkflowTemplate.Version + 1
+			}
+			err = controller.db.AddWorkflowTemplate(workflowTemplate)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			addedWorkflowTemplate, err := controller.db.GetWorkflowTemplateByID(workflowTemplate.ID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.templateReplyChan <- addedWorkflowTemplate
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case addedWorkflowTemplate := <-cmd.templateReplyChan:
+		return addedWorkflowTemplate, nil
+	}
+}
+
+func (controller *coloniesController) getWorkflowTemplate(ctx context.Context, colonyID string, name string, version int) (*core.WorkflowTemplate, error) {
+	cmd := &command{ctx: ctx, templateReplyChan: make(chan *core.WorkflowTemplate, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			workflowTemplate, err := controller.db.GetWorkflowTemplate(colonyID, name, version)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.templateReplyChan <- workflowTemplate
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case workflowTemplate := <-cmd.templateReplyChan:
+		return workflowTemplate, nil
+	}
+}
+
+func (controller *coloniesController) getWorkflowTemplates(ctx context.Context, colonyID string, count int) ([]*core.WorkflowTemplate, error) {
+	cmd := &command{ctx: ctx, templatesReplyChan: make(chan []*core.WorkflowTemplate, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			workflowTemplates, err := controller.db.FindWorkflowTemplatesByColonyID(colonyID, count)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.templatesReplyChan <- workflowTemplates
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case workflowTemplates := <-cmd.templatesReplyChan:
+		return workflowTemplates, nil
+	}
+}
+
+func (controller *coloniesController) getWorkflowTemplateVersions(ctx context.Context, colonyID string, name string) ([]*core.WorkflowTemplate, error) {
+	cmd := &command{ctx: ctx, templatesReplyChan: make(chan []*core.WorkflowTemplate, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			workflowTemplates, err := controller.db.FindWorkflowTemplateVersions(colonyID, name)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.templatesReplyChan <- workflowTemplates
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case workflowTemplates := <-cmd.templatesReplyChan:
+		return workflowTemplates, nil
+	}
+}
+
+func (controller *coloniesController) deleteWorkflowTemplate(ctx context.Context, colonyID string, name string, version int) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			cmd.errorChan <- controller.db.DeleteWorkflowTemplate(colonyID, name, version)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) submitWorkflowTemplate(ctx context.Context, colonyID string, workflowTemplateRef *core.WorkflowTemplateRef) (*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphReplyChan: make(chan *core.ProcessGraph, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			workflowSpec, err := controller.resolveWorkflowSpec(colonyID, "", workflowTemplateRef)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			addedProcessGraph, err := controller.createProcessGraph(workflowSpec, []string{})
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			cmd.processGraphReplyChan <- addedProcessGraph
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case processGraph := <-cmd.processGraphReplyChan:
+		return processGraph, nil
+	}
+}
+
+func (controller *coloniesController) setSecret(ctx context.Context, secret *core.Secret) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			cmd.errorChan <- controller.db.SetSecret(secret)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) getSecret(ctx context.Context, colonyID string, name string) (*core.Secret, error) {
+	cmd := &command{ctx: ctx, secretReplyChan: make(chan *core.Secret, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			secret, err := controller.db.GetSecret(colonyID, name)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.secretReplyChan <- secret
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case secret := <-cmd.secretReplyChan:
+		return secret, nil
+	}
+}
+
+func (controller *coloniesController) getSecrets(ctx context.Context, colonyID string) ([]*core.Secret, error) {
+	cmd := &command{ctx: ctx, secretsReplyChan: make(chan []*core.Secret, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			secrets, err := controller.db.GetSecrets(colonyID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.secretsReplyChan <- secrets
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case secrets := <-cmd.secretsReplyChan:
+		return secrets, nil
+	}
+}
+
+func (controller *coloniesController) deleteSecret(ctx context.Context, colonyID string, name string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			cmd.errorChan <- controller.db.DeleteSecret(colonyID, name)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) addWebhook(ctx context.Context, webhook *core.Webhook) (*core.Webhook, error) {
+	cmd := &command{ctx: ctx, webhookReplyChan: make(chan *core.Webhook, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			err := controller.db.AddWebhook(webhook)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			addedWebhook, err := controller.db.GetWebhookByID(webhook.ID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			cmd.webhookReplyChan <- addedWebhook
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case addedWebhook := <-cmd.webhookReplyChan:
+		return addedWebhook, nil
+	}
+}
+
+func (controller *coloniesController) getWebhook(ctx context.Context, webhookID string) (*core.Webhook, error) {
+	cmd := &command{ctx: ctx, webhookReplyChan: make(chan *core.Webhook, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			webhook, err := controller.db.GetWebhookByID(webhookID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			cmd.webhookReplyChan <- webhook
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case webhook := <-cmd.webhookReplyChan:
+		return webhook, nil
+	}
+}
+
+func (controller *coloniesController) getWebhooks(ctx context.Context, colonyID string) ([]*core.Webhook, error) {
+	cmd := &command{ctx: ctx, webhooksReplyChan: make(chan []*core.Webhook, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			webhooks, err := controller.db.GetWebhooksByColonyID(colonyID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			cmd.webhooksReplyChan <- webhooks
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case webhooks := <-cmd.webhooksReplyChan:
+		return webhooks, nil
+	}
+}
+
+func (controller *coloniesController) deleteWebhook(ctx context.Context, webhookID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			cmd.errorChan <- controller.db.DeleteWebhookByID(webhookID)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) getWebhookDeliveries(ctx context.Context, webhookID string, state int, count int) ([]*core.WebhookDelivery, error) {
+	cmd := &command{ctx: ctx, deliveriesReplyChan: make(chan []*core.WebhookDelivery, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			deliveries, err := controller.db.FindWebhookDeliveries(webhookID, state, count)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			cmd.deliveriesReplyChan <- deliveries
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case deliveries := <-cmd.deliveriesReplyChan:
+		return deliveries, nil
+	}
+}
+
+// enqueueWebhookDeliveries stores a delivery for every webhook in the colony accepted by match. It is called from
+// command handlers, so the delivery is stored by the server that made the state change, and is posted by the
+// leader. Failing to store a delivery does not fail the state change.
+func (controller *coloniesController) enqueueWebhookDeliveries(colonyID string, event string, match func(webhook *core.Webhook) bool, webhookEvent *core.WebhookEvent) {
+	webhooks, err := controller.db.GetWebhooksByColonyID(colonyID)
+	if err != nil {
+		log.WithFields(log.Fields{"ColonyID": colonyID, "Error": err}).Error("Failed to get webhooks")
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !match(webhook) {
+			continue
+		}
+
+		webhookEvent.DeliveryID = core.GenerateRandomID()
+		webhookEvent.WebhookID = webhook.ID
+		payload, err := webhookEvent.ToJSON()
+		if err != nil {
+			log.WithFields(log.Fields{"WebhookID": webhook.ID, "Error": err}).Error("Failed to create webhook event JSON")
+			continue
+		}
+
+		delivery := core.CreateWebhookDelivery(webhook, event, payload)
+		delivery.ID = webhookEvent.DeliveryID
+		delivery.NextAttempt = time.Now()
+		err = controller.db.AddWebhookDelivery(delivery)
+		if err != nil {
+			log.WithFields(log.Fields{"WebhookID": webhook.ID, "Error": err}).Error("Failed to add webhook delivery")
+		}
+	}
+}
+
+func (controller *coloniesController) enqueueProcessWebhooks(process *core.Process) {
+	webhookEvent := &core.WebhookEvent{ColonyID: process.ProcessSpec.Conditions.ColonyID, Event: core.WEBHOOK_EVENT_PROCESS, Time: time.Now(), Process: process}
+	controller.enqueueWebhookDeliveries(webhookEvent.ColonyID, core.WEBHOOK_EVENT_PROCESS, func(webhook *core.Webhook) bool {
+		return webhook.MatchProcess(process)
+	}, webhookEvent)
+}
+
+func (controller *coloniesController) enqueueProcessGraphWebhooks(processGraph *core.ProcessGraph) {
+	webhookEvent := &core.WebhookEvent{ColonyID: processGraph.ColonyID, Event: core.WEBHOOK_EVENT_PROCESSGRAPH, Time: time.Now(), ProcessGraph: processGraph}
+	controller.enqueueWebhookDeliveries(webhookEvent.ColonyID, core.WEBHOOK_EVENT_PROCESSGRAPH, func(webhook *core.Webhook) bool {
+		return webhook.MatchProcessGraph(processGraph)
+	}, webhookEvent)
+}
+
+// deliverWebhooks posts all due webhook deliveries, it is only called by the leader
+func (controller *coloniesController) deliverWebhooks() {
+	db := tracing.DatabaseWithContext(controller.db, context.Background())
+	deliveries, err := db.FindPendingWebhookDeliveries(WEBHOOK_DELIVERY_BATCH_SIZE)
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err}).Error("Failed to find pending webhook deliveries")
+		return
+	}
+
+	webhooks := make(map[string]*core.Webhook)
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook, err = db.GetWebhookByID(delivery.WebhookID)
+			if err != nil {
+				log.WithFields(log.Fields{"WebhookID": delivery.WebhookID, "Error": err}).Error("Failed to get webhook")
+				continue
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook == nil {
+			// The webhook has been deleted
+			err = db.DeleteWebhookDeliveryByID(delivery.ID)
+			if err != nil {
+				log.WithFields(log.Fields{"DeliveryID": delivery.ID, "Error": err}).Error("Failed to delete webhook delivery")
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(webhook *core.Webhook, delivery *core.WebhookDelivery) {
+			defer wg.Done()
+			controller.webhookDeliverer.deliver(db, webhook, delivery)
+		}(webhook, delivery)
+	}
+
+	wg.Wait()
+}
+
+func (controller *coloniesController) subscribeProcesses(ctx context.Context, runtimeID string, subscription *subscription) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			controller.wsSubCtrl.addProcessesSubscriber(runtimeID, subscription)
 			cmd.errorChan <- nil
@@ -418,12 +849,17 @@ func (controller *coloniesController) subscribeProcesses(runtimeID string, subsc
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) subscribeProcess(runtimeID string, subscription *subscription) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) subscribeProcess(ctx context.Context, runtimeID string, subscription *subscription) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			process, err := controller.db.GetProcessByID(subscription.processID)
 			if err != nil {
 				cmd.errorChan <- err
+				return
+			}
+			if process == nil {
+				cmd.errorChan <- core.WithErrorCode(errors.New("Process with id <"+subscription.processID+"> could not be found"), core.ErrorCodeNotFound)
+				return
 			}
 
 			controller.wsSubCtrl.addProcessSubscriber(runtimeID, process, subscription)
@@ -434,8 +870,30 @@ func (controller *coloniesController) subscribeProcess(runtimeID string, subscri
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) getColonies() ([]*core.Colony, error) {
-	cmd := &command{coloniesReplyChan: make(chan []*core.Colony),
+func (controller *coloniesController) subscribeColony(ctx context.Context, runtimeID string, subscription *subscription) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			controller.wsSubCtrl.addColonySubscriber(runtimeID, subscription)
+			cmd.errorChan <- nil
+		}}
+	controller.cmdQueue <- cmd
+
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) subscribeProcessGraph(ctx context.Context, runtimeID string, subscription *subscription) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			controller.wsSubCtrl.addProcessGraphSubscriber(runtimeID, subscription)
+			cmd.errorChan <- nil
+		}}
+	controller.cmdQueue <- cmd
+
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) getColonies(ctx context.Context) ([]*core.Colony, error) {
+	cmd := &command{ctx: ctx, coloniesReplyChan: make(chan []*core.Colony),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			colonies, err := controller.db.GetColonies()
@@ -456,8 +914,8 @@ func (controller *coloniesController) getColonies() ([]*core.Colony, error) {
 	}
 }
 
-func (controller *coloniesController) getColony(colonyID string) (*core.Colony, error) {
-	cmd := &command{colonyReplyChan: make(chan *core.Colony),
+func (controller *coloniesController) getColony(ctx context.Context, colonyID string) (*core.Colony, error) {
+	cmd := &command{ctx: ctx, colonyReplyChan: make(chan *core.Colony),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			colony, err := controller.db.GetColonyByID(colonyID)
@@ -477,8 +935,8 @@ func (controller *coloniesController) getColony(colonyID string) (*core.Colony,
 	}
 }
 
-func (controller *coloniesController) addColony(colony *core.Colony) (*core.Colony, error) {
-	cmd := &command{colonyReplyChan: make(chan *core.Colony, 1),
+func (controller *coloniesController) addColony(ctx context.Context, colony *core.Colony) (*core.Colony, error) {
+	cmd := &command{ctx: ctx, colonyReplyChan: make(chan *core.Colony, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.AddColony(colony)
@@ -503,8 +961,8 @@ func (controller *coloniesController) addColony(colony *core.Colony) (*core.Colo
 	}
 }
 
-func (controller *coloniesController) deleteColony(colonyID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteColony(ctx context.Context, colonyID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteColonyByID(colonyID)
 			if err != nil {
@@ -518,11 +976,41 @@ func (controller *coloniesController) deleteColony(colonyID string) error {
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) addRuntime(runtime *core.Runtime) (*core.Runtime, error) {
-	cmd := &command{runtimeReplyChan: make(chan *core.Runtime, 1),
+func (controller *coloniesController) rotateColonyKey(ctx context.Context, colonyID string, ownerID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			runtime, err := controller.db.GetRuntimeByID(ownerID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			if runtime != nil {
+				cmd.errorChan <- core.WithErrorCode(errors.New("New colony key is already used by a runtime"), core.ErrorCodeConflict)
+				return
+			}
+
+			cmd.errorChan <- controller.db.SetColonyOwner(colonyID, ownerID)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) addRuntime(ctx context.Context, runtime *core.Runtime) (*core.Runtime, error) {
+	cmd := &command{ctx: ctx, runtimeReplyChan: make(chan *core.Runtime, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
-			err := controller.db.AddRuntime(runtime)
+			revocation, err := controller.db.GetRevocation(runtime.ID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			if revocation != nil {
+				cmd.errorChan <- core.WithErrorCode(errors.New("Runtime with id <"+runtime.ID+"> has been revoked and cannot be added again"), core.ErrorCodeForbidden)
+				return
+			}
+
+			err = controller.db.AddRuntime(runtime)
 			if err != nil {
 				cmd.errorChan <- err
 				return
@@ -544,8 +1032,8 @@ func (controller *coloniesController) addRuntime(runtime *core.Runtime) (*core.R
 	}
 }
 
-func (controller *coloniesController) getRuntime(runtimeID string) (*core.Runtime, error) {
-	cmd := &command{runtimeReplyChan: make(chan *core.Runtime),
+func (controller *coloniesController) getRuntime(ctx context.Context, runtimeID string) (*core.Runtime, error) {
+	cmd := &command{ctx: ctx, runtimeReplyChan: make(chan *core.Runtime),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			runtime, err := controller.db.GetRuntimeByID(runtimeID)
@@ -565,8 +1053,8 @@ func (controller *coloniesController) getRuntime(runtimeID string) (*core.Runtim
 	}
 }
 
-func (controller *coloniesController) getRuntimeByColonyID(colonyID string) ([]*core.Runtime, error) {
-	cmd := &command{runtimesReplyChan: make(chan []*core.Runtime),
+func (controller *coloniesController) getRuntimeByColonyID(ctx context.Context, colonyID string) ([]*core.Runtime, error) {
+	cmd := &command{ctx: ctx, runtimesReplyChan: make(chan []*core.Runtime),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			runtimes, err := controller.db.GetRuntimesByColonyID(colonyID)
@@ -589,8 +1077,8 @@ func (controller *coloniesController) getRuntimeByColonyID(colonyID string) ([]*
 	return runtimes, nil
 }
 
-func (controller *coloniesController) approveRuntime(runtimeID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) approveRuntime(ctx context.Context, runtimeID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			runtime, err := controller.db.GetRuntimeByID(runtimeID)
 			if err != nil {
@@ -604,8 +1092,8 @@ func (controller *coloniesController) approveRuntime(runtimeID string) error {
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) rejectRuntime(runtimeID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) rejectRuntime(ctx context.Context, runtimeID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			runtime, err := controller.db.GetRuntimeByID(runtimeID)
 			if err != nil {
@@ -619,8 +1107,49 @@ func (controller *coloniesController) rejectRuntime(runtimeID string) error {
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) deleteRuntime(runtimeID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) addRuntimeRole(ctx context.Context, runtime *core.Runtime, role string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			cmd.errorChan <- controller.db.AddRuntimeRole(runtime.ID, runtime.ColonyID, role)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) removeRuntimeRole(ctx context.Context, runtimeID string, role string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			cmd.errorChan <- controller.db.RemoveRuntimeRole(runtimeID, role)
+		}}
+
+	controller.cmdQueue <- cmd
+	return <-cmd.errorChan
+}
+
+func (controller *coloniesController) getRuntimeRoles(ctx context.Context, runtimeID string) ([]string, error) {
+	cmd := &command{ctx: ctx, rolesReplyChan: make(chan []string),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			roles, err := controller.db.GetRuntimeRoles(runtimeID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.rolesReplyChan <- roles
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case roles := <-cmd.rolesReplyChan:
+		return roles, nil
+	}
+}
+
+func (controller *coloniesController) deleteRuntime(ctx context.Context, runtimeID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteRuntimeByID(runtimeID)
 			cmd.errorChan <- err
@@ -630,6 +1159,74 @@ func (controller *coloniesController) deleteRuntime(runtimeID string) error {
 	return <-cmd.errorChan
 }
 
+// revokeRuntime adds the runtime to the revocation list and deletes it. Long-polls and subscriptions held by the
+// runtime are terminated on all cluster nodes, and its running processes are moved back to the queue.
+func (controller *coloniesController) revokeRuntime(ctx context.Context, runtime *core.Runtime, reason string) (*core.Revocation, error) {
+	cmd := &command{ctx: ctx, revocationReplyChan: make(chan *core.Revocation, 1),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			revocation := core.CreateRevocation(runtime.ID, runtime.ColonyID, reason)
+			err := controller.db.AddRevocation(revocation)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			processes, err := controller.db.FindRunningProcessesByRuntimeID(runtime.ID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			// Also moves the running processes back to the queue
+			err = controller.db.DeleteRuntimeByID(runtime.ID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+
+			controller.eventHandler.revoke(controller.commandContext(), runtime.ID)
+
+			for _, process := range processes {
+				process.Unassign()
+				process.SetState(core.WAITING)
+				controller.eventHandler.signal(controller.commandContext(), process)
+				controller.enqueueProcessWebhooks(process)
+			}
+
+			cmd.revocationReplyChan <- revocation
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case revocation := <-cmd.revocationReplyChan:
+		return revocation, nil
+	}
+}
+
+func (controller *coloniesController) getRevocations(ctx context.Context, colonyID string) ([]*core.Revocation, error) {
+	cmd := &command{ctx: ctx, revocationsReplyChan: make(chan []*core.Revocation),
+		errorChan: make(chan error, 1),
+		handler: func(cmd *command) {
+			revocations, err := controller.db.GetRevocationsByColonyID(colonyID)
+			if err != nil {
+				cmd.errorChan <- err
+				return
+			}
+			cmd.revocationsReplyChan <- revocations
+		}}
+
+	controller.cmdQueue <- cmd
+	select {
+	case err := <-cmd.errorChan:
+		return nil, err
+	case revocations := <-cmd.revocationsReplyChan:
+		return revocations, nil
+	}
+}
+
 func (controller *coloniesController) addProcessAndSetWaitingDeadline(process *core.Process) (*core.Process, error) {
 	err := controller.db.AddProcess(process)
 	if err != nil {
@@ -652,8 +1249,8 @@ func (controller *coloniesController) addProcessAndSetWaitingDeadline(process *c
 	return addedProcess, nil
 }
 
-func (controller *coloniesController) addProcess(process *core.Process) (*core.Process, error) {
-	cmd := &command{processReplyChan: make(chan *core.Process, 1),
+func (controller *coloniesController) addProcess(ctx context.Context, process *core.Process) (*core.Process, error) {
+	cmd := &command{ctx: ctx, processReplyChan: make(chan *core.Process, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			addedProcess, err := controller.addProcessAndSetWaitingDeadline(process)
@@ -662,7 +1259,8 @@ func (controller *coloniesController) addProcess(process *core.Process) (*core.P
 				return
 			}
 
-			controller.eventHandler.signal(addedProcess)
+			controller.eventHandler.signal(controller.commandContext(), addedProcess)
+			controller.enqueueProcessWebhooks(addedProcess)
 			cmd.processReplyChan <- addedProcess
 		}}
 
@@ -675,8 +1273,36 @@ func (controller *coloniesController) addProcess(process *core.Process) (*core.P
 	}
 }
 
-func (controller *coloniesController) getProcess(processID string) (*core.Process, error) {
-	cmd := &command{processReplyChan: make(chan *core.Process, 1),
+// addProcesses adds a batch of processes in a single command, the returned errors tell which processes could not
+// be added
+func (controller *coloniesController) addProcesses(ctx context.Context, processes []*core.Process) ([]*core.Process, []error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process, 1),
+		errorsReplyChan: make(chan []error, 1),
+		handler: func(cmd *command) {
+			addedProcesses := make([]*core.Process, len(processes))
+			errs := make([]error, len(processes))
+			for i, process := range processes {
+				addedProcess, err := controller.addProcessAndSetWaitingDeadline(process)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				controller.eventHandler.signal(controller.commandContext(), addedProcess)
+				controller.enqueueProcessWebhooks(addedProcess)
+				addedProcesses[i] = addedProcess
+			}
+
+			cmd.processesReplyChan <- addedProcesses
+			cmd.errorsReplyChan <- errs
+		}}
+
+	controller.cmdQueue <- cmd
+
+	return <-cmd.processesReplyChan, <-cmd.errorsReplyChan
+}
+
+func (controller *coloniesController) getProcess(ctx context.Context, processID string) (*core.Process, error) {
+	cmd := &command{ctx: ctx, processReplyChan: make(chan *core.Process, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			process, err := controller.db.GetProcessByID(processID)
@@ -696,8 +1322,8 @@ func (controller *coloniesController) getProcess(processID string) (*core.Proces
 	}
 }
 
-func (controller *coloniesController) findProcessHistory(colonyID string, runtimeID string, seconds int, state int) ([]*core.Process, error) {
-	cmd := &command{processesReplyChan: make(chan []*core.Process),
+func (controller *coloniesController) findProcessHistory(ctx context.Context, colonyID string, runtimeID string, seconds int, state int) ([]*core.Process, error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			var processes []*core.Process
@@ -728,13 +1354,13 @@ func (controller *coloniesController) findProcessHistory(colonyID string, runtim
 	}
 }
 
-func (controller *coloniesController) findPrioritizedProcesses(runtimeID string, colonyID string, count int) ([]*core.Process, error) {
-	cmd := &command{processesReplyChan: make(chan []*core.Process),
+func (controller *coloniesController) findPrioritizedProcesses(ctx context.Context, runtimeID string, colonyID string, count int) ([]*core.Process, error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			var processes []*core.Process
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			processes, err := controller.db.FindWaitingProcesses(colonyID, count)
@@ -756,12 +1382,12 @@ func (controller *coloniesController) findPrioritizedProcesses(runtimeID string,
 	}
 }
 
-func (controller *coloniesController) findWaitingProcesses(colonyID string, count int) ([]*core.Process, error) {
-	cmd := &command{processesReplyChan: make(chan []*core.Process),
+func (controller *coloniesController) findWaitingProcesses(ctx context.Context, colonyID string, count int) ([]*core.Process, error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			processes, err := controller.db.FindWaitingProcesses(colonyID, count)
@@ -782,12 +1408,12 @@ func (controller *coloniesController) findWaitingProcesses(colonyID string, coun
 	}
 }
 
-func (controller *coloniesController) findRunningProcesses(colonyID string, count int) ([]*core.Process, error) {
-	cmd := &command{processesReplyChan: make(chan []*core.Process),
+func (controller *coloniesController) findRunningProcesses(ctx context.Context, colonyID string, count int) ([]*core.Process, error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			processes, err := controller.db.FindRunningProcesses(colonyID, count)
@@ -808,12 +1434,12 @@ func (controller *coloniesController) findRunningProcesses(colonyID string, coun
 	}
 }
 
-func (controller *coloniesController) findSuccessfulProcesses(colonyID string, count int) ([]*core.Process, error) {
-	cmd := &command{processesReplyChan: make(chan []*core.Process),
+func (controller *coloniesController) findSuccessfulProcesses(ctx context.Context, colonyID string, count int) ([]*core.Process, error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			processes, err := controller.db.FindSuccessfulProcesses(colonyID, count)
@@ -834,12 +1460,12 @@ func (controller *coloniesController) findSuccessfulProcesses(colonyID string, c
 	}
 }
 
-func (controller *coloniesController) findFailedProcesses(colonyID string, count int) ([]*core.Process, error) {
-	cmd := &command{processesReplyChan: make(chan []*core.Process),
+func (controller *coloniesController) findFailedProcesses(ctx context.Context, colonyID string, count int) ([]*core.Process, error) {
+	cmd := &command{ctx: ctx, processesReplyChan: make(chan []*core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			processes, err := controller.db.FindFailedProcesses(colonyID, count)
@@ -865,6 +1491,23 @@ func (controller *coloniesController) updateProcessGraph(graph *core.ProcessGrap
 	return graph.UpdateProcessIDs()
 }
 
+// resolveProcessGraph updates the state of the processes in the graph and of the graph itself, webhooks are
+// notified if the state of the graph changed
+func (controller *coloniesController) resolveProcessGraph(processGraph *core.ProcessGraph) error {
+	state := processGraph.State
+	processGraph.SetStorage(controller.db)
+	err := processGraph.Resolve()
+	if err != nil {
+		return err
+	}
+
+	if processGraph.State != state {
+		controller.enqueueProcessGraphWebhooks(processGraph)
+	}
+
+	return nil
+}
+
 func (controller *coloniesController) createProcessGraph(workflowSpec *core.WorkflowSpec, args []string) (*core.ProcessGraph, error) {
 	processgraph, err := core.CreateProcessGraph(workflowSpec.ColonyID)
 
@@ -881,11 +1524,6 @@ func (controller *coloniesController) createProcessGraph(workflowSpec *core.Work
 		if len(processSpec.Conditions.Dependencies) == 0 {
 			// The process is a root process, let it start immediately
 			process.WaitForParents = false
-			if len(args) > 0 {
-				// TODO: May be we should not overwrite the args
-				// This will only happen when using Generators
-				process.ProcessSpec.Args = args
-			}
 			rootProcesses = append(rootProcesses, process)
 			processgraph.AddRoot(process.ID)
 		} else {
@@ -897,6 +1535,22 @@ func (controller *coloniesController) createProcessGraph(workflowSpec *core.Work
 		processMap[process.ProcessSpec.Name] = process
 	}
 
+	// This will only happen when using Generators, the args are substituted into the root process specs
+	// where requested and are also attached as an IN attribute so that the spec args are preserved
+	if len(args) > 0 {
+		generatorArgsJSON, err := core.ConvertGeneratorArgsToJSON(args)
+		if err != nil {
+			return nil, err
+		}
+		for _, process := range rootProcesses {
+			process.ProcessSpec.Args, err = core.SubstituteGeneratorArgs(process.ProcessSpec.Args, args)
+			if err != nil {
+				return nil, err
+			}
+			process.Attributes = append(process.Attributes, core.CreateAttribute(process.ID, workflowSpec.ColonyID, processgraph.ID, core.IN, core.GENERATOR_ARGS_KEY, generatorArgsJSON))
+		}
+	}
+
 	err = controller.db.AddProcessGraph(processgraph)
 	if err != nil {
 		msg := "Failed to submit workflow, failed to add processgraph"
@@ -913,7 +1567,7 @@ func (controller *coloniesController) createProcessGraph(workflowSpec *core.Work
 			if parentProcess == nil {
 				msg := "Failed to submit workflow, invalid dependencies, are you depending on a process spec name that does not exits?"
 				log.WithFields(log.Fields{"Error": err}).Error(msg)
-				return nil, errors.New(msg)
+				return nil, core.WithErrorCode(errors.New(msg), core.ErrorCodeValidationFailed)
 			}
 			process.AddParent(parentProcess.ID)
 			parentProcess.AddChild(process.ID)
@@ -926,20 +1580,23 @@ func (controller *coloniesController) createProcessGraph(workflowSpec *core.Work
 		// we will cause a deadlock if we call controller.addProcess
 		addedProcess, err := controller.addProcessAndSetWaitingDeadline(process)
 		log.WithFields(log.Fields{"ProcessID": process.ID}).Debug("Submitting process part of processgraph")
-		controller.eventHandler.signal(addedProcess)
+		controller.eventHandler.signal(controller.commandContext(), addedProcess)
 
 		if err != nil {
 			msg := "Failed to submit workflow, failed to add process"
 			log.WithFields(log.Fields{"Error": err}).Error(msg)
 			return nil, errors.New(msg)
 		}
+		controller.enqueueProcessWebhooks(addedProcess)
 	}
 
+	controller.enqueueProcessGraphWebhooks(processgraph)
+
 	return processgraph, nil
 }
 
-func (controller *coloniesController) submitWorkflowSpec(workflowSpec *core.WorkflowSpec) (*core.ProcessGraph, error) {
-	cmd := &command{processGraphReplyChan: make(chan *core.ProcessGraph, 1),
+func (controller *coloniesController) submitWorkflowSpec(ctx context.Context, workflowSpec *core.WorkflowSpec) (*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphReplyChan: make(chan *core.ProcessGraph, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			addedProcessGraph, err := controller.createProcessGraph(workflowSpec, []string{})
@@ -960,8 +1617,8 @@ func (controller *coloniesController) submitWorkflowSpec(workflowSpec *core.Work
 	}
 }
 
-func (controller *coloniesController) getProcessGraphByID(processGraphID string) (*core.ProcessGraph, error) {
-	cmd := &command{processGraphReplyChan: make(chan *core.ProcessGraph, 1),
+func (controller *coloniesController) getProcessGraphByID(ctx context.Context, processGraphID string) (*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphReplyChan: make(chan *core.ProcessGraph, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			graph, err := controller.db.GetProcessGraphByID(processGraphID)
@@ -991,12 +1648,12 @@ func (controller *coloniesController) getProcessGraphByID(processGraphID string)
 	}
 }
 
-func (controller *coloniesController) findWaitingProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
-	cmd := &command{processGraphsReplyChan: make(chan []*core.ProcessGraph),
+func (controller *coloniesController) findWaitingProcessGraphs(ctx context.Context, colonyID string, count int) ([]*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphsReplyChan: make(chan []*core.ProcessGraph),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			graphs, err := controller.db.FindWaitingProcessGraphs(colonyID, count)
@@ -1025,12 +1682,12 @@ func (controller *coloniesController) findWaitingProcessGraphs(colonyID string,
 	}
 }
 
-func (controller *coloniesController) findRunningProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
-	cmd := &command{processGraphsReplyChan: make(chan []*core.ProcessGraph),
+func (controller *coloniesController) findRunningProcessGraphs(ctx context.Context, colonyID string, count int) ([]*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphsReplyChan: make(chan []*core.ProcessGraph),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			graphs, err := controller.db.FindRunningProcessGraphs(colonyID, count)
@@ -1058,12 +1715,12 @@ func (controller *coloniesController) findRunningProcessGraphs(colonyID string,
 	}
 }
 
-func (controller *coloniesController) findSuccessfulProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
-	cmd := &command{processGraphsReplyChan: make(chan []*core.ProcessGraph),
+func (controller *coloniesController) findSuccessfulProcessGraphs(ctx context.Context, colonyID string, count int) ([]*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphsReplyChan: make(chan []*core.ProcessGraph),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			graphs, err := controller.db.FindSuccessfulProcessGraphs(colonyID, count)
@@ -1091,12 +1748,12 @@ func (controller *coloniesController) findSuccessfulProcessGraphs(colonyID strin
 	}
 }
 
-func (controller *coloniesController) findFailedProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
-	cmd := &command{processGraphsReplyChan: make(chan []*core.ProcessGraph),
+func (controller *coloniesController) findFailedProcessGraphs(ctx context.Context, colonyID string, count int) ([]*core.ProcessGraph, error) {
+	cmd := &command{ctx: ctx, processGraphsReplyChan: make(chan []*core.ProcessGraph),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			if count > MAX_COUNT {
-				cmd.errorChan <- errors.New("Count is larger than MaxCount limit <" + strconv.Itoa(MAX_COUNT) + ">")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed)
 				return
 			}
 			graphs, err := controller.db.FindFailedProcessGraphs(colonyID, count)
@@ -1124,8 +1781,8 @@ func (controller *coloniesController) findFailedProcessGraphs(colonyID string, c
 	}
 }
 
-func (controller *coloniesController) deleteProcess(processID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteProcess(ctx context.Context, processID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteProcessByID(processID)
 			cmd.errorChan <- err
@@ -1135,8 +1792,8 @@ func (controller *coloniesController) deleteProcess(processID string) error {
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) deleteAllProcesses(colonyID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteAllProcesses(ctx context.Context, colonyID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteAllProcessesByColonyID(colonyID)
 			cmd.errorChan <- err
@@ -1146,8 +1803,8 @@ func (controller *coloniesController) deleteAllProcesses(colonyID string) error
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) deleteProcessGraph(processID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteProcessGraph(ctx context.Context, processID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteProcessGraphByID(processID)
 			cmd.errorChan <- err
@@ -1157,8 +1814,8 @@ func (controller *coloniesController) deleteProcessGraph(processID string) error
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) deleteAllProcessGraphs(colonyID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) deleteAllProcessGraphs(ctx context.Context, colonyID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.DeleteAllProcessGraphsByColonyID(colonyID)
 			cmd.errorChan <- err
@@ -1168,8 +1825,8 @@ func (controller *coloniesController) deleteAllProcessGraphs(colonyID string) er
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) closeSuccessful(processID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) closeSuccessful(ctx context.Context, processID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			process, err := controller.db.GetProcessByID(processID)
 			if err != nil {
@@ -1190,8 +1847,7 @@ func (controller *coloniesController) closeSuccessful(processID string) error {
 					cmd.errorChan <- err
 					return
 				}
-				processGraph.SetStorage(controller.db)
-				err = processGraph.Resolve()
+				err = controller.resolveProcessGraph(processGraph)
 				if err != nil {
 					cmd.errorChan <- err
 					return
@@ -1199,15 +1855,16 @@ func (controller *coloniesController) closeSuccessful(processID string) error {
 			}
 
 			cmd.errorChan <- nil
-			controller.eventHandler.signal(process)
+			controller.eventHandler.signal(controller.commandContext(), process)
+			controller.enqueueProcessWebhooks(process)
 		}}
 
 	controller.cmdQueue <- cmd
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) closeFailed(processID string, errorMsg string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) closeFailed(ctx context.Context, processID string, errorMsg string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			process, err := controller.db.GetProcessByID(processID)
 			if err != nil {
@@ -1228,8 +1885,7 @@ func (controller *coloniesController) closeFailed(processID string, errorMsg str
 					cmd.errorChan <- err
 					return
 				}
-				processGraph.SetStorage(controller.db)
-				err = processGraph.Resolve()
+				err = controller.resolveProcessGraph(processGraph)
 				if err != nil {
 					cmd.errorChan <- err
 					return
@@ -1237,15 +1893,16 @@ func (controller *coloniesController) closeFailed(processID string, errorMsg str
 			}
 
 			cmd.errorChan <- nil
-			controller.eventHandler.signal(process)
+			controller.eventHandler.signal(controller.commandContext(), process)
+			controller.enqueueProcessWebhooks(process)
 		}}
 
 	controller.cmdQueue <- cmd
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) assignRuntime(runtimeID string, colonyID string, latest bool) (*core.Process, error) {
-	cmd := &command{processReplyChan: make(chan *core.Process),
+func (controller *coloniesController) assignRuntime(ctx context.Context, runtimeID string, colonyID string, latest bool) (*core.Process, error) {
+	cmd := &command{ctx: ctx, processReplyChan: make(chan *core.Process),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			runtime, err := controller.db.GetRuntimeByID(runtimeID)
@@ -1254,7 +1911,7 @@ func (controller *coloniesController) assignRuntime(runtimeID string, colonyID s
 				return
 			}
 			if runtime == nil {
-				cmd.errorChan <- errors.New("Runtime with id <" + runtimeID + "> could not be found")
+				cmd.errorChan <- core.WithErrorCode(errors.New("Runtime with id <"+runtimeID+"> could not be found"), core.ErrorCodeNotFound)
 				return
 			}
 
@@ -1305,8 +1962,7 @@ func (controller *coloniesController) assignRuntime(runtimeID string, colonyID s
 					log.Error(errMsg)
 					cmd.errorChan <- errors.New(errMsg)
 				}
-				processGraph.SetStorage(controller.db)
-				err = processGraph.Resolve()
+				err = controller.resolveProcessGraph(processGraph)
 				if err != nil {
 					log.Error(err)
 					cmd.errorChan <- err
@@ -1314,6 +1970,7 @@ func (controller *coloniesController) assignRuntime(runtimeID string, colonyID s
 				}
 			}
 
+			controller.enqueueProcessWebhooks(selectedProcess)
 			cmd.processReplyChan <- selectedProcess
 		}}
 
@@ -1326,8 +1983,8 @@ func (controller *coloniesController) assignRuntime(runtimeID string, colonyID s
 	}
 }
 
-func (controller *coloniesController) unassignRuntime(processID string) error {
-	cmd := &command{errorChan: make(chan error, 1),
+func (controller *coloniesController) unassignRuntime(ctx context.Context, processID string) error {
+	cmd := &command{ctx: ctx, errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			process, err := controller.db.GetProcessByID(processID)
 			if err != nil {
@@ -1335,15 +1992,16 @@ func (controller *coloniesController) unassignRuntime(processID string) error {
 				return
 			}
 			cmd.errorChan <- controller.db.UnassignRuntime(process)
-			controller.eventHandler.signal(process)
+			controller.eventHandler.signal(controller.commandContext(), process)
+			controller.enqueueProcessWebhooks(process)
 		}}
 
 	controller.cmdQueue <- cmd
 	return <-cmd.errorChan
 }
 
-func (controller *coloniesController) getColonyStatistics(colonyID string) (*core.Statistics, error) {
-	cmd := &command{statisticsReplyChan: make(chan *core.Statistics),
+func (controller *coloniesController) getColonyStatistics(ctx context.Context, colonyID string) (*core.Statistics, error) {
+	cmd := &command{ctx: ctx, statisticsReplyChan: make(chan *core.Statistics),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			colonies := 1
@@ -1414,8 +2072,8 @@ func (controller *coloniesController) getColonyStatistics(colonyID string) (*cor
 	}
 }
 
-func (controller *coloniesController) getStatistics() (*core.Statistics, error) {
-	cmd := &command{statisticsReplyChan: make(chan *core.Statistics),
+func (controller *coloniesController) getStatistics(ctx context.Context) (*core.Statistics, error) {
+	cmd := &command{ctx: ctx, statisticsReplyChan: make(chan *core.Statistics),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			colonies, err := controller.db.CountColonies()
@@ -1490,8 +2148,8 @@ func (controller *coloniesController) getStatistics() (*core.Statistics, error)
 	}
 }
 
-func (controller *coloniesController) addAttribute(attribute core.Attribute) (core.Attribute, error) {
-	cmd := &command{attributeReplyChan: make(chan core.Attribute, 1),
+func (controller *coloniesController) addAttribute(ctx context.Context, attribute core.Attribute) (core.Attribute, error) {
+	cmd := &command{ctx: ctx, attributeReplyChan: make(chan core.Attribute, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			err := controller.db.AddAttribute(attribute)
@@ -1516,8 +2174,39 @@ func (controller *coloniesController) addAttribute(attribute core.Attribute) (co
 	}
 }
 
-func (controller *coloniesController) getAttribute(attributeID string) (core.Attribute, error) {
-	cmd := &command{attributeReplyChan: make(chan core.Attribute, 1),
+// addAttributes adds a batch of attributes in a single command, the returned errors tell which attributes could
+// not be added
+func (controller *coloniesController) addAttributes(ctx context.Context, attributes []core.Attribute) ([]core.Attribute, []error) {
+	cmd := &command{ctx: ctx, attributesReplyChan: make(chan []core.Attribute, 1),
+		errorsReplyChan: make(chan []error, 1),
+		handler: func(cmd *command) {
+			addedAttributes := make([]core.Attribute, len(attributes))
+			errs := make([]error, len(attributes))
+			for i, attribute := range attributes {
+				err := controller.db.AddAttribute(attribute)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				addedAttribute, err := controller.db.GetAttributeByID(attribute.ID)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				addedAttributes[i] = addedAttribute
+			}
+
+			cmd.attributesReplyChan <- addedAttributes
+			cmd.errorsReplyChan <- errs
+		}}
+
+	controller.cmdQueue <- cmd
+
+	return <-cmd.attributesReplyChan, <-cmd.errorsReplyChan
+}
+
+func (controller *coloniesController) getAttribute(ctx context.Context, attributeID string) (core.Attribute, error) {
+	cmd := &command{ctx: ctx, attributeReplyChan: make(chan core.Attribute, 1),
 		errorChan: make(chan error, 1),
 		handler: func(cmd *command) {
 			attribute, err := controller.db.GetAttributeByID(attributeID)
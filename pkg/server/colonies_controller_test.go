@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"testing"
 
 	"github.com/colonyos/colonies/pkg/core"
@@ -19,7 +20,7 @@ func TestColoniesControllerAddColony(t *testing.T) {
 
 	colony, _, err := utils.CreateTestColonyWithKey()
 	assert.Nil(t, err)
-	addedColony, err := controller.addColony(colony)
+	addedColony, err := controller.addColony(context.Background(), colony)
 	assert.Nil(t, err)
 	assert.True(t, colony.Equals(addedColony))
 }
@@ -35,7 +36,7 @@ func TestColoniesControllerAddRuntime(t *testing.T) {
 	runtime, _, err := utils.CreateTestRuntimeWithKey(core.GenerateRandomID())
 	assert.Nil(t, err)
 
-	addedRuntime, err := controller.addRuntime(runtime)
+	addedRuntime, err := controller.addRuntime(context.Background(), runtime)
 	assert.Nil(t, err)
 	assert.True(t, runtime.Equals(addedRuntime))
 }
@@ -51,14 +52,14 @@ func TestColoniesControllerApproveRuntime(t *testing.T) {
 	runtime, _, err := utils.CreateTestRuntimeWithKey(core.GenerateRandomID())
 	assert.Nil(t, err)
 
-	addedRuntime, err := controller.addRuntime(runtime)
+	addedRuntime, err := controller.addRuntime(context.Background(), runtime)
 	assert.Nil(t, err)
 	assert.True(t, runtime.Equals(addedRuntime))
 
-	err = controller.approveRuntime(runtime.ID)
+	err = controller.approveRuntime(context.Background(), runtime.ID)
 	assert.Nil(t, err)
 
-	runtimeFromController, err := controller.getRuntime(runtime.ID)
+	runtimeFromController, err := controller.getRuntime(context.Background(), runtime.ID)
 	assert.Nil(t, err)
 	assert.True(t, runtimeFromController.IsApproved())
 	assert.False(t, runtime.IsApproved())
@@ -77,13 +78,13 @@ func TestColoniesControllerAddProcess(t *testing.T) {
 	runtime, _, err := utils.CreateTestRuntimeWithKey(colonyID)
 	assert.Nil(t, err)
 
-	_, err = controller.addRuntime(runtime)
+	_, err = controller.addRuntime(context.Background(), runtime)
 	assert.Nil(t, err)
 
 	processSpec := utils.CreateTestProcessSpecWithEnv(colonyID, make(map[string]string))
 	process := core.CreateProcess(processSpec)
 
-	addedProcess, err := controller.addProcess(process)
+	addedProcess, err := controller.addProcess(context.Background(), process)
 	assert.Nil(t, err)
 	assert.True(t, process.ID == addedProcess.ID)
 }
@@ -101,15 +102,15 @@ func TestColoniesControllerAssignRuntime(t *testing.T) {
 	runtime, _, err := utils.CreateTestRuntimeWithKey(colonyID)
 	assert.Nil(t, err)
 
-	_, err = controller.addRuntime(runtime)
+	_, err = controller.addRuntime(context.Background(), runtime)
 	assert.Nil(t, err)
 
 	processSpec := utils.CreateTestProcessSpecWithEnv(colonyID, make(map[string]string))
 	process := core.CreateProcess(processSpec)
-	_, err = controller.addProcess(process)
+	_, err = controller.addProcess(context.Background(), process)
 	assert.Nil(t, err)
 
-	assignedProcess, err := controller.assignRuntime(runtime.ID, colonyID, false)
+	assignedProcess, err := controller.assignRuntime(context.Background(), runtime.ID, colonyID, false)
 	assert.Nil(t, err)
 	assert.True(t, process.ID == assignedProcess.ID)
 }
@@ -131,18 +132,18 @@ func TestColoniesControllerAssignRuntimeConcurrency(t *testing.T) {
 
 	runtime1, _, err := utils.CreateTestRuntimeWithKey(colonyID)
 	assert.Nil(t, err)
-	_, err = controller1.addRuntime(runtime1)
+	_, err = controller1.addRuntime(context.Background(), runtime1)
 	assert.Nil(t, err)
 
 	runtime2, _, err := utils.CreateTestRuntimeWithKey(colonyID)
 	assert.Nil(t, err)
-	_, err = controller1.addRuntime(runtime2)
+	_, err = controller1.addRuntime(context.Background(), runtime2)
 	assert.Nil(t, err)
 
 	for i := 0; i < processCount; i++ {
 		processSpec := utils.CreateTestProcessSpecWithEnv(colonyID, make(map[string]string))
 		process := core.CreateProcess(processSpec)
-		_, err = controller1.addProcess(process)
+		_, err = controller1.addProcess(context.Background(), process)
 		assert.Nil(t, err)
 	}
 
@@ -150,7 +151,7 @@ func TestColoniesControllerAssignRuntimeConcurrency(t *testing.T) {
 
 	go func() {
 		for {
-			_, err := controller1.assignRuntime(runtime1.ID, colonyID, false)
+			_, err := controller1.assignRuntime(context.Background(), runtime1.ID, colonyID, false)
 			if err == nil {
 				countChan <- 1
 			}
@@ -163,7 +164,7 @@ func TestColoniesControllerAssignRuntimeConcurrency(t *testing.T) {
 
 	go func() {
 		for {
-			_, err := controller2.assignRuntime(runtime2.ID, colonyID, false)
+			_, err := controller2.assignRuntime(context.Background(), runtime2.ID, colonyID, false)
 			if err == nil {
 				countChan <- 1
 			}
@@ -178,3 +179,19 @@ func TestColoniesControllerAssignRuntimeConcurrency(t *testing.T) {
 		}
 	}
 }
+
+func TestCommandName(t *testing.T) {
+	controller := &coloniesController{}
+	cmd := &command{handler: func(cmd *command) {}}
+	assert.Equal(t, "TestCommandName", commandName(cmd))
+
+	var name string
+	controller.cmdQueue = make(chan *command, 1)
+	go func() {
+		cmd := <-controller.cmdQueue
+		name = commandName(cmd)
+		cmd.errorChan <- nil
+	}()
+	controller.deleteSecret(context.Background(), "", "")
+	assert.Equal(t, "deleteSecret", name)
+}
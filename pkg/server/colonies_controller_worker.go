@@ -1,8 +1,13 @@
 package server
 
 import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
 	"time"
 
+	"github.com/colonyos/colonies/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -64,6 +69,45 @@ func (controller *coloniesController) cronTriggerLoop() {
 	}
 }
 
+func (controller *coloniesController) nonceCleanupLoop() {
+	for {
+		time.Sleep(TIMEOUT_NONCE_CLEANUP_INTERVALL * time.Second)
+
+		controller.stopMutex.Lock()
+		if controller.stopFlag {
+			controller.stopMutex.Unlock()
+			return
+		}
+		controller.stopMutex.Unlock()
+
+		isLeader := controller.tryBecomeLeader()
+		if isLeader {
+			err := tracing.DatabaseWithContext(controller.db, context.Background()).DeleteExpiredNonces()
+			if err != nil {
+				log.WithFields(log.Fields{"Error": err}).Error("Failed to delete expired nonces")
+			}
+		}
+	}
+}
+
+func (controller *coloniesController) webhookDeliveryLoop() {
+	for {
+		time.Sleep(TIMEOUT_WEBHOOK_DELIVERY_INTERVALL * time.Second)
+
+		controller.stopMutex.Lock()
+		if controller.stopFlag {
+			controller.stopMutex.Unlock()
+			return
+		}
+		controller.stopMutex.Unlock()
+
+		isLeader := controller.tryBecomeLeader()
+		if isLeader {
+			controller.deliverWebhooks()
+		}
+	}
+}
+
 func (controller *coloniesController) timeoutLoop() {
 	for {
 		time.Sleep(TIMEOUT_RELEASE_INTERVALL * time.Second)
@@ -74,7 +118,9 @@ func (controller *coloniesController) timeoutLoop() {
 		}
 		controller.stopMutex.Unlock()
 
-		processes, err := controller.db.FindAllRunningProcesses()
+		// The loop is not run as a command, so the database calls must not become part of the current command
+		db := tracing.DatabaseWithContext(controller.db, context.Background())
+		processes, err := db.FindAllRunningProcesses()
 		if err != nil {
 			continue
 		}
@@ -84,7 +130,7 @@ func (controller *coloniesController) timeoutLoop() {
 			}
 			if time.Now().Unix() > process.ExecDeadline.Unix() {
 				if process.Retries >= process.ProcessSpec.MaxRetries && process.ProcessSpec.MaxRetries > -1 {
-					err := controller.closeFailed(process.ID, "Maximum execution time limit exceeded")
+					err := controller.closeFailed(context.Background(), process.ID, "Maximum execution time limit exceeded")
 					if err != nil {
 						log.WithFields(log.Fields{"ProcessID": process.ID, "Error": err}).Info("Max retries reached, but failed to close process")
 						continue
@@ -93,7 +139,7 @@ func (controller *coloniesController) timeoutLoop() {
 					continue
 				}
 
-				err := controller.unassignRuntime(process.ID)
+				err := controller.unassignRuntime(context.Background(), process.ID)
 				if err != nil {
 					log.WithFields(log.Fields{"ProcessID": process.ID, "Error": err}).Error("Failed to unassign process")
 				}
@@ -101,7 +147,7 @@ func (controller *coloniesController) timeoutLoop() {
 			}
 		}
 
-		processes, err = controller.db.FindAllWaitingProcesses()
+		processes, err = db.FindAllWaitingProcesses()
 		if err != nil {
 			continue
 		}
@@ -110,7 +156,7 @@ func (controller *coloniesController) timeoutLoop() {
 				continue
 			}
 			if time.Now().Unix() > process.WaitDeadline.Unix() {
-				err := controller.closeFailed(process.ID, "Maximum waiting time limit exceeded")
+				err := controller.closeFailed(context.Background(), process.ID, "Maximum waiting time limit exceeded")
 				if err != nil {
 					log.WithFields(log.Fields{"ProcessID": process.ID, "Error": err}).Info("Max waiting time reached, but failed to close process")
 					continue
@@ -129,8 +175,59 @@ func (controller *coloniesController) masterWorker() {
 				return
 			}
 			if msg.handler != nil {
-				msg.handler(msg)
+				controller.runCommand(msg)
 			}
 		}
 	}
 }
+
+// runCommand runs the handler of cmd in a span which is a child of the span in cmd.ctx, database calls made by the
+// handler become children of the command span, see commandContext
+func (controller *coloniesController) runCommand(cmd *command) {
+	ctx := cmd.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := tracing.StartChild(ctx, "controller."+commandName(cmd))
+
+	controller.cmdCtxMutex.Lock()
+	controller.cmdCtx = ctx
+	controller.cmdCtxMutex.Unlock()
+
+	cmd.handler(cmd)
+
+	controller.cmdCtxMutex.Lock()
+	controller.cmdCtx = nil
+	controller.cmdCtxMutex.Unlock()
+
+	span.End()
+}
+
+// commandContext returns the context of the command currently run by the master worker, the database is only
+// accessed by commands, except for the timeout and nonce cleanup loops which are never traced
+func (controller *coloniesController) commandContext() context.Context {
+	controller.cmdCtxMutex.Lock()
+	defer controller.cmdCtxMutex.Unlock()
+
+	if controller.cmdCtx == nil {
+		return context.Background()
+	}
+
+	return controller.cmdCtx
+}
+
+// commandName returns the name of the controller method which created cmd, handlers are closures named e.g.
+// github.com/colonyos/colonies/pkg/server.(*coloniesController).addProcess.func1
+func commandName(cmd *command) string {
+	name := runtime.FuncForPC(reflect.ValueOf(cmd.handler).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = name[strings.Index(name, ".")+1:]
+	if i := strings.Index(name, ")."); i >= 0 {
+		name = name[i+2:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+
+	return name
+}
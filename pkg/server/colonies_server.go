@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/colonyos/colonies/pkg/cluster"
@@ -16,10 +15,13 @@ import (
 	"github.com/colonyos/colonies/pkg/security"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/colonyos/colonies/pkg/security/validator"
+	"github.com/colonyos/colonies/pkg/tracing"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 type ColoniesServer struct {
@@ -34,6 +36,11 @@ type ColoniesServer struct {
 	crypto            security.Crypto
 	validator         security.Validator
 	db                database.Database
+	minRPCVersion     int
+	maxClockSkew      time.Duration
+	secretsKey        string
+	wsPolicy          *wsPolicy
+	grpcServer        *grpc.Server
 }
 
 func CreateColoniesServer(db database.Database,
@@ -66,6 +73,9 @@ func CreateColoniesServer(db database.Database,
 	server.tlsCertPath = tlsCertPath
 	server.crypto = crypto.CreateCrypto()
 	server.validator = validator.CreateValidator(db)
+	server.minRPCVersion = rpc.LegacyProtocolVersion
+	server.maxClockSkew = MAX_CLOCK_SKEW * time.Second
+	server.wsPolicy = createWSPolicy()
 
 	server.setupRoutes()
 
@@ -74,8 +84,11 @@ func CreateColoniesServer(db database.Database,
 
 func (server *ColoniesServer) setupRoutes() {
 	server.ginHandler.POST("/api", server.handleAPIRequest)
+	server.ginHandler.GET("/api/schema", server.handleSchemaRequest)
 	server.ginHandler.GET("/health", server.handleHealthRequest)
 	server.ginHandler.GET("/pubsub", server.handleWSRequest)
+	server.ginHandler.GET("/events", server.handleSSERequest)
+	server.ginHandler.POST("/generators/:generatorid/webhook", server.handleGeneratorWebhookRequest)
 }
 
 func (server *ColoniesServer) parseSignature(jsonString string, signature string) (string, error) {
@@ -104,13 +117,24 @@ func (server *ColoniesServer) handleAPIRequest(c *gin.Context) {
 		return
 	}
 
+	server.handleRPCMsg(c, rpcMsg, rpcMsg.DecodePayload())
+}
+
+// handleRPCMsg verifies and handles an RPC message received over HTTP or gRPC, jsonString is the decoded payload
+func (server *ColoniesServer) handleRPCMsg(c *gin.Context, rpcMsg *rpc.RPCMsg, jsonString string) {
+	// The span becomes a child of the caller's span, controller commands and database calls made while handling the
+	// message become children of this span since the handlers pass on the request context
+	ctx, span := tracing.Start(tracing.Extract(c.Request.Context(), rpcMsg.TraceContext), "server."+rpcMsg.PayloadType, trace.SpanKindServer)
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
 	// Version does not require a valid private key
 	if rpcMsg.PayloadType == rpc.VersionPayloadType {
-		server.handleVersionHTTPRequest(c, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleVersionHTTPRequest(c, rpcMsg.PayloadType, jsonString)
 		return
 	}
 
-	recoveredID, err := server.parseSignature(rpcMsg.Payload, rpcMsg.Signature)
+	recoveredID, signerID, err := server.verifyRPCMsg(ctx, rpcMsg)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -118,97 +142,153 @@ func (server *ColoniesServer) handleAPIRequest(c *gin.Context) {
 	switch rpcMsg.PayloadType {
 	// Colony handlers
 	case rpc.AddColonyPayloadType:
-		server.handleAddColonyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleAddColonyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteColonyPayloadType:
-		server.handleDeleteColonyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteColonyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetColoniesPayloadType:
-		server.handleGetColoniesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetColoniesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetColonyPayloadType:
-		server.handleGetColonyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetColonyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.RotateColonyKeyPayloadType:
+		server.handleRotateColonyKeyHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Runtime handlers
 	case rpc.AddRuntimePayloadType:
-		server.handleAddRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleAddRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetRuntimesPayloadType:
-		server.handleGetRuntimesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetRuntimesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetRuntimePayloadType:
-		server.handleGetRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.ApproveRuntimePayloadType:
-		server.handleApproveRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleApproveRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.RejectRuntimePayloadType:
-		server.handleRejectRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleRejectRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteRuntimePayloadType:
-		server.handleDeleteRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.RevokeRuntimePayloadType:
+		server.handleRevokeRuntimeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetRevocationsPayloadType:
+		server.handleGetRevocationsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.AddRuntimeRolePayloadType:
+		server.handleAddRuntimeRoleHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.RemoveRuntimeRolePayloadType:
+		server.handleRemoveRuntimeRoleHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetRuntimeRolesPayloadType:
+		server.handleGetRuntimeRolesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Process handlers
 	case rpc.SubmitProcessSpecPayloadType:
-		server.handleSubmitProcessSpecHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleSubmitProcessSpecHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.AssignProcessPayloadType:
-		server.handleAssignProcessHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleAssignProcessHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetProcessHistPayloadType:
-		server.handleGetProcessHistHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetProcessHistHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetProcessesPayloadType:
-		server.handleGetProcessesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetProcessesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetProcessPayloadType:
-		server.handleGetProcessHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetProcessHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteProcessPayloadType:
-		server.handleDeleteProcessHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteProcessHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteAllProcessesPayloadType:
-		server.handleDeleteAllProcessesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteAllProcessesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.CloseSuccessfulPayloadType:
-		server.handleCloseSuccessfulHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleCloseSuccessfulHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.CloseFailedPayloadType:
-		server.handleCloseFailedHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleCloseFailedHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetColonyStatisticsPayloadType:
-		server.handleColonyStatisticsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleColonyStatisticsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Attribute handlers
 	case rpc.AddAttributePayloadType:
-		server.handleAddAttributeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleAddAttributeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetAttributePayloadType:
-		server.handleGetAttributeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetAttributeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+
+	// Secret handlers
+	case rpc.SetSecretPayloadType:
+		server.handleSetSecretHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetSecretsPayloadType:
+		server.handleGetSecretsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.DeleteSecretPayloadType:
+		server.handleDeleteSecretHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetProcessSecretsPayloadType:
+		server.handleGetProcessSecretsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+
+	// Webhook handlers
+	case rpc.AddWebhookPayloadType:
+		server.handleAddWebhookHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetWebhooksPayloadType:
+		server.handleGetWebhooksHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.DeleteWebhookPayloadType:
+		server.handleDeleteWebhookHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetWebhookDeliveriesPayloadType:
+		server.handleGetWebhookDeliveriesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Workflow and processgraph handlers
 	case rpc.SubmitWorkflowSpecPayloadType:
-		server.handleSubmitWorkflowHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleSubmitWorkflowHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetProcessGraphPayloadType:
-		server.handleGetProcessGraphHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetProcessGraphHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetProcessGraphsPayloadType:
-		server.handleGetProcessGraphsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetProcessGraphsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteProcessGraphPayloadType:
-		server.handleDeleteProcessGraphHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteProcessGraphHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteAllProcessGraphsPayloadType:
-		server.handleDeleteAllProcessGraphsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteAllProcessGraphsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Generators handlers
 	case rpc.AddGeneratorPayloadType:
-		server.handleAddGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleAddGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetGeneratorPayloadType:
-		server.handleGetGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetGeneratorsPayloadType:
-		server.handleGetGeneratorsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetGeneratorsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.PackGeneratorPayloadType:
-		server.handlePackGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handlePackGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteGeneratorPayloadType:
-		server.handleDeleteGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteGeneratorHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Cron handlers
 	case rpc.AddCronPayloadType:
-		server.handleAddCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleAddCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetCronPayloadType:
-		server.handleGetCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetCronsPayloadType:
-		server.handleGetCronsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetCronsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.RunCronPayloadType:
-		server.handleRunCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleRunCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.DeleteCronPayloadType:
-		server.handleDeleteCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleDeleteCronHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+
+	// Workflow template handlers
+	case rpc.AddWorkflowTemplatePayloadType:
+		server.handleAddWorkflowTemplateHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetWorkflowTemplatePayloadType:
+		server.handleGetWorkflowTemplateHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetWorkflowTemplatesPayloadType:
+		server.handleGetWorkflowTemplatesHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetWorkflowTemplateVersionsPayloadType:
+		server.handleGetWorkflowTemplateVersionsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.DeleteWorkflowTemplatePayloadType:
+		server.handleDeleteWorkflowTemplateHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.SubmitWorkflowTemplatePayloadType:
+		server.handleSubmitWorkflowTemplateHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	// Server handlers
 	case rpc.GetStatisiticsPayloadType:
-		server.handleStatisticsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleStatisticsHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 	case rpc.GetClusterPayloadType:
-		server.handleGetClusterHTTPRequest(c, recoveredID, rpcMsg.PayloadType, rpcMsg.DecodePayload())
+		server.handleGetClusterHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.AddClusterNodePayloadType:
+		server.handleAddClusterNodeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.RemoveClusterNodePayloadType:
+		server.handleRemoveClusterNodeHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+	case rpc.GetAuditLogPayloadType:
+		server.handleGetAuditLogHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
+
+	// Batch handlers
+	case rpc.BatchPayloadType:
+		server.handleBatchHTTPRequest(c, recoveredID, rpcMsg.PayloadType, jsonString)
 
 	default:
 		errMsg := "invalid rpcMsg.PayloadType"
@@ -217,10 +297,12 @@ func (server *ColoniesServer) handleAPIRequest(c *gin.Context) {
 			return
 		}
 	}
+
+	server.audit(c, recoveredID, signerID, rpcMsg.PayloadType, jsonString)
 }
 
 func (server *ColoniesServer) generateRPCErrorMsg(err error, errorCode int) (*rpc.RPCReplyMsg, error) {
-	failure := core.CreateFailure(errorCode, err.Error())
+	failure := core.CreateFailureFromError(errorCode, err)
 	jsonString, err := failure.ToJSON()
 	if err != nil {
 		return nil, err
@@ -235,9 +317,19 @@ func (server *ColoniesServer) generateRPCErrorMsg(err error, errorCode int) (*rp
 
 func (server *ColoniesServer) handleHTTPError(c *gin.Context, err error, errorCode int) bool {
 	if err != nil {
-		if !strings.HasPrefix(err.Error(), "No processes can be selected for runtime with Id") {
-			log.Error(err)
+		if core.ErrorCodeOf(err) != core.ErrorCodeNoWorkAvailable {
+			tracing.SetError(c.Request.Context(), err)
+			if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+				log.WithFields(log.Fields{"TraceID": traceID}).Error(err)
+			} else {
+				log.Error(err)
+			}
+		}
+		if writer, ok := c.Writer.(*replyWriter); ok {
+			writer.setErrorReply(err, errorCode)
+			return true
 		}
+		status := core.ErrorStatus(err, errorCode)
 		rpcReplyMsg, err := server.generateRPCErrorMsg(err, errorCode)
 		if err != nil {
 			log.WithFields(log.Fields{"Error": err}).Error("Failed to call server.generateRPCErrorMsg()")
@@ -247,7 +339,7 @@ func (server *ColoniesServer) handleHTTPError(c *gin.Context, err error, errorCo
 			log.WithFields(log.Fields{"Error": err}).Error("Failed to call pcReplyMsg.ToJSON()")
 		}
 
-		c.String(errorCode, rpcReplyMsgJSONString)
+		c.String(status, rpcReplyMsgJSONString)
 		return true
 	}
 
@@ -255,6 +347,10 @@ func (server *ColoniesServer) handleHTTPError(c *gin.Context, err error, errorCo
 }
 
 func (server *ColoniesServer) sendHTTPReply(c *gin.Context, payloadType string, jsonString string) {
+	if writer, ok := c.Writer.(*replyWriter); ok {
+		writer.setReply(http.StatusOK, payloadType, jsonString, false)
+		return
+	}
 	rpcReplyMsg, err := rpc.CreateRPCReplyMsg(payloadType, jsonString)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
@@ -268,6 +364,10 @@ func (server *ColoniesServer) sendHTTPReply(c *gin.Context, payloadType string,
 }
 
 func (server *ColoniesServer) sendEmptyHTTPReply(c *gin.Context, payloadType string) {
+	if writer, ok := c.Writer.(*replyWriter); ok {
+		writer.setReply(http.StatusOK, payloadType, "{}", false)
+		return
+	}
 	rpcReplyMsg, err := rpc.CreateRPCReplyMsg(payloadType, "{}")
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
@@ -304,4 +404,7 @@ func (server *ColoniesServer) Shutdown() {
 		log.WithFields(log.Fields{"Error": err}).Warning("ColoniesServer forced to shutdown")
 	}
 
+	if server.grpcServer != nil {
+		server.grpcServer.Stop()
+	}
 }
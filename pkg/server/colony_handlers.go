@@ -29,16 +29,19 @@ func (server *ColoniesServer) handleAddColonyHTTPRequest(c *gin.Context, recover
 	}
 
 	if msg.Colony == nil {
-		server.handleHTTPError(c, errors.New("Failed to add colony, colony is nil"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add colony, colony is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
 	if len(msg.Colony.ID) != 64 {
-		server.handleHTTPError(c, errors.New("Failed to add colony, invalid colony id length"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add colony, invalid colony id length"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
-	addedColony, err := server.controller.addColony(msg.Colony)
+	// A new colony is always owned by the key from which the colony Id was derived
+	msg.Colony.OwnerID = msg.Colony.ID
+
+	addedColony, err := server.controller.addColony(c.Request.Context(), msg.Colony)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -76,7 +79,7 @@ func (server *ColoniesServer) handleDeleteColonyHTTPRequest(c *gin.Context, reco
 		return
 	}
 
-	err = server.controller.deleteColony(msg.ColonyID)
+	err = server.controller.deleteColony(c.Request.Context(), msg.ColonyID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -86,6 +89,48 @@ func (server *ColoniesServer) handleDeleteColonyHTTPRequest(c *gin.Context, reco
 	server.sendEmptyHTTPReply(c, payloadType)
 }
 
+func (server *ColoniesServer) handleRotateColonyKeyHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateRotateColonyKeyMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to rotate colony key, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to rotate colony key, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	// The handover must be signed by the new key to prove that the new owner possesses it
+	newOwnerID, err := server.crypto.RecoverID(rpc.RotateColonyKeyHandoverData(msg.ColonyID, msg.NewOwnerID), msg.Proof)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if newOwnerID != msg.NewOwnerID {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to rotate colony key, proof was not signed by the new colony key"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+	if newOwnerID == recoveredID {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to rotate colony key, new colony key must differ from the current colony key"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	err = server.controller.rotateColonyKey(c.Request.Context(), msg.ColonyID, newOwnerID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID, "OwnerID": newOwnerID}).Info("Colony key rotated")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
 func (server *ColoniesServer) handleGetColoniesHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
 	msg, err := rpc.CreateGetColoniesMsgFromJSON(jsonString)
 	if err != nil {
@@ -104,7 +149,7 @@ func (server *ColoniesServer) handleGetColoniesHTTPRequest(c *gin.Context, recov
 		return
 	}
 
-	colonies, err := server.controller.getColonies()
+	colonies, err := server.controller.getColonies(c.Request.Context())
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -132,17 +177,17 @@ func (server *ColoniesServer) handleGetColonyHTTPRequest(c *gin.Context, recover
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	colony, err := server.controller.getColony(msg.ColonyID)
+	colony, err := server.controller.getColony(c.Request.Context(), msg.ColonyID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if colony == nil {
-		server.handleHTTPError(c, errors.New("Failed to get colony, colony is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get colony, colony not found"), http.StatusNotFound)
 		return
 	}
 
@@ -169,7 +214,7 @@ func (server *ColoniesServer) handleColonyStatisticsHTTPRequest(c *gin.Context,
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if err != nil {
 		err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
 		if server.handleHTTPError(c, err, http.StatusForbidden) {
@@ -177,7 +222,7 @@ func (server *ColoniesServer) handleColonyStatisticsHTTPRequest(c *gin.Context,
 		}
 	}
 
-	stat, err := server.controller.getColonyStatistics(msg.ColonyID)
+	stat, err := server.controller.getColonyStatistics(c.Request.Context(), msg.ColonyID)
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
 	}
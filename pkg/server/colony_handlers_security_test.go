@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
 	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/stretchr/testify/assert"
 )
@@ -134,3 +135,37 @@ func TestGetProcessStatSecurity(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+func TestRotateColonyKeySecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	newColonyPrvKey, err := crypto.CreateCrypto().GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	err = client.RotateColonyKey(env.colony1ID, newColonyPrvKey, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	err = client.RotateColonyKey(env.colony1ID, newColonyPrvKey, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	err = client.RotateColonyKey(env.colony1ID, newColonyPrvKey, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	// The new key must prove that it is the new owner
+	msg := rpc.CreateRotateColonyKeyMsg(env.colony1ID, env.colony2ID, "")
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+	rpcMsg, err := rpc.CreateRPCMsg(rpc.RotateColonyKeyPayloadType, jsonString, env.colony1PrvKey)
+	assert.Nil(t, err)
+	assert.True(t, postRPCMsg(t, rpcMsg).Error) // Should not work
+
+	err = client.RotateColonyKey(env.colony1ID, newColonyPrvKey, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
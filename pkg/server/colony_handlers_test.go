@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/security/crypto"
 	"github.com/colonyos/colonies/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -149,3 +150,44 @@ func TestGetColonyStatistics(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+func TestRotateColonyKey(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	crypto := crypto.CreateCrypto()
+	newColonyPrvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	newOwnerID, err := crypto.GenerateID(newColonyPrvKey)
+	assert.Nil(t, err)
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	err = client.RotateColonyKey(env.colonyID, newColonyPrvKey, env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	colonyFromServer, err := client.GetColonyByID(env.colonyID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, env.colonyID, colonyFromServer.ID)
+	assert.Equal(t, newOwnerID, colonyFromServer.OwnerID)
+
+	// The old colony key is no longer accepted
+	runtime, _, err := utils.CreateTestRuntimeWithKey(env.colonyID)
+	assert.Nil(t, err)
+	_, err = client.AddRuntime(runtime, env.colonyPrvKey)
+	assert.NotNil(t, err)
+	err = client.RotateColonyKey(env.colonyID, env.colonyPrvKey, env.colonyPrvKey)
+	assert.NotNil(t, err)
+
+	// But the new is
+	_, err = client.AddRuntime(runtime, newColonyPrvKey)
+	assert.Nil(t, err)
+
+	// Runtimes and processes are still associated with the colony
+	processFromServer, err := client.GetProcess(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, env.colonyID, processFromServer.ProcessSpec.Conditions.ColonyID)
+
+	server.Shutdown()
+	<-done
+}
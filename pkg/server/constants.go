@@ -1,5 +1,25 @@
 package server
 
 const MAX_COUNT = 100
+const MAX_CLOCK_SKEW = 300
+const MAX_NONCE_LENGTH = 128
+const MAX_WEBHOOK_BODY_SIZE = 1024 * 1024
 const TESTHOST = "localhost"
 const TESTPORT = 28088
+const TESTGRPCPORT = 28089
+const WEBHOOK_SIGNATURE_HEADER = "X-Colonies-Signature"
+const GITHUB_WEBHOOK_SIGNATURE_HEADER = "X-Hub-Signature-256"
+const MAX_WS_SUBSCRIPTIONS = 10000
+const MAX_WS_SUBSCRIPTIONS_PER_RUNTIME = 20
+const WS_IDLE_TIMEOUT = 60
+const WS_PING_INTERVAL = 20
+const MAX_BATCH_SIZE = 1000
+const WATCH_BUFFER_SIZE = 100
+const WEBHOOK_EVENT_HEADER = "X-Colonies-Event"
+const WEBHOOK_DELIVERY_HEADER = "X-Colonies-Delivery"
+const WEBHOOK_USER_AGENT = "Colonies-Webhook"
+const WEBHOOK_TIMEOUT = 10
+const WEBHOOK_MAX_ATTEMPTS = 8
+const WEBHOOK_BACKOFF = 5
+const WEBHOOK_MAX_BACKOFF = 3600
+const WEBHOOK_DELIVERY_BATCH_SIZE = 100
@@ -24,23 +24,23 @@ func (server *ColoniesServer) handleAddCronHTTPRequest(c *gin.Context, recovered
 		return
 	}
 	if msg.Cron == nil {
-		server.handleHTTPError(c, errors.New("Failed to add cron, msg.Cron is nil"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add cron, msg.Cron is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.Cron.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.Cron.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
 	// Validate that workflow and cron expression is valid
-	_, err = core.ConvertJSONToWorkflowSpec(msg.Cron.WorkflowSpec)
+	err = server.validateWorkflow(c.Request.Context(), msg.Cron.ColonyID, msg.Cron.WorkflowSpec, msg.Cron.WorkflowTemplate)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 
 	if msg.Cron.Interval == 0 {
-		if server.handleHTTPError(c, errors.New("Cron interval must be -1 (disabled) or larger than 0"), http.StatusBadRequest) {
+		if server.handleHTTPError(c, core.WithErrorCode(errors.New("Cron interval must be -1 (disabled) or larger than 0"), core.ErrorCodeValidationFailed), http.StatusBadRequest) {
 			return
 		}
 	}
@@ -51,14 +51,14 @@ func (server *ColoniesServer) handleAddCronHTTPRequest(c *gin.Context, recovered
 			return
 		}
 		if msg.Cron.Random {
-			if server.handleHTTPError(c, errors.New("Random cron is only supported when specifying intervals"), http.StatusBadRequest) {
+			if server.handleHTTPError(c, core.WithErrorCode(errors.New("Random cron is only supported when specifying intervals"), core.ErrorCodeValidationFailed), http.StatusBadRequest) {
 				return
 			}
 		}
 	}
 
 	msg.Cron.ID = core.GenerateRandomID()
-	addedCron, err := server.controller.addCron(msg.Cron)
+	addedCron, err := server.controller.addCron(c.Request.Context(), msg.Cron)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -90,16 +90,16 @@ func (server *ColoniesServer) handleGetCronHTTPRequest(c *gin.Context, recovered
 		return
 	}
 
-	cron, err := server.controller.getCron(msg.CronID)
+	cron, err := server.controller.getCron(c.Request.Context(), msg.CronID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if cron == nil {
-		server.handleHTTPError(c, errors.New("Failed to get cron, cron is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get cron, cron not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, cron.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, cron.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -127,12 +127,12 @@ func (server *ColoniesServer) handleGetCronsHTTPRequest(c *gin.Context, recovere
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	crons, err := server.controller.getCrons(msg.ColonyID, msg.Count)
+	crons, err := server.controller.getCrons(c.Request.Context(), msg.ColonyID, msg.Count)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -164,16 +164,16 @@ func (server *ColoniesServer) handleRunCronHTTPRequest(c *gin.Context, recovered
 		return
 	}
 
-	cron, err := server.controller.runCron(msg.CronID)
+	cron, err := server.controller.runCron(c.Request.Context(), msg.CronID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if cron == nil {
-		server.handleHTTPError(c, errors.New("Failed to run cron, cron is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to run cron, cron not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, cron.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, cron.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -201,21 +201,21 @@ func (server *ColoniesServer) handleDeleteCronHTTPRequest(c *gin.Context, recove
 		return
 	}
 
-	cron, err := server.controller.getCron(msg.CronID)
+	cron, err := server.controller.getCron(c.Request.Context(), msg.CronID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if cron == nil {
-		server.handleHTTPError(c, errors.New("Failed to delete cron, cron is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to delete cron, cron not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, cron.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, cron.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	err = server.controller.deleteCron(cron.ID)
+	err = server.controller.deleteCron(c.Request.Context(), cron.ID)
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
 	}
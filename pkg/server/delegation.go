@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/tracing"
+)
+
+// Payload types submitting work that is fully described in the payload, function and runtime type restrictions
+// of a delegation are checked against the process specs in the payload
+var delegatedSpecPayloadTypes = map[string]bool{
+	rpc.SubmitProcessSpecPayloadType:  true,
+	rpc.SubmitWorkflowSpecPayloadType: true,
+}
+
+// Payload types submitting work that is not described in the payload, they cannot be sent using a delegation
+// restricting functions or runtime types since the restrictions cannot be verified
+var delegatedOpaquePayloadTypes = map[string]bool{
+	rpc.SubmitWorkflowTemplatePayloadType: true,
+	rpc.PackGeneratorPayloadType:          true,
+	rpc.AddGeneratorPayloadType:           true,
+	rpc.AddCronPayloadType:                true,
+	rpc.RunCronPayloadType:                true,
+}
+
+// delegationScope contains the values in a payload that are checked against the scope of a delegation
+type delegationScope struct {
+	colonyIDs       []string
+	processIDs      []string
+	processGraphIDs []string
+	runtimeIDs      []string
+	funcs           []string
+	runtimeTypes    []string
+}
+
+// Objects created by a payload, their Ids do not refer to existing objects so only their colony is checked
+var delegatedObjectTypes = map[reflect.Type]bool{
+	reflect.TypeOf(core.Runtime{}): true,
+}
+
+// parseDelegationScope parses the payload as the message registered for the payload type, so that fields not part
+// of the message, which are ignored by the handlers, can not be used to make the payload look like it belongs to
+// another colony
+func parseDelegationScope(payloadType string, jsonString string) (*delegationScope, error) {
+	message, ok := rpcMessages[payloadType]
+	if !ok || message.request == nil {
+		return nil, errors.New("Payload type <" + payloadType + "> cannot be delegated")
+	}
+
+	msg := reflect.New(reflect.TypeOf(message.request))
+	if err := json.Unmarshal([]byte(jsonString), msg.Interface()); err != nil {
+		return nil, errors.New("Invalid payload, failed to parse JSON")
+	}
+
+	scope := &delegationScope{}
+	scope.add(msg.Elem(), false)
+
+	return scope, nil
+}
+
+func (scope *delegationScope) add(value reflect.Value, created bool) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			scope.add(value.Elem(), created)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			scope.add(value.Index(i), created)
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			scope.add(iter.Value(), created)
+		}
+	case reflect.Struct:
+		created = created || delegatedObjectTypes[value.Type()]
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if field.Type.Kind() != reflect.String {
+				scope.add(value.Field(i), created)
+				continue
+			}
+			scope.addField(strings.Split(field.Tag.Get("json"), ",")[0], value.Field(i).String(), created)
+		}
+	}
+}
+
+func (scope *delegationScope) addField(name string, value string, created bool) {
+	if value == "" {
+		return
+	}
+
+	switch name {
+	case "colonyid", "targetcolonyid":
+		scope.colonyIDs = append(scope.colonyIDs, value)
+	case "func":
+		scope.funcs = append(scope.funcs, value)
+	case "runtimetype":
+		scope.runtimeTypes = append(scope.runtimeTypes, value)
+	}
+
+	if created {
+		return
+	}
+
+	switch name {
+	case "processid", "targetid":
+		scope.processIDs = append(scope.processIDs, value)
+	case "processgraphid":
+		scope.processGraphIDs = append(scope.processGraphIDs, value)
+	case "runtimeid":
+		scope.runtimeIDs = append(scope.runtimeIDs, value)
+	}
+}
+
+// resolveColonyIDs returns the colonies referred to by the payload, both directly and via the processes, process
+// graphs and runtimes it refers to
+func (server *ColoniesServer) resolveColonyIDs(ctx context.Context, scope *delegationScope) ([]string, error) {
+	db := tracing.DatabaseWithContext(server.db, ctx)
+	colonyIDs := append([]string{}, scope.colonyIDs...)
+	for _, processID := range scope.processIDs {
+		process, err := db.GetProcessByID(processID)
+		if err != nil {
+			return nil, err
+		}
+		if process == nil {
+			return nil, errors.New("Failed to verify delegation, process with Id <" + processID + "> does not exist")
+		}
+		colonyIDs = append(colonyIDs, process.ProcessSpec.Conditions.ColonyID)
+	}
+
+	for _, processGraphID := range scope.processGraphIDs {
+		processGraph, err := db.GetProcessGraphByID(processGraphID)
+		if err != nil {
+			return nil, err
+		}
+		if processGraph == nil {
+			return nil, errors.New("Failed to verify delegation, process graph with Id <" + processGraphID + "> does not exist")
+		}
+		colonyIDs = append(colonyIDs, processGraph.ColonyID)
+	}
+
+	for _, runtimeID := range scope.runtimeIDs {
+		runtime, err := db.GetRuntimeByID(runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		if runtime == nil {
+			return nil, errors.New("Failed to verify delegation, runtime with Id <" + runtimeID + "> does not exist")
+		}
+		colonyIDs = append(colonyIDs, runtime.ColonyID)
+	}
+
+	return colonyIDs, nil
+}
+
+// verifyDelegation verifies the delegation chain of an RPC message signed by signerID and returns the Id of the
+// root issuer, on whose behalf the message is then handled. The payload must be within the scope of every
+// delegation in the chain.
+func (server *ColoniesServer) verifyDelegation(ctx context.Context, rpcMsg *rpc.RPCMsg, signerID string) (string, error) {
+	issuerID, err := server.validator.RequireDelegation(rpcMsg.Delegations, signerID)
+	if err != nil {
+		return "", err
+	}
+
+	scope, err := parseDelegationScope(rpcMsg.PayloadType, rpcMsg.DecodePayload())
+	if err != nil {
+		return "", err
+	}
+
+	colonyIDs, err := server.resolveColonyIDs(ctx, scope)
+	if err != nil {
+		return "", err
+	}
+	if len(colonyIDs) == 0 {
+		return "", errors.New("Payload type <" + rpcMsg.PayloadType + "> cannot be delegated, failed to determine colony")
+	}
+
+	for _, delegation := range rpcMsg.Delegations {
+		if !delegation.AllowsPayloadType(rpcMsg.PayloadType) {
+			return "", errors.New("Delegation does not allow payload type <" + rpcMsg.PayloadType + ">")
+		}
+
+		for _, colonyID := range colonyIDs {
+			if colonyID != delegation.ColonyID {
+				return "", errors.New("Delegation does not allow access to colony with Id <" + colonyID + ">")
+			}
+		}
+
+		if !delegation.IsRestricted() {
+			continue
+		}
+
+		if delegatedOpaquePayloadTypes[rpcMsg.PayloadType] {
+			return "", errors.New("Payload type <" + rpcMsg.PayloadType + "> cannot be sent using a delegation restricted to functions or runtime types")
+		}
+
+		if delegatedSpecPayloadTypes[rpcMsg.PayloadType] {
+			if len(scope.funcs) == 0 {
+				return "", errors.New("Failed to verify delegation, no process specs found")
+			}
+			for _, fn := range scope.funcs {
+				if !delegation.AllowsFunc(fn) {
+					return "", errors.New("Delegation does not allow function <" + fn + ">")
+				}
+			}
+			for _, runtimeType := range scope.runtimeTypes {
+				if !delegation.AllowsRuntimeType(runtimeType) {
+					return "", errors.New("Delegation does not allow runtime type <" + runtimeType + ">")
+				}
+			}
+		}
+	}
+
+	return issuerID, nil
+}
@@ -0,0 +1,264 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createDelegateClient(delegations []*core.Delegation) *client.ColoniesClient {
+	delegateClient := client.CreateColoniesClient(TESTHOST, TESTPORT, Insecure, SkipTLSVerify)
+	delegateClient.SetDelegations(delegations)
+
+	return delegateClient
+}
+
+func generateDelegateKey(t *testing.T) (string, string) {
+	c := crypto.CreateCrypto()
+	prvKey, err := c.GeneratePrivateKey()
+	assert.Nil(t, err)
+	id, err := c.GenerateID(prvKey)
+	assert.Nil(t, err)
+
+	return id, prvKey
+}
+
+func TestDelegatedSubmit(t *testing.T) {
+	env, client, server, serverPrvKey, done := setupTestEnv2(t)
+
+	delegateID, delegatePrvKey := generateDelegateKey(t)
+	delegation := core.CreateDelegation(delegateID, env.colonyID, []string{rpc.SubmitProcessSpecPayloadType}, []string{"test_func"}, []string{"test_runtime_type"}, time.Now().Add(time.Hour))
+	err := delegation.Sign(env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	delegateClient := createDelegateClient([]*core.Delegation{delegation})
+
+	addedProcess, err := delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), delegatePrvKey)
+	assert.Nil(t, err) // Should work
+
+	// The delegate key on its own is not a member of the colony
+	_, err = client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), delegatePrvKey)
+	assert.NotNil(t, err)
+
+	// Payload type not delegated
+	_, err = delegateClient.GetProcess(addedProcess.ID, delegatePrvKey)
+	assert.NotNil(t, err)
+
+	// Function not delegated
+	processSpec := utils.CreateTestProcessSpec(env.colonyID)
+	processSpec.Func = "rm"
+	_, err = delegateClient.SubmitProcessSpec(processSpec, delegatePrvKey)
+	assert.NotNil(t, err)
+
+	// Runtime type not delegated
+	_, err = delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpecWithType(env.colonyID, "other_runtime_type"), delegatePrvKey)
+	assert.NotNil(t, err)
+
+	// Signed with the wrong key
+	_, err = delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	// The delegated submission is recorded as made by the issuer and signed by the delegate
+	auditEntries, err := client.GetAuditLog("", 4, 1, serverPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, auditEntries, 1)
+	assert.Equal(t, rpc.SubmitProcessSpecPayloadType, auditEntries[0].PayloadType)
+	assert.Equal(t, env.runtimeID, auditEntries[0].CallerID)
+	assert.Equal(t, delegateID, auditEntries[0].SignerID)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDelegationChain(t *testing.T) {
+	env, _, server, _, done := setupTestEnv2(t)
+
+	delegate1ID, delegate1PrvKey := generateDelegateKey(t)
+	delegation1 := core.CreateDelegation(delegate1ID, env.colonyID, []string{rpc.SubmitProcessSpecPayloadType, rpc.GetProcessPayloadType}, nil, nil, time.Now().Add(time.Hour))
+	err := delegation1.Sign(env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	delegate2ID, delegate2PrvKey := generateDelegateKey(t)
+	delegation2 := core.CreateDelegation(delegate2ID, env.colonyID, []string{rpc.SubmitProcessSpecPayloadType}, nil, nil, time.Now().Add(time.Minute))
+	err = delegation2.Sign(delegate1PrvKey)
+	assert.Nil(t, err)
+
+	delegateClient := createDelegateClient([]*core.Delegation{delegation1, delegation2})
+	addedProcess, err := delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), delegate2PrvKey)
+	assert.Nil(t, err) // Should work
+
+	_, err = delegateClient.GetProcess(addedProcess.ID, delegate2PrvKey)
+	assert.NotNil(t, err) // Should not work, not delegated to delegate2
+
+	delegateClient = createDelegateClient([]*core.Delegation{delegation1})
+	processFromServer, err := delegateClient.GetProcess(addedProcess.ID, delegate1PrvKey)
+	assert.Nil(t, err) // Should work, colony resolved from the process
+	assert.Equal(t, addedProcess.ID, processFromServer.ID)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDelegationExpired(t *testing.T) {
+	env, _, server, _, done := setupTestEnv2(t)
+
+	delegateID, delegatePrvKey := generateDelegateKey(t)
+	delegation := core.CreateDelegation(delegateID, env.colonyID, []string{rpc.SubmitProcessSpecPayloadType}, nil, nil, time.Now().Add(-time.Minute))
+	err := delegation.Sign(env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	delegateClient := createDelegateClient([]*core.Delegation{delegation})
+	_, err = delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), delegatePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDelegationOtherColony(t *testing.T) {
+	env, client, server, serverPrvKey, done := setupTestEnv2(t)
+
+	colony, _, err := utils.CreateTestColonyWithKey()
+	assert.Nil(t, err)
+	_, err = client.AddColony(colony, serverPrvKey)
+	assert.Nil(t, err)
+
+	// The delegation itself is restricted to the colony of the runtime, but the payload targets another colony
+	delegateID, delegatePrvKey := generateDelegateKey(t)
+	delegation := core.CreateDelegation(delegateID, env.colonyID, []string{rpc.SubmitProcessSpecPayloadType}, nil, nil, time.Now().Add(time.Hour))
+	err = delegation.Sign(env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	delegateClient := createDelegateClient([]*core.Delegation{delegation})
+	_, err = delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpec(colony.ID), delegatePrvKey)
+	assert.NotNil(t, err)
+
+	// The runtime is not a member of the colony the delegation is restricted to
+	delegation = core.CreateDelegation(delegateID, colony.ID, []string{rpc.SubmitProcessSpecPayloadType}, nil, nil, time.Now().Add(time.Hour))
+	err = delegation.Sign(env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	delegateClient = createDelegateClient([]*core.Delegation{delegation})
+	_, err = delegateClient.SubmitProcessSpec(utils.CreateTestProcessSpec(colony.ID), delegatePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDelegationInjectedColonyID(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	process1, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), env.runtime1PrvKey)
+	assert.Nil(t, err)
+	process2, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony2ID), env.runtime2PrvKey)
+	assert.Nil(t, err)
+
+	delegateID, delegatePrvKey := generateDelegateKey(t)
+	delegation := core.CreateDelegation(delegateID, env.colony1ID, []string{rpc.GetProcessPayloadType}, nil, nil, time.Now().Add(time.Hour))
+	err = delegation.Sign(env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	getProcess := func(processID string) *rpc.RPCReplyMsg {
+		// The colonyid field is not part of the message, but was used to determine the colony of the payload
+		jsonString := `{"msgtype":"` + rpc.GetProcessPayloadType + `","processid":"` + processID + `","colonyid":"` + env.colony1ID + `"}`
+		rpcMsg, err := rpc.CreateDelegatedRPCMsg(rpc.GetProcessPayloadType, jsonString, delegatePrvKey, []*core.Delegation{delegation})
+		assert.Nil(t, err)
+		return postRPCMsg(t, rpcMsg)
+	}
+
+	// Should work
+	assert.False(t, getProcess(process1.ID).Error)
+
+	// Should not work
+	reply := getProcess(process2.ID)
+	assert.True(t, reply.Error)
+	assert.Contains(t, reply.DecodePayload(), "Delegation does not allow access to colony with Id <"+env.colony2ID+">")
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDelegationByColonyOwner(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	delegateID, delegatePrvKey := generateDelegateKey(t)
+	delegation := core.CreateDelegation(delegateID, env.colonyID, []string{rpc.AddRuntimePayloadType, rpc.ApproveRuntimePayloadType}, nil, nil, time.Now().Add(time.Hour))
+	err := delegation.Sign(env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	delegateClient := createDelegateClient([]*core.Delegation{delegation})
+	runtime := utils.CreateTestRuntime(env.colonyID)
+	_, err = delegateClient.AddRuntime(runtime, delegatePrvKey)
+	assert.Nil(t, err) // Should work
+
+	err = delegateClient.ApproveRuntime(runtime.ID, delegatePrvKey)
+	assert.Nil(t, err) // Should work
+
+	err = delegateClient.RejectRuntime(runtime.ID, delegatePrvKey)
+	assert.NotNil(t, err) // Should not work, not delegated
+
+	runtimeFromServer, err := client.GetRuntime(runtime.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.True(t, runtimeFromServer.IsApproved())
+
+	server.Shutdown()
+	<-done
+}
+
+func TestParseDelegationScope(t *testing.T) {
+	colonyID := core.GenerateRandomID()
+	workflowSpec := core.CreateWorkflowSpec(colonyID)
+	processSpec1 := utils.CreateTestProcessSpec("")
+	processSpec1.Func = "func1"
+	processSpec2 := utils.CreateTestProcessSpecWithType("", "other_runtime_type")
+	processSpec2.Func = "func2"
+	workflowSpec.AddProcessSpec(processSpec1)
+	workflowSpec.AddProcessSpec(processSpec2)
+
+	jsonString, err := rpc.CreateSubmitWorkflowSpecMsg(workflowSpec).ToJSON()
+	assert.Nil(t, err)
+
+	scope, err := parseDelegationScope(rpc.SubmitWorkflowSpecPayloadType, jsonString)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{colonyID}, scope.colonyIDs)
+	assert.ElementsMatch(t, []string{"func1", "func2"}, scope.funcs)
+	assert.ElementsMatch(t, []string{"test_runtime_type", "other_runtime_type"}, scope.runtimeTypes)
+
+	processID := core.GenerateRandomID()
+	jsonString, err = rpc.CreateCloseSuccessfulMsg(processID).ToJSON()
+	assert.Nil(t, err)
+
+	scope, err = parseDelegationScope(rpc.CloseSuccessfulPayloadType, jsonString)
+	assert.Nil(t, err)
+	assert.Len(t, scope.colonyIDs, 0)
+	assert.Equal(t, []string{processID}, scope.processIDs)
+
+	// Fields that are not part of the message are ignored
+	scope, err = parseDelegationScope(rpc.CloseSuccessfulPayloadType, `{"msgtype":"`+rpc.CloseSuccessfulPayloadType+`","processid":"`+processID+`","colonyid":"`+colonyID+`","other":{"colonyid":"`+colonyID+`"}}`)
+	assert.Nil(t, err)
+	assert.Len(t, scope.colonyIDs, 0)
+	assert.Equal(t, []string{processID}, scope.processIDs)
+
+	// The Id of a runtime being added does not refer to an existing runtime
+	runtime, _, err := utils.CreateTestRuntimeWithKey(colonyID)
+	assert.Nil(t, err)
+	jsonString, err = rpc.CreateAddRuntimeMsg(runtime).ToJSON()
+	assert.Nil(t, err)
+	scope, err = parseDelegationScope(rpc.AddRuntimePayloadType, jsonString)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{colonyID}, scope.colonyIDs)
+	assert.Len(t, scope.runtimeIDs, 0)
+
+	_, err = parseDelegationScope(rpc.CloseSuccessfulPayloadType, "invalid json")
+	assert.NotNil(t, err)
+
+	_, err = parseDelegationScope("unknown_payload_type", jsonString)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodes(t *testing.T) {
+	env, coloniesClient, server, _, done := setupTestEnv2(t)
+
+	_, err := coloniesClient.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.True(t, errors.Is(err, client.ErrNoWorkAvailable))
+	var rpcErr *client.RPCError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, 404, rpcErr.Status)
+
+	_, err = coloniesClient.GetProcess(core.GenerateRandomID(), env.runtimePrvKey)
+	assert.True(t, errors.Is(err, client.ErrNotFound))
+
+	_, err = coloniesClient.GetWaitingProcesses(env.colonyID, MAX_COUNT+1, env.runtimePrvKey)
+	assert.True(t, errors.Is(err, client.ErrValidationFailed))
+
+	err = coloniesClient.AddRuntimeRole(env.runtimeID, "invalid_role", env.colonyPrvKey)
+	assert.True(t, errors.Is(err, client.ErrValidationFailed))
+
+	err = coloniesClient.AddRuntimeRole(env.runtimeID, core.ROLE_VIEWER, env.colonyPrvKey)
+	assert.Nil(t, err)
+	err = coloniesClient.AddRuntimeRole(env.runtimeID, core.ROLE_VIEWER, env.colonyPrvKey)
+	assert.True(t, errors.Is(err, client.ErrConflict))
+
+	crypto := crypto.CreateCrypto()
+	invalidPrvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	_, err = coloniesClient.GetColonyByID(env.colonyID, invalidPrvKey)
+	assert.True(t, errors.Is(err, client.ErrForbidden))
+	assert.False(t, errors.Is(err, client.ErrNotFound))
+
+	server.Shutdown()
+	<-done
+}
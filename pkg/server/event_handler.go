@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strconv"
 	"sync"
@@ -13,7 +14,10 @@ import (
 
 type eventHandler struct {
 	listeners         map[string]map[string]chan *core.Process
+	watchers          map[string]map[string]*watcher
 	processIDs        map[string]string
+	runtimeIDs        map[string]string
+	revokedChans      map[string]chan struct{}
 	msgQueue          chan *message
 	idCounter         int
 	stopped           bool
@@ -23,6 +27,27 @@ type eventHandler struct {
 	stopRelayListener chan struct{}
 }
 
+var errRuntimeRevoked = errors.New("Runtime has been revoked")
+var errWatcherOverflow = errors.New("Too many unread events, the watcher has been removed")
+
+// The scopes of a watcher, a watcher receives every state change of the processes in a colony, in a process graph
+// or of a single process regardless of runtime type and state
+const WATCH_COLONY = "colony"
+const WATCH_PROCESSGRAPH = "processgraph"
+const WATCH_PROCESS = "process"
+
+type watcher struct {
+	processChan chan *core.Process
+	errChan     chan error
+	runtimeID   string
+}
+
+// relayRevocation is broadcasted to the other cluster nodes when a runtime is revoked, it is told apart from a
+// relayed process since a process has no revokedruntimeid field
+type relayRevocation struct {
+	RevokedRuntimeID string `json:"revokedruntimeid"`
+}
+
 type message struct {
 	stop    bool // Just for testing purposes
 	handler func(msg *message)
@@ -31,6 +56,7 @@ type message struct {
 
 type replyMessage struct {
 	processChan  chan *core.Process
+	revokedChan  chan struct{}
 	listenerID   string
 	allListeners int  // Just for testing purposes
 	listeners    int  // Just for testing purposes
@@ -41,7 +67,10 @@ type replyMessage struct {
 func createEventHandler(relayServer *cluster.RelayServer) *eventHandler {
 	handler := &eventHandler{}
 	handler.listeners = make(map[string]map[string]chan *core.Process)
+	handler.watchers = make(map[string]map[string]*watcher)
 	handler.processIDs = make(map[string]string)
+	handler.runtimeIDs = make(map[string]string)
+	handler.revokedChans = make(map[string]chan struct{})
 	handler.msgQueue = make(chan *message)
 	handler.relayServer = relayServer
 
@@ -65,6 +94,11 @@ func (handler *eventHandler) relayListener() {
 	for {
 		select {
 		case msg := <-handler.relayChan:
+			var revocation relayRevocation
+			if err := json.Unmarshal(msg, &revocation); err == nil && revocation.RevokedRuntimeID != "" {
+				handler.revokeNoRelay(revocation.RevokedRuntimeID)
+				continue
+			}
 			process, err := core.ConvertJSONToProcess(string(msg))
 			if err != nil {
 				log.WithFields(log.Fields{"Error": err}).Warning("relayListener received invalid process JSON")
@@ -103,7 +137,7 @@ func (handler *eventHandler) target(runtimeType string, state int) string {
 	return runtimeType + strconv.Itoa(state)
 }
 
-func (handler *eventHandler) register(runtimeType string, state int, processID string) (string, chan *core.Process) {
+func (handler *eventHandler) register(runtimeType string, state int, processID string, runtimeID string) (string, chan *core.Process, chan struct{}) {
 	t := handler.target(runtimeType, state)
 	if _, ok := handler.listeners[t]; !ok {
 		handler.listeners[t] = make(map[string]chan *core.Process)
@@ -115,24 +149,75 @@ func (handler *eventHandler) register(runtimeType string, state int, processID s
 	if processID != "" {
 		handler.processIDs[listenerID] = processID
 	}
+	revokedChan := make(chan struct{})
+	if runtimeID != "" {
+		handler.runtimeIDs[listenerID] = runtimeID
+		handler.revokedChans[listenerID] = revokedChan
+	}
 	handler.idCounter++
-	return listenerID, c
+	return listenerID, c, revokedChan
 }
 
 func (handler *eventHandler) unregister(runtimeType string, state int, listenerID string) {
-	t := handler.target(runtimeType, state)
-	if _, ok := handler.listeners[t]; ok {
-		delete(handler.listeners[t], listenerID)
-		delete(handler.processIDs, listenerID)
+	handler.unregisterTarget(handler.target(runtimeType, state), listenerID)
+}
+
+func (handler *eventHandler) unregisterTarget(t string, listenerID string) {
+	if _, ok := handler.listeners[t][listenerID]; !ok {
+		return
 	}
 
+	delete(handler.listeners[t], listenerID)
+	delete(handler.processIDs, listenerID)
+	delete(handler.runtimeIDs, listenerID)
+	delete(handler.revokedChans, listenerID)
+
 	if len(handler.listeners[t]) == 0 {
 		delete(handler.listeners, t)
 	}
 }
 
+func (handler *eventHandler) watchTarget(scope string, id string) string {
+	return scope + ":" + id
+}
+
+// unwatch removes a watcher, and returns false if it had already been removed
+func (handler *eventHandler) unwatch(t string, listenerID string) bool {
+	if _, ok := handler.watchers[t][listenerID]; !ok {
+		return false
+	}
+
+	delete(handler.watchers[t], listenerID)
+	if len(handler.watchers[t]) == 0 {
+		delete(handler.watchers, t)
+	}
+
+	return true
+}
+
+// notifyWatchers is called by the master worker. A watcher which has not read WATCH_BUFFER_SIZE events is removed
+// and receives errWatcherOverflow, since blocking here would delay the events of all other listeners.
+func (handler *eventHandler) notifyWatchers(process *core.Process) {
+	targets := []string{handler.watchTarget(WATCH_COLONY, process.ProcessSpec.Conditions.ColonyID), handler.watchTarget(WATCH_PROCESS, process.ID)}
+	if process.ProcessGraphID != "" {
+		targets = append(targets, handler.watchTarget(WATCH_PROCESSGRAPH, process.ProcessGraphID))
+	}
+
+	for _, t := range targets {
+		for listenerID, w := range handler.watchers[t] {
+			select {
+			case w.processChan <- process.Clone():
+			default:
+				w.errChan <- errWatcherOverflow
+				handler.unwatch(t, listenerID)
+			}
+		}
+	}
+}
+
 func (handler *eventHandler) sendSignal(process *core.Process) {
 	msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
+		handler.notifyWatchers(process)
 		t := handler.target(process.ProcessSpec.Conditions.RuntimeType, process.State)
 		if _, ok := handler.listeners[t]; ok {
 			for listenerID, c := range handler.listeners[t] {
@@ -154,26 +239,71 @@ func (handler *eventHandler) signalNoRelay(process *core.Process) {
 	handler.sendSignal(process)
 }
 
-func (handler *eventHandler) signal(process *core.Process) {
+func (handler *eventHandler) signal(ctx context.Context, process *core.Process) {
 	handler.sendSignal(process)
 
-	// broadcast the msg to the relayServer
-	go func() {
-		if handler.relayServer != nil {
-			jsonStr, err := process.ToJSON()
-			if err != nil {
-				log.WithFields(log.Fields{"Error": err}).Error("Failed to parse JSON in signal")
+	// broadcast the msg to the relayServer, the messages are queued so the order of the signals is kept
+	if handler.relayServer != nil {
+		jsonStr, err := process.ToJSON()
+		if err != nil {
+			log.WithFields(log.Fields{"Error": err}).Error("Failed to parse JSON in signal")
+			return
+		}
+		if err := handler.relayServer.Broadcast(ctx, []byte(jsonStr)); err != nil {
+			log.WithFields(log.Fields{"Error": err}).Warning("Failed to relay process")
+		}
+	}
+}
+
+// revokeNoRelay removes all listeners registered by the runtime and wakes them up with errRuntimeRevoked. The listeners
+// are removed before returning so that no more processes are sent to them.
+func (handler *eventHandler) revokeNoRelay(runtimeID string) {
+	msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
+		for listenerID, id := range handler.runtimeIDs {
+			if id != runtimeID {
+				continue
+			}
+			close(handler.revokedChans[listenerID])
+			for t := range handler.listeners {
+				handler.unregisterTarget(t, listenerID)
 			}
-			handler.relayServer.Broadcast([]byte(jsonStr))
 		}
-	}()
+		for t, watchers := range handler.watchers {
+			for listenerID, w := range watchers {
+				if w.runtimeID == runtimeID {
+					w.errChan <- errRuntimeRevoked
+					handler.unwatch(t, listenerID)
+				}
+			}
+		}
+		msg.reply <- replyMessage{}
+	}}
+
+	handler.msgQueue <- msg
+	<-msg.reply
 }
 
-func (handler *eventHandler) waitForProcess(runtimeType string, state int, processID string, ctx context.Context) (*core.Process, error) {
+func (handler *eventHandler) revoke(ctx context.Context, runtimeID string) {
+	handler.revokeNoRelay(runtimeID)
+
+	// broadcast the revocation to the relayServer
+	if handler.relayServer != nil {
+		jsonBytes, err := json.Marshal(relayRevocation{RevokedRuntimeID: runtimeID})
+		if err != nil {
+			log.WithFields(log.Fields{"Error": err}).Error("Failed to create JSON in revoke")
+			return
+		}
+		if err := handler.relayServer.Broadcast(ctx, jsonBytes); err != nil {
+			log.WithFields(log.Fields{"Error": err}).Warning("Failed to relay revocation")
+		}
+	}
+}
+
+func (handler *eventHandler) waitForProcess(runtimeType string, state int, processID string, runtimeID string, ctx context.Context) (*core.Process, error) {
 	// Register
 	msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
-		listenerID, c := handler.register(runtimeType, state, processID)
-		r := replyMessage{processChan: c, listenerID: listenerID}
+		listenerID, c, revokedChan := handler.register(runtimeType, state, processID, runtimeID)
+		r := replyMessage{processChan: c, revokedChan: revokedChan, listenerID: listenerID}
 		msg.reply <- r
 	}}
 	handler.msgQueue <- msg
@@ -193,17 +323,19 @@ func (handler *eventHandler) waitForProcess(runtimeType string, state int, proce
 		select {
 		case <-ctx.Done():
 			return nil, errors.New("timeout")
+		case <-r.revokedChan:
+			return nil, errRuntimeRevoked
 		case process := <-r.processChan:
 			return process, nil
 		}
 	}
 }
 
-func (handler *eventHandler) subscribe(runtimeType string, state int, processID string, ctx context.Context) (chan *core.Process, chan error) {
+func (handler *eventHandler) subscribe(runtimeType string, state int, processID string, runtimeID string, ctx context.Context) (chan *core.Process, chan error) {
 	// Register
 	msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
-		listenerID, c := handler.register(runtimeType, state, processID)
-		r := replyMessage{processChan: c, listenerID: listenerID}
+		listenerID, c, revokedChan := handler.register(runtimeType, state, processID, runtimeID)
+		r := replyMessage{processChan: c, revokedChan: revokedChan, listenerID: listenerID}
 		msg.reply <- r
 	}}
 	handler.msgQueue <- msg
@@ -225,6 +357,9 @@ func (handler *eventHandler) subscribe(runtimeType string, state int, processID
 				handler.msgQueue <- msg
 				errChan <- errors.New("timeout")
 				return
+			case <-r.revokedChan:
+				errChan <- errRuntimeRevoked
+				return
 			case process := <-r.processChan:
 				processChan <- process
 			}
@@ -234,6 +369,43 @@ func (handler *eventHandler) subscribe(runtimeType string, state int, processID
 	return processChan, errChan
 }
 
+// watch returns a channel receiving every state change of the processes in scope, e.g. WATCH_COLONY and a colony Id,
+// until ctx is cancelled. The error channel receives a single error when the watcher is removed, i.e. a timeout error
+// when ctx is cancelled, errRuntimeRevoked if the runtime is revoked, or errWatcherOverflow if the events are not read
+// fast enough.
+func (handler *eventHandler) watch(scope string, id string, runtimeID string, ctx context.Context) (chan *core.Process, chan error) {
+	t := handler.watchTarget(scope, id)
+	w := &watcher{processChan: make(chan *core.Process, WATCH_BUFFER_SIZE), errChan: make(chan error, 1), runtimeID: runtimeID}
+
+	// Register
+	msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
+		if _, ok := handler.watchers[t]; !ok {
+			handler.watchers[t] = make(map[string]*watcher)
+		}
+		listenerID := strconv.Itoa(handler.idCounter)
+		handler.watchers[t][listenerID] = w
+		handler.idCounter++
+		msg.reply <- replyMessage{listenerID: listenerID}
+	}}
+	handler.msgQueue <- msg
+
+	// Wait for the masterworker to execute the handler code
+	r := <-msg.reply
+
+	go func() {
+		<-ctx.Done()
+		// Unregister
+		msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
+			if handler.unwatch(t, r.listenerID) {
+				w.errChan <- errors.New("timeout")
+			}
+		}}
+		handler.msgQueue <- msg
+	}()
+
+	return w.processChan, w.errChan
+}
+
 func (handler *eventHandler) stop() {
 	handler.msgQueue <- &message{stop: true}
 	if handler.relayServer != nil {
@@ -257,6 +429,17 @@ func (handler *eventHandler) numberOfListeners(runtimeType string, state int) (i
 	return r.allListeners, r.listeners, r.processIDs
 }
 
+func (handler *eventHandler) numberOfWatchers(scope string, id string) int { // Just for testing purposes
+	msg := &message{reply: make(chan replyMessage, 1), handler: func(msg *message) {
+		msg.reply <- replyMessage{listeners: len(handler.watchers[handler.watchTarget(scope, id)])}
+	}}
+
+	handler.msgQueue <- msg
+	r := <-msg.reply
+
+	return r.listeners
+}
+
 func (handler *eventHandler) hasStopped() bool { // Just for testing purposes
 	handler.mutex.Lock()
 	defer handler.mutex.Unlock()
@@ -27,12 +27,12 @@ func TestEventHandler(t *testing.T) {
 	handler := createEventHandler(nil)
 	retChan := make(chan retValues)
 	go func() {
-		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan <- retValues{process: process, err: err}
 	}()
 	time.Sleep(100 * time.Millisecond)
 	go func() {
-		handler.signal(process)
+		handler.signal(context.Background(), process)
 	}()
 	retVal := <-retChan
 	assert.Nil(t, retVal.err) // OK
@@ -50,7 +50,7 @@ func TestEventHandlerTimeout(t *testing.T) {
 	handler := createEventHandler(nil)
 	retChan := make(chan retValues)
 	go func() {
-		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan <- retValues{process: process, err: err}
 	}()
 	retVal := <-retChan
@@ -83,7 +83,7 @@ func TestEventHandlerTimeout2(t *testing.T) {
 	handler := createEventHandler(nil)
 	retChan := make(chan retValues)
 	go func() {
-		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan <- retValues{process: process, err: err}
 	}()
 	time.Sleep(100 * time.Millisecond)
@@ -91,7 +91,7 @@ func TestEventHandlerTimeout2(t *testing.T) {
 		process := utils.CreateTestProcess(core.GenerateRandomID())
 		process.ProcessSpec.Conditions.RuntimeType = "test_runtime_type2" // NOTE: we are signaling to another target
 		process.State = core.WAITING
-		handler.signal(process)
+		handler.signal(context.Background(), process)
 	}()
 	retVal := <-retChan
 	assert.NotNil(t, retVal.err) // Not OK, will timeout
@@ -108,7 +108,7 @@ func TestEventHandlerTimeout3(t *testing.T) {
 	handler := createEventHandler(nil)
 	retChan := make(chan retValues)
 	go func() {
-		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan <- retValues{process: process, err: err}
 	}()
 	time.Sleep(100 * time.Millisecond)
@@ -116,7 +116,7 @@ func TestEventHandlerTimeout3(t *testing.T) {
 		process := utils.CreateTestProcess(core.GenerateRandomID())
 		process.ProcessSpec.Conditions.RuntimeType = "test_runtime_type"
 		process.State = core.RUNNING // NOTE: we are signaling to another target
-		handler.signal(process)
+		handler.signal(context.Background(), process)
 	}()
 	retVal := <-retChan
 	assert.NotNil(t, retVal.err) // Not OK, will timeout
@@ -142,19 +142,19 @@ func TestEventHandlerMany(t *testing.T) {
 		retChan := make(chan retValues)
 		retChans = append(retChans, retChan)
 		go func() {
-			process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+			process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 			retChan <- retValues{process: process, err: err}
 		}()
 	}
 
 	go func() {
-		handler.waitForProcess("test_runtime_type2", core.WAITING, "", ctx)
+		handler.waitForProcess("test_runtime_type2", core.WAITING, "", "", ctx)
 	}()
 
 	time.Sleep(1000 * time.Millisecond)
 
 	go func() {
-		handler.signal(process)
+		handler.signal(context.Background(), process)
 	}()
 
 	// Wait for listers
@@ -180,12 +180,12 @@ func TestEventHandlerUpdate(t *testing.T) {
 	handler := createEventHandler(nil)
 	retChan := make(chan retValues)
 	go func() {
-		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, process.ID, ctx)
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, process.ID, "", ctx)
 		retChan <- retValues{process: process, err: err}
 	}()
 	time.Sleep(100 * time.Millisecond)
 	go func() {
-		handler.signal(process)
+		handler.signal(context.Background(), process)
 	}()
 	retVal := <-retChan
 	assert.Nil(t, retVal.err) // OK
@@ -208,12 +208,12 @@ func TestEventHandlerUpdateTimeout(t *testing.T) {
 	retChan := make(chan retValues)
 	go func() {
 		// Wait for another process with random ID, i.e. we will time out
-		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, core.GenerateRandomID(), ctx)
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, core.GenerateRandomID(), "", ctx)
 		retChan <- retValues{process: process, err: err}
 	}()
 	time.Sleep(100 * time.Millisecond)
 	go func() {
-		handler.signal(process)
+		handler.signal(context.Background(), process)
 	}()
 	retVal := <-retChan
 	assert.NotNil(t, retVal.err) // OK
@@ -232,7 +232,7 @@ func TestEventHandlerSubscribe(t *testing.T) {
 
 	retChan := make(chan retValues)
 	handler := createEventHandler(nil)
-	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", ctx)
+	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", "", ctx)
 	go func() {
 		select {
 		case err := <-errChan:
@@ -242,7 +242,7 @@ func TestEventHandlerSubscribe(t *testing.T) {
 		}
 	}()
 	time.Sleep(100 * time.Millisecond)
-	handler.signal(process)
+	handler.signal(context.Background(), process)
 	retVal := <-retChan
 	assert.Nil(t, retVal.err)
 	assert.True(t, retVal.process.Equals(process))
@@ -256,7 +256,7 @@ func TestEventHandlerSubscribeCancel(t *testing.T) {
 
 	retChan := make(chan retValues)
 	handler := createEventHandler(nil)
-	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", ctx) // Subscribe to all processes
+	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", "", ctx) // Subscribe to all processes
 	go func() {
 		select {
 		case err := <-errChan:
@@ -280,7 +280,7 @@ func TestEventHandlerSubscribeTimeout(t *testing.T) {
 
 	retChan := make(chan retValues)
 	handler := createEventHandler(nil)
-	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", ctx) // Subscribe to all processes
+	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", "", ctx) // Subscribe to all processes
 	go func() {
 		select {
 		case err := <-errChan:
@@ -303,7 +303,7 @@ func TestEventHandlerSubscribeProcessID(t *testing.T) {
 
 	retChan := make(chan retValues)
 	handler := createEventHandler(nil)
-	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, process.ID, ctx)
+	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, process.ID, "", ctx)
 	go func() {
 		select {
 		case err := <-errChan:
@@ -313,7 +313,7 @@ func TestEventHandlerSubscribeProcessID(t *testing.T) {
 		}
 	}()
 	time.Sleep(100 * time.Millisecond)
-	handler.signal(process)
+	handler.signal(context.Background(), process)
 	retVal := <-retChan
 	assert.Nil(t, retVal.err)
 	assert.True(t, retVal.process.Equals(process))
@@ -328,7 +328,7 @@ func TestEventHandlerSubscribeProcessIDFailed(t *testing.T) {
 
 	retChan := make(chan retValues)
 	handler := createEventHandler(nil)
-	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, core.GenerateRandomID(), ctx)
+	processChan, errChan := handler.subscribe("test_runtime_type", core.WAITING, core.GenerateRandomID(), "", ctx)
 	go func() {
 		select {
 		case err := <-errChan:
@@ -338,7 +338,7 @@ func TestEventHandlerSubscribeProcessIDFailed(t *testing.T) {
 		}
 	}()
 	time.Sleep(100 * time.Millisecond)
-	handler.signal(process)
+	handler.signal(context.Background(), process)
 	retVal := <-retChan
 	assert.NotNil(t, retVal.err) // Not OK, will timeout
 }
@@ -365,7 +365,7 @@ func TestEventHandleRelayServer(t *testing.T) {
 	go func() {
 		ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
 		defer cancelCtx()
-		process, err := handler1.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler1.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan1 <- retValues{process: process, err: err}
 	}()
 
@@ -373,7 +373,7 @@ func TestEventHandleRelayServer(t *testing.T) {
 	go func() {
 		ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
 		defer cancelCtx()
-		process, err := handler2.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler2.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan2 <- retValues{process: process, err: err}
 	}()
 
@@ -381,7 +381,7 @@ func TestEventHandleRelayServer(t *testing.T) {
 	go func() {
 		ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
 		defer cancelCtx()
-		process, err := handler3.waitForProcess("test_runtime_type", core.WAITING, "", ctx)
+		process, err := handler3.waitForProcess("test_runtime_type", core.WAITING, "", "", ctx)
 		retChan3 <- retValues{process: process, err: err}
 	}()
 
@@ -391,7 +391,7 @@ func TestEventHandleRelayServer(t *testing.T) {
 	process.ProcessSpec.Conditions.RuntimeType = "test_runtime_type"
 	process.State = core.WAITING
 	go func() {
-		handler3.signal(process)
+		handler3.signal(context.Background(), process)
 	}()
 
 	retVal1 := <-retChan1
@@ -404,3 +404,193 @@ func TestEventHandleRelayServer(t *testing.T) {
 	assert.Nil(t, retVal3.err)
 	assert.True(t, process.Equals(retVal3.process))
 }
+
+func TestEventHandlerRevoke(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+	defer cancelCtx()
+
+	runtimeID := core.GenerateRandomID()
+	handler := createEventHandler(nil)
+	retChan1 := make(chan retValues)
+	go func() {
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", runtimeID, ctx)
+		retChan1 <- retValues{process: process, err: err}
+	}()
+
+	retChan2 := make(chan retValues)
+	go func() {
+		process, err := handler.waitForProcess("test_runtime_type", core.WAITING, "", core.GenerateRandomID(), ctx)
+		retChan2 <- retValues{process: process, err: err}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	handler.revoke(context.Background(), runtimeID)
+	retVal := <-retChan1
+	assert.Equal(t, errRuntimeRevoked, retVal.err)
+	assert.Nil(t, retVal.process)
+
+	// The other runtime is still waiting
+	_, listeners, _ := handler.numberOfListeners("test_runtime_type", core.WAITING)
+	assert.Equal(t, listeners, 1)
+
+	process := utils.CreateTestProcess(core.GenerateRandomID())
+	process.ProcessSpec.Conditions.RuntimeType = "test_runtime_type"
+	process.State = core.WAITING
+	handler.signal(context.Background(), process)
+	retVal = <-retChan2
+	assert.Nil(t, retVal.err)
+	assert.True(t, process.Equals(retVal.process))
+}
+
+func TestEventHandlerSubscribeRevoke(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+	defer cancelCtx()
+
+	runtimeID := core.GenerateRandomID()
+	handler := createEventHandler(nil)
+	_, errChan := handler.subscribe("test_runtime_type", core.WAITING, "", runtimeID, ctx)
+	handler.revoke(context.Background(), runtimeID)
+	err := <-errChan
+	assert.Equal(t, errRuntimeRevoked, err)
+
+	allListeners, listeners, processIDs := handler.numberOfListeners("test_runtime_type", core.WAITING)
+	assert.Equal(t, allListeners, 0)
+	assert.Equal(t, listeners, 0)
+	assert.Equal(t, processIDs, 0)
+}
+
+func TestEventHandleRelayServerRevoke(t *testing.T) {
+	node1 := cluster.Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24400, EtcdPeerPort: 23400, RelayPort: 25400, APIPort: 26400}
+	node2 := cluster.Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24500, EtcdPeerPort: 23500, RelayPort: 25500, APIPort: 26500}
+
+	config := cluster.Config{}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	handler1 := createEventHandler(cluster.CreateRelayServer(node1, config))
+	handler2 := createEventHandler(cluster.CreateRelayServer(node2, config))
+	defer handler1.stop()
+	defer handler2.stop()
+
+	runtimeID := core.GenerateRandomID()
+	retChan := make(chan retValues)
+	go func() {
+		ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+		defer cancelCtx()
+		process, err := handler2.waitForProcess("test_runtime_type", core.WAITING, "", runtimeID, ctx)
+		retChan <- retValues{process: process, err: err}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	// The runtime is revoked on node1, but waits for processes on node2
+	handler1.revoke(context.Background(), runtimeID)
+	retVal := <-retChan
+	assert.Equal(t, errRuntimeRevoked, retVal.err)
+}
+
+func TestEventHandlerWatch(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+	defer cancelCtx()
+
+	colonyID := core.GenerateRandomID()
+	processGraphID := core.GenerateRandomID()
+	process := utils.CreateTestProcess(colonyID)
+	process.ProcessGraphID = processGraphID
+	process.State = core.WAITING
+
+	handler := createEventHandler(nil)
+	colonyChan, _ := handler.watch(WATCH_COLONY, colonyID, "", ctx)
+	processGraphChan, _ := handler.watch(WATCH_PROCESSGRAPH, processGraphID, "", ctx)
+	processChan, _ := handler.watch(WATCH_PROCESS, process.ID, "", ctx)
+	otherChan, _ := handler.watch(WATCH_COLONY, core.GenerateRandomID(), "", ctx)
+
+	// Watchers receive all state changes regardless of state and runtime type
+	handler.signal(context.Background(), process.Clone())
+	process.State = core.RUNNING
+	handler.signal(context.Background(), process.Clone())
+
+	for _, c := range []chan *core.Process{colonyChan, processGraphChan, processChan} {
+		assert.Equal(t, core.WAITING, (<-c).State)
+		assert.Equal(t, core.RUNNING, (<-c).State)
+	}
+	assert.Len(t, otherChan, 0)
+}
+
+func TestEventHandlerWatchCancel(t *testing.T) {
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	colonyID := core.GenerateRandomID()
+	handler := createEventHandler(nil)
+	_, errChan := handler.watch(WATCH_COLONY, colonyID, "", ctx)
+	assert.Equal(t, 1, handler.numberOfWatchers(WATCH_COLONY, colonyID))
+
+	cancelCtx()
+	assert.NotNil(t, <-errChan)
+	assert.Equal(t, 0, handler.numberOfWatchers(WATCH_COLONY, colonyID))
+}
+
+func TestEventHandlerWatchOverflow(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+	defer cancelCtx()
+
+	colonyID := core.GenerateRandomID()
+	handler := createEventHandler(nil)
+	processChan, errChan := handler.watch(WATCH_COLONY, colonyID, "", ctx)
+
+	// The watcher never reads its events, which must not block the event handler
+	for i := 0; i < WATCH_BUFFER_SIZE+1; i++ {
+		handler.signal(context.Background(), utils.CreateTestProcess(colonyID))
+	}
+
+	assert.Equal(t, errWatcherOverflow, <-errChan)
+	assert.Len(t, processChan, WATCH_BUFFER_SIZE)
+	assert.Equal(t, 0, handler.numberOfWatchers(WATCH_COLONY, colonyID))
+}
+
+func TestEventHandlerWatchRevoke(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+	defer cancelCtx()
+
+	runtimeID := core.GenerateRandomID()
+	colonyID := core.GenerateRandomID()
+	handler := createEventHandler(nil)
+	_, errChan := handler.watch(WATCH_COLONY, colonyID, runtimeID, ctx)
+	_, errChan2 := handler.watch(WATCH_COLONY, colonyID, core.GenerateRandomID(), ctx)
+
+	handler.revoke(context.Background(), runtimeID)
+	assert.Equal(t, errRuntimeRevoked, <-errChan)
+	assert.Len(t, errChan2, 0)
+	assert.Equal(t, 1, handler.numberOfWatchers(WATCH_COLONY, colonyID))
+}
+
+func TestEventHandleRelayServerWatch(t *testing.T) {
+	node1 := cluster.Node{Name: "etcd1", Host: "localhost", EtcdClientPort: 24600, EtcdPeerPort: 23600, RelayPort: 25600, APIPort: 26600}
+	node2 := cluster.Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24700, EtcdPeerPort: 23700, RelayPort: 25700, APIPort: 26700}
+
+	config := cluster.Config{}
+	config.AddNode(node1)
+	config.AddNode(node2)
+
+	handler1 := createEventHandler(cluster.CreateRelayServer(node1, config))
+	handler2 := createEventHandler(cluster.CreateRelayServer(node2, config))
+	defer handler1.stop()
+	defer handler2.stop()
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 3000*time.Millisecond)
+	defer cancelCtx()
+
+	colonyID := core.GenerateRandomID()
+	processChan, _ := handler2.watch(WATCH_COLONY, colonyID, "", ctx)
+
+	// The process changes state on node1, but is watched on node2
+	process := utils.CreateTestProcess(colonyID)
+	handler1.signal(context.Background(), process)
+
+	select {
+	case relayedProcess := <-processChan:
+		assert.True(t, process.Equals(relayedProcess))
+	case <-ctx.Done():
+		assert.Fail(t, "Timeout waiting for relayed process")
+	}
+}
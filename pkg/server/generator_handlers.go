@@ -2,14 +2,23 @@ package server
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
 
+	"github.com/colonyos/colonies/internal/crypto"
 	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/rpc"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 )
 
+// hideWebhookSecret removes the webhook secret before a generator is sent to a client, since anyone knowing the
+// secret can pack the generator using its webhook
+func hideWebhookSecret(generator *core.Generator) {
+	generator.WebhookEnabled = generator.WebhookSecret != ""
+	generator.WebhookSecret = ""
+}
+
 func (server *ColoniesServer) handleAddGeneratorHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
 	msg, err := rpc.CreateAddGeneratorMsgFromJSON(jsonString)
 	if err != nil {
@@ -23,23 +32,23 @@ func (server *ColoniesServer) handleAddGeneratorHTTPRequest(c *gin.Context, reco
 		return
 	}
 	if msg.Generator == nil {
-		server.handleHTTPError(c, errors.New("Failed to add generator, msg.Generator is nil"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add generator, msg.Generator is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.Generator.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.Generator.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
 	// Validate that workflow is valid
-	_, err = core.ConvertJSONToWorkflowSpec(msg.Generator.WorkflowSpec)
+	err = server.validateWorkflow(c.Request.Context(), msg.Generator.ColonyID, msg.Generator.WorkflowSpec, msg.Generator.WorkflowTemplate)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 
 	msg.Generator.ID = core.GenerateRandomID()
-	addedGenerator, err := server.controller.addGenerator(msg.Generator)
+	addedGenerator, err := server.controller.addGenerator(c.Request.Context(), msg.Generator)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -48,6 +57,7 @@ func (server *ColoniesServer) handleAddGeneratorHTTPRequest(c *gin.Context, reco
 		return
 	}
 
+	hideWebhookSecret(addedGenerator)
 	jsonString, err = addedGenerator.ToJSON()
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
@@ -71,20 +81,21 @@ func (server *ColoniesServer) handleGetGeneratorHTTPRequest(c *gin.Context, reco
 		return
 	}
 
-	generator, err := server.controller.getGenerator(msg.GeneratorID)
+	generator, err := server.controller.getGenerator(c.Request.Context(), msg.GeneratorID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if generator == nil {
-		server.handleHTTPError(c, errors.New("Failed to get generator, generator is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get generator, generator not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, generator.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, generator.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
+	hideWebhookSecret(generator)
 	jsonString, err = generator.ToJSON()
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
@@ -108,12 +119,12 @@ func (server *ColoniesServer) handleGetGeneratorsHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	generators, err := server.controller.getGenerators(msg.ColonyID, msg.Count)
+	generators, err := server.controller.getGenerators(c.Request.Context(), msg.ColonyID, msg.Count)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -122,6 +133,10 @@ func (server *ColoniesServer) handleGetGeneratorsHTTPRequest(c *gin.Context, rec
 		return
 	}
 
+	for _, generator := range generators {
+		hideWebhookSecret(generator)
+	}
+
 	jsonString, err = core.ConvertGeneratorArrayToJSON(generators)
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
@@ -145,21 +160,21 @@ func (server *ColoniesServer) handlePackGeneratorHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	generator, err := server.controller.getGenerator(msg.GeneratorID)
+	generator, err := server.controller.getGenerator(c.Request.Context(), msg.GeneratorID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if generator == nil {
-		server.handleHTTPError(c, errors.New("Failed to increment generator, generator is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to increment generator, generator not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, generator.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, generator.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	err = server.controller.packGenerator(generator.ID, generator.ColonyID, msg.Arg)
+	err = server.controller.packGenerator(c.Request.Context(), generator.ID, generator.ColonyID, msg.Arg)
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
 	}
@@ -169,6 +184,48 @@ func (server *ColoniesServer) handlePackGeneratorHTTPRequest(c *gin.Context, rec
 	server.sendEmptyHTTPReply(c, payloadType)
 }
 
+func (server *ColoniesServer) handleGeneratorWebhookRequest(c *gin.Context) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MAX_WEBHOOK_BODY_SIZE))
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	generatorID := c.Param("generatorid")
+	generator, err := server.controller.getGenerator(c.Request.Context(), generatorID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if generator == nil {
+		server.handleHTTPError(c, errors.New("Failed to pack generator webhook, generator not found"), http.StatusNotFound)
+		return
+	}
+
+	if generator.WebhookSecret == "" {
+		server.handleHTTPError(c, errors.New("Failed to pack generator webhook, webhook not enabled for generator"), http.StatusForbidden)
+		return
+	}
+
+	// Also accept the header used by GitHub and compatible Git hosting services
+	signature := c.GetHeader(WEBHOOK_SIGNATURE_HEADER)
+	if signature == "" {
+		signature = c.GetHeader(GITHUB_WEBHOOK_SIGNATURE_HEADER)
+	}
+
+	if !crypto.VerifyHMAC(generator.WebhookSecret, body, signature) {
+		server.handleHTTPError(c, errors.New("Failed to pack generator webhook, invalid signature"), http.StatusForbidden)
+		return
+	}
+
+	err = server.controller.packGenerator(c.Request.Context(), generator.ID, generator.ColonyID, string(body))
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"GeneratorID": generator.ID, "Size": len(body)}).Debug("Adding webhook arg to generator")
+
+	server.sendEmptyHTTPReply(c, rpc.PackGeneratorPayloadType)
+}
+
 func (server *ColoniesServer) handleDeleteGeneratorHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
 	msg, err := rpc.CreateDeleteGeneratorMsgFromJSON(jsonString)
 	if err != nil {
@@ -182,21 +239,21 @@ func (server *ColoniesServer) handleDeleteGeneratorHTTPRequest(c *gin.Context, r
 		return
 	}
 
-	generator, err := server.controller.getGenerator(msg.GeneratorID)
+	generator, err := server.controller.getGenerator(c.Request.Context(), msg.GeneratorID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if generator == nil {
-		server.handleHTTPError(c, errors.New("Failed to delete generator, generator is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to delete generator, generator not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, generator.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, generator.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	err = server.controller.deleteGenerator(generator.ID)
+	err = server.controller.deleteGenerator(c.Request.Context(), generator.ID)
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
 	}
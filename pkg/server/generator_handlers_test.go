@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +41,141 @@ func TestAddGeneratorCounter(t *testing.T) {
 	<-done
 }
 
+func TestGeneratorArgs(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	colonyID := env.colonyID
+
+	workflowSpec := core.CreateWorkflowSpec(colonyID)
+	processSpec1 := utils.CreateTestProcessSpec(colonyID)
+	processSpec1.Name = "task1"
+	processSpec1.Args = []string{"--input", "{{generatorargs}}", "--first={{generatorargs[0]}}"}
+	processSpec2 := utils.CreateTestProcessSpec(colonyID)
+	processSpec2.Name = "task2"
+	processSpec2.Args = []string{"{{generatorargs}}"}
+	processSpec2.AddDependency("task1")
+	workflowSpec.AddProcessSpec(processSpec1)
+	workflowSpec.AddProcessSpec(processSpec2)
+	workflowSpecJSON, err := workflowSpec.ToJSON()
+	assert.Nil(t, err)
+
+	generator := core.CreateGenerator(colonyID, "test_genname", workflowSpecJSON, 2)
+	addedGenerator, err := client.AddGenerator(generator, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	err = client.PackGenerator(addedGenerator.ID, "arg1", env.runtimePrvKey)
+	assert.Nil(t, err)
+	err = client.PackGenerator(addedGenerator.ID, "arg2", env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	WaitForProcessGraphs(t, client, colonyID, addedGenerator.ID, env.runtimePrvKey, 1)
+
+	graphs, err := client.GetWaitingProcessGraphs(colonyID, 100, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, graphs, 1)
+	assert.Len(t, graphs[0].Roots, 1)
+
+	rootProcess, err := client.GetProcess(graphs[0].Roots[0], env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"--input", "arg1", "arg2", "--first=arg1"}, rootProcess.ProcessSpec.Args)
+
+	found := false
+	for _, attribute := range rootProcess.Attributes {
+		if attribute.Key == core.GENERATOR_ARGS_KEY && attribute.AttributeType == core.IN {
+			generatorArgs, err := core.ConvertJSONToGeneratorArgs(attribute.Value)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"arg1", "arg2"}, generatorArgs)
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	// Only root processes get the generator args
+	for _, processID := range graphs[0].ProcessIDs {
+		if processID != rootProcess.ID {
+			process, err := client.GetProcess(processID, env.runtimePrvKey)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"{{generatorargs}}"}, process.ProcessSpec.Args)
+		}
+	}
+
+	server.Shutdown()
+	<-done
+}
+
+func TestPackGeneratorWebhook(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	colonyID := env.colonyID
+
+	generator := utils.FakeGenerator(t, colonyID)
+	generator.Trigger = 2
+	generator.WebhookSecret = "test_secret"
+	addedGenerator, err := client.AddGenerator(generator, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	err = client.PackGeneratorWebhook(addedGenerator.ID, "{\"ref\":\"main\"}", "test_secret")
+	assert.Nil(t, err)
+	err = client.PackGeneratorWebhook(addedGenerator.ID, "{\"ref\":\"main\"}", "invalid_secret")
+	assert.NotNil(t, err)
+	err = client.PackGeneratorWebhook(core.GenerateRandomID(), "{\"ref\":\"main\"}", "test_secret")
+	assert.NotNil(t, err)
+	err = client.PackGeneratorWebhook(addedGenerator.ID, "{\"ref\":\"main\"}", "test_secret")
+	assert.Nil(t, err)
+
+	WaitForProcessGraphs(t, client, colonyID, addedGenerator.ID, env.runtimePrvKey, 1)
+
+	graphs, err := client.GetWaitingProcessGraphs(colonyID, 100, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, graphs, 1)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetGeneratorsWebhookSecret(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	generator := utils.FakeGenerator(t, env.colonyID)
+	generator.WebhookSecret = "test_secret"
+	addedGenerator, err := client.AddGenerator(generator, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Empty(t, addedGenerator.WebhookSecret)
+	assert.True(t, addedGenerator.WebhookEnabled)
+
+	generatorFromServer, err := client.GetGenerator(addedGenerator.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Empty(t, generatorFromServer.WebhookSecret)
+	assert.True(t, generatorFromServer.WebhookEnabled)
+
+	generatorsFromServer, err := client.GetGenerators(env.colonyID, 100, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, generatorsFromServer, 1)
+	assert.Empty(t, generatorsFromServer[0].WebhookSecret)
+	assert.True(t, generatorsFromServer[0].WebhookEnabled)
+
+	// The secret is still stored and used to verify webhook calls
+	err = client.PackGeneratorWebhook(addedGenerator.ID, "{\"ref\":\"main\"}", "test_secret")
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestPackGeneratorWebhookNotEnabled(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	generator := utils.FakeGenerator(t, env.colonyID)
+	addedGenerator, err := client.AddGenerator(generator, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	err = client.PackGeneratorWebhook(addedGenerator.ID, "{\"ref\":\"main\"}", "")
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
 func TestGetGenerator(t *testing.T) {
 	env, client, server, _, done := setupTestEnv2(t)
 
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/rpc/pb"
+	"github.com/colonyos/colonies/pkg/tracing"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// grpcService exposes the same operations as the HTTP API over gRPC. RPC messages are verified and handled by
+// the same handlers as messages sent to the /api endpoint.
+type grpcService struct {
+	pb.UnimplementedColoniesServer
+	server *ColoniesServer
+}
+
+// EnableGRPC makes the server also accept RPC messages over gRPC on the given port, using the same TLS
+// certificate as the HTTP API
+func (server *ColoniesServer) EnableGRPC(port int) error {
+	var opts []grpc.ServerOption
+	if server.tls {
+		creds, err := credentials.NewServerTLSFromFile(server.tlsCertPath, server.tlsPrivateKeyPath)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return err
+	}
+
+	server.grpcServer = grpc.NewServer(opts...)
+	pb.RegisterColoniesServer(server.grpcServer, &grpcService{server: server})
+
+	go func() {
+		err := server.grpcServer.Serve(listener)
+		if err != nil {
+			log.WithFields(log.Fields{"Error": err}).Error("gRPC server stopped")
+		}
+	}()
+
+	log.WithFields(log.Fields{"Port": port}).Info("Accepting RPC messages over gRPC")
+
+	return nil
+}
+
+func (service *grpcService) Call(ctx context.Context, req *pb.RPCRequest) (*pb.RPCReply, error) {
+	return service.server.handleGRPCRequest(ctx, req), nil
+}
+
+func (service *grpcService) Assign(stream pb.Colonies_AssignServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var reply *pb.RPCReply
+		if req.PayloadType != rpc.AssignProcessPayloadType {
+			reply = service.server.createGRPCErrorReply(errors.New("Failed to assign process, only "+rpc.AssignProcessPayloadType+" can be sent on an assign stream"), http.StatusBadRequest)
+		} else {
+			reply = service.server.handleGRPCRequest(stream.Context(), req)
+		}
+
+		err = stream.Send(reply)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (service *grpcService) Subscribe(req *pb.RPCRequest, stream pb.Colonies_SubscribeServer) error {
+	server := service.server
+
+	rpcMsg, err := convertGRPCRequestToRPCMsg(req)
+	if err != nil {
+		return stream.Send(server.createGRPCErrorReply(err, http.StatusBadRequest))
+	}
+
+	traceCtx, span := tracing.Start(tracing.Extract(stream.Context(), rpcMsg.TraceContext), "server."+rpcMsg.PayloadType, trace.SpanKindServer)
+	defer span.End()
+
+	recoveredID, _, err := server.verifyRPCMsg(traceCtx, rpcMsg)
+	if err != nil {
+		return stream.Send(server.createGRPCErrorReply(err, http.StatusForbidden))
+	}
+
+	var runtimeType string
+	var state int
+	var timeout int
+	var processID string
+	var scope string
+	var scopeID string
+	switch req.PayloadType {
+	case rpc.SubscribeProcessesPayloadType:
+		msg, err := rpc.CreateSubscribeProcessesMsgFromJSON(string(req.Payload))
+		if err != nil {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to processes, invalid JSON"), http.StatusBadRequest))
+		}
+		if msg.MsgType != req.PayloadType {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to processes, msg.msgType does not match payloadType"), http.StatusBadRequest))
+		}
+		runtimeType, state, timeout = msg.RuntimeType, msg.State, msg.Timeout
+	case rpc.SubscribeProcessPayloadType:
+		msg, err := rpc.CreateSubscribeProcessMsgFromJSON(string(req.Payload))
+		if err != nil {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to process, invalid JSON"), http.StatusBadRequest))
+		}
+		if msg.MsgType != req.PayloadType {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to process, msg.msgType does not match payloadType"), http.StatusBadRequest))
+		}
+		runtimeType, state, timeout, processID = msg.RuntimeType, msg.State, msg.Timeout, msg.ProcessID
+	case rpc.SubscribeColonyPayloadType:
+		msg, err := rpc.CreateSubscribeColonyMsgFromJSON(string(req.Payload))
+		if err != nil {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to colony, invalid JSON"), http.StatusBadRequest))
+		}
+		if msg.MsgType != req.PayloadType {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to colony, msg.msgType does not match payloadType"), http.StatusBadRequest))
+		}
+		scope, scopeID, timeout = WATCH_COLONY, msg.ColonyID, msg.Timeout
+	case rpc.SubscribeProcessGraphPayloadType:
+		msg, err := rpc.CreateSubscribeProcessGraphMsgFromJSON(string(req.Payload))
+		if err != nil {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to process graph, invalid JSON"), http.StatusBadRequest))
+		}
+		if msg.MsgType != req.PayloadType {
+			return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe to process graph, msg.msgType does not match payloadType"), http.StatusBadRequest))
+		}
+		scope, scopeID, timeout = WATCH_PROCESSGRAPH, msg.ProcessGraphID, msg.Timeout
+	default:
+		return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe, invalid payloadType"), http.StatusBadRequest))
+	}
+
+	runtime, err := server.controller.getRuntime(traceCtx, recoveredID)
+	if err != nil {
+		return stream.Send(server.createGRPCErrorReply(err, http.StatusForbidden))
+	}
+	if runtime == nil {
+		return stream.Send(server.createGRPCErrorReply(errors.New("Failed to subscribe, runtime not found"), http.StatusForbidden))
+	}
+
+	// Colony and process graph subscriptions are authorized against the colony they watch
+	colonyID := runtime.ColonyID
+	switch scope {
+	case WATCH_COLONY:
+		colonyID = scopeID
+	case WATCH_PROCESSGRAPH:
+		processGraph, err := server.controller.getProcessGraphByID(traceCtx, scopeID)
+		if err != nil {
+			return stream.Send(server.createGRPCErrorReply(err, http.StatusBadRequest))
+		}
+		if processGraph == nil {
+			return stream.Send(server.createGRPCErrorReply(core.WithErrorCode(errors.New("Failed to subscribe to process graph, process graph not found"), core.ErrorCodeNotFound), http.StatusBadRequest))
+		}
+		colonyID = processGraph.ColonyID
+	}
+
+	err = server.requireRuntimeRole(recoveredID, colonyID, req.PayloadType)
+	if err != nil {
+		return stream.Send(server.createGRPCErrorReply(err, http.StatusForbidden))
+	}
+
+	// gRPC subscriptions count against the same limits as WebSocket subscriptions
+	err = server.wsPolicy.acquire(recoveredID)
+	if err != nil {
+		return stream.Send(server.createGRPCErrorReply(err, http.StatusTooManyRequests))
+	}
+	defer server.wsPolicy.release(recoveredID)
+
+	ctx, cancelCtx := context.WithTimeout(traceCtx, time.Duration(timeout)*time.Second)
+	defer cancelCtx()
+
+	var processChan chan *core.Process
+	var errChan chan error
+	if scope != "" {
+		processChan, errChan = server.controller.eventHandler.watch(scope, scopeID, recoveredID, ctx)
+	} else {
+		processChan, errChan = server.controller.eventHandler.subscribe(runtimeType, state, processID, recoveredID, ctx)
+	}
+
+	if processID != "" {
+		process, err := server.controller.getProcess(ctx, processID)
+		if err != nil {
+			return stream.Send(server.createGRPCErrorReply(err, http.StatusBadRequest))
+		}
+		if process == nil {
+			return stream.Send(server.createGRPCErrorReply(core.WithErrorCode(errors.New("Failed to subscribe to process, process with id <"+processID+"> could not be found"), core.ErrorCodeNotFound), http.StatusBadRequest))
+		}
+
+		// Send an event immediately if the process already has the state the subscriber is looking for
+		if process.State == state {
+			err = server.sendGRPCProcess(stream, req.PayloadType, process)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case err := <-errChan:
+			switch err {
+			case errRuntimeRevoked:
+				return stream.Send(server.createGRPCErrorReply(err, http.StatusForbidden))
+			case errWatcherOverflow:
+				return stream.Send(server.createGRPCErrorReply(err, http.StatusTooManyRequests))
+			}
+			return nil
+		case process := <-processChan:
+			err := server.sendGRPCProcess(stream, req.PayloadType, process)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (server *ColoniesServer) sendGRPCProcess(stream pb.Colonies_SubscribeServer, payloadType string, process *core.Process) error {
+	jsonString, err := process.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&pb.RPCReply{PayloadType: payloadType, Payload: []byte(jsonString)})
+}
+
+// handleGRPCRequest handles an RPC message received over gRPC exactly as if it had been sent to the /api endpoint
+func (server *ColoniesServer) handleGRPCRequest(ctx context.Context, req *pb.RPCRequest) *pb.RPCReply {
+	rpcMsg, err := convertGRPCRequestToRPCMsg(req)
+	if err != nil {
+		return server.createGRPCErrorReply(err, http.StatusBadRequest)
+	}
+
+	writer := server.handleRPCMsgWithReplyWriter(ctx, rpcMsg, string(req.Payload))
+	payloadType, payload, isError := writer.result()
+
+	return &pb.RPCReply{PayloadType: payloadType, Payload: []byte(payload), Error: isError}
+}
+
+func (server *ColoniesServer) createGRPCErrorReply(err error, errorCode int) *pb.RPCReply {
+	failureJSON, err := core.CreateFailureFromError(errorCode, err).ToJSON()
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err}).Error("Failed to call failure.ToJSON()")
+	}
+
+	return &pb.RPCReply{PayloadType: rpc.ErrorPayloadType, Payload: []byte(failureJSON), Error: true}
+}
+
+// convertGRPCRequestToRPCMsg converts a gRPC request to an RPC message, the payload is kept as is since the signature
+// of a rpc.RawProtocolVersion message covers a hash of the payload as is
+func convertGRPCRequestToRPCMsg(req *pb.RPCRequest) (*rpc.RPCMsg, error) {
+	rpcMsg := &rpc.RPCMsg{Signature: req.Signature,
+		PayloadType: req.PayloadType,
+		Version:     int(req.Version),
+		Timestamp:   req.Timestamp,
+		Nonce:       req.Nonce}
+	rpcMsg.SetRawPayload(req.Payload)
+
+	if len(req.Delegations) > 0 {
+		delegations, err := core.ConvertJSONToDelegationArray(string(req.Delegations))
+		if err != nil {
+			return nil, errors.New("Invalid RPC message, invalid delegations")
+		}
+		rpcMsg.Delegations = delegations
+	}
+
+	if len(req.TraceContext) > 0 {
+		err := json.Unmarshal(req.TraceContext, &rpcMsg.TraceContext)
+		if err != nil {
+			return nil, errors.New("Invalid RPC message, invalid trace context")
+		}
+	}
+
+	return rpcMsg, nil
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/rpc/pb"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertGRPCRequestToRPCMsg(t *testing.T) {
+	crypto := crypto.CreateCrypto()
+	prvKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	id, err := crypto.GenerateID(prvKey)
+	assert.Nil(t, err)
+
+	jsonString := "{\"msgtype\":\"getcoloniesmsg\"}"
+	rpcMsg, err := rpc.CreateRPCMsg(rpc.GetColoniesPayloadType, jsonString, prvKey)
+	assert.Nil(t, err)
+
+	req := &pb.RPCRequest{Signature: rpcMsg.Signature,
+		PayloadType: rpcMsg.PayloadType,
+		Payload:     []byte(jsonString),
+		Version:     int32(rpcMsg.Version),
+		Timestamp:   rpcMsg.Timestamp,
+		Nonce:       rpcMsg.Nonce}
+
+	// The signature must still be valid after the conversion
+	rpcMsg2, err := convertGRPCRequestToRPCMsg(req)
+	assert.Nil(t, err)
+	assert.True(t, rpcMsg.Equals(rpcMsg2))
+	recoveredID, err := crypto.RecoverID(rpcMsg2.SignedData(), rpcMsg2.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, id, recoveredID)
+
+	// Version 3 messages are verified without base64 encoding the payload
+	rawRPCMsg, err := rpc.CreateRawRPCMsg(rpc.GetColoniesPayloadType, jsonString, prvKey, nil)
+	assert.Nil(t, err)
+	rawReq := &pb.RPCRequest{Signature: rawRPCMsg.Signature,
+		PayloadType: rawRPCMsg.PayloadType,
+		Payload:     []byte(jsonString),
+		Version:     int32(rawRPCMsg.Version),
+		Timestamp:   rawRPCMsg.Timestamp,
+		Nonce:       rawRPCMsg.Nonce}
+	rpcMsg2, err = convertGRPCRequestToRPCMsg(rawReq)
+	assert.Nil(t, err)
+	assert.Empty(t, rpcMsg2.Payload)
+	assert.Equal(t, jsonString, rpcMsg2.DecodePayload())
+	recoveredID, err = crypto.RecoverID(rpcMsg2.SignedData(), rpcMsg2.Signature)
+	assert.Nil(t, err)
+	assert.Equal(t, id, recoveredID)
+
+	req.TraceContext = []byte("{\"traceparent\":\"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01\"}")
+	rpcMsg2, err = convertGRPCRequestToRPCMsg(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", rpcMsg2.TraceContext["traceparent"])
+
+	req.TraceContext = []byte("invalid")
+	_, err = convertGRPCRequestToRPCMsg(req)
+	assert.NotNil(t, err)
+	req.TraceContext = nil
+
+	req.Delegations = []byte("invalid")
+	_, err = convertGRPCRequestToRPCMsg(req)
+	assert.NotNil(t, err)
+}
+
+func TestGRPCCall(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	err := server.EnableGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+	err = client.UseGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+
+	processSpec := utils.CreateTestProcessSpec(env.colonyID)
+	addedProcess, err := client.SubmitProcessSpec(processSpec, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	process, err := client.GetProcess(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, addedProcess.ID, process.ID)
+
+	// Errors are reported the same way as for the HTTP API
+	_, err = client.GetProcess(core.GenerateRandomID(), env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	crypto := crypto.CreateCrypto()
+	invalidPrivateKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	_, err = client.GetProcess(addedProcess.ID, invalidPrivateKey)
+	assert.NotNil(t, err) // Should not work
+
+	err = client.CloseGRPC()
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGRPCAssign(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	err := server.EnableGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+	err = client.UseGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+
+	assigner, err := client.CreateProcessAssigner(env.colonyID, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	_, err = assigner.Assign(-1, false)
+	assert.NotNil(t, err) // No processes submitted yet
+
+	processSpec1 := utils.CreateTestProcessSpec(env.colonyID)
+	addedProcess1, err := client.SubmitProcessSpec(processSpec1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+
+	processSpec2 := utils.CreateTestProcessSpec(env.colonyID)
+	addedProcess2, err := client.SubmitProcessSpec(processSpec2, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	assignedProcess, err := assigner.Assign(-1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, addedProcess1.ID, assignedProcess.ID)
+
+	assignedProcess, err = assigner.Assign(-1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, addedProcess2.ID, assignedProcess.ID)
+
+	err = assigner.Close()
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGRPCSubscribeProcesses(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	err := server.EnableGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+	err = client.UseGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+
+	subscription, err := client.SubscribeProcesses("test_runtime_type", core.WAITING, 100, env.runtime2PrvKey)
+	assert.Nil(t, err)
+
+	waitForProcess := make(chan error)
+	go func() {
+		select {
+		case <-subscription.ProcessChan:
+			waitForProcess <- nil
+		case err := <-subscription.ErrChan:
+			waitForProcess <- err
+		}
+	}()
+
+	time.Sleep(1 * time.Second)
+
+	processSpec := utils.CreateTestProcessSpec(env.colony1ID)
+	_, err = client.SubmitProcessSpec(processSpec, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	err = <-waitForProcess
+	assert.Nil(t, err)
+
+	err = subscription.Close()
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGRPCSubscribeColony(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	err := server.EnableGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+	err = client.UseGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+
+	subscription, err := client.SubscribeColony(env.colony1ID, 100, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	select {
+	case process := <-subscription.ProcessChan:
+		assert.Equal(t, addedProcess.ID, process.ID)
+	case err := <-subscription.ErrChan:
+		assert.Fail(t, err.Error())
+	}
+
+	err = subscription.Close()
+	assert.Nil(t, err)
+
+	// Should not work, runtime 2 is not a member of colony 1
+	subscription, err = client.SubscribeColony(env.colony1ID, 100, env.runtime2PrvKey)
+	assert.Nil(t, err)
+	err = <-subscription.ErrChan
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGRPCSubscribeProcessesSecurity(t *testing.T) {
+	_, client, server, _, done := setupTestEnv1(t)
+
+	err := server.EnableGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+	err = client.UseGRPC(TESTGRPCPORT)
+	assert.Nil(t, err)
+
+	crypto := crypto.CreateCrypto()
+	invalidPrivateKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+
+	subscription, err := client.SubscribeProcesses("test_runtime_type", core.WAITING, 100, invalidPrivateKey)
+	assert.Nil(t, err)
+
+	waitForProcess := make(chan error)
+	go func() {
+		select {
+		case <-subscription.ProcessChan:
+			waitForProcess <- nil
+		case err := <-subscription.ErrChan:
+			waitForProcess <- err
+		}
+	}()
+
+	err = <-waitForProcess
+	assert.NotNil(t, err) // Should not work, we should have got an error "runtime not found"
+
+	server.Shutdown()
+	<-done
+}
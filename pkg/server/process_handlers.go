@@ -12,30 +12,36 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func (server *ColoniesServer) handleSubmitProcessSpecHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+// prepareSubmitProcessSpec parses and authorizes a submitprocessspecmsg, on failure the HTTP status code to reply
+// with is returned together with the error
+func (server *ColoniesServer) prepareSubmitProcessSpec(recoveredID string, payloadType string, jsonString string) (*core.Process, int, error) {
 	msg, err := rpc.CreateSubmitProcessSpecMsgFromJSON(jsonString)
 	if err != nil {
-		if server.handleHTTPError(c, errors.New("Failed to submit process, invalid JSON"), http.StatusBadRequest) {
-			return
-		}
+		return nil, http.StatusBadRequest, errors.New("Failed to submit process, invalid JSON")
 	}
 
 	if msg.MsgType != payloadType {
-		server.handleHTTPError(c, errors.New("Failed to submit process spec, msg.MsgType does not match payloadType"), http.StatusBadRequest)
-		return
+		return nil, http.StatusBadRequest, errors.New("Failed to submit process spec, msg.MsgType does not match payloadType")
 	}
 	if msg.ProcessSpec == nil {
-		server.handleHTTPError(c, errors.New("Failed to submit process spec, msg.ProcessSpec is nil"), http.StatusBadRequest)
-		return
+		return nil, http.StatusBadRequest, core.WithErrorCode(errors.New("Failed to submit process spec, msg.ProcessSpec is nil"), core.ErrorCodeValidationFailed)
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ProcessSpec.Conditions.ColonyID, true)
-	if server.handleHTTPError(c, err, http.StatusForbidden) {
+	err = server.requireRuntimeRole(recoveredID, msg.ProcessSpec.Conditions.ColonyID, payloadType)
+	if err != nil {
+		return nil, http.StatusForbidden, err
+	}
+
+	return core.CreateProcess(msg.ProcessSpec), http.StatusOK, nil
+}
+
+func (server *ColoniesServer) handleSubmitProcessSpecHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	process, errorCode, err := server.prepareSubmitProcessSpec(recoveredID, payloadType, jsonString)
+	if server.handleHTTPError(c, err, errorCode) {
 		return
 	}
 
-	process := core.CreateProcess(msg.ProcessSpec)
-	addedProcess, err := server.controller.addProcess(process)
+	addedProcess, err := server.controller.addProcess(c.Request.Context(), process)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -67,25 +73,29 @@ func (server *ColoniesServer) handleAssignProcessHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
 	if msg.Timeout == 0 {
-		server.handleHTTPError(c, errors.New("Invalid timeout value, timeout cannot be zero"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Invalid timeout value, timeout cannot be zero"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
-	process, assignErr := server.controller.assignRuntime(recoveredID, msg.ColonyID, msg.Latest)
+	process, assignErr := server.controller.assignRuntime(c.Request.Context(), recoveredID, msg.ColonyID, msg.Latest)
 	if assignErr != nil {
 		if msg.Timeout > 0 {
 			ctx, cancelCtx := context.WithTimeout(context.Background(), time.Duration(msg.Timeout)*time.Second)
 			defer cancelCtx()
-			runtime, err := server.controller.getRuntime(recoveredID)
+			runtime, err := server.controller.getRuntime(c.Request.Context(), recoveredID)
 			if server.handleHTTPError(c, err, http.StatusBadRequest) {
 				return
 			}
+			if runtime == nil {
+				server.handleHTTPError(c, errors.New("Failed to assign process, runtime with id <"+recoveredID+"> could not be found"), http.StatusForbidden)
+				return
+			}
 
 			// Wait for a new process to be submitted to a ColoniesServer in the cluster
 			log.WithFields(log.Fields{
@@ -94,14 +104,18 @@ func (server *ColoniesServer) handleAssignProcessHTTPRequest(c *gin.Context, rec
 				"ColonyID":    msg.ColonyID,
 				"Timeout":     msg.Timeout}).
 				Debug("Waiting for processes")
-			server.controller.eventHandler.waitForProcess(runtime.RuntimeType, core.WAITING, "", ctx)
+			_, err = server.controller.eventHandler.waitForProcess(runtime.RuntimeType, core.WAITING, "", recoveredID, ctx)
+			if err == errRuntimeRevoked {
+				server.handleHTTPError(c, err, http.StatusForbidden)
+				return
+			}
 
 			// Try again! Note there is no guarantees we was assigned as process since multiple workers competes getting jobs
-			process, assignErr = server.controller.assignRuntime(recoveredID, msg.ColonyID, msg.Latest)
+			process, assignErr = server.controller.assignRuntime(c.Request.Context(), recoveredID, msg.ColonyID, msg.Latest)
 		}
 	}
 
-	if server.handleHTTPError(c, assignErr, http.StatusNotFound) {
+	if server.handleHTTPError(c, assignErr, http.StatusInternalServerError) {
 		log.WithFields(log.Fields{"RuntimeID": recoveredID, "ColonyID": msg.ColonyID}).Debug("No process can be assigned")
 		return
 	}
@@ -135,7 +149,7 @@ func (server *ColoniesServer) handleGetProcessHistHTTPRequest(c *gin.Context, re
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if err != nil {
 		err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
 		if server.handleHTTPError(c, err, http.StatusForbidden) {
@@ -143,7 +157,7 @@ func (server *ColoniesServer) handleGetProcessHistHTTPRequest(c *gin.Context, re
 		}
 	}
 
-	processes, err := server.controller.findProcessHistory(msg.ColonyID, msg.RuntimeID, msg.Seconds, msg.State)
+	processes, err := server.controller.findProcessHistory(c.Request.Context(), msg.ColonyID, msg.RuntimeID, msg.Seconds, msg.State)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -175,7 +189,7 @@ func (server *ColoniesServer) handleGetProcessesHTTPRequest(c *gin.Context, reco
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if err != nil {
 		err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
 		if server.handleHTTPError(c, err, http.StatusForbidden) {
@@ -187,7 +201,7 @@ func (server *ColoniesServer) handleGetProcessesHTTPRequest(c *gin.Context, reco
 
 	switch msg.State {
 	case core.WAITING:
-		processes, err := server.controller.findWaitingProcesses(msg.ColonyID, msg.Count)
+		processes, err := server.controller.findWaitingProcesses(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -197,7 +211,7 @@ func (server *ColoniesServer) handleGetProcessesHTTPRequest(c *gin.Context, reco
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	case core.RUNNING:
-		processes, err := server.controller.findRunningProcesses(msg.ColonyID, msg.Count)
+		processes, err := server.controller.findRunningProcesses(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -207,7 +221,7 @@ func (server *ColoniesServer) handleGetProcessesHTTPRequest(c *gin.Context, reco
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	case core.SUCCESS:
-		processes, err := server.controller.findSuccessfulProcesses(msg.ColonyID, msg.Count)
+		processes, err := server.controller.findSuccessfulProcesses(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -217,7 +231,7 @@ func (server *ColoniesServer) handleGetProcessesHTTPRequest(c *gin.Context, reco
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	case core.FAILED:
-		processes, err := server.controller.findFailedProcesses(msg.ColonyID, msg.Count)
+		processes, err := server.controller.findFailedProcesses(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -227,7 +241,7 @@ func (server *ColoniesServer) handleGetProcessesHTTPRequest(c *gin.Context, reco
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	default:
-		err := errors.New("Failed to get processes, invalid msg.State")
+		err := core.WithErrorCode(errors.New("Failed to get processes, invalid msg.State"), core.ErrorCodeValidationFailed)
 		server.handleHTTPError(c, err, http.StatusBadRequest)
 		return
 	}
@@ -246,16 +260,16 @@ func (server *ColoniesServer) handleGetProcessHTTPRequest(c *gin.Context, recove
 		return
 	}
 
-	process, err := server.controller.getProcess(msg.ProcessID)
+	process, err := server.controller.getProcess(c.Request.Context(), msg.ProcessID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if process == nil {
-		server.handleHTTPError(c, errors.New("Failed to get process, process is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get process, process not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, process.ProcessSpec.Conditions.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, process.ProcessSpec.Conditions.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -283,21 +297,21 @@ func (server *ColoniesServer) handleDeleteProcessHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	process, err := server.controller.getProcess(msg.ProcessID)
+	process, err := server.controller.getProcess(c.Request.Context(), msg.ProcessID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if process == nil {
-		server.handleHTTPError(c, errors.New("Failed to delete process, process is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to delete process, process not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, process.ProcessSpec.Conditions.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, process.ProcessSpec.Conditions.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	err = server.controller.deleteProcess(msg.ProcessID)
+	err = server.controller.deleteProcess(c.Request.Context(), msg.ProcessID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -325,7 +339,7 @@ func (server *ColoniesServer) handleDeleteAllProcessesHTTPRequest(c *gin.Context
 		return
 	}
 
-	err = server.controller.deleteAllProcesses(msg.ColonyID)
+	err = server.controller.deleteAllProcesses(c.Request.Context(), msg.ColonyID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -348,18 +362,16 @@ func (server *ColoniesServer) handleCloseSuccessfulHTTPRequest(c *gin.Context, r
 		return
 	}
 
-	process, err := server.controller.getProcess(msg.ProcessID)
+	process, err := server.controller.getProcess(c.Request.Context(), msg.ProcessID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if process == nil {
-		errmsg := "Failed to close process as successful, process is nil"
-		log.Error(errmsg)
-		server.handleHTTPError(c, errors.New(errmsg), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to close process as successful, process not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, process.ProcessSpec.Conditions.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, process.ProcessSpec.Conditions.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -372,7 +384,7 @@ func (server *ColoniesServer) handleCloseSuccessfulHTTPRequest(c *gin.Context, r
 		return
 	}
 
-	err = server.controller.closeSuccessful(process.ID)
+	err = server.controller.closeSuccessful(c.Request.Context(), process.ID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		log.WithFields(log.Fields{"Err": err}).Info("Failed to close process as successful")
 		server.handleHTTPError(c, err, http.StatusInternalServerError)
@@ -397,18 +409,16 @@ func (server *ColoniesServer) handleCloseFailedHTTPRequest(c *gin.Context, recov
 		return
 	}
 
-	process, err := server.controller.getProcess(msg.ProcessID)
+	process, err := server.controller.getProcess(c.Request.Context(), msg.ProcessID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if process == nil {
-		errmsg := "Failed to close process as failed, process is nil"
-		log.Error(errmsg)
-		server.handleHTTPError(c, errors.New(errmsg), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to close process as failed, process not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, process.ProcessSpec.Conditions.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, process.ProcessSpec.Conditions.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -421,7 +431,7 @@ func (server *ColoniesServer) handleCloseFailedHTTPRequest(c *gin.Context, recov
 		return
 	}
 
-	err = server.controller.closeFailed(process.ID, msg.ErrorMsg)
+	err = server.controller.closeFailed(c.Request.Context(), process.ID, msg.ErrorMsg)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		log.WithFields(log.Fields{"Err": err}).Info("Failed to close process as failed")
 		server.handleHTTPError(c, err, http.StatusInternalServerError)
@@ -24,16 +24,16 @@ func (server *ColoniesServer) handleSubmitWorkflowHTTPRequest(c *gin.Context, re
 	}
 
 	if msg.WorkflowSpec == nil {
-		server.handleHTTPError(c, errors.New("Failed to submit workflow, msg.WorkflowSpec is nil"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to submit workflow, msg.WorkflowSpec is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.WorkflowSpec.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.WorkflowSpec.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	processGraph, err := server.controller.submitWorkflowSpec(msg.WorkflowSpec)
+	processGraph, err := server.controller.submitWorkflowSpec(c.Request.Context(), msg.WorkflowSpec)
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
 	}
@@ -59,16 +59,16 @@ func (server *ColoniesServer) handleGetProcessGraphHTTPRequest(c *gin.Context, r
 		return
 	}
 
-	graph, err := server.controller.getProcessGraphByID(msg.ProcessGraphID)
+	graph, err := server.controller.getProcessGraphByID(c.Request.Context(), msg.ProcessGraphID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if graph == nil {
-		server.handleHTTPError(c, errors.New("Failed to get processgraph, graph is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get processgraph, graph not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, graph.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, graph.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -96,7 +96,7 @@ func (server *ColoniesServer) handleGetProcessGraphsHTTPRequest(c *gin.Context,
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, msg.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -105,7 +105,7 @@ func (server *ColoniesServer) handleGetProcessGraphsHTTPRequest(c *gin.Context,
 
 	switch msg.State {
 	case core.WAITING:
-		graphs, err := server.controller.findWaitingProcessGraphs(msg.ColonyID, msg.Count)
+		graphs, err := server.controller.findWaitingProcessGraphs(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -115,7 +115,7 @@ func (server *ColoniesServer) handleGetProcessGraphsHTTPRequest(c *gin.Context,
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	case core.RUNNING:
-		graphs, err := server.controller.findRunningProcessGraphs(msg.ColonyID, msg.Count)
+		graphs, err := server.controller.findRunningProcessGraphs(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -125,7 +125,7 @@ func (server *ColoniesServer) handleGetProcessGraphsHTTPRequest(c *gin.Context,
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	case core.SUCCESS:
-		graphs, err := server.controller.findSuccessfulProcessGraphs(msg.ColonyID, msg.Count)
+		graphs, err := server.controller.findSuccessfulProcessGraphs(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -135,7 +135,7 @@ func (server *ColoniesServer) handleGetProcessGraphsHTTPRequest(c *gin.Context,
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	case core.FAILED:
-		graphs, err := server.controller.findFailedProcessGraphs(msg.ColonyID, msg.Count)
+		graphs, err := server.controller.findFailedProcessGraphs(c.Request.Context(), msg.ColonyID, msg.Count)
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
@@ -145,7 +145,7 @@ func (server *ColoniesServer) handleGetProcessGraphsHTTPRequest(c *gin.Context,
 		}
 		server.sendHTTPReply(c, payloadType, jsonString)
 	default:
-		err := errors.New("invalid msg.State")
+		err := core.WithErrorCode(errors.New("invalid msg.State"), core.ErrorCodeValidationFailed)
 		server.handleHTTPError(c, err, http.StatusBadRequest)
 		return
 	}
@@ -164,21 +164,21 @@ func (server *ColoniesServer) handleDeleteProcessGraphHTTPRequest(c *gin.Context
 		return
 	}
 
-	graph, err := server.controller.getProcessGraphByID(msg.ProcessGraphID)
+	graph, err := server.controller.getProcessGraphByID(c.Request.Context(), msg.ProcessGraphID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if graph == nil {
-		server.handleHTTPError(c, errors.New("Failed to delete processgraph, graph is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to delete processgraph, graph not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, graph.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, graph.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
 
-	err = server.controller.deleteProcessGraph(msg.ProcessGraphID)
+	err = server.controller.deleteProcessGraph(c.Request.Context(), msg.ProcessGraphID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -206,7 +206,7 @@ func (server *ColoniesServer) handleDeleteAllProcessGraphsHTTPRequest(c *gin.Con
 		return
 	}
 
-	err = server.controller.deleteAllProcessGraphs(msg.ColonyID)
+	err = server.controller.deleteAllProcessGraphs(c.Request.Context(), msg.ColonyID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// replyWriter receives the reply of an RPC handler when the RPC message was not received on the /api endpoint,
+// i.e. over gRPC or as part of a batch. Replies are passed to it as is by sendHTTPReply and handleHTTPError,
+// instead of being encoded as JSON HTTP responses.
+type replyWriter struct {
+	header      http.Header
+	status      int
+	body        []byte
+	replied     bool
+	payloadType string
+	payload     string
+	isError     bool
+}
+
+func createReplyWriter() *replyWriter {
+	return &replyWriter{header: make(http.Header), status: http.StatusOK}
+}
+
+// handleRPCMsgWithReplyWriter handles an RPC message exactly as if it had been sent to the /api endpoint
+func (server *ColoniesServer) handleRPCMsgWithReplyWriter(ctx context.Context, rpcMsg *rpc.RPCMsg, jsonString string) *replyWriter {
+	writer := createReplyWriter()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "/api", nil)
+	if err != nil {
+		writer.setErrorReply(err, http.StatusInternalServerError)
+		return writer
+	}
+
+	c := &gin.Context{Request: request, Writer: writer}
+	server.handleRPCMsg(c, rpcMsg, jsonString)
+
+	return writer
+}
+
+func (writer *replyWriter) setReply(status int, payloadType string, jsonString string, isError bool) {
+	writer.status = status
+	writer.replied = true
+	writer.payloadType = payloadType
+	writer.payload = jsonString
+	writer.isError = isError
+}
+
+func (writer *replyWriter) setErrorReply(err error, errorCode int) {
+	failure := core.CreateFailureFromError(errorCode, err)
+	failureJSON, err := failure.ToJSON()
+	if err != nil {
+		log.WithFields(log.Fields{"Error": err}).Error("Failed to call failure.ToJSON()")
+	}
+
+	writer.setReply(failure.Status, rpc.ErrorPayloadType, failureJSON, true)
+}
+
+// result returns the payload type and the decoded payload of the reply, and if the reply is an error
+func (writer *replyWriter) result() (string, string, bool) {
+	if writer.replied {
+		return writer.payloadType, writer.payload, writer.isError
+	}
+
+	// The handler wrote a JSON encoded reply
+	rpcReplyMsg, err := rpc.CreateRPCReplyMsgFromJSON(string(writer.body))
+	if err != nil || rpcReplyMsg == nil {
+		return rpc.ErrorPayloadType, "", true
+	}
+
+	return rpcReplyMsg.PayloadType, rpcReplyMsg.DecodePayload(), rpcReplyMsg.Error
+}
+
+func (writer *replyWriter) Header() http.Header {
+	return writer.header
+}
+
+func (writer *replyWriter) Write(data []byte) (int, error) {
+	writer.body = append(writer.body, data...)
+	return len(data), nil
+}
+
+func (writer *replyWriter) WriteString(s string) (int, error) {
+	return writer.Write([]byte(s))
+}
+
+func (writer *replyWriter) WriteHeader(status int) {
+	writer.status = status
+}
+
+func (writer *replyWriter) WriteHeaderNow() {}
+
+func (writer *replyWriter) Status() int {
+	return writer.status
+}
+
+func (writer *replyWriter) Size() int {
+	return len(writer.body)
+}
+
+func (writer *replyWriter) Written() bool {
+	return writer.replied || len(writer.body) > 0
+}
+
+func (writer *replyWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("Replies to RPC messages cannot be hijacked")
+}
+
+func (writer *replyWriter) Flush() {}
+
+func (writer *replyWriter) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func (writer *replyWriter) Pusher() http.Pusher {
+	return nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplyWriter(t *testing.T) {
+	writer := createReplyWriter()
+	assert.Equal(t, 200, writer.Status())
+	assert.False(t, writer.Written())
+
+	writer.setReply(200, rpc.AddColonyPayloadType, "{}", false)
+	assert.True(t, writer.Written())
+	payloadType, payload, isError := writer.result()
+	assert.Equal(t, rpc.AddColonyPayloadType, payloadType)
+	assert.Equal(t, "{}", payload)
+	assert.False(t, isError)
+
+	writer = createReplyWriter()
+	writer.setErrorReply(errors.New("test error"), 403)
+	assert.Equal(t, 403, writer.Status())
+	payloadType, payload, isError = writer.result()
+	assert.Equal(t, rpc.ErrorPayloadType, payloadType)
+	assert.True(t, isError)
+	failure, err := core.ConvertJSONToFailure(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "test error", failure.Message)
+	assert.Equal(t, core.ErrorCodeForbidden, failure.Code)
+
+	// The status follows the error code attached to the error
+	writer = createReplyWriter()
+	writer.setErrorReply(core.WithErrorCode(errors.New("test error"), core.ErrorCodeNoWorkAvailable), 500)
+	assert.Equal(t, 404, writer.Status())
+	_, payload, _ = writer.result()
+	failure, err = core.ConvertJSONToFailure(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, 404, failure.Status)
+	assert.Equal(t, core.ErrorCodeNoWorkAvailable, failure.Code)
+
+	// Replies written as JSON are also accepted
+	writer = createReplyWriter()
+	rpcReplyMsg, err := rpc.CreateRPCReplyMsg(rpc.AddColonyPayloadType, "{\"test\":1}")
+	assert.Nil(t, err)
+	jsonString, err := rpcReplyMsg.ToJSON()
+	assert.Nil(t, err)
+	_, err = writer.WriteString(jsonString)
+	assert.Nil(t, err)
+	payloadType, payload, isError = writer.result()
+	assert.Equal(t, rpc.AddColonyPayloadType, payloadType)
+	assert.Equal(t, "{\"test\":1}", payload)
+	assert.False(t, isError)
+}
+
+func TestHandleRPCMsgWithReplyWriter(t *testing.T) {
+	server := &ColoniesServer{}
+
+	jsonString, err := rpc.CreateVersionMsg("", "").ToJSON()
+	assert.Nil(t, err)
+	rpcMsg, err := rpc.CreateInsecureRPCMsg(rpc.VersionPayloadType, jsonString)
+	assert.Nil(t, err)
+
+	writer := server.handleRPCMsgWithReplyWriter(context.Background(), rpcMsg, rpcMsg.DecodePayload())
+	payloadType, _, isError := writer.result()
+	assert.Equal(t, rpc.VersionPayloadType, payloadType)
+	assert.False(t, isError)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+)
+
+var allRoles = []string{core.ROLE_VIEWER, core.ROLE_SUBMITTER, core.ROLE_EXECUTOR, core.ROLE_OPERATOR}
+var submitterRoles = []string{core.ROLE_SUBMITTER, core.ROLE_OPERATOR}
+var executorRoles = []string{core.ROLE_EXECUTOR, core.ROLE_OPERATOR}
+var operatorRoles = []string{core.ROLE_OPERATOR}
+
+// The roles allowed to send a payload type, runtimes without any roles are allowed to send all payload types
+var payloadTypeRoles = map[string][]string{
+	// Read-only payload types
+	rpc.GetColonyPayloadType:                   allRoles,
+	rpc.GetColonyStatisticsPayloadType:         allRoles,
+	rpc.GetRuntimePayloadType:                  allRoles,
+	rpc.GetProcessPayloadType:                  allRoles,
+	rpc.GetProcessesPayloadType:                allRoles,
+	rpc.GetProcessHistPayloadType:              allRoles,
+	rpc.GetAttributePayloadType:                allRoles,
+	rpc.GetProcessGraphPayloadType:             allRoles,
+	rpc.GetProcessGraphsPayloadType:            allRoles,
+	rpc.GetGeneratorPayloadType:                allRoles,
+	rpc.GetGeneratorsPayloadType:               allRoles,
+	rpc.GetCronPayloadType:                     allRoles,
+	rpc.GetCronsPayloadType:                    allRoles,
+	rpc.GetWorkflowTemplatePayloadType:         allRoles,
+	rpc.GetWorkflowTemplatesPayloadType:        allRoles,
+	rpc.GetWorkflowTemplateVersionsPayloadType: allRoles,
+	rpc.SubscribeProcessPayloadType:            allRoles,
+	rpc.SubscribeProcessesPayloadType:          allRoles,
+	rpc.SubscribeColonyPayloadType:             allRoles,
+	rpc.SubscribeProcessGraphPayloadType:       allRoles,
+	rpc.StreamEventsPayloadType:                allRoles,
+
+	// Submitting work
+	rpc.SubmitProcessSpecPayloadType:      submitterRoles,
+	rpc.SubmitWorkflowSpecPayloadType:     submitterRoles,
+	rpc.SubmitWorkflowTemplatePayloadType: submitterRoles,
+	rpc.PackGeneratorPayloadType:          submitterRoles,
+
+	// Executing processes
+	rpc.AssignProcessPayloadType:     executorRoles,
+	rpc.CloseSuccessfulPayloadType:   executorRoles,
+	rpc.CloseFailedPayloadType:       executorRoles,
+	rpc.AddAttributePayloadType:      executorRoles,
+	rpc.GetProcessSecretsPayloadType: executorRoles,
+
+	// Managing the colony
+	rpc.DeleteProcessPayloadType:          operatorRoles,
+	rpc.DeleteProcessGraphPayloadType:     operatorRoles,
+	rpc.AddGeneratorPayloadType:           operatorRoles,
+	rpc.DeleteGeneratorPayloadType:        operatorRoles,
+	rpc.AddCronPayloadType:                operatorRoles,
+	rpc.DeleteCronPayloadType:             operatorRoles,
+	rpc.RunCronPayloadType:                operatorRoles,
+	rpc.AddWorkflowTemplatePayloadType:    operatorRoles,
+	rpc.DeleteWorkflowTemplatePayloadType: operatorRoles,
+}
+
+// Requires that recoveredID is an approved runtime member of the colony and has a role allowed to send the payload type
+func (server *ColoniesServer) requireRuntimeRole(recoveredID string, colonyID string, payloadType string) error {
+	roles, ok := payloadTypeRoles[payloadType]
+	if !ok {
+		roles = operatorRoles
+	}
+
+	return server.validator.RequireRuntimeRole(recoveredID, colonyID, roles)
+}
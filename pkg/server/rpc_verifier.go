@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/tracing"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetMinRPCVersion sets the lowest RPC protocol version accepted by the server, setting it to rpc.ProtocolVersion
+// rejects legacy clients that do not sign a timestamp and a nonce
+func (server *ColoniesServer) SetMinRPCVersion(version int) {
+	server.minRPCVersion = version
+}
+
+// SetMaxClockSkew sets how much the timestamp of a signed RPC message may differ from the server clock
+func (server *ColoniesServer) SetMaxClockSkew(maxClockSkew time.Duration) {
+	server.maxClockSkew = maxClockSkew
+}
+
+// verifyRPCMsg recovers the Id of the sender of an RPC message. Messages of protocol version 2 or later are
+// rejected if their timestamp is outside the clock-skew window or if their nonce has already been used. Nonces
+// are stored in the database, which means that a message cannot be replayed against another cluster node either.
+// The first Id returned is the Id the message is authorized as and the second is the Id of the signer, they differ
+// only if the message carries a delegation chain, in which case the first Id is the Id of the root issuer.
+func (server *ColoniesServer) verifyRPCMsg(ctx context.Context, rpcMsg *rpc.RPCMsg) (string, string, error) {
+	version := rpcMsg.ProtocolVersion()
+	if version < server.minRPCVersion {
+		return "", "", errors.New("RPC protocol version <" + strconv.Itoa(version) + "> is no longer supported, minimum version is <" + strconv.Itoa(server.minRPCVersion) + ">")
+	}
+	if version > rpc.RawProtocolVersion {
+		return "", "", errors.New("RPC protocol version <" + strconv.Itoa(version) + "> is not supported")
+	}
+
+	if version == rpc.LegacyProtocolVersion {
+		if len(rpcMsg.Delegations) > 0 {
+			return "", "", errors.New("Invalid RPC message, delegations require RPC protocol version <" + strconv.Itoa(rpc.ProtocolVersion) + ">")
+		}
+		log.WithFields(log.Fields{"PayloadType": rpcMsg.PayloadType}).Debug("Received legacy RPC message without replay protection")
+		signerID, err := server.parseSignature(rpcMsg.SignedData(), rpcMsg.Signature)
+		if err != nil {
+			return "", "", err
+		}
+		return signerID, signerID, nil
+	}
+
+	if rpcMsg.Nonce == "" || len(rpcMsg.Nonce) > MAX_NONCE_LENGTH {
+		return "", "", errors.New("Invalid RPC message, invalid nonce")
+	}
+
+	skew := time.Since(rpcMsg.Time())
+	if skew > server.maxClockSkew || skew < -server.maxClockSkew {
+		return "", "", errors.New("Invalid RPC message, timestamp is outside the allowed clock skew of " + server.maxClockSkew.String())
+	}
+
+	signerID, err := server.parseSignature(rpcMsg.SignedData(), rpcMsg.Signature)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The nonce only needs to be remembered as long as the timestamp is accepted
+	added, err := tracing.DatabaseWithContext(server.db, ctx).AddNonce(rpcMsg.Nonce, rpcMsg.Time().Add(server.maxClockSkew))
+	if err != nil {
+		return "", "", err
+	}
+	if !added {
+		log.WithFields(log.Fields{"PayloadType": rpcMsg.PayloadType, "SignerID": signerID}).Warning("Rejected replayed RPC message")
+		return "", "", errors.New("Invalid RPC message, nonce has already been used")
+	}
+
+	if len(rpcMsg.Delegations) > 0 {
+		issuerID, err := server.verifyDelegation(ctx, rpcMsg, signerID)
+		if err != nil {
+			return "", "", err
+		}
+		return issuerID, signerID, nil
+	}
+
+	return signerID, signerID, nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"crypto/tls"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func postRPCMsg(t *testing.T, rpcMsg *rpc.RPCMsg) *rpc.RPCReplyMsg {
+	jsonString, err := rpcMsg.ToJSON()
+	assert.Nil(t, err)
+
+	restyClient := resty.New()
+	restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true})
+	resp, err := restyClient.R().SetBody(jsonString).Post("https://" + TESTHOST + ":" + strconv.Itoa(TESTPORT) + "/api")
+	assert.Nil(t, err)
+
+	rpcReplyMsg, err := rpc.CreateRPCReplyMsgFromJSON(string(resp.Body()))
+	assert.Nil(t, err)
+
+	return rpcReplyMsg
+}
+
+func createGetColonyRPCMsg(t *testing.T, env *testEnv2) *rpc.RPCMsg {
+	msg := rpc.CreateGetColonyMsg(env.colonyID)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+	rpcMsg, err := rpc.CreateRPCMsg(rpc.GetColonyPayloadType, jsonString, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	return rpcMsg
+}
+
+func TestRPCMsgReplay(t *testing.T) {
+	env, _, server, _, done := setupTestEnv2(t)
+
+	rpcMsg := createGetColonyRPCMsg(t, env)
+	assert.False(t, postRPCMsg(t, rpcMsg).Error)
+	assert.True(t, postRPCMsg(t, rpcMsg).Error) // Replayed
+
+	server.Shutdown()
+	<-done
+}
+
+func TestRPCMsgClockSkew(t *testing.T) {
+	env, _, server, _, done := setupTestEnv2(t)
+
+	server.SetMaxClockSkew(time.Second)
+	rpcMsg := createGetColonyRPCMsg(t, env)
+	time.Sleep(2 * time.Second)
+	assert.True(t, postRPCMsg(t, rpcMsg).Error) // Too old
+
+	server.Shutdown()
+	<-done
+}
+
+func TestRPCMsgMinVersion(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	msg := rpc.CreateGetColonyMsg(env.colonyID)
+	jsonString, err := msg.ToJSON()
+	assert.Nil(t, err)
+
+	legacyRPCMsg, err := rpc.CreateInsecureRPCMsg(rpc.GetColonyPayloadType, jsonString)
+	assert.Nil(t, err)
+	legacyRPCMsg.Signature, err = server.crypto.GenerateSignature(legacyRPCMsg.SignedData(), env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, rpc.LegacyProtocolVersion, legacyRPCMsg.ProtocolVersion())
+
+	assert.False(t, postRPCMsg(t, legacyRPCMsg).Error)
+
+	server.SetMinRPCVersion(rpc.ProtocolVersion)
+	assert.True(t, postRPCMsg(t, legacyRPCMsg).Error)
+
+	_, err = client.GetColonyByID(env.colonyID, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
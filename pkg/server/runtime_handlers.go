@@ -24,7 +24,7 @@ func (server *ColoniesServer) handleAddRuntimeHTTPRequest(c *gin.Context, recove
 	}
 
 	if msg.Runtime == nil {
-		server.handleHTTPError(c, errors.New("Failed to add runtime, runtime is nil"), http.StatusBadRequest)
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add runtime, runtime is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
 		return
 	}
 
@@ -33,7 +33,7 @@ func (server *ColoniesServer) handleAddRuntimeHTTPRequest(c *gin.Context, recove
 		return
 	}
 
-	addedRuntime, err := server.controller.addRuntime(msg.Runtime)
+	addedRuntime, err := server.controller.addRuntime(c.Request.Context(), msg.Runtime)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -73,7 +73,7 @@ func (server *ColoniesServer) handleGetRuntimesHTTPRequest(c *gin.Context, recov
 		}
 	}
 
-	runtimes, err := server.controller.getRuntimeByColonyID(msg.ColonyID)
+	runtimes, err := server.controller.getRuntimeByColonyID(c.Request.Context(), msg.ColonyID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -101,16 +101,16 @@ func (server *ColoniesServer) handleGetRuntimeHTTPRequest(c *gin.Context, recove
 		return
 	}
 
-	runtime, err := server.controller.getRuntime(msg.RuntimeID)
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if runtime == nil {
-		server.handleHTTPError(c, errors.New("Failed to get runtime, runtime is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to get runtime, runtime not found"), http.StatusNotFound)
 		return
 	}
 
-	err = server.validator.RequireRuntimeMembership(recoveredID, runtime.ColonyID, true)
+	err = server.requireRuntimeRole(recoveredID, runtime.ColonyID, payloadType)
 	if server.handleHTTPError(c, err, http.StatusForbidden) {
 		return
 	}
@@ -138,12 +138,12 @@ func (server *ColoniesServer) handleApproveRuntimeHTTPRequest(c *gin.Context, re
 		return
 	}
 
-	runtime, err := server.controller.getRuntime(msg.RuntimeID)
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if runtime == nil {
-		server.handleHTTPError(c, errors.New("Failed to approve runtime, runtime is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to approve runtime, runtime not found"), http.StatusNotFound)
 		return
 	}
 
@@ -152,7 +152,7 @@ func (server *ColoniesServer) handleApproveRuntimeHTTPRequest(c *gin.Context, re
 		return
 	}
 
-	err = server.controller.approveRuntime(msg.RuntimeID)
+	err = server.controller.approveRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -175,12 +175,12 @@ func (server *ColoniesServer) handleRejectRuntimeHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	runtime, err := server.controller.getRuntime(msg.RuntimeID)
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if runtime == nil {
-		server.handleHTTPError(c, errors.New("Failed to reject runtime, runtime is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to reject runtime, runtime not found"), http.StatusNotFound)
 		return
 	}
 
@@ -189,7 +189,7 @@ func (server *ColoniesServer) handleRejectRuntimeHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	err = server.controller.rejectRuntime(msg.RuntimeID)
+	err = server.controller.rejectRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -212,12 +212,12 @@ func (server *ColoniesServer) handleDeleteRuntimeHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	runtime, err := server.controller.getRuntime(msg.RuntimeID)
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
 	if runtime == nil {
-		server.handleHTTPError(c, errors.New("Failed to delete runtime, runtime is nil"), http.StatusInternalServerError)
+		server.handleHTTPError(c, errors.New("Failed to delete runtime, runtime not found"), http.StatusNotFound)
 		return
 	}
 
@@ -226,7 +226,7 @@ func (server *ColoniesServer) handleDeleteRuntimeHTTPRequest(c *gin.Context, rec
 		return
 	}
 
-	err = server.controller.deleteRuntime(msg.RuntimeID)
+	err = server.controller.deleteRuntime(c.Request.Context(), msg.RuntimeID)
 	if server.handleHTTPError(c, err, http.StatusBadRequest) {
 		return
 	}
@@ -235,3 +235,202 @@ func (server *ColoniesServer) handleDeleteRuntimeHTTPRequest(c *gin.Context, rec
 
 	server.sendEmptyHTTPReply(c, payloadType)
 }
+
+func (server *ColoniesServer) handleRevokeRuntimeHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateRevokeRuntimeMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to revoke runtime, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to revoke runtime, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if runtime == nil {
+		server.handleHTTPError(c, errors.New("Failed to revoke runtime, runtime not found"), http.StatusNotFound)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, runtime.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	revocation, err := server.controller.revokeRuntime(c.Request.Context(), runtime, msg.Reason)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = revocation.ToJSON()
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"RuntimeID": runtime.ID, "ColonyID": runtime.ColonyID, "Reason": msg.Reason}).Info("Revoking runtime")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleGetRevocationsHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetRevocationsMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get revocations, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get revocations, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	revocations, err := server.controller.getRevocations(c.Request.Context(), msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = core.ConvertRevocationArrayToJSON(revocations)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID}).Debug("Getting revocations")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleAddRuntimeRoleHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateAddRuntimeRoleMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to add runtime role, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to add runtime role, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	if !core.IsValidRole(msg.Role) {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add runtime role, invalid role <"+msg.Role+">"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if runtime == nil {
+		server.handleHTTPError(c, errors.New("Failed to add runtime role, runtime not found"), http.StatusNotFound)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, runtime.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.controller.addRuntimeRole(c.Request.Context(), runtime, msg.Role)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"RuntimeID": runtime.ID, "Role": msg.Role}).Debug("Adding runtime role")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
+func (server *ColoniesServer) handleRemoveRuntimeRoleHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateRemoveRuntimeRoleMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to remove runtime role, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to remove runtime role, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if runtime == nil {
+		server.handleHTTPError(c, errors.New("Failed to remove runtime role, runtime not found"), http.StatusNotFound)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, runtime.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.controller.removeRuntimeRole(c.Request.Context(), runtime.ID, msg.Role)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"RuntimeID": runtime.ID, "Role": msg.Role}).Debug("Removing runtime role")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
+func (server *ColoniesServer) handleGetRuntimeRolesHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetRuntimeRolesMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get runtime roles, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get runtime roles, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	runtime, err := server.controller.getRuntime(c.Request.Context(), msg.RuntimeID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if runtime == nil {
+		server.handleHTTPError(c, errors.New("Failed to get runtime roles, runtime not found"), http.StatusNotFound)
+		return
+	}
+
+	// A runtime may always look up its own roles
+	if recoveredID != runtime.ID {
+		err = server.validator.RequireColonyOwner(recoveredID, runtime.ColonyID)
+		if server.handleHTTPError(c, err, http.StatusForbidden) {
+			return
+		}
+	}
+
+	roles, err := server.controller.getRuntimeRoles(c.Request.Context(), runtime.ID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = core.ConvertRoleArrayToJSON(roles)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"RuntimeID": runtime.ID}).Debug("Getting runtime roles")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"testing"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -186,3 +187,102 @@ func TestDeleteRuntimeSecurity(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+func TestRevokeRuntimeSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	_, err := client.RevokeRuntime(env.runtime1ID, "", env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.RevokeRuntime(env.runtime1ID, "", env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.RevokeRuntime(env.runtime1ID, "", env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.RevokeRuntime(env.runtime1ID, "", env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	_, err = client.GetRevocations(env.colony1ID, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.GetRevocations(env.colony1ID, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.GetRevocations(env.colony1ID, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	// A revoked runtime cannot do anything
+	_, err = client.GetRuntimes(env.colony1ID, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestAddRuntimeRoleSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	err := client.AddRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.AddRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.AddRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.AddRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestRemoveRuntimeRoleSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	err := client.AddRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.colony1PrvKey)
+	assert.Nil(t, err)
+
+	err = client.RemoveRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.RemoveRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.RemoveRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.RemoveRuntimeRole(env.runtime1ID, core.ROLE_VIEWER, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetRuntimeRolesSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	_, err := client.GetRuntimeRoles(env.runtime1ID, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetRuntimeRoles(env.runtime1ID, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetRuntimeRoles(env.runtime1ID, env.runtime1PrvKey)
+	assert.Nil(t, err) // Should work
+	_, err = client.GetRuntimeRoles(env.runtime1ID, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
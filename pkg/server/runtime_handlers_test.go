@@ -2,6 +2,7 @@ package server
 
 import (
 	"testing"
+	"time"
 
 	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/utils"
@@ -137,3 +138,104 @@ func TestDeleteRuntime(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+func TestRevokeRuntime(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	runtime, runtimePrvKey, err := utils.CreateTestRuntimeWithKey(env.colonyID)
+	assert.Nil(t, err)
+	_, err = client.AddRuntime(runtime, env.colonyPrvKey)
+	assert.Nil(t, err)
+	err = client.ApproveRuntime(runtime.ID, env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	// The runtime is running a process
+	processSpec := utils.CreateTestProcessSpec(env.colonyID)
+	addedProcess, err := client.SubmitProcessSpec(processSpec, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assignedProcess, err := client.AssignProcess(env.colonyID, -1, runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, addedProcess.ID, assignedProcess.ID)
+
+	// The runtime is also subscribing and long-polling for new processes
+	subscription, err := client.SubscribeProcesses(runtime.RuntimeType, core.WAITING, 100, runtimePrvKey)
+	assert.Nil(t, err)
+
+	assignErrChan := make(chan error)
+	go func() {
+		_, err := client.AssignProcess(env.colonyID, 100, runtimePrvKey)
+		assignErrChan <- err
+	}()
+
+	time.Sleep(1 * time.Second)
+
+	revocation, err := client.RevokeRuntime(runtime.ID, "compromised", env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, runtime.ID, revocation.RuntimeID)
+	assert.Equal(t, env.colonyID, revocation.ColonyID)
+
+	err = <-assignErrChan
+	assert.NotNil(t, err)
+
+	select {
+	case err = <-subscription.ErrChan:
+		assert.NotNil(t, err)
+	case <-subscription.ProcessChan:
+		assert.Fail(t, "Revoked runtime should not receive any processes")
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Subscription was not terminated")
+	}
+
+	// The process is moved back to the queue
+	processFromServer, err := client.GetProcess(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, core.WAITING, processFromServer.State)
+	assert.Equal(t, "", processFromServer.AssignedRuntimeID)
+
+	_, err = client.GetRuntime(runtime.ID, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	// The runtime Id cannot be registered again
+	_, err = client.AddRuntime(runtime, env.colonyPrvKey)
+	assert.NotNil(t, err)
+
+	revocations, err := client.GetRevocations(env.colonyID, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, revocations, 1)
+	assert.True(t, revocation.Equals(revocations[0]))
+
+	server.Shutdown()
+	<-done
+}
+
+func TestRuntimeRoles(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	err := client.AddRuntimeRole(env.runtimeID, core.ROLE_SUBMITTER, env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	roles, err := client.GetRuntimeRoles(env.runtimeID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{core.ROLE_SUBMITTER}, roles)
+
+	// A submitter can submit processes but not assign them
+	_, err = client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	err = client.AddRuntimeRole(env.runtimeID, "invalid_role", env.colonyPrvKey)
+	assert.NotNil(t, err)
+
+	// A runtime without roles is unrestricted
+	err = client.RemoveRuntimeRole(env.runtimeID, core.ROLE_SUBMITTER, env.colonyPrvKey)
+	assert.Nil(t, err)
+	roles, err = client.GetRuntimeRoles(env.runtimeID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 0)
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
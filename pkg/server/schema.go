@@ -0,0 +1,284 @@
+package server
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/cluster"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Const                string                 `json:"const,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+}
+
+type rpcMessageSchema struct {
+	Request *jsonSchema `json:"request,omitempty"` // Schema of the decoded RPCMsg payload, not set for replies only messages
+	Reply   *jsonSchema `json:"reply"`             // Schema of the decoded RPCReplyMsg payload
+}
+
+// rpcSchema describes the whole RPC protocol, all $ref point into Defs
+type rpcSchema struct {
+	Schema          string                       `json:"$schema"`
+	Title           string                       `json:"title"`
+	ProtocolVersion int                          `json:"protocolversion"`
+	Request         *jsonSchema                  `json:"request"`
+	Reply           *jsonSchema                  `json:"reply"`
+	Messages        map[string]*rpcMessageSchema `json:"messages"`
+	Defs            map[string]*jsonSchema       `json:"$defs"`
+}
+
+type rpcMessage struct {
+	request interface{}
+	reply   interface{}
+}
+
+// emptyReply is the payload sent by sendEmptyHTTPReply
+type emptyReply struct{}
+
+// rpcMessages maps every payload type to the message sent by the client and the payload of the reply, a payload type
+// missing here is not part of the published schema, see TestRPCSchemaCoversAllPayloadTypes
+var rpcMessages = map[string]rpcMessage{
+	// Colony
+	rpc.AddColonyPayloadType:           {rpc.AddColonyMsg{}, core.Colony{}},
+	rpc.DeleteColonyPayloadType:        {rpc.DeleteColonyMsg{}, emptyReply{}},
+	rpc.RotateColonyKeyPayloadType:     {rpc.RotateColonyKeyMsg{}, emptyReply{}},
+	rpc.GetColoniesPayloadType:         {rpc.GetColoniesMsg{}, []core.Colony{}},
+	rpc.GetColonyPayloadType:           {rpc.GetColonyMsg{}, core.Colony{}},
+	rpc.GetColonyStatisticsPayloadType: {rpc.GetColonyStatisticsMsg{}, core.Statistics{}},
+
+	// Runtime
+	rpc.AddRuntimePayloadType:        {rpc.AddRuntimeMsg{}, core.Runtime{}},
+	rpc.GetRuntimesPayloadType:       {rpc.GetRuntimesMsg{}, []core.Runtime{}},
+	rpc.GetRuntimePayloadType:        {rpc.GetRuntimeMsg{}, core.Runtime{}},
+	rpc.ApproveRuntimePayloadType:    {rpc.ApproveRuntimeRPC{}, emptyReply{}},
+	rpc.RejectRuntimePayloadType:     {rpc.RejectRuntimeMsg{}, emptyReply{}},
+	rpc.DeleteRuntimePayloadType:     {rpc.DeleteRuntimeMsg{}, emptyReply{}},
+	rpc.RevokeRuntimePayloadType:     {rpc.RevokeRuntimeMsg{}, core.Revocation{}},
+	rpc.GetRevocationsPayloadType:    {rpc.GetRevocationsMsg{}, []core.Revocation{}},
+	rpc.AddRuntimeRolePayloadType:    {rpc.AddRuntimeRoleMsg{}, emptyReply{}},
+	rpc.RemoveRuntimeRolePayloadType: {rpc.RemoveRuntimeRoleMsg{}, emptyReply{}},
+	rpc.GetRuntimeRolesPayloadType:   {rpc.GetRuntimeRolesMsg{}, []string{}},
+
+	// Process
+	rpc.SubmitProcessSpecPayloadType:  {rpc.SubmitProcessSpecMsg{}, core.Process{}},
+	rpc.AssignProcessPayloadType:      {rpc.AssignProcessMsg{}, core.Process{}},
+	rpc.GetProcessHistPayloadType:     {rpc.GetProcessHistMsg{}, []core.Process{}},
+	rpc.GetProcessesPayloadType:       {rpc.GetProcessesMsg{}, []core.Process{}},
+	rpc.GetProcessPayloadType:         {rpc.GetProcessMsg{}, core.Process{}},
+	rpc.DeleteProcessPayloadType:      {rpc.DeleteProcessMsg{}, emptyReply{}},
+	rpc.DeleteAllProcessesPayloadType: {rpc.DeleteAllProcessesMsg{}, emptyReply{}},
+	rpc.CloseSuccessfulPayloadType:    {rpc.CloseSuccessfulMsg{}, emptyReply{}},
+	rpc.CloseFailedPayloadType:        {rpc.CloseFailedMsg{}, emptyReply{}},
+	rpc.SubscribeProcessPayloadType:   {rpc.SubscribeProcessMsg{}, core.Process{}},
+	rpc.SubscribeProcessesPayloadType: {rpc.SubscribeProcessesMsg{}, core.Process{}},
+	rpc.SubscribeColonyPayloadType:    {rpc.SubscribeColonyMsg{}, core.Process{}},
+	rpc.StreamEventsPayloadType:       {rpc.StreamEventsMsg{}, core.Process{}},
+
+	// Workflow
+	rpc.SubmitWorkflowSpecPayloadType:          {rpc.SubmitWorkflowSpecMsg{}, core.ProcessGraph{}},
+	rpc.SubscribeProcessGraphPayloadType:       {rpc.SubscribeProcessGraphMsg{}, core.Process{}},
+	rpc.GetProcessGraphPayloadType:             {rpc.GetProcessGraphMsg{}, core.ProcessGraph{}},
+	rpc.GetProcessGraphsPayloadType:            {rpc.GetProcessGraphsMsg{}, []core.ProcessGraph{}},
+	rpc.DeleteProcessGraphPayloadType:          {rpc.DeleteProcessGraphMsg{}, emptyReply{}},
+	rpc.DeleteAllProcessGraphsPayloadType:      {rpc.DeleteAllProcessGraphsMsg{}, emptyReply{}},
+	rpc.AddWorkflowTemplatePayloadType:         {rpc.AddWorkflowTemplateMsg{}, core.WorkflowTemplate{}},
+	rpc.GetWorkflowTemplatePayloadType:         {rpc.GetWorkflowTemplateMsg{}, core.WorkflowTemplate{}},
+	rpc.GetWorkflowTemplatesPayloadType:        {rpc.GetWorkflowTemplatesMsg{}, []core.WorkflowTemplate{}},
+	rpc.GetWorkflowTemplateVersionsPayloadType: {rpc.GetWorkflowTemplateVersionsMsg{}, []core.WorkflowTemplate{}},
+	rpc.DeleteWorkflowTemplatePayloadType:      {rpc.DeleteWorkflowTemplateMsg{}, emptyReply{}},
+	rpc.SubmitWorkflowTemplatePayloadType:      {rpc.SubmitWorkflowTemplateMsg{}, core.ProcessGraph{}},
+
+	// Attribute
+	rpc.AddAttributePayloadType: {rpc.AddAttributeMsg{}, core.Attribute{}},
+	rpc.GetAttributePayloadType: {rpc.GetAttributeMsg{}, core.Attribute{}},
+
+	// Secret
+	rpc.SetSecretPayloadType:         {rpc.SetSecretMsg{}, emptyReply{}},
+	rpc.GetSecretsPayloadType:        {rpc.GetSecretsMsg{}, []core.Secret{}},
+	rpc.DeleteSecretPayloadType:      {rpc.DeleteSecretMsg{}, emptyReply{}},
+	rpc.GetProcessSecretsPayloadType: {rpc.GetProcessSecretsMsg{}, map[string]string{}},
+
+	// Webhook
+	rpc.AddWebhookPayloadType:           {rpc.AddWebhookMsg{}, core.Webhook{}},
+	rpc.GetWebhooksPayloadType:          {rpc.GetWebhooksMsg{}, []core.Webhook{}},
+	rpc.DeleteWebhookPayloadType:        {rpc.DeleteWebhookMsg{}, emptyReply{}},
+	rpc.GetWebhookDeliveriesPayloadType: {rpc.GetWebhookDeliveriesMsg{}, []core.WebhookDelivery{}},
+
+	// Generator
+	rpc.AddGeneratorPayloadType:    {rpc.AddGeneratorMsg{}, core.Generator{}},
+	rpc.GetGeneratorPayloadType:    {rpc.GetGeneratorMsg{}, core.Generator{}},
+	rpc.GetGeneratorsPayloadType:   {rpc.GetGeneratorsMsg{}, []core.Generator{}},
+	rpc.PackGeneratorPayloadType:   {rpc.PackGeneratorMsg{}, emptyReply{}},
+	rpc.DeleteGeneratorPayloadType: {rpc.DeleteGeneratorMsg{}, emptyReply{}},
+
+	// Cron
+	rpc.AddCronPayloadType:    {rpc.AddCronMsg{}, core.Cron{}},
+	rpc.GetCronPayloadType:    {rpc.GetCronMsg{}, core.Cron{}},
+	rpc.GetCronsPayloadType:   {rpc.GetCronsMsg{}, []core.Cron{}},
+	rpc.RunCronPayloadType:    {rpc.RunCronMsg{}, core.Cron{}},
+	rpc.DeleteCronPayloadType: {rpc.DeleteCronMsg{}, emptyReply{}},
+
+	// Server
+	rpc.VersionPayloadType:           {rpc.VersionMsg{}, rpc.VersionMsg{}},
+	rpc.GetStatisiticsPayloadType:    {rpc.GetStatisticsMsg{}, core.Statistics{}},
+	rpc.GetClusterPayloadType:        {rpc.GetClusterMsg{}, cluster.Config{}},
+	rpc.AddClusterNodePayloadType:    {rpc.AddClusterNodeMsg{}, cluster.Config{}},
+	rpc.RemoveClusterNodePayloadType: {rpc.RemoveClusterNodeMsg{}, emptyReply{}},
+	rpc.GetAuditLogPayloadType:       {rpc.GetAuditLogMsg{}, []core.AuditEntry{}},
+	rpc.BatchPayloadType:             {rpc.BatchMsg{}, []rpc.RPCReplyMsg{}},
+
+	// Sent by the server when a request fails
+	rpc.ErrorPayloadType: {nil, core.Failure{}},
+}
+
+type schemaGenerator struct {
+	defs map[string]*jsonSchema
+}
+
+func createSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{defs: make(map[string]*jsonSchema)}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor returns the schema of the JSON produced by encoding/json for t, named struct types are added to the
+// generator defs and referenced, which also makes recursive types possible
+func (generator *schemaGenerator) schemaFor(t reflect.Type) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string", Format: "byte"}
+		}
+		return &jsonSchema{Type: "array", Items: generator.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: generator.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return generator.structSchema(t)
+		}
+		name := t.String()
+		if _, ok := generator.defs[name]; !ok {
+			generator.defs[name] = &jsonSchema{} // Placeholder to stop recursion
+			*generator.defs[name] = *generator.structSchema(t)
+		}
+		return &jsonSchema{Ref: "#/$defs/" + name}
+	}
+
+	// Interfaces and other types can hold any JSON value
+	return &jsonSchema{}
+}
+
+func (generator *schemaGenerator) structSchema(t reflect.Type) *jsonSchema {
+	schema := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema)}
+	generator.addFields(schema, t)
+	sort.Strings(schema.Required)
+
+	return schema
+}
+
+func (generator *schemaGenerator) addFields(schema *jsonSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := field.Name
+		omitEmpty := false
+		if tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, option := range parts[1:] {
+				if option == "omitempty" {
+					omitEmpty = true
+				}
+			}
+		}
+
+		if field.Anonymous && tag == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				generator.addFields(schema, fieldType)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" { // Unexported
+			continue
+		}
+
+		schema.Properties[name] = generator.schemaFor(field.Type)
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func createRPCSchema() *rpcSchema {
+	generator := createSchemaGenerator()
+	schema := &rpcSchema{
+		Schema:          jsonSchemaDraft,
+		Title:           "Colonies RPC protocol",
+		ProtocolVersion: rpc.ProtocolVersion,
+		Request:         generator.schemaFor(reflect.TypeOf(rpc.RPCMsg{})),
+		Reply:           generator.schemaFor(reflect.TypeOf(rpc.RPCReplyMsg{})),
+		Messages:        make(map[string]*rpcMessageSchema),
+	}
+
+	for payloadType, msg := range rpcMessages {
+		msgSchema := &rpcMessageSchema{Reply: generator.schemaFor(reflect.TypeOf(msg.reply))}
+		if msg.request != nil {
+			msgSchema.Request = generator.schemaFor(reflect.TypeOf(msg.request))
+			def := generator.defs[strings.TrimPrefix(msgSchema.Request.Ref, "#/$defs/")]
+			if msgType, ok := def.Properties["msgtype"]; ok {
+				msgType.Const = payloadType
+			}
+		}
+		schema.Messages[payloadType] = msgSchema
+	}
+	schema.Defs = generator.defs
+
+	return schema
+}
+
+func (server *ColoniesServer) handleSchemaRequest(c *gin.Context) {
+	c.JSON(http.StatusOK, createRPCSchema())
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// parsePayloadTypes returns the values of all XPayloadType constants declared in pkg/rpc
+func parsePayloadTypes(t *testing.T) []string {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, "../rpc", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	assert.Nil(t, err)
+
+	var payloadTypes []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					for i, name := range valueSpec.Names {
+						if !strings.HasSuffix(name.Name, "PayloadType") || i >= len(valueSpec.Values) {
+							continue
+						}
+						lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+						if !ok || lit.Kind != token.STRING {
+							continue
+						}
+						payloadType, err := strconv.Unquote(lit.Value)
+						assert.Nil(t, err)
+						payloadTypes = append(payloadTypes, payloadType)
+					}
+				}
+			}
+		}
+	}
+
+	return payloadTypes
+}
+
+func TestRPCSchemaCoversAllPayloadTypes(t *testing.T) {
+	payloadTypes := parsePayloadTypes(t)
+	assert.Greater(t, len(payloadTypes), 50)
+
+	for _, payloadType := range payloadTypes {
+		_, ok := rpcMessages[payloadType]
+		assert.True(t, ok, "payload type <"+payloadType+"> has no schema, add it to rpcMessages in schema.go")
+	}
+	assert.Len(t, rpcMessages, len(payloadTypes))
+}
+
+func checkSchemaRefs(t *testing.T, schema *jsonSchema, defs map[string]*jsonSchema) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		_, ok := defs[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+		assert.True(t, ok, "dangling "+schema.Ref)
+	}
+	for _, property := range schema.Properties {
+		checkSchemaRefs(t, property, defs)
+	}
+	checkSchemaRefs(t, schema.Items, defs)
+	checkSchemaRefs(t, schema.AdditionalProperties, defs)
+}
+
+func TestRPCSchema(t *testing.T) {
+	schema := createRPCSchema()
+	assert.Equal(t, rpc.ProtocolVersion, schema.ProtocolVersion)
+	assert.Len(t, schema.Messages, len(rpcMessages))
+
+	checkSchemaRefs(t, schema.Request, schema.Defs)
+	checkSchemaRefs(t, schema.Reply, schema.Defs)
+	for _, msg := range schema.Messages {
+		checkSchemaRefs(t, msg.Request, schema.Defs)
+		checkSchemaRefs(t, msg.Reply, schema.Defs)
+	}
+	for _, def := range schema.Defs {
+		checkSchemaRefs(t, def, schema.Defs)
+	}
+
+	addColony := schema.Messages[rpc.AddColonyPayloadType]
+	assert.Equal(t, "#/$defs/rpc.AddColonyMsg", addColony.Request.Ref)
+	assert.Equal(t, "#/$defs/core.Colony", addColony.Reply.Ref)
+	addColonyMsg := schema.Defs["rpc.AddColonyMsg"]
+	assert.Equal(t, rpc.AddColonyPayloadType, addColonyMsg.Properties["msgtype"].Const)
+	assert.Contains(t, addColonyMsg.Required, "colony")
+
+	getProcesses := schema.Messages[rpc.GetProcessesPayloadType]
+	assert.Equal(t, "array", getProcesses.Reply.Type)
+	assert.Equal(t, "#/$defs/core.Process", getProcesses.Reply.Items.Ref)
+
+	process := schema.Defs["core.Process"]
+	assert.Equal(t, "string", process.Properties["submissiontime"].Type)
+	assert.Equal(t, "date-time", process.Properties["submissiontime"].Format)
+
+	rpcMsg := schema.Defs["rpc.RPCMsg"]
+	assert.Contains(t, rpcMsg.Required, "signature")
+	assert.NotContains(t, rpcMsg.Required, "delegations")
+
+	errorMsg := schema.Messages[rpc.ErrorPayloadType]
+	assert.Nil(t, errorMsg.Request)
+	assert.Equal(t, "#/$defs/core.Failure", errorMsg.Reply.Ref)
+}
+
+func TestRPCSchemaHTTPRequest(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	server := &ColoniesServer{ginHandler: gin.New()}
+	server.ginHandler.GET("/api/schema", server.handleSchemaRequest)
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/api/schema", nil)
+	server.ginHandler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var schema rpcSchema
+	err := json.Unmarshal(recorder.Body.Bytes(), &schema)
+	assert.Nil(t, err)
+	assert.Equal(t, jsonSchemaDraft, schema.Schema)
+	assert.Len(t, schema.Messages, len(rpcMessages))
+}
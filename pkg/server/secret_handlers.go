@@ -0,0 +1,219 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/colonyos/colonies/internal/crypto"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetSecretsKey sets the hex encoded AES-256 key used to encrypt secrets at rest, all servers in a cluster must use
+// the same key. Secrets are disabled if no key is set.
+func (server *ColoniesServer) SetSecretsKey(secretsKey string) error {
+	if secretsKey != "" {
+		_, err := crypto.Encrypt(secretsKey, []byte{}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	server.secretsKey = secretsKey
+
+	return nil
+}
+
+// secretAdditionalData binds an encrypted secret value to its colony and name, so that a value copied to another
+// secret in the database can not be decrypted
+func secretAdditionalData(colonyID string, name string) []byte {
+	return []byte(colonyID + ":" + name)
+}
+
+func (server *ColoniesServer) requireSecretsEnabled() error {
+	if server.secretsKey == "" {
+		return errors.New("Secrets are not enabled on this server, no secrets key has been configured")
+	}
+
+	return nil
+}
+
+func (server *ColoniesServer) handleSetSecretHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateSetSecretMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to set secret, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to set secret, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.requireSecretsEnabled()
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	err = core.ValidateSecretName(msg.Name)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	encryptedValue, err := crypto.Encrypt(server.secretsKey, []byte(msg.Value), secretAdditionalData(msg.ColonyID, msg.Name))
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	secret := core.CreateSecret(msg.ColonyID, msg.Name, encryptedValue)
+	secret.ID = core.GenerateRandomID()
+	err = server.controller.setSecret(c.Request.Context(), secret)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID, "Name": msg.Name}).Debug("Setting secret")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
+func (server *ColoniesServer) handleGetSecretsHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetSecretsMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get secrets, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get secrets, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	secrets, err := server.controller.getSecrets(c.Request.Context(), msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	// Only the names are returned, values are only given to runtimes assigned to processes referencing the secret
+	for _, secret := range secrets {
+		secret.Value = ""
+	}
+
+	jsonString, err = core.ConvertSecretArrayToJSON(secrets)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID}).Debug("Getting secrets")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleDeleteSecretHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateDeleteSecretMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to delete secret, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to delete secret, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.controller.deleteSecret(c.Request.Context(), msg.ColonyID, msg.Name)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID, "Name": msg.Name}).Debug("Deleting secret")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
+func (server *ColoniesServer) handleGetProcessSecretsHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetProcessSecretsMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get process secrets, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get process secrets, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	process, err := server.controller.getProcess(c.Request.Context(), msg.ProcessID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if process == nil {
+		server.handleHTTPError(c, errors.New("Failed to get process secrets, process not found"), http.StatusNotFound)
+		return
+	}
+
+	colonyID := process.ProcessSpec.Conditions.ColonyID
+	err = server.requireRuntimeRole(recoveredID, colonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	if process.AssignedRuntimeID != recoveredID || process.State != core.RUNNING {
+		server.handleHTTPError(c, errors.New("Failed to get process secrets, only the runtime assigned to a running process can get its secrets"), http.StatusForbidden)
+		return
+	}
+
+	err = server.requireSecretsEnabled()
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	values := make(map[string]string)
+	for _, name := range process.ProcessSpec.Secrets {
+		secret, err := server.controller.getSecret(c.Request.Context(), colonyID, name)
+		if server.handleHTTPError(c, err, http.StatusBadRequest) {
+			return
+		}
+		if secret == nil {
+			server.handleHTTPError(c, errors.New("Failed to get process secrets, secret <"+name+"> not found"), http.StatusNotFound)
+			return
+		}
+
+		value, err := crypto.Decrypt(server.secretsKey, secret.Value, secretAdditionalData(secret.ColonyID, secret.Name))
+		if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+			return
+		}
+		values[name] = string(value)
+	}
+
+	jsonBytes, err := json.Marshal(values)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ProcessID": process.ID, "RuntimeID": recoveredID}).Debug("Getting process secrets")
+
+	server.sendHTTPReply(c, payloadType, string(jsonBytes))
+}
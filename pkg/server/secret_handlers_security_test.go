@@ -0,0 +1,109 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSecretSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	err := client.SetSecret(env.colony1ID, "DB_PASSWORD", "password", env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.SetSecret(env.colony1ID, "DB_PASSWORD", "password", env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.SetSecret(env.colony1ID, "DB_PASSWORD", "password", env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.SetSecret(env.colony1ID, "DB_PASSWORD", "password", env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetSecretsSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	_, err := client.GetSecrets(env.colony1ID, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetSecrets(env.colony1ID, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetSecrets(env.colony1ID, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetSecrets(env.colony1ID, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDeleteSecretSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	err := client.SetSecret(env.colony1ID, "DB_PASSWORD", "password", env.colony1PrvKey)
+	assert.Nil(t, err)
+
+	err = client.DeleteSecret(env.colony1ID, "DB_PASSWORD", env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.DeleteSecret(env.colony1ID, "DB_PASSWORD", env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.DeleteSecret(env.colony1ID, "DB_PASSWORD", env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.DeleteSecret(env.colony1ID, "DB_PASSWORD", env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetProcessSecretsSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+	//   runtime3 is member of colony1
+
+	runtime3, runtime3PrvKey, err := utils.CreateTestRuntimeWithKey(env.colony1ID)
+	assert.Nil(t, err)
+	_, err = client.AddRuntime(runtime3, env.colony1PrvKey)
+	assert.Nil(t, err)
+	err = client.ApproveRuntime(runtime3.ID, env.colony1PrvKey)
+	assert.Nil(t, err)
+
+	err = client.SetSecret(env.colony1ID, "DB_PASSWORD", "password", env.colony1PrvKey)
+	assert.Nil(t, err)
+
+	processSpec := utils.CreateTestProcessSpec(env.colony1ID)
+	processSpec.Secrets = []string{"DB_PASSWORD"}
+	addedProcess, err := client.SubmitProcessSpec(processSpec, env.runtime1PrvKey)
+	assert.Nil(t, err)
+	_, err = client.AssignProcess(env.colony1ID, -1, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.GetProcessSecrets(addedProcess.ID, env.colony1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetProcessSecrets(addedProcess.ID, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetProcessSecrets(addedProcess.ID, runtime3PrvKey)
+	assert.NotNil(t, err) // Should not work, not assigned to the process
+	_, err = client.GetProcessSecrets(addedProcess.ID, env.runtime1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSecret(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	err := client.SetSecret(env.colonyID, "DB_PASSWORD", "password", env.colonyPrvKey)
+	assert.Nil(t, err)
+	err = client.SetSecret(env.colonyID, "API_TOKEN", "token", env.colonyPrvKey)
+	assert.Nil(t, err)
+	err = client.SetSecret(env.colonyID, "INVALID-NAME", "token", env.colonyPrvKey)
+	assert.NotNil(t, err)
+
+	secrets, err := client.GetSecrets(env.colonyID, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, secrets, 2)
+	assert.Equal(t, "API_TOKEN", secrets[0].Name)
+	assert.Equal(t, "DB_PASSWORD", secrets[1].Name)
+	for _, secret := range secrets {
+		assert.Empty(t, secret.Value) // Values are never listed
+	}
+
+	// Values are encrypted at rest
+	secretFromDB, err := server.db.GetSecret(env.colonyID, "DB_PASSWORD")
+	assert.Nil(t, err)
+	assert.NotEqual(t, "password", secretFromDB.Value)
+
+	err = client.DeleteSecret(env.colonyID, "DB_PASSWORD", env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	secrets, err = client.GetSecrets(env.colonyID, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, secrets, 1)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetProcessSecrets(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	err := client.SetSecret(env.colonyID, "DB_PASSWORD", "password", env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	processSpec := utils.CreateTestProcessSpec(env.colonyID)
+	processSpec.Secrets = []string{"DB_PASSWORD"}
+	addedProcess, err := client.SubmitProcessSpec(processSpec, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DB_PASSWORD"}, addedProcess.ProcessSpec.Secrets)
+
+	// Not possible to get secrets before the process has been assigned
+	_, err = client.GetProcessSecrets(addedProcess.ID, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	assignedProcess, err := client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, addedProcess.ID, assignedProcess.ID)
+
+	secrets, err := client.GetProcessSecrets(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"DB_PASSWORD": "password"}, secrets)
+
+	// Not possible to get secrets after the process has been closed
+	err = client.CloseSuccessful(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.GetProcessSecrets(addedProcess.ID, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetProcessSecretsMissingSecret(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	processSpec := utils.CreateTestProcessSpec(env.colonyID)
+	processSpec.Secrets = []string{"DB_PASSWORD"}
+	addedProcess, err := client.SubmitProcessSpec(processSpec, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.GetProcessSecrets(addedProcess.ID, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
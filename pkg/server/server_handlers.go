@@ -53,7 +53,7 @@ func (server *ColoniesServer) handleStatisticsHTTPRequest(c *gin.Context, recove
 		return
 	}
 
-	stat, err := server.controller.getStatistics()
+	stat, err := server.controller.getStatistics(c.Request.Context())
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
 	}
@@ -85,6 +85,7 @@ func (server *ColoniesServer) handleGetClusterHTTPRequest(c *gin.Context, recove
 	}
 
 	cluster := server.controller.etcdServer.CurrentCluster()
+	cluster.RelayMetrics = server.controller.relayServer.Metrics()
 	jsonString, err = cluster.ToJSON()
 	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
 		return
@@ -92,3 +93,67 @@ func (server *ColoniesServer) handleGetClusterHTTPRequest(c *gin.Context, recove
 
 	server.sendHTTPReply(c, payloadType, jsonString)
 }
+
+func (server *ColoniesServer) handleAddClusterNodeHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateAddClusterNodeMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to add cluster node, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to add cluster node, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireServerOwner(recoveredID, server.serverID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.controller.etcdServer.AddNode(c.Request.Context(), msg.Node)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"Name": msg.Node.Name, "Host": msg.Node.Host}).Info("Added cluster node")
+
+	// The added node is not a started member yet, but must be part of the initial cluster config it is started with
+	cluster := server.controller.etcdServer.CurrentCluster()
+	cluster.AddNode(msg.Node)
+	jsonString, err = cluster.ToJSON()
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleRemoveClusterNodeHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateRemoveClusterNodeMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to remove cluster node, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to remove cluster node, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireServerOwner(recoveredID, server.serverID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.controller.etcdServer.RemoveNode(c.Request.Context(), msg.Name)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"Name": msg.Name}).Info("Removed cluster node")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"testing"
 
+	"github.com/colonyos/colonies/pkg/cluster"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,3 +58,55 @@ func TestGetClusterInfoSecurity(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+func TestAddClusterNodeSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	node := cluster.Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 24200, EtcdPeerPort: 23200, RelayPort: 25200, APIPort: 26200}
+
+	_, err := client.AddClusterNode(node, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.AddClusterNode(node, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.AddClusterNode(node, env.colony1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	_, err = client.AddClusterNode(node, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestRemoveClusterNodeSecurity(t *testing.T) {
+	env, client, server, serverPrvKey, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	clusterInfo, err := client.GetClusterInfo(serverPrvKey)
+	assert.Nil(t, err)
+	name := clusterInfo.Nodes[0].Name
+
+	err = client.RemoveClusterNode(name, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	err = client.RemoveClusterNode(name, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	err = client.RemoveClusterNode(name, env.colony1PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	err = client.RemoveClusterNode(name, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+
+	server.Shutdown()
+	<-done
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"testing"
 
+	"github.com/colonyos/colonies/pkg/cluster"
 	"github.com/colonyos/colonies/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -90,6 +91,31 @@ func TestGetClusterInfo(t *testing.T) {
 	<-done
 }
 
+func TestAddRemoveClusterNodeInvalid(t *testing.T) {
+	_, client, server, serverPrvKey, done := setupTestEnv2(t)
+
+	clusterInfo, err := client.GetClusterInfo(serverPrvKey)
+	assert.Nil(t, err)
+	thisNode := clusterInfo.Nodes[0]
+
+	_, err = client.AddClusterNode(cluster.Node{Name: "etcd2", Host: "localhost"}, serverPrvKey)
+	assert.NotNil(t, err) // Ports missing
+	_, err = client.AddClusterNode(thisNode, serverPrvKey)
+	assert.NotNil(t, err) // Already a member
+
+	err = client.RemoveClusterNode(thisNode.Name, serverPrvKey)
+	assert.NotNil(t, err) // A node can not remove itself
+	err = client.RemoveClusterNode("etcd2", serverPrvKey)
+	assert.NotNil(t, err) // Not a member
+
+	clusterInfo, err = client.GetClusterInfo(serverPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, clusterInfo.Nodes, 1)
+
+	server.Shutdown()
+	<-done
+}
+
 func TestCheckHealth(t *testing.T) {
 	_, client, server, _, done := setupTestEnv2(t)
 
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/tracing"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// streamScope returns the watcher scope selected by msg, the Id watched and the Id of the colony the scope belongs to
+func (server *ColoniesServer) streamScope(ctx context.Context, msg *rpc.StreamEventsMsg) (string, string, string, error) {
+	scopes := 0
+	for _, id := range []string{msg.ColonyID, msg.ProcessGraphID, msg.ProcessID} {
+		if id != "" {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		return "", "", "", core.WithErrorCode(errors.New("Failed to stream events, exactly one of colony Id, process graph Id and process Id must be set"), core.ErrorCodeValidationFailed)
+	}
+
+	switch {
+	case msg.ColonyID != "":
+		return WATCH_COLONY, msg.ColonyID, msg.ColonyID, nil
+	case msg.ProcessGraphID != "":
+		processGraph, err := server.controller.getProcessGraphByID(ctx, msg.ProcessGraphID)
+		if err != nil {
+			return "", "", "", err
+		}
+		if processGraph == nil {
+			return "", "", "", core.WithErrorCode(errors.New("Failed to stream events, process graph not found"), core.ErrorCodeNotFound)
+		}
+		return WATCH_PROCESSGRAPH, processGraph.ID, processGraph.ColonyID, nil
+	default:
+		process, err := server.controller.getProcess(ctx, msg.ProcessID)
+		if err != nil {
+			return "", "", "", err
+		}
+		if process == nil {
+			return "", "", "", core.WithErrorCode(errors.New("Failed to stream events, process not found"), core.ErrorCodeNotFound)
+		}
+		return WATCH_PROCESS, process.ID, process.ProcessSpec.Conditions.ColonyID, nil
+	}
+}
+
+// handleSSERequest streams process state changes as Server-Sent Events. The client is authenticated by the token
+// query parameter, which is a signed StreamEventsMsg encoded with RPCMsg.ToToken. The nonce of a token can only be
+// used once, so a client reconnecting after the stream has ended must create a new token.
+func (server *ColoniesServer) handleSSERequest(c *gin.Context) {
+	rpcMsg, err := rpc.CreateRPCMsgFromToken(c.Query("token"))
+	if err != nil {
+		server.handleHTTPError(c, errors.New("Failed to stream events, invalid token"), http.StatusBadRequest)
+		return
+	}
+
+	// Legacy messages have no nonce, so a leaked token could be replayed to open streams for as long as the token is
+	// known, regardless of the minimum protocol version accepted by the server
+	if rpcMsg.ProtocolVersion() < rpc.ProtocolVersion {
+		server.handleHTTPError(c, errors.New("Failed to stream events, token must use protocol version "+strconv.Itoa(rpc.ProtocolVersion)), http.StatusForbidden)
+		return
+	}
+
+	ctx := tracing.Extract(c.Request.Context(), rpcMsg.TraceContext)
+	recoveredID, _, err := server.verifyRPCMsg(ctx, rpcMsg)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	if rpcMsg.PayloadType != rpc.StreamEventsPayloadType {
+		server.handleHTTPError(c, errors.New("Failed to stream events, token must contain a "+rpc.StreamEventsPayloadType), http.StatusBadRequest)
+		return
+	}
+
+	msg, err := rpc.CreateStreamEventsMsgFromJSON(rpcMsg.DecodePayload())
+	if err != nil {
+		server.handleHTTPError(c, errors.New("Failed to stream events, invalid JSON"), http.StatusBadRequest)
+		return
+	}
+
+	if msg.MsgType != rpcMsg.PayloadType {
+		server.handleHTTPError(c, errors.New("Failed to stream events, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	scope, id, colonyID, err := server.streamScope(ctx, msg)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, colonyID, rpcMsg.PayloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	// Streams count against the same subscription limits as WebSocket subscriptions
+	err = server.wsPolicy.acquire(recoveredID)
+	if server.handleHTTPError(c, err, http.StatusTooManyRequests) {
+		return
+	}
+	defer server.wsPolicy.release(recoveredID)
+
+	// The watcher is removed when the client disconnects
+	streamCtx, cancelStream := context.WithCancel(c.Request.Context())
+	defer cancelStream()
+	processChan, errChan := server.controller.eventHandler.watch(scope, id, recoveredID, streamCtx)
+
+	log.WithFields(log.Fields{"RuntimeID": recoveredID, "Scope": scope, "ID": id}).Debug("Streaming events")
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disables response buffering in nginx
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	pingInterval, _ := server.wsPolicy.keepalive()
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-streamCtx.Done():
+			return
+		case err := <-errChan:
+			c.SSEvent("error", err.Error())
+			c.Writer.Flush()
+			return
+		case process := <-processChan:
+			jsonString, err := process.ToJSON()
+			if err != nil {
+				log.WithFields(log.Fields{"Error": err}).Error("Failed to create Process JSON when streaming events")
+				return
+			}
+			c.SSEvent("process", jsonString)
+			c.Writer.Flush()
+		case <-ticker.C:
+			// Comments are ignored by clients, but keep proxies from closing idle connections
+			_, err := c.Writer.WriteString(": keepalive\n\n")
+			if err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEventsSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// Runtime 2 is not a member of colony 1
+	url, err := client.ColonyEventStreamURL(env.colony1ID, env.runtime2PrvKey)
+	assert.Nil(t, err)
+	resp, err := sseHTTPClient.Get(url)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), env.runtime1PrvKey)
+	assert.Nil(t, err)
+	url, err = client.ProcessEventStreamURL(addedProcess.ID, env.runtime2PrvKey)
+	assert.Nil(t, err)
+	resp, err = sseHTTPClient.Get(url)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	crypto := crypto.CreateCrypto()
+	invalidPrivateKey, err := crypto.GeneratePrivateKey()
+	assert.Nil(t, err)
+	url, err = client.ColonyEventStreamURL(env.colony1ID, invalidPrivateKey)
+	assert.Nil(t, err)
+	resp, err = sseHTTPClient.Get(url)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	url, err = client.ProcessEventStreamURL(core.GenerateRandomID(), env.runtime1PrvKey)
+	assert.Nil(t, err)
+	resp, err = sseHTTPClient.Get(url)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Legacy tokens are not accepted, even if the server accepts legacy messages
+	jsonString, err := rpc.CreateStreamEventsMsg(env.colony1ID, "", "").ToJSON()
+	assert.Nil(t, err)
+	legacyRPCMsg, err := rpc.CreateInsecureRPCMsg(rpc.StreamEventsPayloadType, jsonString)
+	assert.Nil(t, err)
+	legacyRPCMsg.Signature, err = crypto.GenerateSignature(legacyRPCMsg.SignedData(), env.runtime1PrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, rpc.LegacyProtocolVersion, legacyRPCMsg.ProtocolVersion())
+	token, err := legacyRPCMsg.ToToken()
+	assert.Nil(t, err)
+	resp, err = sseHTTPClient.Get("https://" + TESTHOST + ":" + strconv.Itoa(TESTPORT) + "/events?token=" + token)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = sseHTTPClient.Get("https://" + TESTHOST + ":" + strconv.Itoa(TESTPORT) + "/events?token=invalid")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	server.Shutdown()
+	<-done
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type sseEvent struct {
+	event string
+	data  string
+}
+
+// The test server uses a self-signed certificate
+var sseHTTPClient = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: SkipTLSVerify}}}
+
+// openEventStream connects to an event stream and returns a channel receiving its events, keepalive comments are
+// skipped
+func openEventStream(t *testing.T, url string) (*http.Response, chan sseEvent) {
+	resp, err := sseHTTPClient.Get(url)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 100)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		event := sseEvent{}
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if event.event != "" {
+					events <- event
+				}
+				event = sseEvent{}
+			case strings.HasPrefix(line, "event:"):
+				event.event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				event.data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+		}
+	}()
+
+	return resp, events
+}
+
+func waitForEvent(t *testing.T, events chan sseEvent) *core.Process {
+	select {
+	case event := <-events:
+		assert.Equal(t, "process", event.event)
+		process, err := core.ConvertJSONToProcess(event.data)
+		assert.Nil(t, err)
+		return process
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Timeout waiting for event")
+		return nil
+	}
+}
+
+func TestStreamColonyEvents(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	url, err := client.ColonyEventStreamURL(env.colonyID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	resp, events := openEventStream(t, url)
+	defer resp.Body.Close()
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+	process := waitForEvent(t, events)
+	assert.Equal(t, addedProcess.ID, process.ID)
+	assert.Equal(t, core.WAITING, process.State)
+
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	process = waitForEvent(t, events)
+	assert.Equal(t, addedProcess.ID, process.ID)
+	assert.Equal(t, core.RUNNING, process.State)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestStreamProcessEvents(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	url, err := client.ProcessEventStreamURL(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	resp, events := openEventStream(t, url)
+	defer resp.Body.Close()
+
+	// Other processes are not streamed
+	_, err = client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	assignedProcess, err := client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, addedProcess.ID, assignedProcess.ID)
+	err = client.CloseSuccessful(assignedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	process := waitForEvent(t, events)
+	assert.Equal(t, addedProcess.ID, process.ID)
+	assert.Equal(t, core.RUNNING, process.State)
+	process = waitForEvent(t, events)
+	assert.Equal(t, addedProcess.ID, process.ID)
+	assert.Equal(t, core.SUCCESS, process.State)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestStreamProcessGraphEvents(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	// Processes which are not part of the process graph are not streamed
+	_, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	graph, err := client.SubmitWorkflowSpec(generateDiamondtWorkflowSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	url, err := client.ProcessGraphEventStreamURL(graph.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	resp, events := openEventStream(t, url)
+	defer resp.Body.Close()
+
+	for i := 0; i < 2; i++ {
+		assignedProcess, err := client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+		assert.Nil(t, err)
+		err = client.CloseSuccessful(assignedProcess.ID, env.runtimePrvKey)
+		assert.Nil(t, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		process := waitForEvent(t, events)
+		assert.Equal(t, graph.ID, process.ProcessGraphID)
+	}
+
+	server.Shutdown()
+	<-done
+}
+
+func TestStreamEventsTokenReplay(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	url, err := client.ColonyEventStreamURL(env.colonyID, env.runtimePrvKey)
+	assert.Nil(t, err)
+	resp, _ := openEventStream(t, url)
+	resp.Body.Close()
+
+	// A token can only be used once
+	resp, err = sseHTTPClient.Get(url)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	server.Shutdown()
+	<-done
+}
@@ -43,6 +43,7 @@ type testEnv2 struct {
 	runtimePrvKey string
 }
 
+const TESTSECRETSKEY = "a9d7f1b0c3e2d4f6a8b0c2e4f6a8b0d2e4f6a8c0b2d4f6e8a0c2e4b6d8f0a2c4"
 const EnableTLS = true
 const Insecure = false
 const SkipTLSVerify = true
@@ -138,6 +139,8 @@ func prepareTests(t *testing.T) (*client.ColoniesClient, *ColoniesServer, string
 	clusterConfig := cluster.Config{}
 	clusterConfig.AddNode(node)
 	server := CreateColoniesServer(db, TESTPORT, serverID, EnableTLS, "../../cert/key.pem", "../../cert/cert.pem", node, clusterConfig, "/tmp/colonies/etcd")
+	err = server.SetSecretsKey(TESTSECRETSKEY)
+	assert.Nil(t, err)
 
 	done := make(chan bool)
 	go func() {
@@ -211,7 +214,7 @@ func waitForProcesses(t *testing.T, server *ColoniesServer, processes []*core.Pr
 	wait := make(chan error)
 	for _, process := range processes {
 		go func(process *core.Process) {
-			_, err := server.controller.eventHandler.waitForProcess(process.ProcessSpec.Conditions.RuntimeType, state, process.ID, ctx)
+			_, err := server.controller.eventHandler.waitForProcess(process.ProcessSpec.Conditions.RuntimeType, state, process.ID, "", ctx)
 			wait <- err
 		}(process)
 	}
@@ -288,6 +291,28 @@ func StartCluster(t *testing.T, db database.Database, size int) []ServerInfo {
 	return servers
 }
 
+// JoinCluster starts a server that joins a running cluster, the node must first have been added with AddClusterNode
+func JoinCluster(t *testing.T, db database.Database, runningCluster []ServerInfo, node cluster.Node) ServerInfo {
+	clusterConfig := cluster.Config{Join: true}
+	for _, s := range runningCluster {
+		clusterConfig.AddNode(s.Node)
+	}
+	clusterConfig.AddNode(node)
+
+	serverID := runningCluster[0].ServerID
+	serverPrvKey := runningCluster[0].ServerPrvKey
+
+	log.WithFields(log.Fields{"APIPort": node.APIPort}).Info("Starting ColoniesServer joining cluster")
+	server := CreateColoniesServer(db, node.APIPort, serverID, false, "", "", node, clusterConfig, "/tmp/colonies/etcd"+strconv.Itoa(len(runningCluster)))
+	done := make(chan struct{})
+	go func() {
+		server.ServeForever()
+		done <- struct{}{}
+	}()
+
+	return ServerInfo{ServerID: serverID, ServerPrvKey: serverPrvKey, Server: server, Node: node, Done: done}
+}
+
 func WaitForCluster(t *testing.T, cluster []ServerInfo) {
 	serverReady := 0
 	for {
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/colonyos/colonies/internal/crypto"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/database"
+	log "github.com/sirupsen/logrus"
+)
+
+// webhookDeliverer posts webhook deliveries and reschedules failed deliveries with exponential backoff, a delivery
+// is marked as dead when it has failed maxAttempts times. Deliveries to loopback, link-local and private addresses
+// are refused unless allowPrivate is set, since colony owners could otherwise use webhooks to reach services on the
+// server's network.
+type webhookDeliverer struct {
+	httpClient   *http.Client
+	maxAttempts  int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	allowPrivate bool
+	mutex        sync.Mutex
+}
+
+func createWebhookDeliverer() *webhookDeliverer {
+	deliverer := &webhookDeliverer{maxAttempts: WEBHOOK_MAX_ATTEMPTS,
+		backoff:    WEBHOOK_BACKOFF * time.Second,
+		maxBackoff: WEBHOOK_MAX_BACKOFF * time.Second}
+
+	// The address is checked when connecting, i.e. after DNS resolution and also when following redirects. No proxy
+	// is used, since the dialer would then only see the address of the proxy.
+	dialer := &net.Dialer{Timeout: WEBHOOK_TIMEOUT * time.Second, Control: deliverer.checkDial}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	deliverer.httpClient = &http.Client{Timeout: WEBHOOK_TIMEOUT * time.Second, Transport: transport}
+
+	return deliverer
+}
+
+// SetWebhookAllowPrivate sets if webhooks may post to loopback, link-local and private addresses, which is refused by
+// default
+func (server *ColoniesServer) SetWebhookAllowPrivate(allowPrivate bool) {
+	deliverer := server.controller.webhookDeliverer
+	deliverer.mutex.Lock()
+	defer deliverer.mutex.Unlock()
+
+	deliverer.allowPrivate = allowPrivate
+}
+
+func isPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
+
+func (deliverer *webhookDeliverer) isPrivateAllowed() bool {
+	deliverer.mutex.Lock()
+	defer deliverer.mutex.Unlock()
+
+	return deliverer.allowPrivate
+}
+
+func (deliverer *webhookDeliverer) checkDial(network string, address string, conn syscall.RawConn) error {
+	if deliverer.isPrivateAllowed() {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || isPrivateAddress(ip) {
+		return errors.New("Webhook target <" + host + "> is a loopback, link-local or private address")
+	}
+
+	return nil
+}
+
+// validateTarget rejects webhooks whose URL directly refers to a loopback, link-local or private address, other
+// host names are checked when the deliveries are posted
+func (deliverer *webhookDeliverer) validateTarget(webhook *core.Webhook) error {
+	if deliverer.isPrivateAllowed() {
+		return nil
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return err
+	}
+
+	host := strings.ToLower(u.Hostname())
+	ip := net.ParseIP(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || (ip != nil && isPrivateAddress(ip)) {
+		return errors.New("Invalid webhook URL <" + webhook.URL + ">, loopback, link-local and private addresses are not allowed")
+	}
+
+	return nil
+}
+
+// SetWebhookRetries sets how many times a webhook delivery is attempted before it is marked as dead, and the delay
+// before the first retry. The delay is doubled for every following retry.
+func (server *ColoniesServer) SetWebhookRetries(maxAttempts int, backoff time.Duration) error {
+	if maxAttempts < 1 || backoff <= 0 {
+		return errors.New("Invalid webhook retries, max attempts and backoff must be positive")
+	}
+
+	deliverer := server.controller.webhookDeliverer
+	deliverer.mutex.Lock()
+	defer deliverer.mutex.Unlock()
+
+	deliverer.maxAttempts = maxAttempts
+	deliverer.backoff = backoff
+
+	return nil
+}
+
+// nextAttempt returns when a delivery that has failed attempts times should be attempted again, and false if the
+// delivery should be marked as dead
+func (deliverer *webhookDeliverer) nextAttempt(attempts int) (time.Time, bool) {
+	deliverer.mutex.Lock()
+	defer deliverer.mutex.Unlock()
+
+	if attempts >= deliverer.maxAttempts {
+		return time.Time{}, false
+	}
+
+	delay := deliverer.backoff
+	for i := 1; i < attempts && delay < deliverer.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > deliverer.maxBackoff {
+		delay = deliverer.maxBackoff
+	}
+
+	return time.Now().Add(delay), true
+}
+
+// post sends the delivery payload to the webhook URL, the body is signed with the webhook secret the same way as
+// incoming generator webhooks, i.e. sha256=<hex encoded HMAC-SHA256>
+func (deliverer *webhookDeliverer) post(webhook *core.Webhook, delivery *core.WebhookDelivery) error {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", WEBHOOK_USER_AGENT)
+	req.Header.Set(WEBHOOK_SIGNATURE_HEADER, crypto.GenerateHMAC(webhook.Secret, body))
+	req.Header.Set(WEBHOOK_EVENT_HEADER, delivery.Event)
+	req.Header.Set(WEBHOOK_DELIVERY_HEADER, delivery.ID)
+
+	resp, err := deliverer.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, MAX_WEBHOOK_BODY_SIZE))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("Webhook responded with status " + strconv.Itoa(resp.StatusCode))
+	}
+
+	return nil
+}
+
+// deliver attempts a delivery, successful deliveries are removed and failed deliveries are rescheduled or marked
+// as dead
+func (deliverer *webhookDeliverer) deliver(db database.Database, webhook *core.Webhook, delivery *core.WebhookDelivery) {
+	err := deliverer.post(webhook, delivery)
+	if err == nil {
+		log.WithFields(log.Fields{"WebhookID": webhook.ID, "DeliveryID": delivery.ID, "Event": delivery.Event}).Debug("Webhook delivered")
+		err = db.DeleteWebhookDeliveryByID(delivery.ID)
+		if err != nil {
+			log.WithFields(log.Fields{"DeliveryID": delivery.ID, "Error": err}).Error("Failed to delete webhook delivery")
+		}
+		return
+	}
+
+	delivery.Attempts++
+	delivery.LastError = err.Error()
+	nextAttempt, retry := deliverer.nextAttempt(delivery.Attempts)
+	if retry {
+		delivery.NextAttempt = nextAttempt
+		log.WithFields(log.Fields{"WebhookID": webhook.ID, "DeliveryID": delivery.ID, "Attempts": delivery.Attempts, "NextAttempt": nextAttempt, "Error": err}).Debug("Webhook delivery failed, retrying")
+	} else {
+		delivery.State = core.WEBHOOK_DELIVERY_DEAD
+		log.WithFields(log.Fields{"WebhookID": webhook.ID, "DeliveryID": delivery.ID, "Attempts": delivery.Attempts, "Error": err}).Warning("Webhook delivery failed, giving up")
+	}
+
+	err = db.UpdateWebhookDelivery(delivery)
+	if err != nil {
+		log.WithFields(log.Fields{"DeliveryID": delivery.ID, "Error": err}).Error("Failed to update webhook delivery")
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/internal/crypto"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDelivererPost(t *testing.T) {
+	var body []byte
+	var header http.Header
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer receiver.Close()
+
+	webhook := core.CreateWebhook(core.GenerateRandomID(), receiver.URL, core.WebhookFilter{}, "secret")
+	webhook.ID = core.GenerateRandomID()
+	delivery := core.CreateWebhookDelivery(webhook, core.WEBHOOK_EVENT_PROCESS, `{"event":"process"}`)
+	delivery.ID = core.GenerateRandomID()
+
+	deliverer := createWebhookDeliverer()
+	deliverer.allowPrivate = true
+	err := deliverer.post(webhook, delivery)
+	assert.Nil(t, err)
+	assert.Equal(t, delivery.Payload, string(body))
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, core.WEBHOOK_EVENT_PROCESS, header.Get(WEBHOOK_EVENT_HEADER))
+	assert.Equal(t, delivery.ID, header.Get(WEBHOOK_DELIVERY_HEADER))
+	assert.True(t, crypto.VerifyHMAC("secret", body, header.Get(WEBHOOK_SIGNATURE_HEADER)))
+	assert.False(t, crypto.VerifyHMAC("wrong secret", body, header.Get(WEBHOOK_SIGNATURE_HEADER)))
+}
+
+func TestWebhookDelivererPostFailed(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	webhook := core.CreateWebhook(core.GenerateRandomID(), receiver.URL, core.WebhookFilter{}, "secret")
+	delivery := core.CreateWebhookDelivery(webhook, core.WEBHOOK_EVENT_PROCESS, "{}")
+
+	deliverer := createWebhookDeliverer()
+	deliverer.allowPrivate = true
+	err := deliverer.post(webhook, delivery)
+	assert.NotNil(t, err)
+
+	// Nothing listening
+	receiver.Close()
+	err = deliverer.post(webhook, delivery)
+	assert.NotNil(t, err)
+}
+
+func TestWebhookDelivererPrivateAddress(t *testing.T) {
+	posted := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted = true
+	}))
+	defer receiver.Close()
+
+	webhook := core.CreateWebhook(core.GenerateRandomID(), receiver.URL, core.WebhookFilter{}, "secret")
+	delivery := core.CreateWebhookDelivery(webhook, core.WEBHOOK_EVENT_PROCESS, "{}")
+
+	// The receiver listens on a loopback address, which is refused by default
+	deliverer := createWebhookDeliverer()
+	err := deliverer.post(webhook, delivery)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "private address")
+	assert.False(t, posted)
+	assert.NotNil(t, deliverer.validateTarget(webhook))
+
+	// Host names are checked after they have been resolved
+	localhostWebhook := core.CreateWebhook(core.GenerateRandomID(), strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1), core.WebhookFilter{}, "secret")
+	err = deliverer.post(localhostWebhook, delivery)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "private address")
+	assert.False(t, posted)
+
+	for _, url := range []string{"http://localhost/hook", "http://10.1.2.3/hook", "http://192.168.0.1/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://[fe80::1]/hook", "http://0.0.0.0/hook"} {
+		assert.NotNil(t, deliverer.validateTarget(core.CreateWebhook(core.GenerateRandomID(), url, core.WebhookFilter{}, "")))
+	}
+	assert.Nil(t, deliverer.validateTarget(core.CreateWebhook(core.GenerateRandomID(), "https://example.com/hook", core.WebhookFilter{}, "")))
+
+	deliverer.allowPrivate = true
+	assert.Nil(t, deliverer.validateTarget(webhook))
+	err = deliverer.post(webhook, delivery)
+	assert.Nil(t, err)
+	assert.True(t, posted)
+}
+
+func TestWebhookDelivererNextAttempt(t *testing.T) {
+	deliverer := createWebhookDeliverer()
+	deliverer.maxAttempts = 5
+	deliverer.backoff = time.Second
+	deliverer.maxBackoff = 5 * time.Second
+
+	// The delay is doubled after every failed attempt, but never longer than the max backoff
+	expectedDelays := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, expectedDelay := range expectedDelays {
+		nextAttempt, retry := deliverer.nextAttempt(i + 1)
+		assert.True(t, retry)
+		assert.WithinDuration(t, time.Now().Add(expectedDelay), nextAttempt, 100*time.Millisecond)
+	}
+
+	_, retry := deliverer.nextAttempt(5)
+	assert.False(t, retry)
+}
+
+func TestSetWebhookRetries(t *testing.T) {
+	server := &ColoniesServer{controller: &coloniesController{webhookDeliverer: createWebhookDeliverer()}}
+
+	assert.NotNil(t, server.SetWebhookRetries(0, time.Second))
+	assert.NotNil(t, server.SetWebhookRetries(3, 0))
+	assert.Nil(t, server.SetWebhookRetries(3, time.Second))
+
+	_, retry := server.controller.webhookDeliverer.nextAttempt(2)
+	assert.True(t, retry)
+	_, retry = server.controller.webhookDeliverer.nextAttempt(3)
+	assert.False(t, retry)
+}
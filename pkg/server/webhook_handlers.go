@@ -0,0 +1,188 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+func (server *ColoniesServer) handleAddWebhookHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateAddWebhookMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to add webhook, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to add webhook, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	if msg.Webhook == nil {
+		server.handleHTTPError(c, errors.New("Failed to add webhook, webhook is nil"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.Webhook.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = msg.Webhook.Validate()
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	err = server.controller.webhookDeliverer.validateTarget(msg.Webhook)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	// A secret is generated if none is given, the secret is only returned when the webhook is added
+	if msg.Webhook.Secret == "" {
+		msg.Webhook.Secret = core.GenerateRandomID()
+	}
+
+	msg.Webhook.ID = core.GenerateRandomID()
+	addedWebhook, err := server.controller.addWebhook(c.Request.Context(), msg.Webhook)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = addedWebhook.ToJSON()
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"WebhookID": addedWebhook.ID, "ColonyID": addedWebhook.ColonyID, "URL": addedWebhook.URL}).Debug("Adding webhook")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleGetWebhooksHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetWebhooksMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get webhooks, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get webhooks, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	webhooks, err := server.controller.getWebhooks(c.Request.Context(), msg.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+
+	jsonString, err = core.ConvertWebhookArrayToJSON(webhooks)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID}).Debug("Getting webhooks")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+// getOwnedWebhook returns the webhook with the given Id if it exists and recoveredID is the owner of its colony
+func (server *ColoniesServer) getOwnedWebhook(c *gin.Context, recoveredID string, webhookID string, errMsg string) (*core.Webhook, bool) {
+	webhook, err := server.controller.getWebhook(c.Request.Context(), webhookID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return nil, false
+	}
+
+	if webhook == nil {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New(errMsg+", webhook not found"), core.ErrorCodeNotFound), http.StatusNotFound)
+		return nil, false
+	}
+
+	err = server.validator.RequireColonyOwner(recoveredID, webhook.ColonyID)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+func (server *ColoniesServer) handleDeleteWebhookHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateDeleteWebhookMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to delete webhook, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to delete webhook, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	webhook, ok := server.getOwnedWebhook(c, recoveredID, msg.WebhookID, "Failed to delete webhook")
+	if !ok {
+		return
+	}
+
+	err = server.controller.deleteWebhook(c.Request.Context(), webhook.ID)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	log.WithFields(log.Fields{"WebhookID": webhook.ID}).Debug("Deleting webhook")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
+func (server *ColoniesServer) handleGetWebhookDeliveriesHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetWebhookDeliveriesMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get webhook deliveries, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get webhook deliveries, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	webhook, ok := server.getOwnedWebhook(c, recoveredID, msg.WebhookID, "Failed to get webhook deliveries")
+	if !ok {
+		return
+	}
+
+	count := msg.Count
+	if count <= 0 || count > MAX_COUNT {
+		count = MAX_COUNT
+	}
+
+	deliveries, err := server.controller.getWebhookDeliveries(c.Request.Context(), webhook.ID, msg.State, count)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = core.ConvertWebhookDeliveryArrayToJSON(deliveries)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"WebhookID": webhook.ID, "State": msg.State, "Count": count}).Debug("Getting webhook deliveries")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
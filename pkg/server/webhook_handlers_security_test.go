@@ -0,0 +1,95 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWebhookSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	webhook := core.CreateWebhook(env.colony1ID, "https://example.com/hook", core.WebhookFilter{}, "")
+	_, err := client.AddWebhook(webhook, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.AddWebhook(webhook, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.AddWebhook(webhook, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.AddWebhook(webhook, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetWebhooksSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	_, err := client.GetWebhooks(env.colony1ID, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetWebhooks(env.colony1ID, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetWebhooks(env.colony1ID, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetWebhooks(env.colony1ID, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDeleteWebhookSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	webhook, err := client.AddWebhook(core.CreateWebhook(env.colony1ID, "https://example.com/hook", core.WebhookFilter{}, ""), env.colony1PrvKey)
+	assert.Nil(t, err)
+
+	err = client.DeleteWebhook(webhook.ID, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.DeleteWebhook(webhook.ID, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.DeleteWebhook(webhook.ID, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	err = client.DeleteWebhook(webhook.ID, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetWebhookDeliveriesSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	webhook, err := client.AddWebhook(core.CreateWebhook(env.colony1ID, "https://example.com/hook", core.WebhookFilter{}, ""), env.colony1PrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.GetWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_DEAD, 10, env.colony2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_DEAD, 10, env.runtime1PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_DEAD, 10, env.runtime2PrvKey)
+	assert.NotNil(t, err) // Should not work
+	_, err = client.GetWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_DEAD, 10, env.colony1PrvKey)
+	assert.Nil(t, err) // Should work
+
+	server.Shutdown()
+	<-done
+}
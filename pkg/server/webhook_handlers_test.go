@@ -0,0 +1,217 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/internal/crypto"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// createWebhookReceiver starts an HTTP server that verifies the signature of posted webhook events and forwards
+// them to the returned channel
+func createWebhookReceiver(t *testing.T, secret string) (*httptest.Server, chan *core.WebhookEvent) {
+	events := make(chan *core.WebhookEvent, 100)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		assert.True(t, crypto.VerifyHMAC(secret, body, r.Header.Get(WEBHOOK_SIGNATURE_HEADER)))
+
+		event, err := core.ConvertJSONToWebhookEvent(string(body))
+		assert.Nil(t, err)
+		assert.Equal(t, event.Event, r.Header.Get(WEBHOOK_EVENT_HEADER))
+		assert.Equal(t, event.DeliveryID, r.Header.Get(WEBHOOK_DELIVERY_HEADER))
+		events <- event
+	}))
+
+	return receiver, events
+}
+
+func waitForWebhookEvent(t *testing.T, events chan *core.WebhookEvent) *core.WebhookEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "Timeout waiting for webhook event")
+		return nil
+	}
+}
+
+func TestAddWebhook(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	webhook := core.CreateWebhook(env.colonyID, "https://example.com/hook", core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESS}}, "")
+	addedWebhook, err := client.AddWebhook(webhook, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, addedWebhook.ID)
+	assert.NotEmpty(t, addedWebhook.Secret) // A secret is generated if none is given
+	assert.Equal(t, webhook.URL, addedWebhook.URL)
+
+	_, err = client.AddWebhook(core.CreateWebhook(env.colonyID, "ftp://example.com/hook", core.WebhookFilter{}, ""), env.colonyPrvKey)
+	assert.NotNil(t, err)
+	_, err = client.AddWebhook(core.CreateWebhook(env.colonyID, "https://example.com/hook", core.WebhookFilter{Events: []string{"runtime"}}, ""), env.colonyPrvKey)
+	assert.NotNil(t, err)
+
+	// Loopback, link-local and private addresses are not allowed by default
+	for _, url := range []string{"http://localhost:8080/hook", "http://127.0.0.1/hook", "http://10.0.0.1/hook", "http://169.254.169.254/latest", "http://[::1]/hook"} {
+		_, err = client.AddWebhook(core.CreateWebhook(env.colonyID, url, core.WebhookFilter{}, ""), env.colonyPrvKey)
+		assert.NotNil(t, err)
+	}
+
+	webhooks, err := client.GetWebhooks(env.colonyID, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, addedWebhook.ID, webhooks[0].ID)
+	assert.Empty(t, webhooks[0].Secret) // Secrets are never listed
+
+	err = client.DeleteWebhook(addedWebhook.ID, env.colonyPrvKey)
+	assert.Nil(t, err)
+	err = client.DeleteWebhook(addedWebhook.ID, env.colonyPrvKey)
+	assert.NotNil(t, err)
+
+	webhooks, err = client.GetWebhooks(env.colonyID, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, webhooks, 0)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestWebhookProcessEvents(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+	server.SetWebhookAllowPrivate(true) // The receivers listen on localhost
+
+	receiver, events := createWebhookReceiver(t, "secret")
+	defer receiver.Close()
+	successReceiver, successEvents := createWebhookReceiver(t, "secret2")
+	defer successReceiver.Close()
+
+	_, err := client.AddWebhook(core.CreateWebhook(env.colonyID, receiver.URL, core.WebhookFilter{}, "secret"), env.colonyPrvKey)
+	assert.Nil(t, err)
+	filter := core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESS}, States: []int{core.SUCCESS}}
+	_, err = client.AddWebhook(core.CreateWebhook(env.colonyID, successReceiver.URL, filter, "secret2"), env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	err = client.CloseSuccessful(addedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	// Deliveries due at the same time are posted concurrently, so the order is not guaranteed
+	states := make(map[int]bool)
+	for i := 0; i < 3; i++ {
+		event := waitForWebhookEvent(t, events)
+		assert.Equal(t, core.WEBHOOK_EVENT_PROCESS, event.Event)
+		assert.Equal(t, env.colonyID, event.ColonyID)
+		assert.Equal(t, addedProcess.ID, event.Process.ID)
+		states[event.Process.State] = true
+	}
+	assert.True(t, states[core.WAITING])
+	assert.True(t, states[core.RUNNING])
+	assert.True(t, states[core.SUCCESS])
+
+	event := waitForWebhookEvent(t, successEvents)
+	assert.Equal(t, core.SUCCESS, event.Process.State)
+
+	select {
+	case event := <-successEvents:
+		assert.Fail(t, "Unexpected webhook event", event.Process.State)
+	case <-time.After(2 * time.Second):
+	}
+
+	server.Shutdown()
+	<-done
+}
+
+func TestWebhookProcessGraphEvents(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+	server.SetWebhookAllowPrivate(true) // The receivers listen on localhost
+
+	receiver, events := createWebhookReceiver(t, "secret")
+	defer receiver.Close()
+
+	filter := core.WebhookFilter{Events: []string{core.WEBHOOK_EVENT_PROCESSGRAPH}}
+	_, err := client.AddWebhook(core.CreateWebhook(env.colonyID, receiver.URL, filter, "secret"), env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	processGraph, err := client.SubmitWorkflowSpec(generateDiamondtWorkflowSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	for i := 0; i < 4; i++ {
+		process, err := client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+		assert.Nil(t, err)
+		err = client.CloseSuccessful(process.ID, env.runtimePrvKey)
+		assert.Nil(t, err)
+	}
+
+	// Events are only posted when the state of the graph changes, it is waiting again between the tasks
+	states := make(map[int]bool)
+	for !states[core.SUCCESS] {
+		event := waitForWebhookEvent(t, events)
+		if event == nil {
+			break
+		}
+		assert.Equal(t, core.WEBHOOK_EVENT_PROCESSGRAPH, event.Event)
+		assert.Nil(t, event.Process)
+		assert.Equal(t, processGraph.ID, event.ProcessGraph.ID)
+		states[event.ProcessGraph.State] = true
+	}
+	assert.True(t, states[core.WAITING])
+	assert.True(t, states[core.RUNNING])
+
+	server.Shutdown()
+	<-done
+}
+
+func TestWebhookDeadLetter(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+	server.SetWebhookAllowPrivate(true) // The receivers listen on localhost
+
+	attempts := make(chan bool, 100)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts <- true
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	err := server.SetWebhookRetries(2, 100*time.Millisecond)
+	assert.Nil(t, err)
+
+	filter := core.WebhookFilter{States: []int{core.WAITING}}
+	webhook, err := client.AddWebhook(core.CreateWebhook(env.colonyID, receiver.URL, filter, ""), env.colonyPrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	var deadDeliveries []*core.WebhookDelivery
+	for i := 0; i < 100 && len(deadDeliveries) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		deadDeliveries, err = client.GetWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_DEAD, 10, env.colonyPrvKey)
+		assert.Nil(t, err)
+	}
+
+	assert.Len(t, deadDeliveries, 1)
+	assert.Equal(t, 2, deadDeliveries[0].Attempts)
+	assert.Contains(t, deadDeliveries[0].LastError, "503")
+	assert.Len(t, attempts, 2)
+
+	// The dead letter keeps the payload so that it can be inspected
+	event, err := core.ConvertJSONToWebhookEvent(deadDeliveries[0].Payload)
+	assert.Nil(t, err)
+	assert.Equal(t, core.WAITING, event.Process.State)
+
+	pendingDeliveries, err := client.GetWebhookDeliveries(webhook.ID, core.WEBHOOK_DELIVERY_PENDING, 10, env.colonyPrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, pendingDeliveries, 0)
+
+	server.Shutdown()
+	<-done
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// Validates the workflow of a cron or generator, which is either given as a workflow spec or as a reference to a workflow template
+func (server *ColoniesServer) validateWorkflow(ctx context.Context, colonyID string, workflowSpec string, workflowTemplateRef *core.WorkflowTemplateRef) error {
+	if workflowTemplateRef == nil {
+		_, err := core.ConvertJSONToWorkflowSpec(workflowSpec)
+		return err
+	}
+
+	workflowTemplate, err := server.controller.getWorkflowTemplate(ctx, colonyID, workflowTemplateRef.Name, workflowTemplateRef.Version)
+	if err != nil {
+		return err
+	}
+	if workflowTemplate == nil {
+		return core.WithErrorCode(errors.New("Workflow template <"+workflowTemplateRef.Name+"> with version <"+strconv.Itoa(workflowTemplateRef.Version)+"> not found"), core.ErrorCodeNotFound)
+	}
+
+	_, err = workflowTemplate.Instantiate(workflowTemplateRef.Parameters)
+	return err
+}
+
+func (server *ColoniesServer) handleAddWorkflowTemplateHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateAddWorkflowTemplateMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to add workflow template, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to add workflow template, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+	if msg.WorkflowTemplate == nil {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to add workflow template, msg.WorkflowTemplate is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, msg.WorkflowTemplate.ColonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = msg.WorkflowTemplate.Validate()
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	msg.WorkflowTemplate.ID = core.GenerateRandomID()
+	addedWorkflowTemplate, err := server.controller.addWorkflowTemplate(c.Request.Context(), msg.WorkflowTemplate)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if addedWorkflowTemplate == nil {
+		server.handleHTTPError(c, errors.New("Failed to add workflow template, addedWorkflowTemplate is nil"), http.StatusInternalServerError)
+		return
+	}
+
+	jsonString, err = addedWorkflowTemplate.ToJSON()
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"WorkflowTemplateID": addedWorkflowTemplate.ID, "Name": addedWorkflowTemplate.Name, "Version": addedWorkflowTemplate.Version}).Debug("Adding workflow template")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleGetWorkflowTemplateHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetWorkflowTemplateMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get workflow template, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get workflow template, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	workflowTemplate, err := server.controller.getWorkflowTemplate(c.Request.Context(), msg.ColonyID, msg.Name, msg.Version)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+	if workflowTemplate == nil {
+		server.handleHTTPError(c, errors.New("Failed to get workflow template, workflow template not found"), http.StatusNotFound)
+		return
+	}
+
+	jsonString, err = workflowTemplate.ToJSON()
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"WorkflowTemplateID": workflowTemplate.ID}).Debug("Getting workflow template")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleGetWorkflowTemplatesHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetWorkflowTemplatesMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get workflow templates, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get workflow templates, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	if msg.Count > MAX_COUNT {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Count is larger than MaxCount limit <"+strconv.Itoa(MAX_COUNT)+">"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	workflowTemplates, err := server.controller.getWorkflowTemplates(c.Request.Context(), msg.ColonyID, msg.Count)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = core.ConvertWorkflowTemplateArrayToJSON(workflowTemplates)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID, "Count": msg.Count}).Debug("Getting workflow templates")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleGetWorkflowTemplateVersionsHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateGetWorkflowTemplateVersionsMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to get workflow template versions, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to get workflow template versions, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	workflowTemplates, err := server.controller.getWorkflowTemplateVersions(c.Request.Context(), msg.ColonyID, msg.Name)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = core.ConvertWorkflowTemplateArrayToJSON(workflowTemplates)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID, "Name": msg.Name}).Debug("Getting workflow template versions")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
+
+func (server *ColoniesServer) handleDeleteWorkflowTemplateHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateDeleteWorkflowTemplateMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to delete workflow template, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to delete workflow template, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	err = server.controller.deleteWorkflowTemplate(c.Request.Context(), msg.ColonyID, msg.Name, msg.Version)
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ColonyID": msg.ColonyID, "Name": msg.Name, "Version": msg.Version}).Debug("Deleting workflow template")
+
+	server.sendEmptyHTTPReply(c, payloadType)
+}
+
+func (server *ColoniesServer) handleSubmitWorkflowTemplateHTTPRequest(c *gin.Context, recoveredID string, payloadType string, jsonString string) {
+	msg, err := rpc.CreateSubmitWorkflowTemplateMsgFromJSON(jsonString)
+	if err != nil {
+		if server.handleHTTPError(c, errors.New("Failed to submit workflow template, invalid JSON"), http.StatusBadRequest) {
+			return
+		}
+	}
+
+	if msg.MsgType != payloadType {
+		server.handleHTTPError(c, errors.New("Failed to submit workflow template, msg.MsgType does not match payloadType"), http.StatusBadRequest)
+		return
+	}
+	if msg.WorkflowTemplate == nil {
+		server.handleHTTPError(c, core.WithErrorCode(errors.New("Failed to submit workflow template, msg.WorkflowTemplate is nil"), core.ErrorCodeValidationFailed), http.StatusBadRequest)
+		return
+	}
+
+	err = server.requireRuntimeRole(recoveredID, msg.ColonyID, payloadType)
+	if server.handleHTTPError(c, err, http.StatusForbidden) {
+		return
+	}
+
+	processGraph, err := server.controller.submitWorkflowTemplate(c.Request.Context(), msg.ColonyID, msg.WorkflowTemplate)
+	if server.handleHTTPError(c, err, http.StatusBadRequest) {
+		return
+	}
+
+	jsonString, err = processGraph.ToJSON()
+	if server.handleHTTPError(c, err, http.StatusInternalServerError) {
+		return
+	}
+
+	log.WithFields(log.Fields{"ProcessGraphID": processGraph.ID, "Name": msg.WorkflowTemplate.Name, "Version": msg.WorkflowTemplate.Version}).Debug("Submitting workflow template")
+
+	server.sendHTTPReply(c, payloadType, jsonString)
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWorkflowTemplateSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colony1ID)
+
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtime2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.AddWorkflowTemplate(workflowTemplate, env.colony1PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.AddWorkflowTemplate(workflowTemplate, env.colony2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.AddWorkflowTemplate(workflowTemplate, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetWorkflowTemplateSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	colonyID := env.colony1ID
+	workflowTemplate := utils.FakeWorkflowTemplate(t, colonyID)
+
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.GetWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.runtime2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.colony1PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.colony2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetWorkflowTemplatesSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	colonyID := env.colony1ID
+	workflowTemplate := utils.FakeWorkflowTemplate(t, colonyID)
+
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.GetWorkflowTemplates(colonyID, 100, env.runtime2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplates(colonyID, 100, env.colony1PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplates(colonyID, 100, env.colony2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplates(colonyID, 100, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	_, err = client.GetWorkflowTemplateVersions(colonyID, workflowTemplate.Name, env.runtime2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.GetWorkflowTemplateVersions(colonyID, workflowTemplate.Name, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestSubmitWorkflowTemplateSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	colonyID := env.colony1ID
+	workflowTemplate := utils.FakeWorkflowTemplate(t, colonyID)
+
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	workflowTemplateRef := core.CreateWorkflowTemplateRef(workflowTemplate.Name, 0, nil)
+	_, err = client.SubmitWorkflowTemplate(colonyID, workflowTemplateRef, env.runtime2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.SubmitWorkflowTemplate(colonyID, workflowTemplateRef, env.colony1PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.SubmitWorkflowTemplate(colonyID, workflowTemplateRef, env.colony2PrvKey)
+	assert.NotNil(t, err)
+	_, err = client.SubmitWorkflowTemplate(colonyID, workflowTemplateRef, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDeleteWorkflowTemplateSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// The setup looks like this:
+	//   runtime1 is member of colony1
+	//   runtime2 is member of colony2
+
+	colonyID := env.colony1ID
+	workflowTemplate := utils.FakeWorkflowTemplate(t, colonyID)
+
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	err = client.DeleteWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.runtime2PrvKey)
+	assert.NotNil(t, err)
+	err = client.DeleteWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.colony1PrvKey)
+	assert.NotNil(t, err)
+	err = client.DeleteWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.colony2PrvKey)
+	assert.NotNil(t, err)
+	err = client.DeleteWorkflowTemplate(colonyID, workflowTemplate.Name, 0, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	server.Shutdown()
+	<-done
+}
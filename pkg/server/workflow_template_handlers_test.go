@@ -0,0 +1,166 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWorkflowTemplate(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colonyID)
+	addedWorkflowTemplate, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.NotNil(t, addedWorkflowTemplate)
+	assert.Equal(t, 1, addedWorkflowTemplate.Version)
+
+	addedWorkflowTemplate2, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, addedWorkflowTemplate2.Version)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestAddWorkflowTemplateFail(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colonyID)
+	workflowTemplate.WorkflowSpec = "error"
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	workflowTemplate = utils.FakeWorkflowTemplate(t, env.colonyID)
+	workflowTemplate.Parameters = append(workflowTemplate.Parameters, core.TemplateParameter{Name: "input"})
+	_, err = client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetWorkflowTemplate(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colonyID)
+	addedWorkflowTemplate1, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+	addedWorkflowTemplate2, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	workflowTemplateFromServer, err := client.GetWorkflowTemplate(env.colonyID, workflowTemplate.Name, 1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, addedWorkflowTemplate1.ID, workflowTemplateFromServer.ID)
+
+	workflowTemplateFromServer, err = client.GetWorkflowTemplate(env.colonyID, workflowTemplate.Name, 0, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, addedWorkflowTemplate2.ID, workflowTemplateFromServer.ID)
+
+	_, err = client.GetWorkflowTemplate(env.colonyID, "does_not_exists", 0, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestGetWorkflowTemplates(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	workflowTemplate1 := utils.FakeWorkflowTemplate(t, env.colonyID)
+	workflowTemplate1.Name = "test_template1"
+	_, err := client.AddWorkflowTemplate(workflowTemplate1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.AddWorkflowTemplate(workflowTemplate1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	workflowTemplate2 := utils.FakeWorkflowTemplate(t, env.colonyID)
+	workflowTemplate2.Name = "test_template2"
+	_, err = client.AddWorkflowTemplate(workflowTemplate2, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	workflowTemplatesFromServer, err := client.GetWorkflowTemplates(env.colonyID, 100, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, workflowTemplatesFromServer, 2)
+
+	versions, err := client.GetWorkflowTemplateVersions(env.colonyID, workflowTemplate1.Name, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestDeleteWorkflowTemplate(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colonyID)
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+	_, err = client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	err = client.DeleteWorkflowTemplate(env.colonyID, workflowTemplate.Name, 1, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	versions, err := client.GetWorkflowTemplateVersions(env.colonyID, workflowTemplate.Name, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, versions, 1)
+
+	err = client.DeleteWorkflowTemplate(env.colonyID, workflowTemplate.Name, 0, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	versions, err = client.GetWorkflowTemplateVersions(env.colonyID, workflowTemplate.Name, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, versions, 0)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestSubmitWorkflowTemplate(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colonyID)
+	_, err := client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	workflowTemplateRef := core.CreateWorkflowTemplateRef(workflowTemplate.Name, 0, map[string]string{"input": "hello"})
+	graph, err := client.SubmitWorkflowTemplate(env.colonyID, workflowTemplateRef, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Len(t, graph.Roots, 1)
+
+	rootProcess, err := client.GetProcess(graph.Roots[0], env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"hello"}, rootProcess.ProcessSpec.Args)
+
+	// Unknown parameters are rejected
+	workflowTemplateRef = core.CreateWorkflowTemplateRef(workflowTemplate.Name, 0, map[string]string{"unknown": "hello"})
+	_, err = client.SubmitWorkflowTemplate(env.colonyID, workflowTemplateRef, env.runtimePrvKey)
+	assert.NotNil(t, err)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestAddCronWithWorkflowTemplate(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	cron := utils.FakeCron(t, env.colonyID)
+	cron.WorkflowTemplate = core.CreateWorkflowTemplateRef("test_template", 0, nil)
+	_, err := client.AddCron(cron, env.runtimePrvKey)
+	assert.NotNil(t, err) // Template does not exist yet
+
+	workflowTemplate := utils.FakeWorkflowTemplate(t, env.colonyID)
+	_, err = client.AddWorkflowTemplate(workflowTemplate, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	addedCron, err := client.AddCron(cron, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.NotNil(t, addedCron.WorkflowTemplate)
+
+	server.Shutdown()
+	<-done
+}
@@ -1,10 +1,14 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"time"
 
+	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/rpc"
+	"github.com/colonyos/colonies/pkg/tracing"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
@@ -32,11 +36,46 @@ func (server *ColoniesServer) sendWSErrorMsg(err error, errorCode int, wsConn *w
 	return nil
 }
 
+// keepWSAlive pings the client until ctx is cancelled, a client that does not answer with a pong before the read
+// deadline expires is disconnected by the read loop in handleWSRequest
+func (server *ColoniesServer) keepWSAlive(ctx context.Context, wsConn *websocket.Conn, pingInterval time.Duration) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := wsConn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(pingInterval))
+			if err != nil {
+				log.WithFields(log.Fields{"Error": err}).Debug("Failed to ping WebSocket client, closing connection")
+				wsConn.Close()
+				return
+			}
+		}
+	}
+}
+
+// acquireWSSubscription reserves a subscription slot for the runtime, and prepares the subscription to release it
+// when the subscription ends
+func (server *ColoniesServer) acquireWSSubscription(ctx context.Context, runtimeID string, subscription *subscription) error {
+	err := server.wsPolicy.acquire(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	subscription.ctx = ctx
+	subscription.release = func() { server.wsPolicy.release(runtimeID) }
+
+	return nil
+}
+
 func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 	w := c.Writer
 	r := c.Request
 	var wsupgrader = websocket.Upgrader{}
-	wsupgrader.CheckOrigin = func(r *http.Request) bool { return true } // TODO: Insecure
+	wsupgrader.CheckOrigin = server.wsPolicy.checkOrigin
 	var err error
 	var wsConn *websocket.Conn
 	wsConn, err = wsupgrader.Upgrade(w, r, nil)
@@ -44,20 +83,38 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 		log.WithFields(log.Fields{"Error": err}).Error("Failed to call wsupgrader.Upgrade()")
 		return
 	}
+	defer wsConn.Close()
+
+	// All subscriptions opened on this connection are cancelled when the connection is closed
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	pingInterval, idleTimeout := server.wsPolicy.keepalive()
+	wsConn.SetReadDeadline(time.Now().Add(idleTimeout))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(idleTimeout))
+	})
+	go server.keepWSAlive(ctx, wsConn, pingInterval)
 
 	for {
 		wsMsgType, data, err := wsConn.ReadMessage()
 		if err != nil {
 			return
 		}
+		wsConn.SetReadDeadline(time.Now().Add(idleTimeout))
 
 		rpcMsg, err := rpc.CreateRPCMsgFromJSON(string(data))
 		if server.handleHTTPError(c, err, http.StatusBadRequest) {
 			return
 		}
 
-		recoveredID, err := server.parseSignature(rpcMsg.Payload, rpcMsg.Signature)
-		if server.handleHTTPError(c, err, http.StatusForbidden) {
+		msgCtx := tracing.Extract(c.Request.Context(), rpcMsg.TraceContext)
+		recoveredID, _, err := server.verifyRPCMsg(msgCtx, rpcMsg)
+		if err != nil {
+			err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
+			if err != nil {
+				log.WithFields(log.Fields{"Error": err}).Error("Failed to verify RPC message, failed to call server.sendWSErrorMsg()")
+			}
 			return
 		}
 
@@ -81,7 +138,7 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 				return
 			}
 
-			runtime, err := server.controller.getRuntime(recoveredID)
+			runtime, err := server.controller.getRuntime(msgCtx, recoveredID)
 			if err != nil {
 				err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
 				if err != nil {
@@ -98,7 +155,7 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 			}
 
 			// This test is strictly not needed, since the request does not specifiy a colony, but is rather derived from the database
-			err = server.validator.RequireRuntimeMembership(recoveredID, runtime.ColonyID, true)
+			err = server.requireRuntimeRole(recoveredID, runtime.ColonyID, rpcMsg.PayloadType)
 			if err != nil {
 				err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
 				if err != nil {
@@ -108,7 +165,15 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 			}
 
 			processSubcription := createProcessesSubscription(wsConn, wsMsgType, msg.RuntimeType, msg.Timeout, msg.State)
-			server.controller.subscribeProcesses(recoveredID, processSubcription)
+			err = server.acquireWSSubscription(ctx, recoveredID, processSubcription)
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusTooManyRequests, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to processes, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+			server.controller.subscribeProcesses(msgCtx, recoveredID, processSubcription)
 
 		case rpc.SubscribeProcessPayloadType:
 			msg, err := rpc.CreateSubscribeProcessMsgFromJSON(rpcMsg.DecodePayload())
@@ -123,7 +188,7 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 				return
 			}
 
-			runtime, err := server.controller.getRuntime(recoveredID)
+			runtime, err := server.controller.getRuntime(msgCtx, recoveredID)
 			if err != nil {
 				err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
 				if err != nil {
@@ -141,7 +206,7 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 
 			// This test is strictly not needed, since the request does not specifiy a colony, but is rather
 			// derived from the database
-			err = server.validator.RequireRuntimeMembership(recoveredID, runtime.ColonyID, true)
+			err = server.requireRuntimeRole(recoveredID, runtime.ColonyID, rpcMsg.PayloadType)
 			if err != nil {
 				err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
 				if err != nil {
@@ -151,7 +216,109 @@ func (server *ColoniesServer) handleWSRequest(c *gin.Context) {
 			}
 
 			processSubcription := createProcessSubscription(wsConn, wsMsgType, msg.ProcessID, msg.RuntimeType, msg.Timeout, msg.State)
-			server.controller.subscribeProcess(recoveredID, processSubcription)
+			err = server.acquireWSSubscription(ctx, recoveredID, processSubcription)
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusTooManyRequests, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+			err = server.controller.subscribeProcess(msgCtx, recoveredID, processSubcription)
+			if err != nil {
+				server.wsPolicy.release(recoveredID)
+				err := server.sendWSErrorMsg(err, http.StatusBadRequest, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+
+		case rpc.SubscribeColonyPayloadType:
+			msg, err := rpc.CreateSubscribeColonyMsgFromJSON(rpcMsg.DecodePayload())
+			if err != nil {
+				err := server.sendWSErrorMsg(errors.New("Failed to subscribe to colony, invalid JSON"), http.StatusBadRequest, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to colony, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+			if msg.MsgType != rpcMsg.PayloadType {
+				err := server.sendWSErrorMsg(errors.New("Failed to subscribe to colony, msg.msgType does not match rpcMsg.PayloadType"), http.StatusForbidden, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to colony, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+
+			err = server.requireRuntimeRole(recoveredID, msg.ColonyID, rpcMsg.PayloadType)
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to colony, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+
+			colonySubscription := createColonySubscription(wsConn, wsMsgType, msg.ColonyID, msg.Timeout)
+			err = server.acquireWSSubscription(ctx, recoveredID, colonySubscription)
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusTooManyRequests, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to colony, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+			server.controller.subscribeColony(msgCtx, recoveredID, colonySubscription)
+
+		case rpc.SubscribeProcessGraphPayloadType:
+			msg, err := rpc.CreateSubscribeProcessGraphMsgFromJSON(rpcMsg.DecodePayload())
+			if err != nil {
+				err := server.sendWSErrorMsg(errors.New("Failed to subscribe to process graph, invalid JSON"), http.StatusBadRequest, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process graph, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+			if msg.MsgType != rpcMsg.PayloadType {
+				err := server.sendWSErrorMsg(errors.New("Failed to subscribe to process graph, msg.msgType does not match rpcMsg.PayloadType"), http.StatusForbidden, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process graph, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+
+			processGraph, err := server.controller.getProcessGraphByID(msgCtx, msg.ProcessGraphID)
+			if err == nil && processGraph == nil {
+				err = core.WithErrorCode(errors.New("Failed to subscribe to process graph, process graph not found"), core.ErrorCodeNotFound)
+			}
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusBadRequest, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process graph, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+
+			err = server.requireRuntimeRole(recoveredID, processGraph.ColonyID, rpcMsg.PayloadType)
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusForbidden, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process graph, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+
+			processGraphSubscription := createProcessGraphSubscription(wsConn, wsMsgType, processGraph.ID, msg.Timeout)
+			err = server.acquireWSSubscription(ctx, recoveredID, processGraphSubscription)
+			if err != nil {
+				err := server.sendWSErrorMsg(err, http.StatusTooManyRequests, wsConn, wsMsgType)
+				if err != nil {
+					log.WithFields(log.Fields{"Error": err}).Error("Failed to subscribe to process graph, failed to call server.sendWSErrorMsg()")
+				}
+				return
+			}
+			server.controller.subscribeProcessGraph(msgCtx, recoveredID, processGraphSubscription)
 		}
 	}
 }
@@ -1,10 +1,15 @@
 package server
 
 import (
+	"crypto/tls"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/colonyos/colonies/pkg/core"
 	"github.com/colonyos/colonies/pkg/security/crypto"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,3 +68,114 @@ func TestSubscribeChangeStateProcessSecurity(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+func TestSubscribeProcessesLimitSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	server.SetMaxWSSubscriptions(100, 1)
+
+	runtimeType := "test_runtime_type"
+
+	// Should work
+	subscription1, err := client.SubscribeProcesses(runtimeType, core.WAITING, 100, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	// Should work, runtime 2 has its own limit
+	_, err = client.SubscribeProcesses(runtimeType, core.WAITING, 100, env.runtime2PrvKey)
+	assert.Nil(t, err)
+
+	// Should not work, runtime 1 already has one subscription
+	subscription3, err := client.SubscribeProcesses(runtimeType, core.WAITING, 100, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	waitForProcess := make(chan error)
+	go func() {
+		select {
+		case <-subscription3.ProcessChan:
+			waitForProcess <- nil
+		case err := <-subscription3.ErrChan:
+			waitForProcess <- err
+		}
+	}()
+
+	err = <-waitForProcess
+	assert.NotNil(t, err)
+
+	// The slot is given back when the subscription is closed
+	subscription1.Close()
+	for i := 0; i < 50; i++ {
+		runtimeSubscriptions, _ := server.wsPolicy.numberOfSubscriptions(env.runtime1ID)
+		if runtimeSubscriptions == 0 {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	runtimeSubscriptions, _ := server.wsPolicy.numberOfSubscriptions(env.runtime1ID)
+	assert.Equal(t, 0, runtimeSubscriptions)
+
+	server.Shutdown()
+	<-done
+}
+
+func TestWSOriginSecurity(t *testing.T) {
+	_, _, server, _, done := setupTestEnv1(t)
+
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	u := "wss://" + TESTHOST + ":" + strconv.Itoa(TESTPORT) + "/pubsub"
+
+	// Should not work, cross-origin requests are rejected by default
+	header := http.Header{}
+	header.Set("Origin", "https://evil.example.com")
+	_, _, err := dialer.Dial(u, header)
+	assert.NotNil(t, err)
+
+	// Should work
+	server.SetWSAllowedOrigins([]string{"https://app.example.com"})
+	header.Set("Origin", "https://app.example.com")
+	wsConn, _, err := dialer.Dial(u, header)
+	assert.Nil(t, err)
+	wsConn.Close()
+
+	server.Shutdown()
+	<-done
+}
+
+func TestSubscribeColonySecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	// Should not work, runtime 2 is not a member of colony 1
+	subscription, err := client.SubscribeColony(env.colony1ID, 100, env.runtime2PrvKey)
+	assert.Nil(t, err)
+
+	select {
+	case <-subscription.ProcessChan:
+		assert.Fail(t, "Runtime 2 should not receive events from colony 1")
+	case err := <-subscription.ErrChan:
+		assert.NotNil(t, err)
+	}
+
+	server.Shutdown()
+	<-done
+}
+
+func TestSubscribeProcessGraphSecurity(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	graph, err := client.SubmitWorkflowSpec(generateDiamondtWorkflowSpec(env.colony1ID), env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	// Should not work, runtime 2 is not a member of colony 1
+	subscription, err := client.SubscribeProcessGraph(graph.ID, 100, env.runtime2PrvKey)
+	assert.Nil(t, err)
+
+	select {
+	case <-subscription.ProcessChan:
+		assert.Fail(t, "Runtime 2 should not receive events from colony 1")
+	case err := <-subscription.ErrChan:
+		assert.NotNil(t, err)
+	}
+
+	server.Shutdown()
+	<-done
+}
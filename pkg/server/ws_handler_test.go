@@ -131,3 +131,74 @@ func TestSubscribeChangeStateProcess2(t *testing.T) {
 	server.Shutdown()
 	<-done
 }
+
+// Runtime 1 subscribes on all events in colony 1 and expects to receive every state change of a process
+func TestSubscribeColony(t *testing.T) {
+	env, client, server, _, done := setupTestEnv1(t)
+
+	subscription, err := client.SubscribeColony(env.colony1ID, 100, env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	// Processes in other colonies are not received
+	_, err = client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony2ID), env.runtime2PrvKey)
+	assert.Nil(t, err)
+
+	addedProcess, err := client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colony1ID), env.runtime1PrvKey)
+	assert.Nil(t, err)
+	assignedProcess, err := client.AssignProcess(env.colony1ID, -1, env.runtime1PrvKey)
+	assert.Nil(t, err)
+	err = client.CloseFailed(assignedProcess.ID, "error", env.runtime1PrvKey)
+	assert.Nil(t, err)
+
+	for _, state := range []int{core.WAITING, core.RUNNING, core.FAILED} {
+		select {
+		case process := <-subscription.ProcessChan:
+			assert.Equal(t, addedProcess.ID, process.ID)
+			assert.Equal(t, state, process.State)
+		case err := <-subscription.ErrChan:
+			assert.Fail(t, err.Error())
+		}
+	}
+
+	server.Shutdown()
+	<-done
+}
+
+// Runtime 1 subscribes on all events in a process graph and expects to receive the state changes of its processes
+func TestSubscribeProcessGraph(t *testing.T) {
+	env, client, server, _, done := setupTestEnv2(t)
+
+	graph, err := client.SubmitWorkflowSpec(generateDiamondtWorkflowSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	subscription, err := client.SubscribeProcessGraph(graph.ID, 100, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	// Processes which are not part of the process graph are not received
+	_, err = client.SubmitProcessSpec(utils.CreateTestProcessSpec(env.colonyID), env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	assignedProcess, err := client.AssignProcess(env.colonyID, -1, env.runtimePrvKey)
+	assert.Nil(t, err)
+	assert.Equal(t, graph.ID, assignedProcess.ProcessGraphID)
+	err = client.CloseSuccessful(assignedProcess.ID, env.runtimePrvKey)
+	assert.Nil(t, err)
+
+	for _, state := range []int{core.RUNNING, core.SUCCESS} {
+		select {
+		case process := <-subscription.ProcessChan:
+			assert.Equal(t, assignedProcess.ID, process.ID)
+			assert.Equal(t, graph.ID, process.ProcessGraphID)
+			assert.Equal(t, state, process.State)
+		case err := <-subscription.ErrChan:
+			assert.Fail(t, err.Error())
+		}
+	}
+
+	server.Shutdown()
+	<-done
+}
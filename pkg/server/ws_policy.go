@@ -0,0 +1,149 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// wsPolicy decides which origins may open WebSocket connections, and keeps track of how many subscriptions each
+// runtime has open on this server
+type wsPolicy struct {
+	allowedOrigins             []string
+	maxSubscriptions           int
+	maxSubscriptionsPerRuntime int
+	idleTimeout                time.Duration
+	pingInterval               time.Duration
+	subscriptions              map[string]int
+	totalSubscriptions         int
+	mutex                      sync.Mutex
+}
+
+func createWSPolicy() *wsPolicy {
+	return &wsPolicy{maxSubscriptions: MAX_WS_SUBSCRIPTIONS,
+		maxSubscriptionsPerRuntime: MAX_WS_SUBSCRIPTIONS_PER_RUNTIME,
+		idleTimeout:                WS_IDLE_TIMEOUT * time.Second,
+		pingInterval:               WS_PING_INTERVAL * time.Second,
+		subscriptions:              make(map[string]int)}
+}
+
+// SetWSAllowedOrigins sets the origins browsers may open WebSocket connections from, e.g. https://app.example.com.
+// If no origins are set, only same-origin requests are accepted, "*" accepts any origin. Requests without an Origin
+// header, i.e. requests not sent by a browser, are always accepted.
+func (server *ColoniesServer) SetWSAllowedOrigins(origins []string) {
+	server.wsPolicy.mutex.Lock()
+	defer server.wsPolicy.mutex.Unlock()
+
+	server.wsPolicy.allowedOrigins = nil
+	for _, origin := range origins {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			server.wsPolicy.allowedOrigins = append(server.wsPolicy.allowedOrigins, strings.ToLower(origin))
+		}
+	}
+}
+
+// SetMaxWSSubscriptions sets how many WebSocket subscriptions may be open on this server in total, and by a
+// single runtime
+func (server *ColoniesServer) SetMaxWSSubscriptions(maxSubscriptions int, maxSubscriptionsPerRuntime int) {
+	server.wsPolicy.mutex.Lock()
+	defer server.wsPolicy.mutex.Unlock()
+
+	server.wsPolicy.maxSubscriptions = maxSubscriptions
+	server.wsPolicy.maxSubscriptionsPerRuntime = maxSubscriptionsPerRuntime
+}
+
+// SetWSKeepalive sets how often the server pings WebSocket clients, and how long a connection may be silent,
+// i.e. not answer pings, before it is closed. The ping interval must be shorter than the idle timeout.
+func (server *ColoniesServer) SetWSKeepalive(pingInterval time.Duration, idleTimeout time.Duration) error {
+	if pingInterval <= 0 || idleTimeout <= pingInterval {
+		return errors.New("Invalid WebSocket keepalive, ping interval must be positive and shorter than the idle timeout")
+	}
+
+	server.wsPolicy.mutex.Lock()
+	defer server.wsPolicy.mutex.Unlock()
+
+	server.wsPolicy.pingInterval = pingInterval
+	server.wsPolicy.idleTimeout = idleTimeout
+
+	return nil
+}
+
+func (policy *wsPolicy) keepalive() (time.Duration, time.Duration) {
+	policy.mutex.Lock()
+	defer policy.mutex.Unlock()
+
+	return policy.pingInterval, policy.idleTimeout
+}
+
+func (policy *wsPolicy) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	policy.mutex.Lock()
+	allowedOrigins := policy.allowedOrigins
+	policy.mutex.Unlock()
+
+	if len(allowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		return strings.EqualFold(u.Host, r.Host)
+	}
+
+	origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+	for _, allowedOrigin := range allowedOrigins {
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acquire reserves a subscription slot for the runtime, the slot must be given back by calling release
+func (policy *wsPolicy) acquire(runtimeID string) error {
+	policy.mutex.Lock()
+	defer policy.mutex.Unlock()
+
+	if policy.totalSubscriptions >= policy.maxSubscriptions {
+		return errors.New("Failed to subscribe, the server already has the maximum number of subscriptions <" + strconv.Itoa(policy.maxSubscriptions) + ">")
+	}
+
+	if policy.subscriptions[runtimeID] >= policy.maxSubscriptionsPerRuntime {
+		return errors.New("Failed to subscribe, runtime with id <" + runtimeID + "> already has the maximum number of subscriptions <" + strconv.Itoa(policy.maxSubscriptionsPerRuntime) + ">")
+	}
+
+	policy.subscriptions[runtimeID]++
+	policy.totalSubscriptions++
+
+	return nil
+}
+
+func (policy *wsPolicy) release(runtimeID string) {
+	policy.mutex.Lock()
+	defer policy.mutex.Unlock()
+
+	if policy.subscriptions[runtimeID] > 0 {
+		policy.subscriptions[runtimeID]--
+		policy.totalSubscriptions--
+	}
+
+	if policy.subscriptions[runtimeID] == 0 {
+		delete(policy.subscriptions, runtimeID)
+	}
+}
+
+func (policy *wsPolicy) numberOfSubscriptions(runtimeID string) (int, int) { // Just for testing purposes
+	policy.mutex.Lock()
+	defer policy.mutex.Unlock()
+
+	return policy.subscriptions[runtimeID], policy.totalSubscriptions
+}
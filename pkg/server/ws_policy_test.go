@@ -0,0 +1,71 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createWSRequest(host string, origin string) *http.Request {
+	r, _ := http.NewRequest("GET", "http://"+host+"/pubsub", nil)
+	if origin != "" {
+		r.Header.Set("Origin", origin)
+	}
+	return r
+}
+
+func TestWSPolicyCheckOrigin(t *testing.T) {
+	server := &ColoniesServer{wsPolicy: createWSPolicy()}
+
+	// Same-origin only by default, non-browser clients do not send an Origin header
+	assert.True(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "")))
+	assert.True(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "https://colonies.example.com")))
+	assert.False(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "https://evil.example.com")))
+
+	server.SetWSAllowedOrigins([]string{"https://app.example.com/", " https://other.example.com"})
+	assert.True(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "https://app.example.com")))
+	assert.True(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "https://OTHER.example.com")))
+	assert.False(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "http://app.example.com")))
+	assert.False(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "https://colonies.example.com")))
+
+	server.SetWSAllowedOrigins([]string{"*"})
+	assert.True(t, server.wsPolicy.checkOrigin(createWSRequest("colonies.example.com", "https://evil.example.com")))
+}
+
+func TestWSPolicySubscriptionLimits(t *testing.T) {
+	server := &ColoniesServer{wsPolicy: createWSPolicy()}
+	server.SetMaxWSSubscriptions(3, 2)
+
+	assert.Nil(t, server.wsPolicy.acquire("runtime1"))
+	assert.Nil(t, server.wsPolicy.acquire("runtime1"))
+	assert.NotNil(t, server.wsPolicy.acquire("runtime1")) // Should not work, per runtime limit reached
+	assert.Nil(t, server.wsPolicy.acquire("runtime2"))
+	assert.NotNil(t, server.wsPolicy.acquire("runtime3")) // Should not work, server limit reached
+
+	runtimeSubscriptions, totalSubscriptions := server.wsPolicy.numberOfSubscriptions("runtime1")
+	assert.Equal(t, 2, runtimeSubscriptions)
+	assert.Equal(t, 3, totalSubscriptions)
+
+	server.wsPolicy.release("runtime1")
+	assert.Nil(t, server.wsPolicy.acquire("runtime3"))
+
+	server.wsPolicy.release("runtime1")
+	server.wsPolicy.release("runtime1") // Releasing more than acquired should have no effect
+	runtimeSubscriptions, totalSubscriptions = server.wsPolicy.numberOfSubscriptions("runtime1")
+	assert.Equal(t, 0, runtimeSubscriptions)
+	assert.Equal(t, 2, totalSubscriptions)
+}
+
+func TestWSPolicyKeepalive(t *testing.T) {
+	server := &ColoniesServer{wsPolicy: createWSPolicy()}
+
+	assert.NotNil(t, server.SetWSKeepalive(0, time.Second))
+	assert.NotNil(t, server.SetWSKeepalive(2*time.Second, time.Second))
+	assert.Nil(t, server.SetWSKeepalive(time.Second, 3*time.Second))
+
+	pingInterval, idleTimeout := server.wsPolicy.keepalive()
+	assert.Equal(t, time.Second, pingInterval)
+	assert.Equal(t, 3*time.Second, idleTimeout)
+}
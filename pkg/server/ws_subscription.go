@@ -17,6 +17,10 @@ type subscription struct {
 	runtimeType string
 	state       int
 	processID   string
+	scope       string // Set for subscriptions receiving every state change in a colony or process graph
+	scopeID     string
+	ctx         context.Context // Cancelled when the WebSocket connection is closed
+	release     func()          // Called when the subscription ends
 }
 
 type wsSubscriptionController struct {
@@ -51,6 +55,22 @@ func createProcessSubscription(wsConn *websocket.Conn, wsMsgType int, processID
 		state:       state}
 }
 
+func createColonySubscription(wsConn *websocket.Conn, wsMsgType int, colonyID string, timeout int) *subscription {
+	return &subscription{wsConn: wsConn,
+		wsMsgType: wsMsgType,
+		timeout:   timeout,
+		scope:     WATCH_COLONY,
+		scopeID:   colonyID}
+}
+
+func createProcessGraphSubscription(wsConn *websocket.Conn, wsMsgType int, processGraphID string, timeout int) *subscription {
+	return &subscription{wsConn: wsConn,
+		wsMsgType: wsMsgType,
+		timeout:   timeout,
+		scope:     WATCH_PROCESSGRAPH,
+		scopeID:   processGraphID}
+}
+
 // Used by coloniesController
 func createWSSubscriptionController(eventHandler *eventHandler) *wsSubscriptionController {
 	wsSubCtrl := &wsSubscriptionController{}
@@ -109,10 +129,17 @@ func (wsSubCtrl *wsSubscriptionController) sendProcessToWS(runtimeID string,
 // Used by coloniesController
 func (wsSubCtrl *wsSubscriptionController) subscribe(runtimeID string, processID string, subscription *subscription) {
 	go func() {
-		ctx, cancelCtx := context.WithTimeout(context.Background(), time.Duration(subscription.timeout)*time.Second)
+		parentCtx := subscription.ctx
+		if parentCtx == nil {
+			parentCtx = context.Background()
+		}
+		ctx, cancelCtx := context.WithTimeout(parentCtx, time.Duration(subscription.timeout)*time.Second)
 		defer cancelCtx()
+		if subscription.release != nil {
+			defer subscription.release()
+		}
 
-		processChan, errChan := wsSubCtrl.eventHandler.subscribe(subscription.runtimeType, subscription.state, processID, ctx)
+		processChan, errChan := wsSubCtrl.eventHandler.subscribe(subscription.runtimeType, subscription.state, processID, runtimeID, ctx)
 		for {
 			select {
 			case err := <-errChan:
@@ -131,6 +158,46 @@ func (wsSubCtrl *wsSubscriptionController) subscribe(runtimeID string, processID
 	}()
 }
 
+// watch works as subscribe, but sends every state change of the processes in the scope of the subscription
+func (wsSubCtrl *wsSubscriptionController) watch(runtimeID string, subscription *subscription) {
+	go func() {
+		parentCtx := subscription.ctx
+		if parentCtx == nil {
+			parentCtx = context.Background()
+		}
+		ctx, cancelCtx := context.WithTimeout(parentCtx, time.Duration(subscription.timeout)*time.Second)
+		defer cancelCtx()
+		if subscription.release != nil {
+			defer subscription.release()
+		}
+
+		processChan, errChan := wsSubCtrl.eventHandler.watch(subscription.scope, subscription.scopeID, runtimeID, ctx)
+		for {
+			select {
+			case err := <-errChan:
+				log.WithFields(log.Fields{
+					"RuntimeID": runtimeID,
+					"Scope":     subscription.scope,
+					"ScopeID":   subscription.scopeID,
+					"Err":       err}).
+					Debug("Subscriber timed out")
+				subscription.wsConn.Close()
+				return
+			case process := <-processChan:
+				wsSubCtrl.sendProcessToWS(runtimeID, process, subscription.wsConn, subscription.wsMsgType, func() { cancelCtx() })
+			}
+		}
+	}()
+}
+
+func (wsSubCtrl *wsSubscriptionController) addColonySubscriber(runtimeID string, subscription *subscription) {
+	wsSubCtrl.watch(runtimeID, subscription)
+}
+
+func (wsSubCtrl *wsSubscriptionController) addProcessGraphSubscriber(runtimeID string, subscription *subscription) {
+	wsSubCtrl.watch(runtimeID, subscription)
+}
+
 func (wsSubCtrl *wsSubscriptionController) addProcessesSubscriber(runtimeID string, subscription *subscription) {
 	wsSubCtrl.subscribe(runtimeID, "", subscription)
 }
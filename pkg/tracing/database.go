@@ -0,0 +1,1163 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedDatabase records a span for every database call, the span becomes a child of the span in the context
+// returned by contextFunc
+type tracedDatabase struct {
+	db          database.Database
+	contextFunc func() context.Context
+}
+
+// TraceDatabase wraps db so that every call records a span, calls are only recorded if the context returned by
+// contextFunc is traced
+func TraceDatabase(db database.Database, contextFunc func() context.Context) database.Database {
+	return &tracedDatabase{db: db, contextFunc: contextFunc}
+}
+
+// DatabaseWithContext returns db with all calls recorded as children of the span in ctx, db is returned unchanged if
+// it was not created by TraceDatabase
+func DatabaseWithContext(db database.Database, ctx context.Context) database.Database {
+	if tracedDB, ok := db.(*tracedDatabase); ok {
+		return &tracedDatabase{db: tracedDB.db, contextFunc: func() context.Context { return ctx }}
+	}
+
+	return db
+}
+
+func (db *tracedDatabase) start(operation string) trace.Span {
+	_, span := StartChild(db.contextFunc(), "db."+operation, attribute.String("db.operation", operation))
+	return span
+}
+
+func (db *tracedDatabase) Close() {
+	db.db.Close()
+}
+
+func (db *tracedDatabase) AddColony(colony *core.Colony) error {
+	span := db.start("AddColony")
+	err := db.db.AddColony(colony)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetColonies() ([]*core.Colony, error) {
+	span := db.start("GetColonies")
+	result, err := db.db.GetColonies()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetColonyByID(id string) (*core.Colony, error) {
+	span := db.start("GetColonyByID")
+	result, err := db.db.GetColonyByID(id)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) SetColonyOwner(colonyID string, ownerID string) error {
+	span := db.start("SetColonyOwner")
+	err := db.db.SetColonyOwner(colonyID, ownerID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteColonyByID(colonyID string) error {
+	span := db.start("DeleteColonyByID")
+	err := db.db.DeleteColonyByID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) CountColonies() (int, error) {
+	span := db.start("CountColonies")
+	result, err := db.db.CountColonies()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddRuntime(runtime *core.Runtime) error {
+	span := db.start("AddRuntime")
+	err := db.db.AddRuntime(runtime)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetRuntimes() ([]*core.Runtime, error) {
+	span := db.start("GetRuntimes")
+	result, err := db.db.GetRuntimes()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetRuntimeByID(runtimeID string) (*core.Runtime, error) {
+	span := db.start("GetRuntimeByID")
+	result, err := db.db.GetRuntimeByID(runtimeID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetRuntimesByColonyID(colonyID string) ([]*core.Runtime, error) {
+	span := db.start("GetRuntimesByColonyID")
+	result, err := db.db.GetRuntimesByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) ApproveRuntime(runtime *core.Runtime) error {
+	span := db.start("ApproveRuntime")
+	err := db.db.ApproveRuntime(runtime)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) RejectRuntime(runtime *core.Runtime) error {
+	span := db.start("RejectRuntime")
+	err := db.db.RejectRuntime(runtime)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) MarkAlive(runtime *core.Runtime) error {
+	span := db.start("MarkAlive")
+	err := db.db.MarkAlive(runtime)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteRuntimeByID(runtimeID string) error {
+	span := db.start("DeleteRuntimeByID")
+	err := db.db.DeleteRuntimeByID(runtimeID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteRuntimesByColonyID(colonyID string) error {
+	span := db.start("DeleteRuntimesByColonyID")
+	err := db.db.DeleteRuntimesByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) CountRuntimes() (int, error) {
+	span := db.start("CountRuntimes")
+	result, err := db.db.CountRuntimes()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountRuntimesByColonyID(colonyID string) (int, error) {
+	span := db.start("CountRuntimesByColonyID")
+	result, err := db.db.CountRuntimesByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddRuntimeRole(runtimeID string, colonyID string, role string) error {
+	span := db.start("AddRuntimeRole")
+	err := db.db.AddRuntimeRole(runtimeID, colonyID, role)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) RemoveRuntimeRole(runtimeID string, role string) error {
+	span := db.start("RemoveRuntimeRole")
+	err := db.db.RemoveRuntimeRole(runtimeID, role)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetRuntimeRoles(runtimeID string) ([]string, error) {
+	span := db.start("GetRuntimeRoles")
+	result, err := db.db.GetRuntimeRoles(runtimeID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddRevocation(revocation *core.Revocation) error {
+	span := db.start("AddRevocation")
+	err := db.db.AddRevocation(revocation)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetRevocation(runtimeID string) (*core.Revocation, error) {
+	span := db.start("GetRevocation")
+	result, err := db.db.GetRevocation(runtimeID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetRevocationsByColonyID(colonyID string) ([]*core.Revocation, error) {
+	span := db.start("GetRevocationsByColonyID")
+	result, err := db.db.GetRevocationsByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddProcess(process *core.Process) error {
+	span := db.start("AddProcess")
+	err := db.db.AddProcess(process)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetProcesses() ([]*core.Process, error) {
+	span := db.start("GetProcesses")
+	result, err := db.db.GetProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetProcessByID(processID string) (*core.Process, error) {
+	span := db.start("GetProcessByID")
+	result, err := db.db.GetProcessByID(processID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindProcessesByColonyID(colonyID string, seconds int, state int) ([]*core.Process, error) {
+	span := db.start("FindProcessesByColonyID")
+	result, err := db.db.FindProcessesByColonyID(colonyID, seconds, state)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindProcessesByRuntimeID(colonyID string, runtimeID string, seconds int, state int) ([]*core.Process, error) {
+	span := db.start("FindProcessesByRuntimeID")
+	result, err := db.db.FindProcessesByRuntimeID(colonyID, runtimeID, seconds, state)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindWaitingProcesses(colonyID string, count int) ([]*core.Process, error) {
+	span := db.start("FindWaitingProcesses")
+	result, err := db.db.FindWaitingProcesses(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindRunningProcesses(colonyID string, count int) ([]*core.Process, error) {
+	span := db.start("FindRunningProcesses")
+	result, err := db.db.FindRunningProcesses(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindRunningProcessesByRuntimeID(runtimeID string) ([]*core.Process, error) {
+	span := db.start("FindRunningProcessesByRuntimeID")
+	result, err := db.db.FindRunningProcessesByRuntimeID(runtimeID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindAllRunningProcesses() ([]*core.Process, error) {
+	span := db.start("FindAllRunningProcesses")
+	result, err := db.db.FindAllRunningProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindAllWaitingProcesses() ([]*core.Process, error) {
+	span := db.start("FindAllWaitingProcesses")
+	result, err := db.db.FindAllWaitingProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindSuccessfulProcesses(colonyID string, count int) ([]*core.Process, error) {
+	span := db.start("FindSuccessfulProcesses")
+	result, err := db.db.FindSuccessfulProcesses(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindFailedProcesses(colonyID string, count int) ([]*core.Process, error) {
+	span := db.start("FindFailedProcesses")
+	result, err := db.db.FindFailedProcesses(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindUnassignedProcesses(colonyID string, runtimeID string, runtimeType string, count int, latest bool) ([]*core.Process, error) {
+	span := db.start("FindUnassignedProcesses")
+	result, err := db.db.FindUnassignedProcesses(colonyID, runtimeID, runtimeType, count, latest)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteProcessByID(processID string) error {
+	span := db.start("DeleteProcessByID")
+	err := db.db.DeleteProcessByID(processID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllProcesses() error {
+	span := db.start("DeleteAllProcesses")
+	err := db.db.DeleteAllProcesses()
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllProcessesByColonyID(colonyID string) error {
+	span := db.start("DeleteAllProcessesByColonyID")
+	err := db.db.DeleteAllProcessesByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllProcessesByProcessGraphID(processGraphID string) error {
+	span := db.start("DeleteAllProcessesByProcessGraphID")
+	err := db.db.DeleteAllProcessesByProcessGraphID(processGraphID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllProcessesInProcessGraphsByColonyID(colonyID string) error {
+	span := db.start("DeleteAllProcessesInProcessGraphsByColonyID")
+	err := db.db.DeleteAllProcessesInProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) ResetProcess(process *core.Process) error {
+	span := db.start("ResetProcess")
+	err := db.db.ResetProcess(process)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) SetProcessState(processID string, state int) error {
+	span := db.start("SetProcessState")
+	err := db.db.SetProcessState(processID, state)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) SetWaitForParents(processID string, waitingForParent bool) error {
+	span := db.start("SetWaitForParents")
+	err := db.db.SetWaitForParents(processID, waitingForParent)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) SetWaitDeadline(process *core.Process, waitDeadline time.Time) error {
+	span := db.start("SetWaitDeadline")
+	err := db.db.SetWaitDeadline(process, waitDeadline)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) SetExecDeadline(process *core.Process, execDeadline time.Time) error {
+	span := db.start("SetExecDeadline")
+	err := db.db.SetExecDeadline(process, execDeadline)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) ResetAllProcesses(process *core.Process) error {
+	span := db.start("ResetAllProcesses")
+	err := db.db.ResetAllProcesses(process)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AssignRuntime(runtimeID string, process *core.Process) error {
+	span := db.start("AssignRuntime")
+	err := db.db.AssignRuntime(runtimeID, process)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) UnassignRuntime(process *core.Process) error {
+	span := db.start("UnassignRuntime")
+	err := db.db.UnassignRuntime(process)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) MarkSuccessful(process *core.Process) error {
+	span := db.start("MarkSuccessful")
+	err := db.db.MarkSuccessful(process)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) MarkFailed(process *core.Process, errorMsg string) error {
+	span := db.start("MarkFailed")
+	err := db.db.MarkFailed(process, errorMsg)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) CountProcesses() (int, error) {
+	span := db.start("CountProcesses")
+	result, err := db.db.CountProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountWaitingProcesses() (int, error) {
+	span := db.start("CountWaitingProcesses")
+	result, err := db.db.CountWaitingProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountRunningProcesses() (int, error) {
+	span := db.start("CountRunningProcesses")
+	result, err := db.db.CountRunningProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountSuccessfulProcesses() (int, error) {
+	span := db.start("CountSuccessfulProcesses")
+	result, err := db.db.CountSuccessfulProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountFailedProcesses() (int, error) {
+	span := db.start("CountFailedProcesses")
+	result, err := db.db.CountFailedProcesses()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountWaitingProcessesByColonyID(colonyID string) (int, error) {
+	span := db.start("CountWaitingProcessesByColonyID")
+	result, err := db.db.CountWaitingProcessesByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountRunningProcessesByColonyID(colonyID string) (int, error) {
+	span := db.start("CountRunningProcessesByColonyID")
+	result, err := db.db.CountRunningProcessesByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountSuccessfulProcessesByColonyID(colonyID string) (int, error) {
+	span := db.start("CountSuccessfulProcessesByColonyID")
+	result, err := db.db.CountSuccessfulProcessesByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountFailedProcessesByColonyID(colonyID string) (int, error) {
+	span := db.start("CountFailedProcessesByColonyID")
+	result, err := db.db.CountFailedProcessesByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddAttribute(attribute core.Attribute) error {
+	span := db.start("AddAttribute")
+	err := db.db.AddAttribute(attribute)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddAttributes(attribute []core.Attribute) error {
+	span := db.start("AddAttributes")
+	err := db.db.AddAttributes(attribute)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetAttributeByID(attributeID string) (core.Attribute, error) {
+	span := db.start("GetAttributeByID")
+	result, err := db.db.GetAttributeByID(attributeID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetAttribute(targetID string, key string, attributeType int) (core.Attribute, error) {
+	span := db.start("GetAttribute")
+	result, err := db.db.GetAttribute(targetID, key, attributeType)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetAttributes(targetID string) ([]core.Attribute, error) {
+	span := db.start("GetAttributes")
+	result, err := db.db.GetAttributes(targetID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetAttributesByType(targetID string, attributeType int) ([]core.Attribute, error) {
+	span := db.start("GetAttributesByType")
+	result, err := db.db.GetAttributesByType(targetID, attributeType)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) UpdateAttribute(attribute core.Attribute) error {
+	span := db.start("UpdateAttribute")
+	err := db.db.UpdateAttribute(attribute)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAttributeByID(attributeID string) error {
+	span := db.start("DeleteAttributeByID")
+	err := db.db.DeleteAttributeByID(attributeID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllAttributesByColonyID(colonyID string) error {
+	span := db.start("DeleteAllAttributesByColonyID")
+	err := db.db.DeleteAllAttributesByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllAttributesByProcessGraphID(processGraphID string) error {
+	span := db.start("DeleteAllAttributesByProcessGraphID")
+	err := db.db.DeleteAllAttributesByProcessGraphID(processGraphID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllAttributesInProcessGraphsByColonyID(colonyID string) error {
+	span := db.start("DeleteAllAttributesInProcessGraphsByColonyID")
+	err := db.db.DeleteAllAttributesInProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAttributesByTargetID(targetID string, attributeType int) error {
+	span := db.start("DeleteAttributesByTargetID")
+	err := db.db.DeleteAttributesByTargetID(targetID, attributeType)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllAttributesByTargetID(targetID string) error {
+	span := db.start("DeleteAllAttributesByTargetID")
+	err := db.db.DeleteAllAttributesByTargetID(targetID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllAttributes() error {
+	span := db.start("DeleteAllAttributes")
+	err := db.db.DeleteAllAttributes()
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddProcessGraph(processGraph *core.ProcessGraph) error {
+	span := db.start("AddProcessGraph")
+	err := db.db.AddProcessGraph(processGraph)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetProcessGraphByID(processGraphID string) (*core.ProcessGraph, error) {
+	span := db.start("GetProcessGraphByID")
+	result, err := db.db.GetProcessGraphByID(processGraphID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) SetProcessGraphState(processGraphID string, state int) error {
+	span := db.start("SetProcessGraphState")
+	err := db.db.SetProcessGraphState(processGraphID, state)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) FindWaitingProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
+	span := db.start("FindWaitingProcessGraphs")
+	result, err := db.db.FindWaitingProcessGraphs(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindRunningProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
+	span := db.start("FindRunningProcessGraphs")
+	result, err := db.db.FindRunningProcessGraphs(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindSuccessfulProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
+	span := db.start("FindSuccessfulProcessGraphs")
+	result, err := db.db.FindSuccessfulProcessGraphs(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindFailedProcessGraphs(colonyID string, count int) ([]*core.ProcessGraph, error) {
+	span := db.start("FindFailedProcessGraphs")
+	result, err := db.db.FindFailedProcessGraphs(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteProcessGraphByID(processGraphID string) error {
+	span := db.start("DeleteProcessGraphByID")
+	err := db.db.DeleteProcessGraphByID(processGraphID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllProcessGraphsByColonyID(colonyID string) error {
+	span := db.start("DeleteAllProcessGraphsByColonyID")
+	err := db.db.DeleteAllProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) CountWaitingProcessGraphs() (int, error) {
+	span := db.start("CountWaitingProcessGraphs")
+	result, err := db.db.CountWaitingProcessGraphs()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountRunningProcessGraphs() (int, error) {
+	span := db.start("CountRunningProcessGraphs")
+	result, err := db.db.CountRunningProcessGraphs()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountSuccessfulProcessGraphs() (int, error) {
+	span := db.start("CountSuccessfulProcessGraphs")
+	result, err := db.db.CountSuccessfulProcessGraphs()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountFailedProcessGraphs() (int, error) {
+	span := db.start("CountFailedProcessGraphs")
+	result, err := db.db.CountFailedProcessGraphs()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountWaitingProcessGraphsByColonyID(colonyID string) (int, error) {
+	span := db.start("CountWaitingProcessGraphsByColonyID")
+	result, err := db.db.CountWaitingProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountRunningProcessGraphsByColonyID(colonyID string) (int, error) {
+	span := db.start("CountRunningProcessGraphsByColonyID")
+	result, err := db.db.CountRunningProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountSuccessfulProcessGraphsByColonyID(colonyID string) (int, error) {
+	span := db.start("CountSuccessfulProcessGraphsByColonyID")
+	result, err := db.db.CountSuccessfulProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountFailedProcessGraphsByColonyID(colonyID string) (int, error) {
+	span := db.start("CountFailedProcessGraphsByColonyID")
+	result, err := db.db.CountFailedProcessGraphsByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddGenerator(generator *core.Generator) error {
+	span := db.start("AddGenerator")
+	err := db.db.AddGenerator(generator)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) SetGeneratorLastRun(generatorID string) error {
+	span := db.start("SetGeneratorLastRun")
+	err := db.db.SetGeneratorLastRun(generatorID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetGeneratorByID(generatorID string) (*core.Generator, error) {
+	span := db.start("GetGeneratorByID")
+	result, err := db.db.GetGeneratorByID(generatorID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindGeneratorsByColonyID(colonyID string, count int) ([]*core.Generator, error) {
+	span := db.start("FindGeneratorsByColonyID")
+	result, err := db.db.FindGeneratorsByColonyID(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindAllGenerators() ([]*core.Generator, error) {
+	span := db.start("FindAllGenerators")
+	result, err := db.db.FindAllGenerators()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteGeneratorByID(generatorID string) error {
+	span := db.start("DeleteGeneratorByID")
+	err := db.db.DeleteGeneratorByID(generatorID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllGeneratorsByColonyID(colonyID string) error {
+	span := db.start("DeleteAllGeneratorsByColonyID")
+	err := db.db.DeleteAllGeneratorsByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddGeneratorArg(generatorArg *core.GeneratorArg) error {
+	span := db.start("AddGeneratorArg")
+	err := db.db.AddGeneratorArg(generatorArg)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetGeneratorArgs(generatorID string, count int) ([]*core.GeneratorArg, error) {
+	span := db.start("GetGeneratorArgs")
+	result, err := db.db.GetGeneratorArgs(generatorID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) CountGeneratorArgs(generatorID string) (int, error) {
+	span := db.start("CountGeneratorArgs")
+	result, err := db.db.CountGeneratorArgs(generatorID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteGeneratorArgByID(generatorArgsID string) error {
+	span := db.start("DeleteGeneratorArgByID")
+	err := db.db.DeleteGeneratorArgByID(generatorArgsID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllGeneratorArgsByGeneratorID(generatorID string) error {
+	span := db.start("DeleteAllGeneratorArgsByGeneratorID")
+	err := db.db.DeleteAllGeneratorArgsByGeneratorID(generatorID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllGeneratorArgsByColonyID(generatorID string) error {
+	span := db.start("DeleteAllGeneratorArgsByColonyID")
+	err := db.db.DeleteAllGeneratorArgsByColonyID(generatorID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddCron(cron *core.Cron) error {
+	span := db.start("AddCron")
+	err := db.db.AddCron(cron)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) UpdateCron(cronID string, nextRun time.Time, lastRun time.Time, lastProcessGraphID string) error {
+	span := db.start("UpdateCron")
+	err := db.db.UpdateCron(cronID, nextRun, lastRun, lastProcessGraphID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetCronByID(cronID string) (*core.Cron, error) {
+	span := db.start("GetCronByID")
+	result, err := db.db.GetCronByID(cronID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindCronsByColonyID(colonyID string, count int) ([]*core.Cron, error) {
+	span := db.start("FindCronsByColonyID")
+	result, err := db.db.FindCronsByColonyID(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindAllCrons() ([]*core.Cron, error) {
+	span := db.start("FindAllCrons")
+	result, err := db.db.FindAllCrons()
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteCronByID(cronID string) error {
+	span := db.start("DeleteCronByID")
+	err := db.db.DeleteCronByID(cronID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllCronsByColonyID(colonyID string) error {
+	span := db.start("DeleteAllCronsByColonyID")
+	err := db.db.DeleteAllCronsByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddWorkflowTemplate(workflowTemplate *core.WorkflowTemplate) error {
+	span := db.start("AddWorkflowTemplate")
+	err := db.db.AddWorkflowTemplate(workflowTemplate)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetWorkflowTemplateByID(workflowTemplateID string) (*core.WorkflowTemplate, error) {
+	span := db.start("GetWorkflowTemplateByID")
+	result, err := db.db.GetWorkflowTemplateByID(workflowTemplateID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetWorkflowTemplate(colonyID string, name string, version int) (*core.WorkflowTemplate, error) {
+	span := db.start("GetWorkflowTemplate")
+	result, err := db.db.GetWorkflowTemplate(colonyID, name, version)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindWorkflowTemplatesByColonyID(colonyID string, count int) ([]*core.WorkflowTemplate, error) {
+	span := db.start("FindWorkflowTemplatesByColonyID")
+	result, err := db.db.FindWorkflowTemplatesByColonyID(colonyID, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindWorkflowTemplateVersions(colonyID string, name string) ([]*core.WorkflowTemplate, error) {
+	span := db.start("FindWorkflowTemplateVersions")
+	result, err := db.db.FindWorkflowTemplateVersions(colonyID, name)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteWorkflowTemplate(colonyID string, name string, version int) error {
+	span := db.start("DeleteWorkflowTemplate")
+	err := db.db.DeleteWorkflowTemplate(colonyID, name, version)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllWorkflowTemplatesByColonyID(colonyID string) error {
+	span := db.start("DeleteAllWorkflowTemplatesByColonyID")
+	err := db.db.DeleteAllWorkflowTemplatesByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) SetSecret(secret *core.Secret) error {
+	span := db.start("SetSecret")
+	err := db.db.SetSecret(secret)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetSecret(colonyID string, name string) (*core.Secret, error) {
+	span := db.start("GetSecret")
+	result, err := db.db.GetSecret(colonyID, name)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetSecrets(colonyID string) ([]*core.Secret, error) {
+	span := db.start("GetSecrets")
+	result, err := db.db.GetSecrets(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteSecret(colonyID string, name string) error {
+	span := db.start("DeleteSecret")
+	err := db.db.DeleteSecret(colonyID, name)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllSecretsByColonyID(colonyID string) error {
+	span := db.start("DeleteAllSecretsByColonyID")
+	err := db.db.DeleteAllSecretsByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddWebhook(webhook *core.Webhook) error {
+	span := db.start("AddWebhook")
+	err := db.db.AddWebhook(webhook)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) GetWebhookByID(webhookID string) (*core.Webhook, error) {
+	span := db.start("GetWebhookByID")
+	result, err := db.db.GetWebhookByID(webhookID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) GetWebhooksByColonyID(colonyID string) ([]*core.Webhook, error) {
+	span := db.start("GetWebhooksByColonyID")
+	result, err := db.db.GetWebhooksByColonyID(colonyID)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteWebhookByID(webhookID string) error {
+	span := db.start("DeleteWebhookByID")
+	err := db.db.DeleteWebhookByID(webhookID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteAllWebhooksByColonyID(colonyID string) error {
+	span := db.start("DeleteAllWebhooksByColonyID")
+	err := db.db.DeleteAllWebhooksByColonyID(colonyID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AddWebhookDelivery(delivery *core.WebhookDelivery) error {
+	span := db.start("AddWebhookDelivery")
+	err := db.db.AddWebhookDelivery(delivery)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) FindPendingWebhookDeliveries(count int) ([]*core.WebhookDelivery, error) {
+	span := db.start("FindPendingWebhookDeliveries")
+	result, err := db.db.FindPendingWebhookDeliveries(count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) FindWebhookDeliveries(webhookID string, state int, count int) ([]*core.WebhookDelivery, error) {
+	span := db.start("FindWebhookDeliveries")
+	result, err := db.db.FindWebhookDeliveries(webhookID, state, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) UpdateWebhookDelivery(delivery *core.WebhookDelivery) error {
+	span := db.start("UpdateWebhookDelivery")
+	err := db.db.UpdateWebhookDelivery(delivery)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) DeleteWebhookDeliveryByID(deliveryID string) error {
+	span := db.start("DeleteWebhookDeliveryByID")
+	err := db.db.DeleteWebhookDeliveryByID(deliveryID)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AppendAuditEntry(auditEntry *core.AuditEntry) error {
+	span := db.start("AppendAuditEntry")
+	err := db.db.AppendAuditEntry(auditEntry)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) AppendAuditEntries(auditEntries []*core.AuditEntry) error {
+	span := db.start("AppendAuditEntries")
+	err := db.db.AppendAuditEntries(auditEntries)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) FindAuditEntries(targetID string, fromSeq int64, count int) ([]*core.AuditEntry, error) {
+	span := db.start("FindAuditEntries")
+	result, err := db.db.FindAuditEntries(targetID, fromSeq, count)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) AddNonce(nonce string, expires time.Time) (bool, error) {
+	span := db.start("AddNonce")
+	result, err := db.db.AddNonce(nonce, expires)
+	End(span, err)
+
+	return result, err
+}
+
+func (db *tracedDatabase) DeleteExpiredNonces() error {
+	span := db.start("DeleteExpiredNonces")
+	err := db.db.DeleteExpiredNonces()
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) Lock(timeout int) error {
+	span := db.start("Lock")
+	err := db.db.Lock(timeout)
+	End(span, err)
+
+	return err
+}
+
+func (db *tracedDatabase) Unlock() error {
+	span := db.start("Unlock")
+	err := db.db.Unlock()
+	End(span, err)
+
+	return err
+}
@@ -0,0 +1,138 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jsonExporter writes every exported batch as an OTLP ExportTraceServiceRequest on a single JSON line, the same
+// format the OpenTelemetry collector file exporter writes, so traces can be recorded without a collector and
+// replayed later
+type jsonExporter struct {
+	writer    io.Writer
+	closeFile bool
+	mutex     sync.Mutex
+}
+
+func createJSONExporter(writer io.Writer, closeFile bool) *jsonExporter {
+	return &jsonExporter{writer: writer, closeFile: closeFile}
+}
+
+func (exporter *jsonExporter) ExportSpans(ctx context.Context, spans []*sdktrace.SpanSnapshot) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	jsonBytes, err := protojson.Marshal(convertSpans(spans))
+	if err != nil {
+		return err
+	}
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+	_, err = exporter.writer.Write(append(jsonBytes, '\n'))
+
+	return err
+}
+
+func (exporter *jsonExporter) Shutdown(ctx context.Context) error {
+	if closer, ok := exporter.writer.(io.Closer); ok && exporter.closeFile {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+func convertSpans(spans []*sdktrace.SpanSnapshot) *collectortracepb.ExportTraceServiceRequest {
+	request := &collectortracepb.ExportTraceServiceRequest{}
+	resourceSpans := make(map[attribute.Distinct]*tracepb.ResourceSpans)
+	for _, span := range spans {
+		var key attribute.Distinct
+		var attributes []attribute.KeyValue
+		if span.Resource != nil {
+			key = span.Resource.Equivalent()
+			attributes = span.Resource.Attributes()
+		}
+
+		rs, ok := resourceSpans[key]
+		if !ok {
+			rs = &tracepb.ResourceSpans{
+				Resource: &resourcepb.Resource{Attributes: convertAttributes(attributes)},
+				InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{{
+					InstrumentationLibrary: &commonpb.InstrumentationLibrary{
+						Name:    span.InstrumentationLibrary.Name,
+						Version: span.InstrumentationLibrary.Version}}}}
+			resourceSpans[key] = rs
+			request.ResourceSpans = append(request.ResourceSpans, rs)
+		}
+		rs.InstrumentationLibrarySpans[0].Spans = append(rs.InstrumentationLibrarySpans[0].Spans, convertSpan(span))
+	}
+
+	return request
+}
+
+func convertSpan(span *sdktrace.SpanSnapshot) *tracepb.Span {
+	traceID := span.SpanContext.TraceID()
+	spanID := span.SpanContext.SpanID()
+	s := &tracepb.Span{
+		TraceId:           traceID[:],
+		SpanId:            spanID[:],
+		TraceState:        span.SpanContext.TraceState().String(),
+		Name:              span.Name,
+		Kind:              tracepb.Span_SpanKind(span.SpanKind),
+		StartTimeUnixNano: uint64(span.StartTime.UnixNano()),
+		EndTimeUnixNano:   uint64(span.EndTime.UnixNano()),
+		Attributes:        convertAttributes(span.Attributes),
+		Status:            &tracepb.Status{Message: span.StatusMessage}}
+
+	if span.Parent.IsValid() {
+		parentSpanID := span.Parent.SpanID()
+		s.ParentSpanId = parentSpanID[:]
+	}
+
+	switch span.StatusCode {
+	case codes.Ok:
+		s.Status.Code = tracepb.Status_STATUS_CODE_OK
+	case codes.Error:
+		s.Status.Code = tracepb.Status_STATUS_CODE_ERROR
+	}
+
+	for _, event := range span.MessageEvents {
+		s.Events = append(s.Events, &tracepb.Span_Event{
+			Name:         event.Name,
+			TimeUnixNano: uint64(event.Time.UnixNano()),
+			Attributes:   convertAttributes(event.Attributes)})
+	}
+
+	return s
+}
+
+func convertAttributes(attributes []attribute.KeyValue) []*commonpb.KeyValue {
+	var keyValues []*commonpb.KeyValue
+	for _, kv := range attributes {
+		value := &commonpb.AnyValue{}
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			value.Value = &commonpb.AnyValue_BoolValue{BoolValue: kv.Value.AsBool()}
+		case attribute.INT64:
+			value.Value = &commonpb.AnyValue_IntValue{IntValue: kv.Value.AsInt64()}
+		case attribute.FLOAT64:
+			value.Value = &commonpb.AnyValue_DoubleValue{DoubleValue: kv.Value.AsFloat64()}
+		default:
+			value.Value = &commonpb.AnyValue_StringValue{StringValue: kv.Value.Emit()}
+		}
+		keyValues = append(keyValues, &commonpb.KeyValue{Key: string(kv.Key), Value: value})
+	}
+
+	return keyValues
+}
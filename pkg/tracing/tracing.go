@@ -0,0 +1,166 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The exporter is configured with a string, EXPORTER_NONE disables tracing, EXPORTER_STDOUT writes spans as OTLP
+// JSON lines to stdout, file:<path> appends them to a file and otlp:<host:port> sends them to an OTLP gRPC collector
+const EXPORTER_NONE = ""
+const EXPORTER_STDOUT = "stdout"
+const EXPORTER_FILE_PREFIX = "file:"
+const EXPORTER_OTLP_PREFIX = "otlp:"
+
+const INSTRUMENTATION_NAME = "github.com/colonyos/colonies"
+const SHUTDOWN_TIMEOUT = 5 * time.Second
+
+var propagator = propagation.TraceContext{}
+
+// Init installs a global tracer provider exporting spans to exporter, the returned function flushes and stops the
+// exporter and should be called before the program exits
+func Init(exporter string, serviceName string) (func(), error) {
+	if exporter == EXPORTER_NONE {
+		return func() {}, nil
+	}
+
+	spanExporter, err := createExporter(exporter)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(serviceName))))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+		defer cancel()
+		provider.Shutdown(ctx)
+	}, nil
+}
+
+func createExporter(exporter string) (sdktrace.SpanExporter, error) {
+	switch {
+	case exporter == EXPORTER_STDOUT:
+		return createJSONExporter(os.Stdout, false), nil
+	case strings.HasPrefix(exporter, EXPORTER_FILE_PREFIX):
+		path := strings.TrimPrefix(exporter, EXPORTER_FILE_PREFIX)
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return createJSONExporter(file, true), nil
+	case strings.HasPrefix(exporter, EXPORTER_OTLP_PREFIX):
+		endpoint := strings.TrimPrefix(exporter, EXPORTER_OTLP_PREFIX)
+		driver := otlpgrpc.NewDriver(otlpgrpc.WithInsecure(), otlpgrpc.WithEndpoint(endpoint))
+		return otlp.NewExporter(context.Background(), driver)
+	}
+
+	return nil, errors.New("Invalid tracing exporter <" + exporter + ">, must be stdout, file:<path> or otlp:<host:port>")
+}
+
+// Start starts a span, it becomes a child of the span in ctx if there is one
+func Start(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(INSTRUMENTATION_NAME).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// StartChild starts a span only if ctx already contains one, so work done outside a traced request, e.g. by
+// background loops, does not create new traces
+func StartChild(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	return Start(ctx, name, trace.SpanKindInternal, attributes...)
+}
+
+// End ends span and marks it as failed if err is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// SetError marks the span in ctx as failed
+func SetError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Inject returns the trace context of ctx in W3C Trace Context format, or nil if ctx is not traced
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+
+	carrier := mapCarrier{}
+	propagator.Inject(ctx, carrier)
+
+	return carrier
+}
+
+// Extract returns ctx with the remote span context in traceContext, spans started from the returned context become
+// children of the remote span
+func Extract(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+
+	return propagator.Extract(ctx, mapCarrier(traceContext))
+}
+
+// ExtractHTTPHeader works as Extract, but reads the trace context from HTTP headers, e.g. sent by a message injected
+// with Inject
+func ExtractHTTPHeader(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// TraceID returns the ID of the trace in ctx, or an empty string if ctx is not traced
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
+
+type mapCarrier map[string]string
+
+func (carrier mapCarrier) Get(key string) string {
+	return carrier[key]
+}
+
+func (carrier mapCarrier) Set(key string, value string) {
+	carrier[key] = value
+}
+
+func (carrier mapCarrier) Keys() []string {
+	keys := make([]string, 0, len(carrier))
+	for key := range carrier {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// setupTestTracer installs a tracer provider which synchronously writes all spans to the returned buffer
+func setupTestTracer() *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(createJSONExporter(buffer, false))))
+
+	return buffer
+}
+
+func parseSpans(t *testing.T, data []byte) map[string]*tracepb.Span {
+	spans := make(map[string]*tracepb.Span)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		request := &collectortracepb.ExportTraceServiceRequest{}
+		assert.Nil(t, protojson.Unmarshal(scanner.Bytes(), request))
+		for _, rs := range request.ResourceSpans {
+			for _, ils := range rs.InstrumentationLibrarySpans {
+				for _, span := range ils.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}
+
+	return spans
+}
+
+type fakeDatabase struct {
+	database.Database
+}
+
+func (db *fakeDatabase) GetColonies() ([]*core.Colony, error) {
+	return nil, errors.New("error")
+}
+
+func (db *fakeDatabase) CountColonies() (int, error) {
+	return 1, nil
+}
+
+func TestPropagation(t *testing.T) {
+	buffer := setupTestTracer()
+
+	clientCtx, clientSpan := Start(context.Background(), "client", trace.SpanKindClient)
+	traceContext := Inject(clientCtx)
+	assert.Contains(t, traceContext, "traceparent")
+
+	serverCtx, serverSpan := Start(Extract(context.Background(), traceContext), "server", trace.SpanKindServer)
+	assert.Equal(t, TraceID(clientCtx), TraceID(serverCtx))
+
+	_, childSpan := StartChild(serverCtx, "child")
+	End(childSpan, errors.New("error"))
+	serverSpan.End()
+	clientSpan.End()
+
+	spans := parseSpans(t, buffer.Bytes())
+	assert.Len(t, spans, 3)
+	assert.Equal(t, spans["client"].TraceId, spans["server"].TraceId)
+	assert.Equal(t, spans["client"].SpanId, spans["server"].ParentSpanId)
+	assert.Equal(t, spans["server"].SpanId, spans["child"].ParentSpanId)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, spans["server"].Kind)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, spans["child"].Status.Code)
+	assert.Equal(t, "error", spans["child"].Status.Message)
+}
+
+func TestUntracedContext(t *testing.T) {
+	buffer := setupTestTracer()
+
+	assert.Nil(t, Inject(context.Background()))
+	assert.Equal(t, "", TraceID(context.Background()))
+	ctx := Extract(context.Background(), nil)
+	assert.Equal(t, "", TraceID(ctx))
+
+	// Spans are only started as children of existing spans
+	_, span := StartChild(context.Background(), "child")
+	span.End()
+	assert.Equal(t, 0, buffer.Len())
+}
+
+func TestDatabase(t *testing.T) {
+	buffer := setupTestTracer()
+
+	ctx, span := Start(context.Background(), "command", trace.SpanKindInternal)
+	db := TraceDatabase(&fakeDatabase{}, func() context.Context { return ctx })
+
+	count, err := db.CountColonies()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	_, err = db.GetColonies()
+	assert.NotNil(t, err)
+
+	// Calls outside a traced context are not recorded
+	_, err = DatabaseWithContext(db, context.Background()).CountColonies()
+	assert.Nil(t, err)
+	span.End()
+
+	spans := parseSpans(t, buffer.Bytes())
+	assert.Len(t, spans, 3)
+	assert.Equal(t, spans["command"].SpanId, spans["db.CountColonies"].ParentSpanId)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, spans["db.GetColonies"].Status.Code)
+
+	// Databases not created by TraceDatabase are returned unchanged
+	fakeDB := &fakeDatabase{}
+	assert.Equal(t, fakeDB, DatabaseWithContext(fakeDB, ctx))
+}
+
+func TestInit(t *testing.T) {
+	_, err := Init("invalid", "test")
+	assert.NotNil(t, err)
+
+	shutdown, err := Init(EXPORTER_NONE, "test")
+	assert.Nil(t, err)
+	shutdown()
+
+	dir, err := ioutil.TempDir("", "tracing")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "traces.json")
+
+	shutdown, err = Init(EXPORTER_FILE_PREFIX+path, "test")
+	assert.Nil(t, err)
+	_, span := Start(context.Background(), "span", trace.SpanKindInternal)
+	span.End()
+	shutdown()
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	spans := parseSpans(t, data)
+	assert.Contains(t, spans, "span")
+	assert.Contains(t, string(data), "colonies")
+}
@@ -98,6 +98,23 @@ func FakeGenerator(t *testing.T, colonyID string) *core.Generator {
 	return generator
 }
 
+func FakeWorkflowTemplate(t *testing.T, colonyID string) *core.WorkflowTemplate {
+	workflowSpec := core.CreateWorkflowSpec(colonyID)
+	processSpec1 := CreateTestProcessSpec(colonyID)
+	processSpec1.Name = "task1"
+	processSpec1.Args = []string{"{{input}}"}
+	processSpec2 := CreateTestProcessSpec(colonyID)
+	processSpec2.Name = "task2"
+	processSpec2.AddDependency("task1")
+	workflowSpec.AddProcessSpec(processSpec1)
+	workflowSpec.AddProcessSpec(processSpec2)
+	jsonStr, err := workflowSpec.ToJSON()
+	assert.Nil(t, err)
+	parameters := []core.TemplateParameter{{Name: "input", Default: "test_input"}}
+	workflowTemplate := core.CreateWorkflowTemplate(colonyID, "test_template", parameters, jsonStr)
+	return workflowTemplate
+}
+
 func FakeCron(t *testing.T, colonyID string) *core.Cron {
 	workflowSpec := core.CreateWorkflowSpec(colonyID)
 	processSpec1 := CreateTestProcessSpec(colonyID)
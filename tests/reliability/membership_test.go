@@ -0,0 +1,124 @@
+package reliability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/colonyos/colonies/pkg/client"
+	"github.com/colonyos/colonies/pkg/cluster"
+	"github.com/colonyos/colonies/pkg/core"
+	"github.com/colonyos/colonies/pkg/database/postgresql"
+	"github.com/colonyos/colonies/pkg/server"
+	"github.com/colonyos/colonies/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForClusterSize(t *testing.T, c *client.ColoniesClient, serverPrvKey string, size int) {
+	var clusterInfo *cluster.Config
+	var err error
+	for i := 0; i < 100; i++ {
+		clusterInfo, err = c.GetClusterInfo(serverPrvKey)
+		assert.Nil(t, err)
+		if len(clusterInfo.Nodes) == size {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.Len(t, clusterInfo.Nodes, size)
+}
+
+func TestClusterMembership(t *testing.T) {
+	db, err := postgresql.PrepareTests()
+	defer db.Close()
+	assert.Nil(t, err)
+
+	clusterSize := 2
+
+	// Create a cluster
+	runningCluster := server.StartCluster(t, db, clusterSize)
+	assert.Len(t, runningCluster, clusterSize)
+
+	server.WaitForCluster(t, runningCluster)
+	log.Info("Cluster ready")
+
+	serverPrvKey := runningCluster[0].ServerPrvKey
+	c1 := client.CreateColoniesClient("localhost", runningCluster[0].Node.APIPort, true, true)
+	c2 := client.CreateColoniesClient("localhost", runningCluster[1].Node.APIPort, true, true)
+
+	// Add a third node, etcd rejects membership changes until the members have been connected for a while
+	node := cluster.Node{Name: "etcd2", Host: "localhost", EtcdClientPort: 21002, EtcdPeerPort: 22002, RelayPort: 23002, APIPort: 24002}
+	var clusterConfig *cluster.Config
+	for i := 0; i < 30; i++ {
+		clusterConfig, err = c1.AddClusterNode(node, serverPrvKey)
+		if err == nil {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	assert.Nil(t, err)
+	assert.Len(t, clusterConfig.Nodes, 3)
+
+	newServer := server.JoinCluster(t, db, runningCluster, node)
+	runningCluster = append(runningCluster, newServer)
+	server.WaitForCluster(t, runningCluster)
+	log.Info("Node added to cluster")
+
+	// All nodes know about the new node, also the node that did not add it
+	waitForClusterSize(t, c2, serverPrvKey, 3)
+	c3 := client.CreateColoniesClient("localhost", node.APIPort, true, true)
+	waitForClusterSize(t, c3, serverPrvKey, 3)
+
+	// Setup a test environment
+	colony, colonyPrvKey, err := utils.CreateTestColonyWithKey()
+	assert.Nil(t, err)
+	_, err = c1.AddColony(colony, serverPrvKey)
+	assert.Nil(t, err)
+	runtime, runtimePrvKey, err := utils.CreateTestRuntimeWithKey(colony.ID)
+	assert.Nil(t, err)
+	_, err = c1.AddRuntime(runtime, colonyPrvKey)
+	assert.Nil(t, err)
+	err = c1.ApproveRuntime(runtime.ID, colonyPrvKey)
+	assert.Nil(t, err)
+
+	// A worker waiting on the new node is woken up by a process submitted to another node, which requires that the
+	// event is relayed to the new node
+	assigned := make(chan *core.Process)
+	go func() {
+		process, err := c3.AssignProcess(colony.ID, 20, runtimePrvKey)
+		assert.Nil(t, err)
+		assigned <- process
+	}()
+	time.Sleep(1 * time.Second)
+
+	start := time.Now()
+	addedProcess, err := c1.SubmitProcessSpec(utils.CreateTestProcessSpec(colony.ID), runtimePrvKey)
+	assert.Nil(t, err)
+	process := <-assigned
+	assert.Equal(t, addedProcess.ID, process.ID)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	// A node can not remove itself
+	err = c3.RemoveClusterNode(node.Name, serverPrvKey)
+	assert.NotNil(t, err)
+
+	for i := 0; i < 30; i++ {
+		err = c1.RemoveClusterNode(node.Name, serverPrvKey)
+		if err == nil {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	assert.Nil(t, err)
+
+	waitForClusterSize(t, c1, serverPrvKey, 2)
+	waitForClusterSize(t, c2, serverPrvKey, 2)
+
+	for _, s := range runningCluster {
+		s.Server.Shutdown()
+	}
+
+	for _, s := range runningCluster {
+		<-s.Done
+	}
+}
@@ -2,6 +2,7 @@ package reliability
 
 import (
 	"testing"
+	"time"
 
 	"github.com/colonyos/colonies/pkg/client"
 	"github.com/colonyos/colonies/pkg/core"
@@ -249,3 +250,77 @@ func TestCronReliability(t *testing.T) {
 		<-s.Done
 	}
 }
+
+func TestRelayReliability(t *testing.T) {
+	db, err := postgresql.PrepareTests()
+	defer db.Close()
+	assert.Nil(t, err)
+
+	clusterSize := 3
+
+	// Create a cluster
+	runningCluster := server.StartCluster(t, db, clusterSize)
+	assert.Len(t, runningCluster, clusterSize)
+
+	server.WaitForCluster(t, runningCluster)
+	log.Info("Cluster ready")
+
+	serverPrvKey := runningCluster[0].ServerPrvKey
+	c1 := client.CreateColoniesClient("localhost", runningCluster[0].Node.APIPort, true, true)
+	c3 := client.CreateColoniesClient("localhost", runningCluster[2].Node.APIPort, true, true)
+
+	// Setup a test environment
+	colony, colonyPrvKey, err := utils.CreateTestColonyWithKey()
+	assert.Nil(t, err)
+	_, err = c1.AddColony(colony, serverPrvKey)
+	assert.Nil(t, err)
+	runtime, runtimePrvKey, err := utils.CreateTestRuntimeWithKey(colony.ID)
+	assert.Nil(t, err)
+	_, err = c1.AddRuntime(runtime, colonyPrvKey)
+	assert.Nil(t, err)
+	err = c1.ApproveRuntime(runtime.ID, colonyPrvKey)
+	assert.Nil(t, err)
+
+	// Now kill server 2, which server 1 relays to before server 3
+	runningCluster[1].Server.Shutdown()
+	server.WaitForServerToDie(t, runningCluster[1])
+	log.Info("Server 2 is dead now")
+
+	// A worker waiting on server 3 must be woken up by processes submitted to server 1, even though the events can
+	// not be relayed to server 2
+	for i := 0; i < 3; i++ {
+		assigned := make(chan *core.Process)
+		go func() {
+			process, err := c3.AssignProcess(colony.ID, 20, runtimePrvKey)
+			assert.Nil(t, err)
+			assigned <- process
+		}()
+		time.Sleep(1 * time.Second)
+
+		start := time.Now()
+		addedProcess, err := c1.SubmitProcessSpec(utils.CreateTestProcessSpec(colony.ID), runtimePrvKey)
+		assert.Nil(t, err)
+		process := <-assigned
+		assert.Equal(t, addedProcess.ID, process.ID)
+		assert.Less(t, time.Since(start), 10*time.Second)
+	}
+
+	clusterInfo, err := c1.GetClusterInfo(serverPrvKey)
+	assert.Nil(t, err)
+	for _, metrics := range clusterInfo.RelayMetrics {
+		if metrics.Name == runningCluster[2].Node.Name {
+			assert.True(t, metrics.Delivered > 0)
+		}
+		if metrics.Name == runningCluster[1].Node.Name {
+			assert.NotEmpty(t, metrics.LastError)
+		}
+	}
+
+	// Kill the remaining servers, this will also end the test
+	runningCluster[0].Server.Shutdown()
+	runningCluster[2].Server.Shutdown()
+
+	for _, s := range runningCluster {
+		<-s.Done
+	}
+}